@@ -0,0 +1,52 @@
+package main
+
+import "Product_Catalog_Microservice/internal/handlers"
+
+// politicasCache declara el Cache-Control de cada ruta. Al arrancar se verifica que toda ruta
+// registrada tenga una entrada, de modo que una ruta nueva sin política no llegue a producción.
+var politicasCache = handlers.PoliticasCache{
+	"POST /catalogo/productor":                              handlers.CacheNoStore,
+	"POST /catalogo/productores":                            handlers.CacheNoStore,
+	"POST /catalogo/producto":                               handlers.CacheNoStore,
+	"POST /catalogo/productos/excedente":                    handlers.CacheNoStore,
+	"POST /catalogo/productos/:id/agotar":                   handlers.CacheNoStore,
+	"PUT /catalogo/productos/disponibilidad":                handlers.CacheNoStore,
+	"GET /catalogo/productos":                               handlers.CacheListado,
+	"GET /catalogo/productos/zona":                          handlers.CacheListado,
+	"GET /catalogo/productos/categoria/:categoria":          handlers.CacheListado,
+	"GET /catalogo/productos/tipo/:tipo":                    handlers.CacheListado,
+	"GET /catalogo/productos/temporada":                     handlers.CacheListado,
+	"GET /catalogo/productos/excedentes":                    handlers.CacheListado,
+	"GET /catalogo/productos/:id":                           handlers.CacheListado,
+	"PUT /catalogo/productos/:id":                           handlers.CacheNoStore,
+	"PUT /catalogo/productos/:id/precio":                    handlers.CacheNoStore,
+	"PUT /catalogo/productos/:id/stock":                     handlers.CacheNoStore,
+	"GET /catalogo/producto/:id":                            handlers.CacheListado,
+	"GET /catalogo/completo":                                handlers.CacheListado,
+	"GET /catalogo/buscar":                                  handlers.CacheListado,
+	"GET /catalogo/agrupado":                                handlers.CacheListado,
+	"GET /catalogo/pronostico":                              handlers.CacheListado,
+	"GET /catalogo/sugerencias/temporada":                   handlers.CacheListado,
+	"GET /catalogo/vistas":                                  handlers.CacheLarga,
+	"GET /catalogo/vistas/:nombre":                          handlers.CacheListado,
+	"GET /catalogo/estadisticas/zonas":                      handlers.CacheListado,
+	"GET /catalogo/productores/practica":                    handlers.CacheListado,
+	"GET /catalogo/productores/aptos":                       handlers.CacheNoStore,
+	"GET /catalogo/productores/:id":                         handlers.CacheListado,
+	"GET /catalogo/productores/:id/productos":               handlers.CacheListado,
+	"PUT /catalogo/productores/:id/preferencias":            handlers.CacheNoStore,
+	"PUT /catalogo/productores/:id/reputacion":              handlers.CacheNoStore,
+	"PUT /catalogo/productores/:id/suspender":               handlers.CacheNoStore,
+	"PUT /catalogo/productores/:id/reactivar":               handlers.CacheNoStore,
+	"POST /catalogo/productores/:id/verificacion/iniciar":   handlers.CacheNoStore,
+	"POST /catalogo/productores/:id/verificacion/completar": handlers.CacheNoStore,
+	"GET /catalogo/mis-rechazos":                            handlers.CachePrivada,
+	"GET /catalogo/admin/rechazos":                          handlers.CacheNoStore,
+	"GET /catalogo/admin/configuracion":                     handlers.CacheNoStore,
+	"GET /catalogo/admin/productores/cohorte":               handlers.CacheNoStore,
+	"GET /catalogo/admin/productores/inactivos":             handlers.CacheNoStore,
+	"GET /healthz":                                          handlers.CacheNoStore,
+	"GET /readyz":                                           handlers.CacheNoStore,
+	"POST /catalogo/admin/auditar-invariantes":              handlers.CacheNoStore,
+	"GET /metrics":                                          handlers.CacheNoStore,
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"Product_Catalog_Microservice/internal/handlers"
+)
+
+// Toda ruta de la tabla compartida tiene política de caché, y no sobran políticas de rutas
+// que ya no existen
+func TestPoliticasCache_CubrenLasRutas(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	handlers.RegistrarRutas(r, handlers.Rutas{
+		Producto:  &handlers.ProductoHandler{},
+		Productor: &handlers.ProductorHandler{},
+		Admin:     &handlers.AdminHandler{},
+		Salud:     &handlers.SaludHandler{},
+		Metricas:  func(*gin.Context) {},
+
+		GuardiaAdmin: func(*gin.Context) {},
+	})
+	if err := politicasCache.VerificarRutas(r.Routes()); err != nil {
+		t.Fatal(err)
+	}
+
+	registradas := make(map[string]bool)
+	for _, ruta := range r.Routes() {
+		registradas[ruta.Method+" "+ruta.Path] = true
+	}
+	for clave := range politicasCache {
+		if !registradas[clave] {
+			t.Errorf("política de caché para %s, que no está registrada", clave)
+		}
+	}
+}
@@ -0,0 +1,354 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+	"Product_Catalog_Microservice/internal/domain/productor"
+)
+
+// resumenComando es la salida en stdout de cada subcomando, pensada para scripts
+type resumenComando struct {
+	Comando string         `json:"comando"`
+	OK      bool           `json:"ok"`
+	Error   string         `json:"error,omitempty"`
+	Datos   map[string]any `json:"datos,omitempty"`
+}
+
+// terminar imprime el resumen del subcomando y retorna el código de salida
+func terminar(comando string, datos map[string]any, err error) int {
+	resumen := resumenComando{Comando: comando, OK: err == nil, Datos: datos}
+	if err != nil {
+		resumen.Error = err.Error()
+	}
+	json.NewEncoder(os.Stdout).Encode(resumen)
+	if err != nil {
+		return 1
+	}
+	return 0
+}
+
+// fixtureSemilla es el formato del archivo de datos iniciales de seed
+type fixtureSemilla struct {
+	Productores []fixtureProductor `yaml:"productores"`
+}
+
+// fixtureProductor crea un productor nuevo o, si trae productor_id, publica productos de uno existente
+type fixtureProductor struct {
+	ProductorID string            `yaml:"productor_id"`
+	Nombre      string            `yaml:"nombre"`
+	ZonaVeredal string            `yaml:"zona_veredal"`
+	Finca       string            `yaml:"finca"`
+	Reputacion  float32           `yaml:"reputacion"`
+	Practicas   string            `yaml:"practicas"`
+	Verificado  bool              `yaml:"verificado"`
+	Productos   []fixtureProducto `yaml:"productos"`
+}
+
+type fixtureProducto struct {
+	Nombre          string  `yaml:"nombre"`
+	Descripcion     string  `yaml:"descripcion"`
+	Categoria       string  `yaml:"categoria"`
+	TipoProduccion  string  `yaml:"tipo_produccion"`
+	TemporadaInicio string  `yaml:"temporada_inicio"` // formato: "2006-01-02"
+	TemporadaFin    string  `yaml:"temporada_fin"`    // formato: "2006-01-02"
+	ZonaVeredal     string  `yaml:"zona_veredal"`
+	Finca           string  `yaml:"finca"`
+	ImagenURL       string  `yaml:"imagen_url"`
+	ImagenDesc      string  `yaml:"imagen_desc"`
+	PrecioValor     float64 `yaml:"precio_valor"`
+	PrecioMoneda    string  `yaml:"precio_moneda"`
+	CantidadValor   int     `yaml:"cantidad_valor"`
+	CantidadUnidad  string  `yaml:"cantidad_unidad"`
+}
+
+// sembrar carga productores y productos desde un archivo YAML. Si el backend se queda sin
+// capacidad, el resumen indica en reanudar_desde (y reanudar_desde_producto) la posición con la
+// que continuar usando --desde y --desde-producto.
+func sembrar(args []string) int {
+	flags := flag.NewFlagSet("seed", flag.ExitOnError)
+	archivo := flags.String("file", "", "archivo YAML con productores y productos")
+	desde := flags.Int("desde", 0, "índice del primer productor del archivo a cargar")
+	desdeProducto := flags.Int("desde-producto", 0, "índice del primer producto a cargar del productor indicado en --desde")
+	flags.Parse(args)
+
+	if *archivo == "" {
+		return terminar("seed", nil, fmt.Errorf("falta --file"))
+	}
+	data, err := os.ReadFile(*archivo)
+	if err != nil {
+		return terminar("seed", nil, err)
+	}
+	var fixture fixtureSemilla
+	if err := yaml.Unmarshal(data, &fixture); err != nil {
+		return terminar("seed", nil, fmt.Errorf("archivo inválido: %w", err))
+	}
+
+	app := construirAplicacion(cargarConfig())
+	defer app.cerrar()
+
+	productores, productos := 0, 0
+	// fallar arma el resumen de error; ante falta de capacidad agrega desde dónde reanudar
+	fallar := func(i, j int, productorCreado productor.ProductorID, err error) int {
+		datos := map[string]any{"productores": productores, "productos": productos}
+		if errors.Is(err, producto.ErrCapacidadAlcanzada) || errors.Is(err, productor.ErrCapacidadAlcanzada) {
+			datos["reanudar_desde"] = i
+			datos["reanudar_desde_producto"] = j
+			if productorCreado != "" {
+				// El productor ya quedó registrado: al reanudar se debe indicar su productor_id
+				datos["productor_id"] = productorCreado
+			}
+		}
+		return terminar("seed", datos, err)
+	}
+
+	for i, fp := range fixture.Productores {
+		if i < *desde {
+			continue
+		}
+
+		productorID := productor.ProductorID(fp.ProductorID)
+		var productorCreado productor.ProductorID
+		if productorID == "" {
+			prod, err := nuevoProductorSemilla(app, fp)
+			if err != nil {
+				return fallar(i, 0, "", fmt.Errorf("productor %d: %w", i, err))
+			}
+			if err := app.catalogo.RegistrarProductor(prod); err != nil {
+				return fallar(i, 0, "", fmt.Errorf("productor %d: %w", i, err))
+			}
+			productorID = prod.ID
+			productorCreado = prod.ID
+			productores++
+		}
+
+		for j, fprod := range fp.Productos {
+			if i == *desde && j < *desdeProducto {
+				continue
+			}
+			if err := publicarProductoSemilla(app, productorID, fprod); err != nil {
+				return fallar(i, j, productorCreado, fmt.Errorf("productor %d, producto %d: %w", i, j, err))
+			}
+			productos++
+		}
+	}
+
+	return terminar("seed", map[string]any{"productores": productores, "productos": productos}, nil)
+}
+
+func nuevoProductorSemilla(app *aplicacion, fp fixtureProductor) (*productor.Productor, error) {
+	nombre, err := productor.NewNombreProducto(fp.Nombre)
+	if err != nil {
+		return nil, err
+	}
+	ubicacion, err := productor.NewUbicacion(fp.ZonaVeredal, fp.Finca)
+	if err != nil {
+		return nil, err
+	}
+	reputacion, err := productor.NuevaReputacion(fp.Reputacion)
+	if err != nil {
+		return nil, err
+	}
+	practicas, err := productor.NuevaPracticasDeCultivo(fp.Practicas)
+	if err != nil {
+		return nil, err
+	}
+	valorVerificacion := productor.NoVerificado
+	if fp.Verificado {
+		valorVerificacion = productor.Verificado
+	}
+	verificacion, err := productor.NewEstadoVerificacion(valorVerificacion)
+	if err != nil {
+		return nil, err
+	}
+	actividad, err := productor.NewEstadoActividad(productor.Activo)
+	if err != nil {
+		return nil, err
+	}
+	return productor.NewProductor(app.ids.NewProductorID(), nombre, ubicacion, verificacion, actividad, reputacion, practicas)
+}
+
+// publicarProductoSemilla publica el producto a través del servicio, con las mismas reglas que la API
+func publicarProductoSemilla(app *aplicacion, productorID productor.ProductorID, fp fixtureProducto) error {
+	nombre, err := producto.NewNombreProducto(fp.Nombre)
+	if err != nil {
+		return err
+	}
+	desc, err := producto.NewDescripcionProducto(fp.Descripcion)
+	if err != nil {
+		return err
+	}
+	categoria, err := producto.NewCategoria(fp.Categoria)
+	if err != nil {
+		return err
+	}
+	inicio, err := time.ParseInLocation("2006-01-02", fp.TemporadaInicio, producto.ZonaHoraria())
+	if err != nil {
+		return fmt.Errorf("formato de fecha de inicio inválido")
+	}
+	fin, err := time.ParseInLocation("2006-01-02", fp.TemporadaFin, producto.ZonaHoraria())
+	if err != nil {
+		return fmt.Errorf("formato de fecha de fin inválido")
+	}
+	temporada, err := producto.NewTemporadaLocal(inicio, fin)
+	if err != nil {
+		return err
+	}
+	ubicacion, err := producto.NewUbicacion(fp.ZonaVeredal, fp.Finca)
+	if err != nil {
+		return err
+	}
+	imagen, err := producto.NewImagen(fp.ImagenURL, fp.ImagenDesc)
+	if err != nil {
+		return err
+	}
+	precio, err := producto.NewPrecio(fp.PrecioValor, fp.PrecioMoneda)
+	if err != nil {
+		return err
+	}
+	cantidad, err := producto.NewCantidadDisponible(fp.CantidadValor, fp.CantidadUnidad)
+	if err != nil {
+		return err
+	}
+
+	_, err = app.catalogo.PublicarProducto(
+		context.Background(),
+		productorID,
+		app.ids.NewProductoID(),
+		nombre,
+		desc,
+		categoria,
+		producto.TipoProduccion(fp.TipoProduccion),
+		temporada,
+		ubicacion,
+		imagen,
+		precio,
+		cantidad,
+		0,
+	)
+	return err
+}
+
+// recalcularDisponibilidad recalcula el estado de todos los productos según la temporada actual
+func recalcularDisponibilidad(args []string) int {
+	flags := flag.NewFlagSet("recalcular-disponibilidad", flag.ExitOnError)
+	flags.Parse(args)
+
+	app := construirAplicacion(cargarConfig())
+	defer app.cerrar()
+	err := app.catalogo.ActualizarDisponibilidadPorTemporada(time.Now())
+	return terminar("recalcular-disponibilidad", nil, err)
+}
+
+// auditarInvariantes imprime el reporte de invariantes; equivale a serve -auditar-invariantes
+func auditarInvariantes(args []string) int {
+	flags := flag.NewFlagSet("auditar-invariantes", flag.ExitOnError)
+	reparar := flags.Bool("reparar", false, "corrige los problemas seguros")
+	flags.Parse(args)
+
+	app := construirAplicacion(cargarConfig())
+	defer app.cerrar()
+	return ejecutarAuditoria(app.catalogo, *reparar)
+}
+
+// exportar escribe los productos o productores en CSV
+func exportar(args []string) int {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	tipo := flags.String("tipo", "productos", "qué exportar: productos o productores")
+	salida := flags.String("salida", "", "archivo CSV de salida (obligatorio)")
+	flags.Parse(args)
+
+	if *salida == "" {
+		return terminar("export", nil, fmt.Errorf("falta --salida"))
+	}
+
+	app := construirAplicacion(cargarConfig())
+	defer app.cerrar()
+
+	archivo, err := os.Create(*salida)
+	if err != nil {
+		return terminar("export", nil, err)
+	}
+	defer archivo.Close()
+
+	var registros int
+	switch *tipo {
+	case "productos":
+		registros, err = exportarProductos(app, archivo)
+	case "productores":
+		registros, err = exportarProductores(app, archivo)
+	default:
+		err = fmt.Errorf("tipo inválido %q, valores permitidos: productos, productores", *tipo)
+	}
+	if err == nil {
+		err = archivo.Close()
+	}
+
+	return terminar("export", map[string]any{"tipo": *tipo, "salida": *salida, "registros": registros}, err)
+}
+
+func exportarProductos(app *aplicacion, w io.Writer) (int, error) {
+	resultados, err := app.catalogo.BuscarProductosConFiltroAvanzado("", producto.ProductoFiltro{})
+	if err != nil {
+		return 0, err
+	}
+
+	escritor := csv.NewWriter(w)
+	escritor.Write([]string{"id", "nombre", "categoria", "tipo_produccion", "estado", "zona_veredal", "finca", "productor_id", "temporada_inicio", "temporada_fin", "precio_valor", "precio_moneda", "cantidad_valor", "cantidad_unidad"})
+	for _, r := range resultados {
+		p := r.Producto
+		escritor.Write([]string{
+			string(p.ID),
+			p.Nombre.Value,
+			string(p.Categoria),
+			string(p.TipoProduccion),
+			p.Estado.Value,
+			p.Ubicacion.ZonaVeredal,
+			p.Ubicacion.Finca,
+			p.ProductorID,
+			p.Temporada.Inicio.Format("2006-01-02"),
+			p.Temporada.Fin.Format("2006-01-02"),
+			strconv.FormatFloat(p.Precio.Valor, 'f', -1, 64),
+			p.Precio.Moneda,
+			strconv.Itoa(p.Cantidad.Valor),
+			p.Cantidad.Unidad,
+		})
+	}
+	escritor.Flush()
+	return len(resultados), escritor.Error()
+}
+
+func exportarProductores(app *aplicacion, w io.Writer) (int, error) {
+	productores, err := app.catalogo.GetProductores()
+	if err != nil {
+		return 0, err
+	}
+
+	escritor := csv.NewWriter(w)
+	escritor.Write([]string{"id", "nombre", "zona_veredal", "finca", "verificacion", "actividad", "reputacion", "fecha_registro"})
+	for _, p := range productores {
+		escritor.Write([]string{
+			string(p.ID),
+			p.Nombre.Value,
+			p.Ubicacion.ZonaVeredal,
+			p.Ubicacion.Finca,
+			p.EstadoVerificacion.Value,
+			p.EstadoActividad.Value,
+			strconv.FormatFloat(float64(p.Reputacion), 'f', 2, 32),
+			p.FechaRegistro.Format(time.RFC3339),
+		})
+	}
+	escritor.Flush()
+	return len(productores), escritor.Error()
+}
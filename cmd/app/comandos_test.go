@@ -0,0 +1,153 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const fixtureValido = `
+productores:
+  - nombre: Ana Ruiz
+    zona_veredal: Vereda El Placer
+    finca: Finca Los Robles
+    reputacion: 4.2
+    practicas: Abonos orgánicos
+    verificado: true
+    productos:
+      - nombre: Fresa
+        descripcion: Fresas cultivadas sin agroquímicos
+        categoria: Fruta
+        tipo_produccion: Agroecologico
+        temporada_inicio: "2030-01-01"
+        temporada_fin: "2030-03-01"
+        zona_veredal: Vereda El Placer
+        finca: Finca Los Robles
+        imagen_url: https://img.example.com/fresa.jpg
+        imagen_desc: Fresas
+        precio_valor: 4500
+        precio_moneda: COP
+        cantidad_valor: 40
+        cantidad_unidad: kg
+`
+
+func escribirArchivo(t *testing.T, nombre, contenido string) string {
+	t.Helper()
+	ruta := filepath.Join(t.TempDir(), nombre)
+	if err := os.WriteFile(ruta, []byte(contenido), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	return ruta
+}
+
+func TestSembrar(t *testing.T) {
+	if codigo := sembrar([]string{"--file", escribirArchivo(t, "fixtures.yaml", fixtureValido)}); codigo != 0 {
+		t.Errorf("seed con un archivo válido terminó con código %d", codigo)
+	}
+
+	// Un producto que no pasa las validaciones del servicio hace fallar el comando
+	invalido := escribirArchivo(t, "invalido.yaml", `
+productores:
+  - nombre: Ana Ruiz
+    zona_veredal: Vereda El Placer
+    finca: Finca Los Robles
+    reputacion: 4.2
+    practicas: Abonos orgánicos
+    verificado: true
+    productos:
+      - nombre: Fresa
+        categoria: Desconocida
+`)
+	if codigo := sembrar([]string{"--file", invalido}); codigo != 1 {
+		t.Errorf("seed con un producto inválido terminó con código %d, se esperaba 1", codigo)
+	}
+	if codigo := sembrar(nil); codigo != 1 {
+		t.Errorf("seed sin --file terminó con código %d, se esperaba 1", codigo)
+	}
+}
+
+// capturarResumen ejecuta el subcomando y decodifica el resumen que imprime en stdout
+func capturarResumen(t *testing.T, subcomando func() int) (int, resumenComando) {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	original := os.Stdout
+	os.Stdout = w
+	codigo := subcomando()
+	os.Stdout = original
+	w.Close()
+
+	salida, _ := io.ReadAll(r)
+	var resumen resumenComando
+	if err := json.Unmarshal(salida, &resumen); err != nil {
+		t.Fatalf("resumen inválido: %v\n%s", err, salida)
+	}
+	return codigo, resumen
+}
+
+func TestSembrar_SinCapacidadIndicaDondeReanudar(t *testing.T) {
+	t.Setenv("CATALOGO_MAX_PRODUCTOS", "1")
+	fixture := fixtureValido + `
+      - nombre: Mora
+        descripcion: Moras cultivadas sin agroquímicos
+        categoria: Fruta
+        tipo_produccion: Agroecologico
+        temporada_inicio: "2030-01-01"
+        temporada_fin: "2030-03-01"
+        zona_veredal: Vereda El Placer
+        finca: Finca Los Robles
+        imagen_url: https://img.example.com/mora.jpg
+        imagen_desc: Moras
+        precio_valor: 6000
+        precio_moneda: COP
+        cantidad_valor: 40
+        cantidad_unidad: kg
+`
+	archivo := escribirArchivo(t, "fixtures.yaml", fixture)
+
+	codigo, resumen := capturarResumen(t, func() int { return sembrar([]string{"--file", archivo}) })
+	if codigo != 1 {
+		t.Fatalf("código = %d, se esperaba 1", codigo)
+	}
+	if resumen.Datos["reanudar_desde"] != 0.0 || resumen.Datos["reanudar_desde_producto"] != 1.0 || resumen.Datos["productor_id"] == nil {
+		t.Errorf("datos = %v, se esperaba reanudar desde el productor 0, producto 1, con su productor_id", resumen.Datos)
+	}
+
+	// Reanudando desde la posición indicada solo queda por cargar la Mora
+	codigo, resumen = capturarResumen(t, func() int {
+		return sembrar([]string{"--file", archivo, "--desde", "0", "--desde-producto", "1"})
+	})
+	if codigo != 0 || resumen.Datos["productos"] != 1.0 {
+		t.Errorf("código = %d, datos = %v; se esperaba cargar un producto", codigo, resumen.Datos)
+	}
+}
+
+func TestExportar_Productores(t *testing.T) {
+	salida := filepath.Join(t.TempDir(), "productores.csv")
+	if codigo := exportar([]string{"--tipo", "productores", "--salida", salida}); codigo != 0 {
+		t.Fatalf("export terminó con código %d", codigo)
+	}
+
+	archivo, err := os.Open(salida)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer archivo.Close()
+	filas, err := csv.NewReader(archivo).ReadAll()
+	if err != nil {
+		t.Fatalf("CSV inválido: %v", err)
+	}
+	// Encabezado más los dos productores semilla del repositorio en memoria
+	if len(filas) != 3 || filas[0][0] != "id" {
+		t.Errorf("filas = %v, se esperaba el encabezado y 2 productores", filas)
+	}
+
+	if codigo := exportar([]string{"--tipo", "pedidos", "--salida", salida}); codigo != 1 {
+		t.Errorf("export con un tipo inválido terminó con código %d, se esperaba 1", codigo)
+	}
+}
@@ -0,0 +1,297 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+	"Product_Catalog_Microservice/internal/domain/productor"
+	"Product_Catalog_Microservice/internal/domain/service"
+	"Product_Catalog_Microservice/internal/handlers"
+	"Product_Catalog_Microservice/internal/ids"
+)
+
+// Datos de compilación, inyectados con:
+//
+//	go build -ldflags "-X main.version=1.2.0 -X main.commit=$(git rev-parse --short HEAD)"
+var (
+	version = "dev"
+	commit  = "desconocido"
+)
+
+// Backends de repositorios
+const (
+	BackendRepositoriosMemoria  = "memoria"  // repositorios en memoria, se pierden al reiniciar
+	BackendRepositoriosPostgres = "postgres" // PostgreSQL con el esquema de migrations/
+)
+
+// Backends de eventos de dominio
+const (
+	BackendEventosEnProceso = "en_proceso" // solo suscriptores dentro del proceso
+	BackendEventosKafka     = "kafka"      // además se publican en Kafka
+)
+
+// Config reúne la configuración efectiva del servicio leída del entorno.
+// No debe contener credenciales: se expone tal cual en catalogo/admin/configuracion.
+type Config struct {
+	Version             string `json:"version"`
+	Commit              string `json:"commit"`
+	ZonaHoraria         string `json:"zona_horaria"`
+	BackendRepositorios string `json:"backend_repositorios"`
+	BackendEventos      string `json:"backend_eventos"`
+	FormatoIDs          string `json:"formato_ids"`
+	CacheTTLSegundos    int    `json:"cache_ttl_segundos"`
+
+	// Límite de concurrencia de cada grupo de rutas costosas, por nombre de grupo
+	LimitesRutasCostosas map[string]LimiteRuta `json:"limites_rutas_costosas"`
+
+	// Token de las rutas catalogo/admin; sin él esas rutas no se registran. Nunca se expone.
+	AdminToken      string `json:"-"`
+	AdminHabilitado bool   `json:"admin_habilitado"`
+
+	// Solo con BackendRepositorios postgres. Incluye la contraseña: nunca se expone.
+	DatabaseURL string `json:"-"`
+
+	// Caché en Redis de los productos leídos por ID; sin RedisURL no se usa
+	RedisURL              string `json:"-"`
+	CacheRedis            bool   `json:"cache_redis"`
+	CacheRedisTTLSegundos int    `json:"cache_redis_ttl_segundos,omitempty"`
+
+	// Solo con BackendEventos kafka
+	KafkaBrokers     []string          `json:"kafka_brokers,omitempty"`
+	KafkaTopicPrefix string            `json:"kafka_topic_prefix,omitempty"`
+	KafkaProducer    map[string]string `json:"kafka_producer,omitempty"`
+
+	TransicionesEstrictas []string `json:"transiciones_estrictas"`
+	CatalogoEstricto      bool     `json:"catalogo_estricto"`
+
+	MaxRechazosPorProductor  int `json:"max_rechazos_por_productor"`
+	PresupuestoPublicacionMs int `json:"presupuesto_publicacion_ms"`
+	UmbralStockBajo          int `json:"umbral_stock_bajo"`
+	IdempotenciaTTLSegundos  int `json:"idempotencia_ttl_segundos"`
+
+	// Reputación mínima por defecto de catalogo/productores/aptos
+	MinReputacionAptos float32 `json:"min_reputacion_aptos"`
+
+	// Capacidad del backend en memoria; cero es sin límite
+	MaxProductos   int `json:"max_productos"`
+	MaxProductores int `json:"max_productores"`
+
+	MuestreoLogPorcentaje int `json:"muestreo_log_porcentaje"`
+	UmbralLogLentoMs      int `json:"umbral_log_lento_ms"`
+
+	VistasArchivo string                  `json:"vistas_archivo"`
+	Vistas        []service.VistaCatalogo `json:"vistas"`
+}
+
+// LimiteRuta es el límite de concurrencia de un grupo de rutas costosas
+type LimiteRuta struct {
+	Limite   int `json:"limite"`    // peticiones simultáneas
+	EsperaMs int `json:"espera_ms"` // espera máxima por un cupo antes de responder 503
+}
+
+// limiteRutaCostosaPorDefecto aplica a los grupos sin configuración propia
+var limiteRutaCostosaPorDefecto = LimiteRuta{Limite: 16, EsperaMs: 250}
+
+// cargarConfig lee la configuración del entorno aplicando valores por defecto
+func cargarConfig() Config {
+	cfg := Config{
+		Version:             version,
+		Commit:              commit,
+		ZonaHoraria:         os.Getenv("CATALOGO_ZONA_HORARIA"),
+		BackendRepositorios: os.Getenv("CATALOGO_BACKEND_REPOSITORIOS"),
+		BackendEventos:      os.Getenv("CATALOGO_BACKEND_EVENTOS"),
+		FormatoIDs:          os.Getenv("ID_FORMAT"),
+		CacheTTLSegundos:    enteroDesdeEntorno("CATALOGO_CACHE_TTL_S", 600),
+
+		LimitesRutasCostosas: limitesRutasCostosasDesdeEntorno(),
+
+		TransicionesEstrictas: listaDesdeEntorno("CATALOGO_TRANSICIONES_ESTRICTAS"),
+		CatalogoEstricto:      os.Getenv("CATALOGO_COMPLETO_ESTRICTO") == "true",
+
+		MaxRechazosPorProductor:  enteroDesdeEntorno("CATALOGO_MAX_RECHAZOS_POR_PRODUCTOR", 50),
+		PresupuestoPublicacionMs: enteroDesdeEntorno("CATALOGO_PRESUPUESTO_PUBLICACION_MS", 300),
+		UmbralStockBajo:          enteroDesdeEntorno("CATALOGO_UMBRAL_STOCK_BAJO", 5),
+		IdempotenciaTTLSegundos:  enteroDesdeEntorno("CATALOGO_IDEMPOTENCIA_TTL_S", 86400),
+
+		MinReputacionAptos: reputacionDesdeEntorno("CATALOGO_MIN_REPUTACION_APTOS", 3),
+
+		MaxProductos:   enteroDesdeEntorno("CATALOGO_MAX_PRODUCTOS", 0),
+		MaxProductores: enteroDesdeEntorno("CATALOGO_MAX_PRODUCTORES", 0),
+
+		MuestreoLogPorcentaje: enteroDesdeEntorno("CATALOGO_LOG_MUESTREO_PCT", 10),
+		UmbralLogLentoMs:      enteroDesdeEntorno("CATALOGO_LOG_LENTO_MS", 500),
+
+		VistasArchivo: os.Getenv("CATALOGO_VISTAS_ARCHIVO"),
+		Vistas:        make([]service.VistaCatalogo, 0),
+	}
+
+	// Zona horaria en la que se interpretan las fechas de temporada
+	if cfg.ZonaHoraria == "" {
+		cfg.ZonaHoraria = "America/Bogota"
+	}
+	if loc, err := time.LoadLocation(cfg.ZonaHoraria); err != nil {
+		log.Printf("Zona horaria %q inválida, se usa America/Bogota: %v\n", cfg.ZonaHoraria, err)
+		cfg.ZonaHoraria = "America/Bogota"
+	} else {
+		producto.ConfigurarZonaHoraria(loc)
+	}
+
+	// Almacenamiento de productos y productores
+	switch cfg.BackendRepositorios {
+	case BackendRepositoriosPostgres:
+		cfg.DatabaseURL = os.Getenv("DATABASE_URL")
+		if cfg.DatabaseURL == "" {
+			log.Printf("CATALOGO_BACKEND_REPOSITORIOS=postgres sin DATABASE_URL; se usan repositorios en memoria\n")
+			cfg.BackendRepositorios = BackendRepositoriosMemoria
+		}
+	case "", BackendRepositoriosMemoria:
+		cfg.BackendRepositorios = BackendRepositoriosMemoria
+	default:
+		log.Printf("Backend de repositorios %q inválido, se usa %s\n", cfg.BackendRepositorios, BackendRepositoriosMemoria)
+		cfg.BackendRepositorios = BackendRepositoriosMemoria
+	}
+
+	// Rutas de administración, solo con token
+	if cfg.AdminToken = os.Getenv("CATALOGO_ADMIN_TOKEN"); cfg.AdminToken != "" {
+		cfg.AdminHabilitado = true
+	} else {
+		log.Printf("Sin CATALOGO_ADMIN_TOKEN; las rutas catalogo/admin no se registran\n")
+	}
+
+	// Caché de productos en Redis, opcional con cualquier backend de repositorios
+	if cfg.RedisURL = os.Getenv("REDIS_URL"); cfg.RedisURL != "" {
+		cfg.CacheRedis = true
+		cfg.CacheRedisTTLSegundos = enteroDesdeEntorno("CATALOGO_CACHE_REDIS_TTL_S", 60)
+	}
+
+	// Destino de los eventos de dominio: solo en proceso o además Kafka
+	switch cfg.BackendEventos {
+	case BackendEventosKafka:
+		cfg.KafkaBrokers = listaDesdeEntorno("KAFKA_BROKERS")
+		cfg.KafkaTopicPrefix = os.Getenv("CATALOGO_KAFKA_TOPIC_PREFIX")
+		if cfg.KafkaTopicPrefix == "" {
+			cfg.KafkaTopicPrefix = "catalogo"
+		}
+		cfg.KafkaProducer = mapaDesdeEntorno("CATALOGO_KAFKA_PRODUCER")
+		if len(cfg.KafkaBrokers) == 0 {
+			log.Printf("CATALOGO_BACKEND_EVENTOS=kafka sin KAFKA_BROKERS; los eventos solo se publican en proceso\n")
+			cfg.BackendEventos = BackendEventosEnProceso
+		}
+	case "", BackendEventosEnProceso:
+		cfg.BackendEventos = BackendEventosEnProceso
+	default:
+		log.Printf("Backend de eventos %q inválido, se usa %s\n", cfg.BackendEventos, BackendEventosEnProceso)
+		cfg.BackendEventos = BackendEventosEnProceso
+	}
+
+	// Cantidad por debajo de la cual se emite ProductoStockBajo
+	producto.ConfigurarUmbralStockBajo(cfg.UmbralStockBajo)
+
+	// Formato de los IDs generados para productos y productores
+	if cfg.FormatoIDs == "" {
+		cfg.FormatoIDs = ids.FormatoUUID
+	}
+	if _, err := ids.NewDesdeFormato(cfg.FormatoIDs); err != nil {
+		log.Printf("%v; se usa uuid\n", err)
+		cfg.FormatoIDs = ids.FormatoUUID
+	}
+
+	// Vistas con nombre para compradores institucionales
+	if cfg.VistasArchivo != "" {
+		vistas, err := cargarVistas(cfg.VistasArchivo)
+		if err != nil {
+			log.Printf("No se pudieron cargar las vistas de %q: %v\n", cfg.VistasArchivo, err)
+		} else {
+			cfg.Vistas = vistas
+		}
+	}
+
+	return cfg
+}
+
+// cargarVistas lee las definiciones de vistas del catálogo desde un archivo JSON
+func cargarVistas(ruta string) ([]service.VistaCatalogo, error) {
+	data, err := os.ReadFile(ruta)
+	if err != nil {
+		return nil, err
+	}
+	var vistas []service.VistaCatalogo
+	if err := json.Unmarshal(data, &vistas); err != nil {
+		return nil, err
+	}
+	return vistas, nil
+}
+
+// enteroDesdeEntorno lee una variable de entorno entera positiva o retorna el valor por defecto
+func enteroDesdeEntorno(nombre string, defecto int) int {
+	valor, err := strconv.Atoi(os.Getenv(nombre))
+	if err != nil || valor <= 0 {
+		return defecto
+	}
+	return valor
+}
+
+// limitesRutasCostosasDesdeEntorno lee el límite de cada grupo de rutas costosas.
+// CATALOGO_LIMITE_RUTAS_COSTOSAS y CATALOGO_ESPERA_RUTAS_COSTOSAS_MS fijan los valores comunes;
+// CATALOGO_LIMITE_RUTAS_COSTOSAS_{GRUPO} y CATALOGO_ESPERA_RUTAS_COSTOSAS_{GRUPO}_MS los
+// reemplazan para un grupo, p. ej. CATALOGO_LIMITE_RUTAS_COSTOSAS_BUSCAR.
+func limitesRutasCostosasDesdeEntorno() map[string]LimiteRuta {
+	comun := LimiteRuta{
+		Limite:   enteroDesdeEntorno("CATALOGO_LIMITE_RUTAS_COSTOSAS", limiteRutaCostosaPorDefecto.Limite),
+		EsperaMs: enteroDesdeEntorno("CATALOGO_ESPERA_RUTAS_COSTOSAS_MS", limiteRutaCostosaPorDefecto.EsperaMs),
+	}
+	limites := make(map[string]LimiteRuta, len(handlers.GruposCostosos))
+	for _, grupo := range handlers.GruposCostosos {
+		sufijo := strings.ToUpper(grupo)
+		limites[grupo] = LimiteRuta{
+			Limite:   enteroDesdeEntorno("CATALOGO_LIMITE_RUTAS_COSTOSAS_"+sufijo, comun.Limite),
+			EsperaMs: enteroDesdeEntorno("CATALOGO_ESPERA_RUTAS_COSTOSAS_"+sufijo+"_MS", comun.EsperaMs),
+		}
+	}
+	return limites
+}
+
+// reputacionDesdeEntorno lee una variable de entorno con una reputación válida (0 a 5)
+// o retorna el valor por defecto
+func reputacionDesdeEntorno(nombre string, defecto float32) float32 {
+	valor, err := strconv.ParseFloat(os.Getenv(nombre), 32)
+	if err != nil {
+		return defecto
+	}
+	reputacion, err := productor.NuevaReputacion(float32(valor))
+	if err != nil {
+		log.Printf("%s inválida, se usa %v: %v\n", nombre, defecto, err)
+		return defecto
+	}
+	return float32(reputacion)
+}
+
+// mapaDesdeEntorno lee una variable de entorno con pares clave=valor separados por comas
+func mapaDesdeEntorno(nombre string) map[string]string {
+	pares := make(map[string]string)
+	for _, par := range listaDesdeEntorno(nombre) {
+		clave, valor, ok := strings.Cut(par, "=")
+		if !ok {
+			log.Printf("%s: se ignora %q, se espera clave=valor\n", nombre, par)
+			continue
+		}
+		pares[strings.TrimSpace(clave)] = strings.TrimSpace(valor)
+	}
+	return pares
+}
+
+// listaDesdeEntorno lee una variable de entorno con valores separados por comas
+func listaDesdeEntorno(nombre string) []string {
+	valores := make([]string, 0)
+	for _, valor := range strings.Split(os.Getenv(nombre), ",") {
+		if valor = strings.TrimSpace(valor); valor != "" {
+			valores = append(valores, valor)
+		}
+	}
+	return valores
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+	"Product_Catalog_Microservice/internal/domain/productor"
+	"Product_Catalog_Microservice/internal/domain/service"
+	"Product_Catalog_Microservice/internal/infrastructure/events"
+)
+
+// eventosDominio lista los eventos que emiten los agregados
+var eventosDominio = []any{
+	producto.ProductoPublicado{},
+	producto.ProductoMarcadoComoExcedente{},
+	producto.ProductoStockBajo{},
+	producto.ProductoAgotado{},
+	productor.ProductorCreado{},
+	productor.ProductorEnVerificacion{},
+	productor.ProductorVerificado{},
+	productor.ReputacionActualizada{},
+	productor.ProductorSuspendido{},
+	productor.ProductorReactivado{},
+	productor.ProductorDesactivado{},
+	productor.PreferenciasNotificacionActualizadas{},
+}
+
+// nuevoPublicadorEventos crea el publicador en proceso. Cada evento se registra en el log y,
+// si externo no es nil, se reenvía a él (p. ej. Kafka). Los demás consumidores se suscriben aquí.
+func nuevoPublicadorEventos(logger *slog.Logger, externo service.EventPublisher) *events.InProcessEventPublisher {
+	publicador := events.NewInProcessEventPublisher()
+	for _, tipo := range eventosDominio {
+		publicador.Subscribe(tipo, func(event any) error {
+			logger.Info("evento de dominio", "tipo", fmt.Sprintf("%T", event), "evento", fmt.Sprintf("%+v", event))
+			return nil
+		})
+		if externo != nil {
+			publicador.Subscribe(tipo, externo.Publish)
+		}
+	}
+	return publicador
+}
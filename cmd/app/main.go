@@ -1,11 +1,30 @@
 package main
 
 import (
+	"database/sql"
+	"encoding/json"
+	"flag"
+	"fmt"
 	"log"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+	_ "time/tzdata" // zonas horarias embebidas para contenedores sin tzdata
+
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/redis/go-redis/v9"
 
+	"Product_Catalog_Microservice/internal/domain/producto"
+	"Product_Catalog_Microservice/internal/domain/productor"
 	"Product_Catalog_Microservice/internal/domain/service"
 	"Product_Catalog_Microservice/internal/handlers"
+	"Product_Catalog_Microservice/internal/ids"
+	"Product_Catalog_Microservice/internal/infrastructure/cache"
+	"Product_Catalog_Microservice/internal/infrastructure/events"
+	"Product_Catalog_Microservice/internal/infrastructure/persistence/postgres"
 	"Product_Catalog_Microservice/internal/repository"
 	
 
@@ -16,44 +35,345 @@ import (
 //   - producto.ProductoRepositoryInterface
 //   - productor.ProductorRepositoryInterface
 
-// DummyEventPublisher es una implementación temporal de EventPublisher
-type DummyEventPublisher struct{}
+// limitarRutaCostosa crea un limitador de concurrencia para una ruta costosa del grupo indicado,
+// con el límite configurado para ese grupo; las peticiones rechazadas se cuentan en descartadas
+func limitarRutaCostosa(cfg Config, grupo string, descartadas *prometheus.CounterVec) gin.HandlerFunc {
+	limite, ok := cfg.LimitesRutasCostosas[grupo]
+	if !ok {
+		limite = limiteRutaCostosaPorDefecto
+	}
+	espera := time.Duration(limite.EsperaMs) * time.Millisecond
+	return handlers.LimitarConcurrencia(limite.Limite, espera, descartadas)
+}
+
+// ejecutarAuditoria imprime el reporte de invariantes en stdout y retorna el código de salida:
+// 0 sin violaciones pendientes, 1 si quedan violaciones o la auditoría falla.
+func ejecutarAuditoria(catalogo *service.CatalogoService, reparar bool) int {
+	reporte, err := catalogo.AuditarInvariantes(reparar)
+	if err != nil {
+		log.Printf("Error auditando invariantes: %v\n", err)
+		return 1
+	}
+
+	salida := json.NewEncoder(os.Stdout)
+	salida.SetIndent("", "  ")
+	if err := salida.Encode(reporte); err != nil {
+		log.Printf("Error escribiendo el reporte: %v\n", err)
+		return 1
+	}
+
+	for _, v := range reporte.Violaciones {
+		if !v.Reparado {
+			return 1
+		}
+	}
+	return 0
+}
+
+// aplicacion reúne las dependencias construidas a partir de la configuración. La comparten
+// el servidor y los subcomandos para que todos usen los mismos repositorios y servicio.
+type aplicacion struct {
+	cfg           Config
+	ids           ids.IDGenerator
+	productoRepo  producto.ProductoRepositoryInterface
+	productorRepo productor.ProductorRepositoryInterface
+	catalogo      *service.CatalogoService
+	metricas      *prometheus.Registry
+
+	// logger es el que instala nuevoLogger; se inyecta en el servicio, los handlers y el access log
+	logger *slog.Logger
+
+	// capacidad reporta la utilización de los repositorios en memoria; nil con postgres
+	capacidad func() any
+
+	// dependencias que comprueba GET /readyz
+	dependencias map[string]handlers.HealthChecker
+
+	// db es nil salvo con CATALOGO_BACKEND_REPOSITORIOS=postgres
+	db *sql.DB
+
+	// redis es nil salvo con REDIS_URL
+	redis *redis.Client
 
-func (d *DummyEventPublisher) Publish(event any) error {
-	// Aquí podrías loggear el evento o simplemente ignorarlo
-	return nil
+	// kafka es nil salvo con CATALOGO_BACKEND_EVENTOS=kafka
+	kafka *events.KafkaEventPublisher
 }
 
+// cerrar libera los recursos externos; se invoca al terminar el servidor o un subcomando
+func (app *aplicacion) cerrar() {
+	if app.kafka != nil {
+		if err := app.kafka.Close(); err != nil {
+			log.Printf("No se pudo cerrar el publicador de Kafka: %v\n", err)
+		}
+	}
+	if app.redis != nil {
+		if err := app.redis.Close(); err != nil {
+			log.Printf("No se pudo cerrar la conexión a Redis: %v\n", err)
+		}
+	}
+	if app.db != nil {
+		if err := app.db.Close(); err != nil {
+			log.Printf("No se pudo cerrar la conexión a la base de datos: %v\n", err)
+		}
+	}
+}
+
+// construirAplicacion crea los repositorios, el registro de métricas y el servicio según la
+// configuración
+func construirAplicacion(cfg Config) *aplicacion {
+	// cargarConfig ya validó el formato
+	generadorIDs, _ := ids.NewDesdeFormato(cfg.FormatoIDs)
+	logger := slog.Default()
+
+	// Repositorios de productos y productores
+	var (
+		productoRepo  producto.ProductoRepositoryInterface
+		productorRepo productor.ProductorRepositoryInterface
+		capacidad     func() any
+		db            *sql.DB
+		dependencias  = make(map[string]handlers.HealthChecker)
+
+		// Solo con el backend en memoria, que es el único con capacidad máxima
+		productoMemoria  *repository.ProductoRepository
+		productorMemoria *repository.ProductorRepository
+	)
+	if cfg.BackendRepositorios == BackendRepositoriosPostgres {
+		var err error
+		db, err = abrirPostgres(cfg.DatabaseURL)
+		if err != nil {
+			log.Fatalf("No se pudo conectar a PostgreSQL: %v", err)
+		}
+		productoPostgres := postgres.NewProductoRepositoryPostgres(db)
+		productorPostgres := postgres.NewProductorRepositoryPostgres(db)
+		productoRepo, productorRepo = productoPostgres, productorPostgres
+		dependencias["productos"], dependencias["productores"] = productoPostgres, productorPostgres
+	} else {
+		productoMemoria = repository.NewProductoRepository(cfg.MaxProductos)
+		productorMemoria = repository.NewProductorRepository(cfg.MaxProductores)
+		productoRepo, productorRepo = productoMemoria, productorMemoria
+		dependencias["productos"], dependencias["productores"] = productoMemoria, productorMemoria
+		capacidad = func() any {
+			return []repository.Capacidad{productoMemoria.Capacidad(), productorMemoria.Capacidad()}
+		}
+	}
+
+	// Caché de lecturas por ID delante del repositorio de productos
+	var clienteRedis *redis.Client
+	if cfg.CacheRedis {
+		opciones, err := redis.ParseURL(cfg.RedisURL)
+		if err != nil {
+			log.Fatalf("REDIS_URL inválida: %v", err)
+		}
+		clienteRedis = redis.NewClient(opciones)
+		productoRepo = cache.NewRedisProductoRepository(
+			productoRepo,
+			clienteRedis,
+			time.Duration(cfg.CacheRedisTTLSegundos)*time.Second,
+			logger,
+		)
+	}
 
+	// Rechazos e idempotencia siguen en memoria con cualquier backend
+	rechazoRepo := repository.NewRechazoRepository(cfg.MaxRechazosPorProductor)
+	idempotenciaRepo := repository.NewIdempotenciaRepository(time.Duration(cfg.IdempotenciaTTLSegundos) * time.Second)
+
+	// Eventos de dominio
+	var kafka *events.KafkaEventPublisher
+	var externo service.EventPublisher
+	if cfg.BackendEventos == BackendEventosKafka {
+		var err error
+		kafka, err = events.NewKafkaEventPublisher(events.KafkaConfig{
+			Brokers:        cfg.KafkaBrokers,
+			TopicPrefix:    cfg.KafkaTopicPrefix,
+			ProducerConfig: cfg.KafkaProducer,
+		})
+		if err != nil {
+			log.Fatalf("Configuración de Kafka inválida: %v", err)
+		}
+		externo = kafka
+	}
+
+	// Registro propio de métricas. Los eventos pasan por las métricas de negocio antes de
+	// llegar al publicador
+	registroMetricas := prometheus.NewRegistry()
+	registroMetricas.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	publicador := nuevoPublicadorEventos(logger, externo)
+	metricasNegocio := handlers.NuevasMetricasNegocio(registroMetricas, publicador)
+	degradaciones := handlers.NuevoContadorDegradaciones(registroMetricas)
+	if productoMemoria != nil {
+		handlers.NuevoGaugeCapacidad(registroMetricas, "productos", func() (int, int) {
+			c := productoMemoria.Capacidad()
+			return c.Usados, c.Maximo
+		})
+		handlers.NuevoGaugeCapacidad(registroMetricas, "productores", func() (int, int) {
+			c := productorMemoria.Capacidad()
+			return c.Usados, c.Maximo
+		})
+	}
+
+	// Servicio
+	eventPublisher := metricasNegocio
+	dependencias["eventos"] = publicador
+	if kafka != nil {
+		dependencias["eventos"] = kafka
+	}
+	catalogoService := service.NewCatalogoService(
+		productorRepo,
+		productoRepo,
+		eventPublisher,
+		service.WithCatalogoCacheTTL(time.Duration(cfg.CacheTTLSegundos)*time.Second),
+		service.WithTransicionesEstrictas(cfg.TransicionesEstrictas...),
+		service.WithVistasCatalogo(cfg.Vistas),
+		service.WithCatalogoEstricto(cfg.CatalogoEstricto),
+		service.WithAlDegradar(func(motivo string) {
+			degradaciones.WithLabelValues(motivo).Inc()
+		}),
+		service.WithRechazoRepository(rechazoRepo),
+		service.WithIdempotenciaRepository(idempotenciaRepo),
+		service.WithMetricas(handlers.NuevasMetricasCatalogo(registroMetricas)),
+		service.WithLogger(logger),
+	)
+
+	return &aplicacion{
+		cfg:           cfg,
+		ids:           generadorIDs,
+		productoRepo:  productoRepo,
+		productorRepo: productorRepo,
+		catalogo:      catalogoService,
+		metricas:      registroMetricas,
+		logger:        logger,
+		capacidad:     capacidad,
+		dependencias:  dependencias,
+		db:            db,
+		redis:         clienteRedis,
+		kafka:         kafka,
+	}
+}
+
+// nuevoLogger crea el logger JSON del servicio en stderr, para no mezclarse con la salida de
+// los subcomandos, y lo vuelve el logger por defecto para que los mensajes del paquete log
+// también salgan en JSON
+func nuevoLogger() *slog.Logger {
+	logger := slog.New(slog.NewJSONHandler(os.Stderr, nil))
+	slog.SetDefault(logger)
+	return logger
+}
+
+// main despacha el subcomando indicado en el primer argumento; sin subcomando se usa serve.
 func main() {
-	// Repositorios en memoria (simulación por ahora)
-	productoRepo := repository.NewProductoRepository()
-	productorRepo := repository.NewProductorRepository()
+	nuevoLogger()
+
+	comando, args := "serve", os.Args[1:]
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		comando, args = args[0], args[1:]
+	}
+
+	switch comando {
+	case "serve":
+		os.Exit(servir(args))
+	case "seed":
+		os.Exit(sembrar(args))
+	case "recalcular-disponibilidad":
+		os.Exit(recalcularDisponibilidad(args))
+	case "auditar-invariantes":
+		os.Exit(auditarInvariantes(args))
+	case "export":
+		os.Exit(exportar(args))
+	default:
+		fmt.Fprintf(os.Stderr, "subcomando desconocido %q; disponibles: serve, seed, recalcular-disponibilidad, auditar-invariantes, export\n", comando)
+		os.Exit(2)
+	}
+}
+
+// servir arranca el servidor HTTP. Conserva los flags -auditar-invariantes y -reparar
+// anteriores a los subcomandos.
+func servir(args []string) int {
+	flags := flag.NewFlagSet("serve", flag.ExitOnError)
+	auditar := flags.Bool("auditar-invariantes", false, "audita los agregados, imprime el reporte en JSON y termina")
+	reparar := flags.Bool("reparar", false, "con -auditar-invariantes, corrige los problemas seguros")
+	flags.Parse(args)
+
+	cfg := cargarConfig()
+	app := construirAplicacion(cfg)
+	defer app.cerrar()
+	catalogoService := app.catalogo
+	generadorIDs := app.ids
+	registroMetricas := app.metricas
 
 	// Imprimir los IDs de los productores guardados
-	if all, err := productorRepo.GetAll(); err == nil {
+	if all, err := app.productorRepo.GetAll(); err == nil {
 		log.Println("Productores cargados por defecto:")
 		for _, prod := range all {
 			log.Printf("ID: %s, Nombre: %s\n", prod.ID, prod.Nombre.Value)
 		}
 	}
 
-	// Servicio
-	eventPublisher := &DummyEventPublisher{}
-	catalogoService := service.NewCatalogoService(productorRepo, productoRepo, eventPublisher)
+	if *auditar {
+		return ejecutarAuditoria(catalogoService, *reparar)
+	}
 
 	// Handler
-	productoHandler := &handlers.ProductoHandler{Catalogo: catalogoService}
+	productoHandler := &handlers.ProductoHandler{
+		Catalogo:               catalogoService,
+		IDs:                    generadorIDs,
+		PresupuestoPublicacion: time.Duration(cfg.PresupuestoPublicacionMs) * time.Millisecond,
+		Logger:                 app.logger,
+		EtapasPublicacion:      handlers.NuevoHistogramaEtapasPublicacion(registroMetricas),
+	}
+	productorHandler := &handlers.ProductorHandler{
+		Catalogo:           catalogoService,
+		IDs:                generadorIDs,
+		MinReputacionAptos: productor.Reputacion(cfg.MinReputacionAptos),
+	}
+	adminHandler := &handlers.AdminHandler{
+		Catalogo:      catalogoService,
+		Configuracion: cfg,
+		Capacidad:     app.capacidad,
+	}
+
+	saludHandler := &handlers.SaludHandler{Dependencias: app.dependencias}
 
 	// Router con Gin
-	r := gin.Default()
+	// gin.New evita el logger en texto plano de Gin; el access log estructurado lo reemplaza
+	r := gin.New()
+	r.Use(gin.Recovery())
+	r.Use(handlers.MetricasHTTP(registroMetricas))
+	r.Use(handlers.AccessLog(app.logger, handlers.MuestreoAccessLog{
+		Porcentaje:  cfg.MuestreoLogPorcentaje,
+		UmbralLento: time.Duration(cfg.UmbralLogLentoMs) * time.Millisecond,
+	}))
+	r.Use(politicasCache.Middleware())
+
+	// Las peticiones rechazadas por los limitadores de las rutas costosas se cuentan en
+	// http_requests_shed_total
+	descartes := handlers.NuevoContadorDescartes(registroMetricas)
+	var guardiaAdmin gin.HandlerFunc
+	if cfg.AdminHabilitado {
+		guardiaAdmin = handlers.GuardiaAdmin(cfg.AdminToken)
+	}
+	handlers.RegistrarRutas(r, handlers.Rutas{
+		Producto:     productoHandler,
+		Productor:    productorHandler,
+		Admin:        adminHandler,
+		Salud:        saludHandler,
+		GuardiaAdmin: guardiaAdmin,
+		Metricas:     handlers.ExponerMetricas(registroMetricas),
+		LimitarRutaCostosa: func(grupo string) gin.HandlerFunc {
+			return limitarRutaCostosa(cfg, grupo, descartes)
+		},
+	})
+
+	if err := politicasCache.VerificarRutas(r.Routes()); err != nil {
+		log.Fatalf("Política de caché incompleta: %v", err)
+	}
 
-	// Endpoints
-	r.POST("catalogo/producto", productoHandler.PublicarProducto)
-	r.POST("catalogo/productos/excedente", productoHandler.MarcarProductoComoExcedente)
-	r.PUT("catalogo/productos/disponibilidad", productoHandler.ActualizarDisponibilidadPorTemporada)
-  	r.GET("catalogo/completo", productoHandler.GetCatalogoCompleto)
 	// Iniciar servidor
 	log.Println("Servidor iniciado en :8080")
-	r.Run(":8080")
+	if err := r.Run(":8080"); err != nil {
+		return 1
+	}
+	return 0
 }
\ No newline at end of file
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"Product_Catalog_Microservice/internal/handlers"
+)
+
+// Cada grupo de rutas costosas descarta peticiones al alcanzar su propio límite
+func TestLimitarRutaCostosa_LimitePorGrupo(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Setenv("CATALOGO_LIMITE_RUTAS_COSTOSAS", "")
+	t.Setenv("CATALOGO_ESPERA_RUTAS_COSTOSAS_MS", "20")
+	t.Setenv("CATALOGO_LIMITE_RUTAS_COSTOSAS_BUSCAR", "1")
+	t.Setenv("CATALOGO_LIMITE_RUTAS_COSTOSAS_COMPLETO", "2")
+	cfg := cargarConfig()
+	descartadas := handlers.NuevoContadorDescartes(prometheus.NewRegistry())
+
+	dentro := make(chan struct{})
+	liberar := make(chan struct{})
+	bloquear := func(c *gin.Context) {
+		dentro <- struct{}{}
+		<-liberar
+		c.Status(http.StatusOK)
+	}
+	r := gin.New()
+	r.GET("/buscar", limitarRutaCostosa(cfg, handlers.GrupoCostosoBuscar, descartadas), bloquear)
+	r.GET("/completo", limitarRutaCostosa(cfg, handlers.GrupoCostosoCompleto, descartadas), bloquear)
+
+	hacer := func(ruta string) int {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, ruta, nil))
+		return w.Code
+	}
+	// ocupar deja una petición bloqueada en el handler, ocupando un cupo de la ruta
+	var enCurso sync.WaitGroup
+	defer enCurso.Wait()
+	defer close(liberar)
+	ocupar := func(ruta string) {
+		enCurso.Add(1)
+		go func() {
+			defer enCurso.Done()
+			hacer(ruta)
+		}()
+		select {
+		case <-dentro:
+		case <-time.After(time.Second):
+			t.Fatalf("%s no admitió la petición dentro de su límite", ruta)
+		}
+	}
+
+	ocupar("/buscar")
+	if got := hacer("/buscar"); got != http.StatusServiceUnavailable {
+		t.Errorf("segunda petición a /buscar: status = %d, se esperaba 503", got)
+	}
+
+	ocupar("/completo")
+	ocupar("/completo")
+	if got := hacer("/completo"); got != http.StatusServiceUnavailable {
+		t.Errorf("tercera petición a /completo: status = %d, se esperaba 503", got)
+	}
+
+	for _, ruta := range []string{"/buscar", "/completo"} {
+		if n := testutil.ToFloat64(descartadas.WithLabelValues(ruta)); n != 1 {
+			t.Errorf("http_requests_shed_total{route=%q} = %v, se esperaba 1", ruta, n)
+		}
+	}
+}
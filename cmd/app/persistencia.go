@@ -0,0 +1,29 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	_ "github.com/jackc/pgx/v5/stdlib" // registra el driver "pgx" de database/sql
+)
+
+// esperaConexionBaseDatos es el tiempo máximo para comprobar la conexión al arrancar
+const esperaConexionBaseDatos = 5 * time.Second
+
+// abrirPostgres abre el pool de conexiones y comprueba que la base responde, para que una
+// DATABASE_URL errónea falle al arrancar y no en la primera petición
+func abrirPostgres(url string) (*sql.DB, error) {
+	db, err := sql.Open("pgx", url)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), esperaConexionBaseDatos)
+	defer cancel()
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
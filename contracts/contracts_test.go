@@ -0,0 +1,278 @@
+package contracts
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+	"Product_Catalog_Microservice/internal/domain/productor"
+)
+
+var actualizar = flag.Bool("update", false, "regenera testdata/catalogo con la serialización actual")
+
+const (
+	dirCatalogo     = "testdata/catalogo"
+	dirConsumidores = "testdata/consumidores"
+)
+
+// momento es la fecha de todos los eventos de muestra, para que la serialización sea estable
+var momento = time.Date(2026, time.March, 14, 9, 30, 0, 0, time.FixedZone("COT", -5*60*60))
+
+// eventosMuestra tiene un evento de cada tipo que emiten los agregados, con todos sus campos
+// completos. Un tipo nuevo de evento se agrega aquí.
+var eventosMuestra = []any{
+	producto.ProductoPublicado{ProductoID: "p-1", At: momento},
+	producto.ProductoMarcadoComoExcedente{ProductoID: "p-1", At: momento,
+		PreferenciasProductor: productor.PreferenciasNotificacionPorDefecto().Copia()},
+	producto.ProductoAgotado{ProductoID: "p-1", At: momento,
+		PreferenciasProductor: productor.PreferenciasNotificacionPorDefecto().Copia()},
+	producto.ProductoStockBajo{ProductoID: "p-1", At: momento,
+		Cantidad:              producto.CantidadDisponible{Valor: 4, Unidad: producto.UnidadKilogramo},
+		PreferenciasProductor: productor.PreferenciasNotificacionPorDefecto().Copia()},
+	productor.ProductorCreado{ProductorID: "quemado-1", At: momento},
+	productor.ProductorEnVerificacion{ProductorID: "quemado-1", At: momento},
+	productor.ProductorVerificado{ProductorID: "quemado-1", At: momento},
+	productor.ReputacionActualizada{ProductorID: "quemado-1", NuevaReputacion: 4.5, At: momento},
+	productor.ProductorSuspendido{ProductorID: "quemado-1", Motivo: "Uso de agroquímicos prohibidos", At: momento},
+	productor.ProductorReactivado{ProductorID: "quemado-1", At: momento},
+	productor.ProductorDesactivado{ProductorID: "quemado-1", Motivo: "Inactividad", At: momento},
+	productor.PreferenciasNotificacionActualizadas{ProductorID: "quemado-1", At: momento,
+		Preferencias: productor.PreferenciasNotificacionPorDefecto().Copia()},
+}
+
+// sobre es la forma en que los contratos describen un evento publicado: el nombre de su tipo
+// y el struct de dominio serializado
+type sobre struct {
+	Tipo    string          `json:"tipo"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+// serializar retorna, por nombre de tipo, el mensaje de cada evento de muestra
+func serializar(t *testing.T) map[string][]byte {
+	t.Helper()
+	mensajes := make(map[string][]byte, len(eventosMuestra))
+	for _, evento := range eventosMuestra {
+		payload, err := json.Marshal(evento)
+		if err != nil {
+			t.Fatalf("Marshal(%T): %v", evento, err)
+		}
+		nombre := reflect.TypeOf(evento).Name()
+		data, err := json.MarshalIndent(sobre{Tipo: nombre, Payload: payload}, "", "  ")
+		if err != nil {
+			t.Fatalf("MarshalIndent(%T): %v", evento, err)
+		}
+		mensajes[nombre] = append(data, '\n')
+	}
+	return mensajes
+}
+
+// TestGenerateContracts compara la serialización actual con testdata/catalogo. Con -update
+// la reescribe en lugar de compararla.
+func TestGenerateContracts(t *testing.T) {
+	mensajes := serializar(t)
+
+	if *actualizar {
+		if err := os.RemoveAll(dirCatalogo); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.MkdirAll(dirCatalogo, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		for nombre, data := range mensajes {
+			if err := os.WriteFile(filepath.Join(dirCatalogo, nombre+".json"), data, 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return
+	}
+
+	for nombre, data := range mensajes {
+		guardado, err := os.ReadFile(filepath.Join(dirCatalogo, nombre+".json"))
+		if err != nil {
+			t.Errorf("%s: %v; regenerar con go test ./contracts -run TestGenerateContracts -update", nombre, err)
+			continue
+		}
+		if !bytes.Equal(guardado, data) {
+			t.Errorf("%s cambió de formato; si es intencional, regenerar con go test ./contracts -run TestGenerateContracts -update\nguardado:\n%s\nactual:\n%s",
+				nombre, guardado, data)
+		}
+	}
+
+	archivos, err := filepath.Glob(filepath.Join(dirCatalogo, "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, archivo := range archivos {
+		if _, ok := mensajes[strings.TrimSuffix(filepath.Base(archivo), ".json")]; !ok {
+			t.Errorf("%s no corresponde a ningún evento de muestra", archivo)
+		}
+	}
+}
+
+// TestContratosConsumidores comprueba que la serialización actual cumple cada contrato de
+// testdata/consumidores
+func TestContratosConsumidores(t *testing.T) {
+	mensajes := serializar(t)
+
+	contratos, err := filepath.Glob(filepath.Join(dirConsumidores, "*", "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(contratos) == 0 {
+		t.Fatalf("no hay contratos en %s", dirConsumidores)
+	}
+
+	cubiertos := map[string]bool{}
+	for _, contrato := range contratos {
+		equipo := filepath.Base(filepath.Dir(contrato))
+		nombre := strings.TrimSuffix(filepath.Base(contrato), ".json")
+		t.Run(equipo+"/"+nombre, func(t *testing.T) {
+			actual, ok := mensajes[nombre]
+			if !ok {
+				t.Fatalf("%s espera el evento %s, que ya no se publica", equipo, nombre)
+			}
+			cubiertos[nombre] = true
+
+			data, err := os.ReadFile(contrato)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var esperado, obtenido any
+			if err := json.Unmarshal(data, &esperado); err != nil {
+				t.Fatalf("el contrato no es JSON válido: %v", err)
+			}
+			if err := json.Unmarshal(actual, &obtenido); err != nil {
+				t.Fatalf("la serialización no es JSON válido: %v", err)
+			}
+
+			if tipo, _ := esperado.(map[string]any)["tipo"].(string); tipo != obtenido.(map[string]any)["tipo"] {
+				t.Errorf("tipo = %v, %s espera %q", obtenido.(map[string]any)["tipo"], equipo, tipo)
+			}
+			for _, problema := range compararContrato("", esperado, obtenido) {
+				t.Error(problema)
+			}
+		})
+	}
+
+	var sinConsumidor []string
+	for nombre := range mensajes {
+		if !cubiertos[nombre] {
+			sinConsumidor = append(sinConsumidor, nombre)
+		}
+	}
+	sort.Strings(sinConsumidor)
+	if len(sinConsumidor) > 0 {
+		t.Logf("eventos sin contrato de ningún consumidor: %v", sinConsumidor)
+	}
+}
+
+// compararContrato retorna los campos de esperado que faltan en obtenido o cuyo tipo JSON
+// difiere. Los campos adicionales de obtenido se ignoran; en los arreglos, el primer elemento
+// del contrato describe a todos los elementos.
+func compararContrato(ruta string, esperado, obtenido any) []string {
+	if esperado == nil {
+		return nil
+	}
+	if tipoJSON(esperado) != tipoJSON(obtenido) {
+		return []string{fmt.Sprintf("%s: tipo %s, se esperaba %s", rutaOSobre(ruta), tipoJSON(obtenido), tipoJSON(esperado))}
+	}
+
+	var problemas []string
+	switch e := esperado.(type) {
+	case map[string]any:
+		o := obtenido.(map[string]any)
+		claves := make([]string, 0, len(e))
+		for clave := range e {
+			claves = append(claves, clave)
+		}
+		sort.Strings(claves)
+		for _, clave := range claves {
+			campo := strings.TrimPrefix(ruta+"."+clave, ".")
+			valor, ok := o[clave]
+			if !ok {
+				problemas = append(problemas, fmt.Sprintf("%s: falta el campo", campo))
+				continue
+			}
+			problemas = append(problemas, compararContrato(campo, e[clave], valor)...)
+		}
+	case []any:
+		if len(e) == 0 {
+			break
+		}
+		for i, elemento := range obtenido.([]any) {
+			problemas = append(problemas, compararContrato(fmt.Sprintf("%s[%d]", ruta, i), e[0], elemento)...)
+		}
+	}
+	return problemas
+}
+
+func rutaOSobre(ruta string) string {
+	if ruta == "" {
+		return "sobre"
+	}
+	return ruta
+}
+
+// tipoJSON es el nombre del tipo JSON de un valor decodificado por encoding/json
+func tipoJSON(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
+
+func TestCompararContrato(t *testing.T) {
+	casos := []struct {
+		nombre    string
+		esperado  string
+		obtenido  string
+		problemas []string
+	}{
+		{"igual", `{"a":"x","b":1}`, `{"a":"y","b":2}`, nil},
+		{"campos adicionales", `{"a":"x"}`, `{"a":"x","b":true}`, nil},
+		{"falta un campo", `{"a":"x","b":1}`, `{"a":"x"}`, []string{"b: falta el campo"}},
+		{"campo renombrado", `{"ProductoID":"p"}`, `{"ProductoId":"p"}`, []string{"ProductoID: falta el campo"}},
+		{"cambio de tipo", `{"a":1}`, `{"a":"1"}`, []string{"a: tipo string, se esperaba number"}},
+		{"anidado", `{"c":{"Valor":1}}`, `{"c":{"Valor":"1"}}`, []string{"c.Valor: tipo string, se esperaba number"}},
+		{"null acepta cualquier tipo", `{"a":null}`, `{"a":[1]}`, nil},
+		{"null exige el campo", `{"a":null}`, `{}`, []string{"a: falta el campo"}},
+		{"arreglo", `{"l":[{"id":"x"}]}`, `{"l":[{"id":"y"},{"id":2}]}`, []string{"l[1].id: tipo number, se esperaba string"}},
+		{"sobre", `{"a":1}`, `[1]`, []string{"sobre: tipo array, se esperaba object"}},
+	}
+	for _, tc := range casos {
+		t.Run(tc.nombre, func(t *testing.T) {
+			var esperado, obtenido any
+			if err := json.Unmarshal([]byte(tc.esperado), &esperado); err != nil {
+				t.Fatal(err)
+			}
+			if err := json.Unmarshal([]byte(tc.obtenido), &obtenido); err != nil {
+				t.Fatal(err)
+			}
+			problemas := compararContrato("", esperado, obtenido)
+			if fmt.Sprint(problemas) != fmt.Sprint(tc.problemas) {
+				t.Errorf("problemas = %q, se esperaba %q", problemas, tc.problemas)
+			}
+		})
+	}
+}
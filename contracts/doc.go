@@ -0,0 +1,15 @@
+// Package contracts contiene las pruebas de contrato de los eventos de dominio con sus
+// consumidores. No tiene código de producción: los contratos viven en testdata.
+//
+//   - testdata/consumidores/{equipo}/{Evento}.json es un mensaje de ejemplo que entrega cada
+//     equipo consumidor, con el sobre (tipo) y el payload. Todo campo presente en el ejemplo
+//     es obligatorio y debe conservar su tipo JSON; un null acepta cualquier tipo. Los campos
+//     que el ejemplo no menciona pueden cambiar libremente.
+//   - testdata/catalogo/{Evento}.json es la serialización actual de un evento de muestra.
+//
+// Los eventos se publican como los structs de dominio serializados con encoding/json, y las
+// pruebas fallan si un campo obligatorio desaparece o cambia de tipo. Cuando un cambio del
+// formato es intencional y versionado, testdata/catalogo se regenera con
+//
+//	go test ./contracts -run TestGenerateContracts -update
+package contracts
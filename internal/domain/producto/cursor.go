@@ -0,0 +1,24 @@
+package producto
+
+import "time"
+
+// CursorProducto es la posición de un producto en el orden de publicación: por PublicadoEn y,
+// entre productos publicados en el mismo instante, por ID. A diferencia de un offset, sigue
+// apuntando al mismo lugar aunque se publiquen productos entre una página y la siguiente.
+type CursorProducto struct {
+	PublicadoEn time.Time
+	ID          ProductoID
+}
+
+// CursorDe retorna la posición del producto en el orden de publicación
+func CursorDe(p *ProductoAgroecologico) CursorProducto {
+	return CursorProducto{PublicadoEn: p.PublicadoEn(), ID: p.ID}
+}
+
+// Antes indica si la posición del cursor precede a la del producto en el orden de publicación
+func (c CursorProducto) Antes(p *ProductoAgroecologico) bool {
+	if !c.PublicadoEn.Equal(p.PublicadoEn()) {
+		return c.PublicadoEn.Before(p.PublicadoEn())
+	}
+	return c.ID < p.ID
+}
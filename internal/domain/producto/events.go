@@ -8,11 +8,21 @@ type ProductoPublicado struct {
 }
 
 type ProductoMarcadoComoExcedente struct {
-    ProductoID ProductoID
-    At         time.Time
+    ProductoID            ProductoID
+    At                    time.Time
+    PreferenciasProductor map[string]bool // copia de las preferencias de notificación del productor, la completa el servicio
+}
+
+// ProductoStockBajo se emite cuando la cantidad disponible baja del umbral configurado
+type ProductoStockBajo struct {
+    ProductoID            ProductoID
+    Cantidad              CantidadDisponible
+    At                    time.Time
+    PreferenciasProductor map[string]bool // copia de las preferencias de notificación del productor, la completa el servicio
 }
 
 type ProductoAgotado struct {
-    ProductoID ProductoID
-    At         time.Time
+    ProductoID            ProductoID
+    At                    time.Time
+    PreferenciasProductor map[string]bool // copia de las preferencias de notificación del productor, la completa el servicio
 }
@@ -0,0 +1,28 @@
+package producto
+
+// ProductoFiltro agrupa los criterios opcionales para consultar productos.
+// Un campo vacío significa que no se filtra por ese criterio; los criterios
+// presentes se combinan con AND.
+type ProductoFiltro struct {
+	Categoria      Categoria
+	Estado         EstadoDisponibilidad
+	ZonaVeredal    string
+	TipoProduccion TipoProduccion
+}
+
+// Cumple indica si el producto satisface todos los criterios del filtro.
+func (f ProductoFiltro) Cumple(p *ProductoAgroecologico) bool {
+	if f.Categoria != "" && p.Categoria != f.Categoria {
+		return false
+	}
+	if f.Estado.Value != "" && p.Estado != f.Estado {
+		return false
+	}
+	if f.ZonaVeredal != "" && p.Ubicacion.ZonaVeredal != f.ZonaVeredal {
+		return false
+	}
+	if f.TipoProduccion != "" && p.TipoProduccion != f.TipoProduccion {
+		return false
+	}
+	return true
+}
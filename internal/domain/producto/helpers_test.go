@@ -0,0 +1,55 @@
+package producto
+
+import (
+	"testing"
+	"time"
+)
+
+// bogota es la zona horaria por defecto del despliegue
+var bogota = time.FixedZone("America/Bogota", -5*60*60)
+
+// enBogota retorna el instante indicado en la hora local de Bogotá
+func enBogota(anio int, mes time.Month, dia, hora, minuto int) time.Time {
+	return time.Date(anio, mes, dia, hora, minuto, 0, 0, bogota)
+}
+
+// anioProximo es un año en el futuro, para que las temporadas de prueba no terminen en el pasado
+func anioProximo() int {
+	return time.Now().Year() + 1
+}
+
+// usarZonaHoraria cambia la zona horaria del despliegue durante la prueba
+func usarZonaHoraria(t *testing.T, loc *time.Location) {
+	t.Helper()
+	anterior := ZonaHoraria()
+	ConfigurarZonaHoraria(loc)
+	t.Cleanup(func() { ConfigurarZonaHoraria(anterior) })
+}
+
+// nuevaTemporadaPrueba crea una temporada válida o falla la prueba
+func nuevaTemporadaPrueba(t testing.TB, inicio, fin time.Time) TemporadaLocal {
+	t.Helper()
+	temporada, err := NewTemporadaLocal(inicio, fin)
+	if err != nil {
+		t.Fatalf("NewTemporadaLocal(%v, %v): %v", inicio, fin, err)
+	}
+	return temporada
+}
+
+// nuevoProductoPrueba crea un producto Disponible con la temporada indicada
+func nuevoProductoPrueba(t testing.TB, temporada TemporadaLocal) *ProductoAgroecologico {
+	t.Helper()
+	nombre, _ := NewNombreProducto("Fresa")
+	desc, _ := NewDescripcionProducto("Fresas de la vereda sin agroquímicos")
+	ubicacion, _ := NewUbicacion("Vereda El Paraíso", "Finca La Esperanza")
+	imagen, _ := NewImagen("https://img.example.com/fresa.jpg", "Fresas")
+	precio, _ := NewPrecio(4500, "COP")
+	cantidad, _ := NewCantidadDisponible(40, UnidadKilogramo)
+
+	p, err := NewProductoAgroecologico("p-1", nombre, desc, "Fruta", ProduccionAgroecologica,
+		temporada, ubicacion, imagen, precio, cantidad, "quemado-1")
+	if err != nil {
+		t.Fatalf("NewProductoAgroecologico: %v", err)
+	}
+	return p
+}
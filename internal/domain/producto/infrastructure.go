@@ -1,17 +1,39 @@
 package producto
 
-import "time"
+import (
+	"errors"
+	"time"
+
+	"Product_Catalog_Microservice/internal/domain/shared"
+)
+
+// ErrCapacidadAlcanzada indica que el repositorio llegó a su máximo de productos configurado.
+// Se distingue de un ID duplicado: el registro es válido pero no hay espacio para guardarlo.
+var ErrCapacidadAlcanzada = errors.New("se alcanzó la capacidad máxima de productos")
+
+// ErrNoEncontrado indica que no existe un producto con el ID solicitado. Las
+// implementaciones del repositorio lo envuelven para que el servicio lo distinga de otras fallas.
+var ErrNoEncontrado = errors.New("no existe un producto con ese id")
+
+// ErrProductoDuplicado indica que ya existe un producto con el mismo ID.
+var ErrProductoDuplicado = errors.New("ya existe un producto con ese id")
 
 type ProductoRepositoryInterface interface {
     Save(producto *ProductoAgroecologico) error
     GetByID(id ProductoID) (*ProductoAgroecologico, error)
     Update(producto *ProductoAgroecologico) error 
     GetByProductorID(productorID string) ([]*ProductoAgroecologico, error)
+    GetByProductorIDAndCategoria(productorID string, categoria Categoria) ([]*ProductoAgroecologico, error)
+    ExisteNombreParaProductor(nombre NombreProducto, productorID string) (bool, error)
     GetByCategoria(categoria Categoria) ([]*ProductoAgroecologico, error)
+    GetByTipoProduccion(tipo TipoProduccion) ([]*ProductoAgroecologico, error)
     GetByEstado(estado EstadoDisponibilidad) ([]*ProductoAgroecologico, error)
     GetByUbicacion(ubicacion Ubicacion) ([]*ProductoAgroecologico, error)
     GetAll() ([]*ProductoAgroecologico, error)
+    GetAllPaginated(params shared.PaginationParams) (shared.PagedResult[*ProductoAgroecologico], error) // ordenado por ID
     GetAvailableProducts() ([]*ProductoAgroecologico, error)
+    GetAvailableProductsPaginated(params shared.PaginationParams) (shared.PagedResult[*ProductoAgroecologico], error) // ordenado por ID
+    GetPageAfter(cursor *CursorProducto, limit int) ([]*ProductoAgroecologico, error) // hasta limit productos posteriores al cursor en el orden de publicación; nil empieza desde el primero
     GetProductsInSeason(now time.Time) ([]*ProductoAgroecologico, error)
     UpdateEstadoDisponibilidad(id ProductoID, estado EstadoDisponibilidad) error
 }
\ No newline at end of file
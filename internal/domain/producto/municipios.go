@@ -0,0 +1,48 @@
+package producto
+
+import (
+	"embed"
+	"encoding/json"
+
+	"Product_Catalog_Microservice/internal/domain/shared"
+)
+
+//go:embed municipios.json
+var municipiosFS embed.FS
+
+// MunicipiosConocidos retorna la lista oficial de municipios incluida en el binario.
+func MunicipiosConocidos() ([]string, error) {
+	data, err := municipiosFS.ReadFile("municipios.json")
+	if err != nil {
+		return nil, err
+	}
+
+	var municipios []string
+	if err := json.Unmarshal(data, &municipios); err != nil {
+		return nil, err
+	}
+	return municipios, nil
+}
+
+// NewUbicacionWithMunicipioValidation crea una Ubicacion y exige que la zona
+// veredal corresponda con confianza a uno de los municipios conocidos.
+//
+// Parámetros:
+//   - zona: nombre de la zona veredal
+//   - finca: nombre de la finca
+//   - knownMunicipios: nombres oficiales de municipios
+//
+// Retorna:
+//   - Ubicacion: instancia válida del value object
+//   - error: error de validación si la ubicación es inválida o no coincide con ningún municipio
+func NewUbicacionWithMunicipioValidation(zona, finca string, knownMunicipios []string) (Ubicacion, error) {
+	u, err := NewUbicacion(zona, finca)
+	if err != nil {
+		return Ubicacion{}, err
+	}
+
+	if municipio, _ := u.NearestMunicipio(knownMunicipios); municipio == "" {
+		return Ubicacion{}, shared.NewErrorValidacion("zona_veredal", "la zona veredal no corresponde a ningún municipio conocido")
+	}
+	return u, nil
+}
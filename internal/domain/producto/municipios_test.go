@@ -0,0 +1,61 @@
+package producto
+
+import "testing"
+
+func TestMunicipiosConocidos(t *testing.T) {
+	municipios, err := MunicipiosConocidos()
+	if err != nil {
+		t.Fatalf("MunicipiosConocidos: %v", err)
+	}
+	if len(municipios) == 0 {
+		t.Fatal("la lista embebida de municipios está vacía")
+	}
+	vistos := make(map[string]bool, len(municipios))
+	for _, m := range municipios {
+		if vistos[m] {
+			t.Errorf("municipio repetido: %q", m)
+		}
+		vistos[m] = true
+	}
+}
+
+func TestNewUbicacionWithMunicipioValidation(t *testing.T) {
+	municipios, err := MunicipiosConocidos()
+	if err != nil {
+		t.Fatalf("MunicipiosConocidos: %v", err)
+	}
+
+	casos := []struct {
+		nombre string
+		zona   string
+		valida bool
+	}{
+		{"exacto", "Popayán", true},
+		{"error de digitación", "Popayn", true},
+		{"sin coincidencia", "Vereda Desconocida", false},
+	}
+	for _, tc := range casos {
+		t.Run(tc.nombre, func(t *testing.T) {
+			u, err := NewUbicacionWithMunicipioValidation(tc.zona, "Finca La Esperanza", municipios)
+			if tc.valida {
+				if err != nil {
+					t.Fatalf("err = %v, se esperaba una ubicación válida", err)
+				}
+				if u.ZonaVeredal != tc.zona {
+					t.Errorf("ZonaVeredal = %q, se esperaba %q", u.ZonaVeredal, tc.zona)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("se esperaba error para la zona %q", tc.zona)
+			}
+		})
+	}
+}
+
+func TestNewUbicacionWithMunicipioValidation_UbicacionInvalida(t *testing.T) {
+	municipios, _ := MunicipiosConocidos()
+	if _, err := NewUbicacionWithMunicipioValidation("Popayán", "", municipios); err == nil {
+		t.Fatal("se esperaba error por finca vacía")
+	}
+}
@@ -3,10 +3,34 @@ package producto
 import (
     "errors"
     "time"
+
+    "Product_Catalog_Microservice/internal/domain/shared"
 )
 
 type ProductoID string
 
+// ErrSinCambios indica que el producto ya se encuentra en el estado solicitado.
+// La transición no genera eventos; el llamador decide si tratarlo como error.
+var ErrSinCambios = errors.New("el producto ya se encuentra en el estado solicitado")
+
+// ErrNoDisponible indica que se intentó agotar un producto que no está 'Disponible'.
+var ErrNoDisponible = errors.New("solo un producto 'Disponible' puede marcarse como 'Agotado'")
+
+// ErrStockInsuficiente indica que se intentó reducir más de la cantidad disponible.
+var ErrStockInsuficiente = errors.New("la cantidad solicitada supera la cantidad disponible")
+
+// ErrFueraDeTemporada indica que se intentó marcar como excedente un producto fuera de su temporada.
+var ErrFueraDeTemporada = errors.New("solo se puede marcar como 'Excedente' dentro de la temporada")
+
+// ErrProductoAgotado indica que se intentó modificar la información de un producto agotado.
+var ErrProductoAgotado = errors.New("no se puede actualizar información de un producto agotado")
+
+// MaxEventosPendientes es la cantidad máxima de eventos sin publicar que acumula un producto.
+const MaxEventosPendientes = 32
+
+// ErrDemasiadosEventosPendientes indica que el producto alcanzó MaxEventosPendientes.
+var ErrDemasiadosEventosPendientes = errors.New("el producto tiene demasiados eventos pendientes de publicar")
+
 // Entidad raíz del agregado ProductoAgroecologico
 type ProductoAgroecologico struct {
     ID               ProductoID
@@ -18,6 +42,8 @@ type ProductoAgroecologico struct {
     Estado           EstadoDisponibilidad
     Ubicacion        Ubicacion
     Imagen           Imagen
+    Precio           Precio
+    Cantidad         CantidadDisponible
     ProductorID      string // referencia por identidad al productor
     publicadoEn      time.Time
 
@@ -34,10 +60,12 @@ func NewProductoAgroecologico(
     temporada TemporadaLocal,
     ubicacion Ubicacion,
     imagen Imagen,
+    precio Precio,
+    cantidad CantidadDisponible,
     productorID string,
 ) (*ProductoAgroecologico, error) {
     if productorID == "" {
-        return nil, errors.New("productorID cannot be empty")
+        return nil, shared.NewErrorValidacion("productor_id", "productorID cannot be empty")
     }
 
     estado := EstadoDisponibilidad{
@@ -54,6 +82,8 @@ func NewProductoAgroecologico(
         Estado:         estado,
         Ubicacion:      ubicacion,
         Imagen:         imagen,
+        Precio:         precio,
+        Cantidad:       cantidad,
         ProductorID:    productorID,
         publicadoEn:    time.Now(),
         eventsPending:  make([]interface{}, 0),
@@ -68,9 +98,18 @@ func NewProductoAgroecologico(
     return producto, nil
 }
 
+// MarcarComoExcedente indica que el productor tiene más oferta de la que se demanda.
+// Invariante: el excedente ocurre durante la cosecha, por lo que solo se permite
+// mientras el producto está en temporada; fuera de ella no hay oferta que sobre.
 func (p *ProductoAgroecologico) MarcarComoExcedente(now time.Time) error {
-    if p.Temporada.IsInSeason(now) {
-        return errors.New("no se puede marcar como 'Excedente' dentro de la temporada")
+    if p.Estado.Value == Excedente {
+        return ErrSinCambios
+    }
+    if !p.Temporada.IsInSeason(now) {
+        return ErrFueraDeTemporada
+    }
+    if err := p.verificarCupoEventos(); err != nil {
+        return err
     }
     p.Estado = EstadoDisponibilidad{Value: Excedente}
     
@@ -84,8 +123,14 @@ func (p *ProductoAgroecologico) MarcarComoExcedente(now time.Time) error {
 }
 
 func (p *ProductoAgroecologico) Agotar() error {
+    if p.Estado.Value == Agotado {
+        return ErrSinCambios
+    }
     if p.Estado.Value != Disponible {
-        return errors.New("solo un producto 'Disponible' puede marcarse como 'Agotado'")
+        return ErrNoDisponible
+    }
+    if err := p.verificarCupoEventos(); err != nil {
+        return err
     }
     p.Estado = EstadoDisponibilidad{Value: Agotado}
     
@@ -98,19 +143,47 @@ func (p *ProductoAgroecologico) Agotar() error {
     return nil
 }
 
-// Recalcula el estado de disponibilidad en base a la temporada actual
+// AgotarPorSuspension retira el producto de la venta porque su productor fue suspendido.
+// A diferencia de Agotar, también se aplica a un producto en Excedente.
+func (p *ProductoAgroecologico) AgotarPorSuspension() error {
+    if p.Estado.Value == Agotado {
+        return ErrSinCambios
+    }
+    if err := p.verificarCupoEventos(); err != nil {
+        return err
+    }
+    p.Estado = EstadoDisponibilidad{Value: Agotado}
+
+    p.addEvent(ProductoAgotado{
+        ProductoID: p.ID,
+        At:         time.Now(),
+    })
+
+    return nil
+}
+
+// RecalcularDisponibilidad ajusta el estado según la temporada en now:
+//
+//	Agotado    + en temporada       -> Disponible
+//	Disponible + en temporada       -> Disponible (sin cambios)
+//	Excedente  + en temporada       -> Excedente (lo marcó el productor, no se revierte)
+//	cualquiera + fuera de temporada -> Agotado
+//
+// Un estado inválido se trata como Agotado, lo que permite a la auditoría repararlo.
 func (p *ProductoAgroecologico) RecalcularDisponibilidad(now time.Time) {
-    if p.Temporada.IsInSeason(now) {
-        p.Estado = EstadoDisponibilidad{Value: Disponible}
-    } else if p.Estado.Value != Excedente { 
+    if !p.Temporada.IsInSeason(now) {
         p.Estado = EstadoDisponibilidad{Value: Agotado}
+        return
+    }
+    if p.Estado.Value != Disponible && p.Estado.Value != Excedente {
+        p.Estado = EstadoDisponibilidad{Value: Disponible}
     }
 }
 
 func (p *ProductoAgroecologico) ActualizarInformacion(nombre NombreProducto, desc DescripcionProducto, imagen Imagen) error {
     // Validar que el producto no esté en un estado que impida actualizaciones
     if p.Estado.Value == Agotado {
-        return errors.New("no se puede actualizar información de un producto agotado")
+        return ErrProductoAgotado
     }
     
     p.Nombre = nombre
@@ -119,11 +192,77 @@ func (p *ProductoAgroecologico) ActualizarInformacion(nombre NombreProducto, des
     return nil
 }
 
+// ActualizarPrecio cambia el precio de venta del producto.
+// Retorna ErrSinCambios si el precio es el mismo.
+func (p *ProductoAgroecologico) ActualizarPrecio(nuevo Precio) error {
+    if p.Precio == nuevo {
+        return ErrSinCambios
+    }
+    p.Precio = nuevo
+    return nil
+}
+
+// ReducirCantidad descuenta n unidades de la cantidad disponible.
+// Emite ProductoStockBajo cuando la cantidad cruza por debajo del umbral configurado.
+func (p *ProductoAgroecologico) ReducirCantidad(n int) error {
+    if n <= 0 {
+        return shared.NewErrorValidacion("cantidad", "la cantidad a reducir debe ser mayor que cero")
+    }
+    if n > p.Cantidad.Valor {
+        return ErrStockInsuficiente
+    }
+    if err := p.verificarCupoEventos(); err != nil {
+        return err
+    }
+
+    anterior := p.Cantidad.Valor
+    p.Cantidad.Valor -= n
+
+    if anterior >= umbralStockBajo && p.Cantidad.Valor < umbralStockBajo {
+        p.addEvent(ProductoStockBajo{
+            ProductoID: p.ID,
+            Cantidad:   p.Cantidad,
+            At:         time.Now(),
+        })
+    }
+    return nil
+}
+
+// PublicadoEn retorna el momento en que se publicó el producto
+func (p *ProductoAgroecologico) PublicadoEn() time.Time {
+    return p.publicadoEn
+}
+
+// RestaurarProducto reconstruye un producto ya persistido a partir de sus campos exportados y
+// su fecha de publicación. No valida ni emite eventos: solo la usan los repositorios.
+func RestaurarProducto(p ProductoAgroecologico, publicadoEn time.Time) *ProductoAgroecologico {
+    p.publicadoEn = publicadoEn
+    p.eventsPending = make([]interface{}, 0)
+    return &p
+}
+
 // Métodos para manejar eventos
 func (p *ProductoAgroecologico) addEvent(event interface{}) {
     p.eventsPending = append(p.eventsPending, event)
 }
 
+// verificarCupoEventos impide nuevas transiciones cuando el agregado acumula demasiados eventos
+// sin publicar, lo que indica que algún camino muta el agregado sin publicar sus eventos.
+func (p *ProductoAgroecologico) verificarCupoEventos() error {
+    if len(p.eventsPending) >= MaxEventosPendientes {
+        return ErrDemasiadosEventosPendientes
+    }
+    return nil
+}
+
+// TomarEventos retorna los eventos pendientes y los elimina del agregado en una sola operación,
+// de modo que un reintento posterior no vuelva a publicarlos.
+func (p *ProductoAgroecologico) TomarEventos() []interface{} {
+    eventos := p.eventsPending
+    p.eventsPending = make([]interface{}, 0)
+    return eventos
+}
+
 func (p *ProductoAgroecologico) GetPendingEvents() []interface{} {
     return p.eventsPending
 }
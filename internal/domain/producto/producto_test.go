@@ -0,0 +1,184 @@
+package producto
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestVerificarCupoEventos_LimitaLosEventosPendientes(t *testing.T) {
+	hoy := time.Now()
+	p := nuevoProductoPrueba(t, nuevaTemporadaPrueba(t, hoy, hoy.AddDate(0, 0, 30)))
+	p.TomarEventos()
+	for len(p.GetPendingEvents()) < MaxEventosPendientes {
+		p.addEvent(ProductoPublicado{ProductoID: p.ID, At: hoy})
+	}
+
+	if err := p.Agotar(); !errors.Is(err, ErrDemasiadosEventosPendientes) {
+		t.Fatalf("Agotar err = %v, se esperaba ErrDemasiadosEventosPendientes", err)
+	}
+	if p.Estado.Value != Disponible {
+		t.Errorf("Estado = %q, la transición rechazada no debía aplicarse", p.Estado.Value)
+	}
+	if n := len(p.GetPendingEvents()); n != MaxEventosPendientes {
+		t.Errorf("eventos pendientes = %d, se esperaba %d", n, MaxEventosPendientes)
+	}
+
+	// Al publicar los pendientes el agregado vuelve a aceptar transiciones
+	if n := len(p.TomarEventos()); n != MaxEventosPendientes {
+		t.Errorf("TomarEventos retornó %d eventos, se esperaba %d", n, MaxEventosPendientes)
+	}
+	if err := p.Agotar(); err != nil {
+		t.Fatalf("Agotar tras vaciar los eventos: %v", err)
+	}
+}
+
+func TestTomarEventos_VaciaElAgregado(t *testing.T) {
+	hoy := time.Now()
+	p := nuevoProductoPrueba(t, nuevaTemporadaPrueba(t, hoy, hoy.AddDate(0, 0, 30)))
+	p.TomarEventos()
+	if err := p.Agotar(); err != nil {
+		t.Fatalf("Agotar: %v", err)
+	}
+
+	eventos := p.TomarEventos()
+	if len(eventos) != 1 {
+		t.Fatalf("TomarEventos = %v, se esperaba un ProductoAgotado", eventos)
+	}
+	if _, ok := eventos[0].(ProductoAgotado); !ok {
+		t.Errorf("evento = %T, se esperaba ProductoAgotado", eventos[0])
+	}
+	if n := len(p.TomarEventos()); n != 0 {
+		t.Errorf("un segundo TomarEventos retornó %d eventos, se esperaba 0", n)
+	}
+}
+
+// ProductoStockBajo se emite solo al cruzar el umbral, no en cada reducción por debajo de él
+func TestReducirCantidad_StockBajoAlCruzarElUmbral(t *testing.T) {
+	hoy := time.Now()
+	p := nuevoProductoPrueba(t, nuevaTemporadaPrueba(t, hoy, hoy.AddDate(0, 0, 30)))
+	p.TomarEventos()
+
+	pasos := []struct {
+		n         int
+		restante  int
+		stockBajo bool
+	}{
+		{30, 10, false},
+		{5, 5, false},
+		{1, 4, true},
+		{2, 2, false},
+	}
+	for _, paso := range pasos {
+		if err := p.ReducirCantidad(paso.n); err != nil {
+			t.Fatalf("ReducirCantidad(%d): %v", paso.n, err)
+		}
+		if p.Cantidad.Valor != paso.restante {
+			t.Errorf("tras reducir %d la cantidad = %d, se esperaba %d", paso.n, p.Cantidad.Valor, paso.restante)
+		}
+		eventos := p.TomarEventos()
+		if paso.stockBajo != (len(eventos) == 1) {
+			t.Fatalf("tras reducir %d los eventos = %v, se esperaba ProductoStockBajo = %v", paso.n, eventos, paso.stockBajo)
+		}
+		if paso.stockBajo {
+			if e, ok := eventos[0].(ProductoStockBajo); !ok || e.Cantidad.Valor != paso.restante {
+				t.Errorf("evento = %+v, se esperaba ProductoStockBajo con %d", eventos[0], paso.restante)
+			}
+		}
+	}
+
+	if err := p.ReducirCantidad(3); !errors.Is(err, ErrStockInsuficiente) {
+		t.Errorf("ReducirCantidad(3) err = %v, se esperaba ErrStockInsuficiente", err)
+	}
+	if err := p.ReducirCantidad(0); err == nil {
+		t.Error("ReducirCantidad(0) debía fallar")
+	}
+	if p.Cantidad.Valor != 2 {
+		t.Errorf("Cantidad = %d, las reducciones rechazadas no debían aplicarse", p.Cantidad.Valor)
+	}
+}
+
+// Un producto solo puede marcarse como excedente dentro de su temporada, límites incluidos
+func TestMarcarComoExcedente_SoloDentroDeLaTemporada(t *testing.T) {
+	usarZonaHoraria(t, bogota)
+	anio := anioProximo()
+	temporada := nuevaTemporadaPrueba(t, enBogota(anio, time.March, 1, 0, 0), enBogota(anio, time.March, 31, 0, 0))
+
+	casos := []struct {
+		nombre string
+		now    time.Time
+		err    error
+	}{
+		{"primer instante", enBogota(anio, time.March, 1, 0, 0), nil},
+		{"a mitad de temporada", enBogota(anio, time.March, 15, 12, 0), nil},
+		{"último instante", enBogota(anio, time.April, 1, 0, 0).Add(-time.Nanosecond), nil},
+		{"un instante antes", enBogota(anio, time.March, 1, 0, 0).Add(-time.Nanosecond), ErrFueraDeTemporada},
+		{"un día después", enBogota(anio, time.April, 1, 0, 0), ErrFueraDeTemporada},
+	}
+	for _, tc := range casos {
+		t.Run(tc.nombre, func(t *testing.T) {
+			p := nuevoProductoPrueba(t, temporada)
+			p.TomarEventos()
+
+			err := p.MarcarComoExcedente(tc.now)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("err = %v, se esperaba %v", err, tc.err)
+			}
+			eventos := p.TomarEventos()
+			if tc.err != nil {
+				if p.Estado.Value != Disponible || len(eventos) != 0 {
+					t.Errorf("Estado = %q, eventos = %v; el rechazo no debía cambiar nada", p.Estado.Value, eventos)
+				}
+				return
+			}
+			if p.Estado.Value != Excedente || len(eventos) != 1 {
+				t.Errorf("Estado = %q, eventos = %v; se esperaba Excedente y un evento", p.Estado.Value, eventos)
+			}
+		})
+	}
+}
+
+// La suspensión del productor agota también los excedentes, a diferencia de Agotar
+func TestAgotarPorSuspension(t *testing.T) {
+	hoy := time.Now().In(ZonaHoraria())
+	excedente := nuevoProductoPrueba(t, nuevaTemporadaPrueba(t, hoy, hoy.AddDate(0, 0, 30)))
+	if err := excedente.MarcarComoExcedente(time.Now()); err != nil {
+		t.Fatalf("MarcarComoExcedente: %v", err)
+	}
+	if err := excedente.Agotar(); !errors.Is(err, ErrNoDisponible) {
+		t.Fatalf("Agotar sobre un excedente = %v, se esperaba ErrNoDisponible", err)
+	}
+	excedente.TomarEventos()
+
+	if err := excedente.AgotarPorSuspension(); err != nil {
+		t.Fatalf("AgotarPorSuspension: %v", err)
+	}
+	if eventos := excedente.TomarEventos(); excedente.Estado.Value != Agotado || len(eventos) != 1 {
+		t.Errorf("Estado = %q, eventos = %v; se esperaba Agotado y un evento", excedente.Estado.Value, eventos)
+	}
+
+	// Repetirla sobre un producto ya agotado no genera otro evento
+	if err := excedente.AgotarPorSuspension(); !errors.Is(err, ErrSinCambios) {
+		t.Errorf("err = %v, se esperaba ErrSinCambios", err)
+	}
+	if eventos := excedente.TomarEventos(); len(eventos) != 0 {
+		t.Errorf("eventos = %v, la repetición no debía generar eventos", eventos)
+	}
+}
+
+func TestRestaurarProducto_ConservaPublicacionSinEventos(t *testing.T) {
+	hoy := time.Now().In(ZonaHoraria())
+	original := nuevoProductoPrueba(t, nuevaTemporadaPrueba(t, hoy, hoy.AddDate(0, 0, 30)))
+	publicadoEn := time.Date(2025, time.May, 2, 8, 0, 0, 0, time.UTC)
+
+	restaurado := RestaurarProducto(*original, publicadoEn)
+	if !restaurado.PublicadoEn().Equal(publicadoEn) {
+		t.Errorf("PublicadoEn = %s, se esperaba %s", restaurado.PublicadoEn(), publicadoEn)
+	}
+	if restaurado.ID != original.ID || restaurado.Nombre != original.Nombre {
+		t.Errorf("restaurado = %+v, se esperaban los campos del original", restaurado)
+	}
+	if eventos := restaurado.TomarEventos(); len(eventos) != 0 {
+		t.Errorf("eventos = %v, un producto restaurado no tiene eventos pendientes", eventos)
+	}
+}
@@ -0,0 +1,189 @@
+package producto
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewTemporadaLocal_NormalizaADiasCompletosEnLaZonaDelDespliegue(t *testing.T) {
+	anio := anioProximo()
+	temporada := nuevaTemporadaPrueba(t, enBogota(anio, time.March, 1, 15, 30), enBogota(anio, time.March, 31, 8, 0))
+
+	if want := enBogota(anio, time.March, 1, 0, 0); !temporada.Inicio.Equal(want) {
+		t.Errorf("Inicio = %v, se esperaba %v", temporada.Inicio, want)
+	}
+	if want := enBogota(anio, time.April, 1, 0, 0).Add(-time.Nanosecond); !temporada.Fin.Equal(want) {
+		t.Errorf("Fin = %v, se esperaba %v", temporada.Fin, want)
+	}
+}
+
+func TestNewTemporadaLocal_ConservaElDiaDeUnaFechaUTC(t *testing.T) {
+	// 31 de diciembre a medianoche UTC es el 30 a las 19:00 en Bogotá, pero el productor
+	// escribió el 31: la temporada debe incluir el 31 completo
+	anio := anioProximo()
+	temporada := nuevaTemporadaPrueba(t,
+		time.Date(anio, time.December, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(anio, time.December, 31, 0, 0, 0, 0, time.UTC),
+	)
+
+	if !temporada.IsInSeason(enBogota(anio, time.December, 31, 23, 59)) {
+		t.Error("el 31 de diciembre a las 23:59 en Bogotá debía estar en temporada")
+	}
+	if !temporada.Inicio.Equal(enBogota(anio, time.December, 1, 0, 0)) {
+		t.Errorf("Inicio = %v, se esperaba el 1 de diciembre a medianoche en Bogotá", temporada.Inicio)
+	}
+}
+
+func TestIsInSeason_LimitesDeMedianoche(t *testing.T) {
+	anio := anioProximo()
+	temporada := nuevaTemporadaPrueba(t, enBogota(anio, time.March, 1, 0, 0), enBogota(anio, time.March, 31, 0, 0))
+
+	casos := []struct {
+		nombre string
+		now    time.Time
+		espera bool
+	}{
+		{"un instante antes del inicio", enBogota(anio, time.March, 1, 0, 0).Add(-time.Nanosecond), false},
+		{"medianoche del inicio", enBogota(anio, time.March, 1, 0, 0), true},
+		{"inicio en UTC, aún el día anterior en Bogotá", time.Date(anio, time.March, 1, 4, 59, 0, 0, time.UTC), false},
+		{"inicio en UTC, ya el primer día en Bogotá", time.Date(anio, time.March, 1, 5, 0, 0, 0, time.UTC), true},
+		{"último día a las 19:00, medianoche en UTC", time.Date(anio, time.April, 1, 0, 0, 0, 0, time.UTC), true},
+		{"último día a las 23:59", enBogota(anio, time.March, 31, 23, 59), true},
+		{"último instante", enBogota(anio, time.April, 1, 0, 0).Add(-time.Nanosecond), true},
+		{"medianoche siguiente", enBogota(anio, time.April, 1, 0, 0), false},
+	}
+	for _, tc := range casos {
+		t.Run(tc.nombre, func(t *testing.T) {
+			if got := temporada.IsInSeason(tc.now); got != tc.espera {
+				t.Errorf("IsInSeason(%v) = %v, se esperaba %v", tc.now, got, tc.espera)
+			}
+		})
+	}
+}
+
+func TestIsInSeason_ZonaHorariaConfigurada(t *testing.T) {
+	usarZonaHoraria(t, time.UTC)
+
+	anio := anioProximo()
+	temporada := nuevaTemporadaPrueba(t,
+		time.Date(anio, time.March, 1, 12, 0, 0, 0, time.UTC),
+		time.Date(anio, time.March, 31, 12, 0, 0, 0, time.UTC),
+	)
+
+	// Con el despliegue en UTC el día termina a medianoche UTC, no en la de Bogotá
+	if !temporada.IsInSeason(time.Date(anio, time.March, 31, 23, 59, 0, 0, time.UTC)) {
+		t.Error("el 31 a las 23:59 UTC debía estar en temporada")
+	}
+	if temporada.IsInSeason(time.Date(anio, time.April, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Error("el 1 de abril a medianoche UTC ya no debía estar en temporada")
+	}
+}
+
+func TestRecalcularDisponibilidad_FinDeTemporadaEnMedianocheLocal(t *testing.T) {
+	anio := anioProximo()
+	temporada := nuevaTemporadaPrueba(t, enBogota(anio, time.March, 1, 0, 0), enBogota(anio, time.March, 31, 0, 0))
+	p := nuevoProductoPrueba(t, temporada)
+
+	// Cinco horas antes de la medianoche local el producto sigue disponible
+	p.RecalcularDisponibilidad(time.Date(anio, time.April, 1, 0, 0, 0, 0, time.UTC))
+	if p.Estado.Value != Disponible {
+		t.Fatalf("Estado = %s a las 19:00 del último día, se esperaba Disponible", p.Estado.Value)
+	}
+
+	p.RecalcularDisponibilidad(enBogota(anio, time.April, 1, 0, 0))
+	if p.Estado.Value != Agotado {
+		t.Errorf("Estado = %s a la medianoche siguiente, se esperaba Agotado", p.Estado.Value)
+	}
+}
+
+func TestRecalcularDisponibilidad_EstadoYTemporada(t *testing.T) {
+	anio := anioProximo()
+	temporada := nuevaTemporadaPrueba(t, enBogota(anio, time.March, 1, 0, 0), enBogota(anio, time.March, 31, 0, 0))
+	enTemporada := enBogota(anio, time.March, 15, 12, 0)
+	fueraDeTemporada := enBogota(anio, time.April, 15, 12, 0)
+
+	casos := []struct {
+		nombre string
+		estado string
+		now    time.Time
+		espera string
+	}{
+		{"agotado en temporada", Agotado, enTemporada, Disponible},
+		{"disponible en temporada", Disponible, enTemporada, Disponible},
+		{"excedente en temporada", Excedente, enTemporada, Excedente},
+		{"estado inválido en temporada", "Desconocido", enTemporada, Disponible},
+		{"agotado fuera de temporada", Agotado, fueraDeTemporada, Agotado},
+		{"disponible fuera de temporada", Disponible, fueraDeTemporada, Agotado},
+		{"excedente fuera de temporada", Excedente, fueraDeTemporada, Agotado},
+		{"estado inválido fuera de temporada", "Desconocido", fueraDeTemporada, Agotado},
+	}
+	for _, tc := range casos {
+		t.Run(tc.nombre, func(t *testing.T) {
+			p := nuevoProductoPrueba(t, temporada)
+			p.Estado = EstadoDisponibilidad{Value: tc.estado}
+
+			p.RecalcularDisponibilidad(tc.now)
+			if p.Estado.Value != tc.espera {
+				t.Errorf("Estado = %s, se esperaba %s", p.Estado.Value, tc.espera)
+			}
+		})
+	}
+}
+
+func TestTemporadaDeUnSoloDia(t *testing.T) {
+	anio := anioProximo()
+	dia := enBogota(anio, time.July, 1, 0, 0)
+	temporada := nuevaTemporadaPrueba(t, dia, dia)
+
+	casos := []struct {
+		nombre string
+		now    time.Time
+		espera bool
+	}{
+		{"último segundo del día anterior", dia.Add(-time.Second), false},
+		{"primer segundo", dia, true},
+		{"mediodía", enBogota(anio, time.July, 1, 12, 0), true},
+		{"último segundo", dia.AddDate(0, 0, 1).Add(-time.Second), true},
+		{"día siguiente", dia.AddDate(0, 0, 1), false},
+	}
+	for _, tc := range casos {
+		t.Run(tc.nombre, func(t *testing.T) {
+			if got := temporada.IsInSeason(tc.now); got != tc.espera {
+				t.Errorf("IsInSeason(%v) = %v, se esperaba %v", tc.now, got, tc.espera)
+			}
+		})
+	}
+	if d := temporada.Fin.Sub(temporada.Inicio); d != 24*time.Hour-time.Nanosecond {
+		t.Errorf("la temporada de un día dura %v, se esperaba el día completo", d)
+	}
+}
+
+func TestNewTemporadaLocal_Validaciones(t *testing.T) {
+	hoy := time.Now().In(bogota)
+	anio := anioProximo()
+
+	casos := []struct {
+		nombre      string
+		inicio, fin time.Time
+		valida      bool
+	}{
+		{"un solo día", enBogota(anio, time.July, 1, 0, 0), enBogota(anio, time.July, 1, 0, 0), true},
+		{"fin a una hora anterior del mismo día", enBogota(anio, time.July, 1, 18, 0), enBogota(anio, time.July, 1, 6, 0), true},
+		{"fin el día anterior al inicio", enBogota(anio, time.July, 2, 0, 0), enBogota(anio, time.July, 1, 23, 59), false},
+		{"termina hoy", hoy.AddDate(0, 0, -10), hoy, true},
+		{"terminó ayer", hoy.AddDate(0, 0, -10), hoy.AddDate(0, 0, -1), false},
+		{"365 días", enBogota(anio, time.March, 1, 0, 0), enBogota(anio, time.March, 1, 0, 0).AddDate(0, 0, 365), true},
+		{"366 días", enBogota(anio, time.March, 1, 0, 0), enBogota(anio, time.March, 1, 0, 0).AddDate(0, 0, 366), false},
+	}
+	for _, tc := range casos {
+		t.Run(tc.nombre, func(t *testing.T) {
+			_, err := NewTemporadaLocal(tc.inicio, tc.fin)
+			if tc.valida && err != nil {
+				t.Errorf("err = %v, se esperaba una temporada válida", err)
+			}
+			if !tc.valida && err == nil {
+				t.Error("se esperaba error de validación")
+			}
+		})
+	}
+}
@@ -3,9 +3,13 @@
 package producto
 
 import (
-	"errors"
-	"regexp"
+	"Product_Catalog_Microservice/internal/domain/shared"
+	"Product_Catalog_Microservice/internal/domain/ubicacion"
+	"math"
+	"net/url"
+	"strings"
 	"time"
+	"unicode/utf8"
 )
 
 // NombreProducto representa el nombre de un producto como value object.
@@ -24,11 +28,14 @@ type NombreProducto struct {
 //   - NombreProducto: instancia válida del value object
 //   - error: error de validación si el nombre es inválido
 func NewNombreProducto(value string) (NombreProducto, error) {
-	if value == "" {
-		return NombreProducto{}, errors.New("el nombre del producto no puede estar vacío")
+	if strings.TrimSpace(value) == "" {
+		return NombreProducto{}, shared.NewErrorValidacion("nombre", "el nombre del producto no puede estar vacío")
 	}
-	if len(value) > 100 {
-		return NombreProducto{}, errors.New("el nombre del producto no puede superar 100 caracteres")
+	if !utf8.ValidString(value) {
+		return NombreProducto{}, shared.NewErrorValidacion("nombre", "el nombre del producto contiene caracteres inválidos")
+	}
+	if utf8.RuneCountInString(value) > 100 {
+		return NombreProducto{}, shared.NewErrorValidacion("nombre", "el nombre del producto no puede superar 100 caracteres")
 	}
 	return NombreProducto{Value: value}, nil
 }
@@ -49,11 +56,14 @@ type DescripcionProducto struct {
 //   - DescripcionProducto: instancia válida del value object
 //   - error: error de validación si la descripción es inválida
 func NewDescripcionProducto(value string) (DescripcionProducto, error) {
-	if len(value) < 10 {
-		return DescripcionProducto{}, errors.New("la descripción debe tener al menos 10 caracteres")
+	if !utf8.ValidString(value) {
+		return DescripcionProducto{}, shared.NewErrorValidacion("descripcion", "la descripción contiene caracteres inválidos")
+	}
+	if utf8.RuneCountInString(value) < 10 {
+		return DescripcionProducto{}, shared.NewErrorValidacion("descripcion", "la descripción debe tener al menos 10 caracteres")
 	}
-	if len(value) > 500 {
-		return DescripcionProducto{}, errors.New("la descripción no puede superar 500 caracteres")
+	if utf8.RuneCountInString(value) > 500 {
+		return DescripcionProducto{}, shared.NewErrorValidacion("descripcion", "la descripción no puede superar 500 caracteres")
 	}
 	return DescripcionProducto{Value: value}, nil
 }
@@ -85,10 +95,15 @@ func NewCategoria(value string) (Categoria, error) {
 	case CategoriaFruta, CategoriaHortaliza, CategoriaTuberculo, CategoriaMedicinal, CategoriaLacteo:
 		return Categoria(value), nil
 	default:
-		return "", errors.New("categoría inválida")
+		return "", shared.NewErrorValidacion("categoria", "categoría inválida")
 	}
 }
 
+// Categorias retorna todas las categorías válidas en orden de declaración.
+func Categorias() []Categoria {
+	return []Categoria{CategoriaFruta, CategoriaHortaliza, CategoriaTuberculo, CategoriaMedicinal, CategoriaLacteo}
+}
+
 // TipoProduccion representa los diferentes métodos de producción agrícola.
 // Define los tipos de producción según las prácticas utilizadas.
 type TipoProduccion string
@@ -100,40 +115,95 @@ const (
 	ProduccionTradicional   TipoProduccion = "Tradicional"   // Producción tradicional
 )
 
+// NewTipoProduccion crea una nueva instancia de TipoProduccion.
+// Valida que el tipo sea uno de los tipos de producción predefinidos.
+//
+// Parámetros:
+//   - value: el valor del tipo de producción como string
+//
+// Retorna:
+//   - TipoProduccion: instancia válida del value object
+//   - error: error de validación si el tipo no es válido
+func NewTipoProduccion(value string) (TipoProduccion, error) {
+	switch TipoProduccion(value) {
+	case ProduccionAgroecologica, ProduccionOrganica, ProduccionTradicional:
+		return TipoProduccion(value), nil
+	default:
+		return "", shared.NewErrorValidacion("tipo_produccion", "tipo de producción inválido")
+	}
+}
+
+// zonaHoraria es la zona horaria del despliegue en la que se interpretan las fechas
+// de temporada. Colombia no tiene horario de verano, por lo que un offset fijo es exacto.
+var zonaHoraria = time.FixedZone("America/Bogota", -5*60*60)
+
+// ConfigurarZonaHoraria establece la zona horaria del despliegue.
+// Debe llamarse una sola vez al iniciar el servicio, antes de atender peticiones.
+func ConfigurarZonaHoraria(loc *time.Location) {
+	if loc != nil {
+		zonaHoraria = loc
+	}
+}
+
+// ZonaHoraria retorna la zona horaria del despliegue usada para las temporadas.
+func ZonaHoraria() *time.Location {
+	return zonaHoraria
+}
+
 // TemporadaLocal representa el período de temporada local de un producto.
-// Define cuándo está disponible naturalmente en la región.
+// Define cuándo está disponible naturalmente en la región. Las fechas son días
+// completos inclusivos: Inicio es la medianoche del primer día y Fin el último
+// instante (23:59:59.999999999) del último día, en la zona horaria del despliegue.
 type TemporadaLocal struct {
-	Inicio time.Time // Fecha de inicio de la temporada
-	Fin    time.Time // Fecha de fin de la temporada
+	Inicio time.Time // Inicio del primer día de la temporada
+	Fin    time.Time // Último instante del último día de la temporada
 }
 
 // NewTemporadaLocal crea una nueva instancia de TemporadaLocal.
+// Solo se toma el día calendario de cada fecha: inicio se normaliza al comienzo
+// del día y fin al final del día en la zona horaria del despliegue, de modo que
+// una temporada de un solo día (inicio == fin) dure el día completo.
 // Valida que la fecha de fin no sea anterior a la fecha de inicio.
 //
 // Parámetros:
 //   - inicio: fecha de inicio de la temporada
-//   - fin: fecha de fin de la temporada
+//   - fin: fecha de fin de la temporada (inclusiva)
 //
 // Retorna:
 //   - TemporadaLocal: instancia válida del value object
 //   - error: error de validación si las fechas son inválidas
 func NewTemporadaLocal(inicio, fin time.Time) (TemporadaLocal, error) {
-	if fin.Before(inicio) {
-		return TemporadaLocal{}, errors.New("la fecha de fin no puede ser antes del inicio")
+	inicio = inicioDelDia(inicio)
+	ultimoDia := inicioDelDia(fin)
+	fin = finDelDia(fin)
+
+	if ultimoDia.Before(inicio) {
+		return TemporadaLocal{}, shared.NewErrorValidacion("temporada_fin", "la fecha de fin no puede ser antes del inicio")
 	}
 
 	if fin.Before(time.Now()) {
-		return TemporadaLocal{}, errors.New("la fecha de fin no puede estar en el pasado")
+		return TemporadaLocal{}, shared.NewErrorValidacion("temporada_fin", "la fecha de fin no puede estar en el pasado")
 	}
 
-	if fin.Sub(inicio).Hours() > 24*365 {
-		return TemporadaLocal{}, errors.New("la temporada no puede durar más de un año")
+	if ultimoDia.Sub(inicio).Hours() > 24*365 {
+		return TemporadaLocal{}, shared.NewErrorValidacion("temporada_fin", "la temporada no puede durar más de un año")
 	}
 
 	return TemporadaLocal{Inicio: inicio, Fin: fin}, nil
 }
 
-// Funcion auxiliar para saber si actualmente está en temporada
+// inicioDelDia retorna la medianoche del día calendario de t en la zona horaria del despliegue.
+func inicioDelDia(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, zonaHoraria)
+}
+
+// finDelDia retorna el último instante del día calendario de t en la zona horaria del despliegue.
+func finDelDia(t time.Time) time.Time {
+	return inicioDelDia(t).AddDate(0, 0, 1).Add(-time.Nanosecond)
+}
+
+// IsInSeason indica si el instante now cae dentro de la temporada, incluyendo
+// el primer y el último instante de la misma.
 func (t TemporadaLocal) IsInSeason(now time.Time) bool {
     return (now.Equal(t.Inicio) || now.After(t.Inicio)) &&
            (now.Equal(t.Fin) || now.Before(t.Fin))
@@ -170,62 +240,28 @@ func NewEstadoDisponibilidad(value string) (EstadoDisponibilidad, error) {
     case Disponible, Agotado, Excedente:
         return EstadoDisponibilidad{Value: value}, nil
     default:
-        return EstadoDisponibilidad{}, errors.New("estado de disponibilidad inválido")
+        return EstadoDisponibilidad{}, shared.NewErrorValidacion("estado", "estado de disponibilidad inválido")
     }
 }
 
 // Ubicacion representa la ubicación geográfica donde se produce el producto.
-// Incluye información sobre la zona veredal y la finca específica.
-type Ubicacion struct {
-	ZonaVeredal string // Zona veredal donde se encuentra la finca
-	Finca       string // Nombre de la finca productora
-}
+// Es un alias del value object compartido ubicacion.Ubicacion, de modo que
+// productos y productores usan el mismo tipo y la misma representación JSON.
+type Ubicacion = ubicacion.Ubicacion
 
 // NewUbicacion crea una nueva instancia de Ubicacion.
 // Valida que tanto la zona veredal como la finca estén especificadas,
 // que no excedan la longitud máxima y que no contengan caracteres prohibidos.
 //
 // Parámetros:
-//   - zona: nombre de la zona veredal (máximo 50 caracteres)
-//   - finca: nombre de la finca (máximo 80 caracteres)
+//   - zona: nombre de la zona veredal (máximo 40 caracteres)
+//   - finca: nombre de la finca (máximo 50 caracteres)
 //
 // Retorna:
 //   - Ubicacion: instancia válida del value object
 //   - error: error de validación si algún campo es inválido
 func NewUbicacion(zona, finca string) (Ubicacion, error) {
-    // Validar campos vacíos
-    if zona == "" || finca == "" {
-        return Ubicacion{}, errors.New("zona veredal y finca no pueden estar vacíos")
-    }
-
-    // Validar longitud máxima
-    if len(zona) > 40 {
-        return Ubicacion{}, errors.New("la zona veredal no puede superar 40 caracteres")
-    }
-    if len(finca) > 50 {
-        return Ubicacion{}, errors.New("el nombre de la finca no puede superar 50 caracteres")
-    }
-
-    // Validar caracteres prohibidos
-    if err := validarCaracteresProhibidos(zona, "zona veredal"); err != nil {
-        return Ubicacion{}, err
-    }
-    if err := validarCaracteresProhibidos(finca, "finca"); err != nil {
-        return Ubicacion{}, err
-    }
-
-    return Ubicacion{ZonaVeredal: zona, Finca: finca}, nil
-}
-
-// validarCaracteresProhibidos valida que el texto solo contenga caracteres permitidos
-// para nombres de ubicaciones (letras, números, espacios, guiones, apostrofes, puntos).
-func validarCaracteresProhibidos(texto, campo string) error {
-    // Permite letras (incluye acentos), números, espacios, guiones, apostrofes y puntos
-    patron := regexp.MustCompile(`^[a-zA-ZáéíóúñüÁÉÍÓÚÑÜ0-9\s\-'\.]+$`)
-    if !patron.MatchString(texto) {
-        return errors.New("el campo " + campo + " contiene caracteres no permitidos")
-    }
-    return nil
+    return ubicacion.New(zona, finca)
 }
 
 // Imagen representa una imagen asociada a un producto.
@@ -236,19 +272,94 @@ type Imagen struct {
 }
 
 // NewImagen crea una nueva instancia de Imagen.
-// Valida que la URL tenga un formato válido (HTTP o HTTPS).
+// Valida que la URL tenga un formato válido (HTTP o HTTPS) con un host.
 //
 // Parámetros:
-//   - url: URL de la imagen (debe comenzar con http:// o https://)
+//   - rawURL: URL de la imagen (debe comenzar con http:// o https://)
 //   - desc: descripción corta de la imagen
 //
 // Retorna:
 //   - Imagen: instancia válida del value object
 //   - error: error de validación si la URL no es válida
-func NewImagen(url, desc string) (Imagen, error) {
-	regex := regexp.MustCompile(`^https?://`)
-	if !regex.MatchString(url) {
-		return Imagen{}, errors.New("la URL de la imagen no es válida")
+func NewImagen(rawURL, desc string) (Imagen, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return Imagen{}, shared.NewErrorValidacion("imagen_url", "la URL de la imagen no es válida")
+	}
+	return Imagen{URL: rawURL, DescripcionCorta: desc}, nil
+}
+
+// Precio representa el precio de venta de un producto en una moneda.
+type Precio struct {
+	Valor  float64 // Valor en unidades de la moneda, no negativo
+	Moneda string  // Código ISO 4217 de tres letras, p. ej. "COP"
+}
+
+// NewPrecio crea una nueva instancia de Precio.
+// Valida que el valor sea un número no negativo y que la moneda sea un código
+// ISO 4217 de tres letras mayúsculas.
+//
+// Parámetros:
+//   - valor: el precio en unidades de la moneda
+//   - moneda: código ISO 4217 de la moneda (p. ej. "COP", "USD")
+//
+// Retorna:
+//   - Precio: instancia válida del value object
+//   - error: error de validación si el valor o la moneda son inválidos
+func NewPrecio(valor float64, moneda string) (Precio, error) {
+	if math.IsNaN(valor) || math.IsInf(valor, 0) || valor < 0 {
+		return Precio{}, shared.NewErrorValidacion("precio_valor", "el precio debe ser un número mayor o igual a cero")
+	}
+	if len(moneda) != 3 || strings.IndexFunc(moneda, func(r rune) bool { return r < 'A' || r > 'Z' }) >= 0 {
+		return Precio{}, shared.NewErrorValidacion("precio_moneda", "la moneda debe ser un código ISO 4217 de tres letras, p. ej. COP")
+	}
+	return Precio{Valor: valor, Moneda: moneda}, nil
+}
+
+// CantidadDisponible representa el inventario disponible de un producto.
+type CantidadDisponible struct {
+	Valor  int    // Cantidad disponible, no negativa
+	Unidad string // Unidad de medida: kg, g, L, unidad o docena
+}
+
+// Unidades de medida válidas para CantidadDisponible
+const (
+	UnidadKilogramo = "kg"
+	UnidadGramo     = "g"
+	UnidadLitro     = "L"
+	UnidadUnidad    = "unidad"
+	UnidadDocena    = "docena"
+)
+
+// NewCantidadDisponible crea una nueva instancia de CantidadDisponible.
+// Valida que la cantidad no sea negativa y que la unidad sea una de las predefinidas.
+//
+// Parámetros:
+//   - valor: la cantidad disponible
+//   - unidad: la unidad de medida (kg, g, L, unidad, docena)
+//
+// Retorna:
+//   - CantidadDisponible: instancia válida del value object
+//   - error: error de validación si la cantidad o la unidad son inválidas
+func NewCantidadDisponible(valor int, unidad string) (CantidadDisponible, error) {
+	if valor < 0 {
+		return CantidadDisponible{}, shared.NewErrorValidacion("cantidad_valor", "la cantidad disponible no puede ser negativa")
+	}
+	switch unidad {
+	case UnidadKilogramo, UnidadGramo, UnidadLitro, UnidadUnidad, UnidadDocena:
+		return CantidadDisponible{Valor: valor, Unidad: unidad}, nil
+	default:
+		return CantidadDisponible{}, shared.NewErrorValidacion("cantidad_unidad", "unidad inválida, valores permitidos: kg, g, L, unidad, docena")
+	}
+}
+
+// umbralStockBajo es la cantidad por debajo de la cual un producto tiene stock bajo
+var umbralStockBajo = 5
+
+// ConfigurarUmbralStockBajo establece la cantidad por debajo de la cual se emite ProductoStockBajo.
+// Debe llamarse una sola vez al iniciar el servicio, antes de atender peticiones.
+func ConfigurarUmbralStockBajo(umbral int) {
+	if umbral >= 0 {
+		umbralStockBajo = umbral
 	}
-	return Imagen{URL: url, DescripcionCorta: desc}, nil
 }
@@ -0,0 +1,126 @@
+package producto
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+// Los fuzzers de este archivo parten del corpus de testdata/fuzz. Para explorar más entradas:
+//
+//	go test ./internal/domain/producto -run '^$' -fuzz FuzzNewNombreProducto -fuzztime 30s
+
+func FuzzNewNombreProducto(f *testing.F) {
+	f.Add("Tomate Cherry")
+	f.Fuzz(func(t *testing.T, valor string) {
+		nombre, err := NewNombreProducto(valor)
+		if err != nil {
+			return
+		}
+		if nombre.Value != valor {
+			t.Fatalf("Value = %q, se esperaba el valor recibido %q", nombre.Value, valor)
+		}
+		if !utf8.ValidString(valor) || strings.TrimSpace(valor) == "" || utf8.RuneCountInString(valor) > 100 {
+			t.Fatalf("se aceptó un nombre inválido: %q", valor)
+		}
+	})
+}
+
+func FuzzNewDescripcionProducto(f *testing.F) {
+	f.Add("Cosecha fresca sin agroquímicos")
+	f.Fuzz(func(t *testing.T, valor string) {
+		desc, err := NewDescripcionProducto(valor)
+		if err != nil {
+			return
+		}
+		if desc.Value != valor {
+			t.Fatalf("Value = %q, se esperaba el valor recibido %q", desc.Value, valor)
+		}
+		if n := utf8.RuneCountInString(valor); !utf8.ValidString(valor) || n < 10 || n > 500 {
+			t.Fatalf("se aceptó una descripción inválida de %d caracteres: %q", n, valor)
+		}
+	})
+}
+
+func FuzzNewImagen(f *testing.F) {
+	f.Add("https://img.example.com/fresa.jpg", "Fresas")
+	f.Fuzz(func(t *testing.T, rawURL, desc string) {
+		imagen, err := NewImagen(rawURL, desc)
+		if err != nil {
+			return
+		}
+		if imagen.URL != rawURL || imagen.DescripcionCorta != desc {
+			t.Fatalf("Imagen = %+v, se esperaban los valores recibidos", imagen)
+		}
+		u, err := url.Parse(imagen.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			t.Fatalf("se aceptó una URL sin esquema http(s) o sin host: %q", rawURL)
+		}
+		if _, err := NewImagen(imagen.URL, imagen.DescripcionCorta); err != nil {
+			t.Fatalf("la imagen aceptada no vuelve a validar: %v", err)
+		}
+	})
+}
+
+// FuzzNewTemporadaLocal recibe pares de fechas como segundos Unix
+func FuzzNewTemporadaLocal(f *testing.F) {
+	ahora := time.Now().Unix()
+	f.Add(ahora, ahora+30*24*3600)
+	f.Fuzz(func(t *testing.T, inicioUnix, finUnix int64) {
+		// Se acotan a unos 34.000 años alrededor de 1970 para no desbordar la aritmética de time
+		inicio := time.Unix(inicioUnix%(1<<40), 0).In(ZonaHoraria())
+		fin := time.Unix(finUnix%(1<<40), 0).In(ZonaHoraria())
+		antes := time.Now()
+
+		temporada, err := NewTemporadaLocal(inicio, fin)
+		if err != nil {
+			return
+		}
+
+		// Días completos: Inicio a medianoche y Fin en el último instante de su día
+		if !temporada.Inicio.Equal(inicioDelDia(temporada.Inicio)) {
+			t.Fatalf("Inicio = %v no es medianoche", temporada.Inicio)
+		}
+		if !temporada.Fin.Equal(finDelDia(temporada.Fin)) {
+			t.Fatalf("Fin = %v no es el último instante del día", temporada.Fin)
+		}
+		if temporada.Fin.Before(temporada.Inicio) {
+			t.Fatalf("Fin %v es anterior a Inicio %v", temporada.Fin, temporada.Inicio)
+		}
+		if temporada.Fin.Before(antes) {
+			t.Fatalf("se aceptó una temporada que ya terminó: %v", temporada.Fin)
+		}
+		if d := temporada.Fin.Sub(temporada.Inicio); d > 366*24*time.Hour {
+			t.Fatalf("se aceptó una temporada de %v", d)
+		}
+
+		// Los límites son inclusivos
+		if !temporada.IsInSeason(temporada.Inicio) || !temporada.IsInSeason(temporada.Fin) {
+			t.Fatalf("los límites de %+v no están en temporada", temporada)
+		}
+		if temporada.IsInSeason(temporada.Inicio.Add(-time.Nanosecond)) || temporada.IsInSeason(temporada.Fin.Add(time.Nanosecond)) {
+			t.Fatalf("%+v está en temporada fuera de sus límites", temporada)
+		}
+	})
+}
+
+func FuzzNewUbicacion(f *testing.F) {
+	f.Add("Vereda El Paraíso", "Finca La Esperanza")
+	f.Fuzz(func(t *testing.T, zona, finca string) {
+		u, err := NewUbicacion(zona, finca)
+		if err != nil {
+			return
+		}
+		if u.ZonaVeredal != zona || u.Finca != finca {
+			t.Fatalf("Ubicacion = %+v, se esperaban los valores recibidos", u)
+		}
+		if zona == "" || finca == "" || utf8.RuneCountInString(zona) > 40 || utf8.RuneCountInString(finca) > 50 {
+			t.Fatalf("se aceptó una ubicación inválida: %q / %q", zona, finca)
+		}
+		if strings.ContainsAny(zona+finca, "<>@$%&\"") {
+			t.Fatalf("se aceptaron caracteres prohibidos: %q / %q", zona, finca)
+		}
+	})
+}
@@ -2,6 +2,11 @@ package productor
 
 import "time"
 
+type ProductorCreado struct {
+    ProductorID ProductorID
+    At          time.Time
+}
+
 type ProductorEnVerificacion struct {
     ProductorID ProductorID
     At         time.Time
@@ -18,3 +23,25 @@ type ReputacionActualizada struct {
     At             time.Time
 }
 
+type ProductorSuspendido struct {
+    ProductorID ProductorID
+    Motivo      string
+    At          time.Time
+}
+
+type ProductorReactivado struct {
+    ProductorID ProductorID
+    At          time.Time
+}
+
+type ProductorDesactivado struct {
+    ProductorID ProductorID
+    Motivo      string
+    At          time.Time
+}
+
+type PreferenciasNotificacionActualizadas struct {
+    ProductorID  ProductorID
+    Preferencias map[string]bool
+    At           time.Time
+}
@@ -0,0 +1,20 @@
+package productor
+
+import "testing"
+
+// nuevoProductorPrueba crea un productor verificado con el estado de actividad indicado y sin
+// eventos pendientes
+func nuevoProductorPrueba(t testing.TB, actividad string) *Productor {
+	t.Helper()
+	nombre, _ := NewNombreProducto("Juan Pérez")
+	ubicacion, _ := NewUbicacion("Vereda El Paraíso", "Finca La Esperanza")
+	practicas, _ := NuevaPracticasDeCultivo("Rotación de cultivos y abonos orgánicos")
+
+	p, err := NewProductor("p-1", nombre, ubicacion, EstadoVerificacion{Value: Verificado},
+		EstadoActividad{Value: actividad}, 4.5, practicas)
+	if err != nil {
+		t.Fatalf("NewProductor: %v", err)
+	}
+	p.ClearEvents()
+	return p
+}
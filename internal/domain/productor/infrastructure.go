@@ -1,17 +1,41 @@
 package productor
 
+import (
+	"errors"
+	"time"
+
+	"Product_Catalog_Microservice/internal/domain/shared"
+)
+
+// ErrCapacidadAlcanzada indica que el repositorio llegó a su máximo de productores configurado.
+// Se distingue de un ID duplicado: el registro es válido pero no hay espacio para guardarlo.
+var ErrCapacidadAlcanzada = errors.New("se alcanzó la capacidad máxima de productores")
+
+// ErrNoEncontrado indica que no existe un productor con el ID solicitado. Las
+// implementaciones del repositorio lo envuelven para que el servicio lo distinga de otras fallas.
+var ErrNoEncontrado = errors.New("no existe un productor con ese id")
+
 type ProductorRepositoryInterface interface {
     Save(productor *Productor) error
     GetByID(id ProductorID) (*Productor, error)
     Delete(id ProductorID) error // Establece al productor como inactivo
 
     GetByUbicacion(ubicacion Ubicacion) ([]*Productor, error)
+    GetByZonaVeredal(zona string) ([]*Productor, error) // cualquier finca de la zona
     GetByEstadoVerificacion(estado EstadoVerificacion) ([]*Productor, error)
     GetByReputacionMinima(minReputacion Reputacion) ([]*Productor, error)
     GetVerificados() ([]*Productor, error)
+    GetVerificadosPaginated(params shared.PaginationParams) (shared.PagedResult[*Productor], error) // ordenado por ID
     GetPendientesVerificacion() ([]*Productor, error)
+    GetByPracticaKeyword(keyword string) ([]*Productor, error)
+    GetRegistradosEnRango(desde, hasta time.Time) ([]*Productor, error) // desde inclusivo, hasta exclusivo
+    GetInactivosPorMasDe(d time.Duration) ([]*Productor, error)
     GetAll() ([]*Productor, error)
+    GetAllPaginated(params shared.PaginationParams) (shared.PagedResult[*Productor], error) // ordenado por ID
     
     UpdateReputacion(id ProductorID, nuevaReputacion Reputacion) error
     UpdateEstadoVerificacion(id ProductorID, nuevoEstado EstadoVerificacion) error
+    UpdateEstadoActividad(id ProductorID, nuevoEstado EstadoActividad) error
+    UpdateUltimaActividad(id ProductorID, ultimaActividad time.Time) error
+    UpdatePreferenciasNotificacion(id ProductorID, preferencias PreferenciasNotificacion) error
 }
\ No newline at end of file
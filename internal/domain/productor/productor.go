@@ -2,11 +2,40 @@ package productor
 
 import (
 	"errors"
+	"fmt"
 	"time"
+
+	"Product_Catalog_Microservice/internal/domain/shared"
 )
 
 type ProductorID string
 
+// ErrSinCambios indica que el productor ya se encuentra en el estado solicitado.
+// La transición no genera eventos; el llamador decide si tratarlo como error.
+var ErrSinCambios = errors.New("el productor ya se encuentra en el estado solicitado")
+
+// Errores de IniciarProcesosVerificacion: el estado actual del productor no permite iniciarla.
+var (
+	ErrProductorNoActivo     = errors.New("el productor no está activo")
+	ErrProductorYaVerificado = errors.New("el productor ya está verificado")
+	ErrVerificacionEnCurso   = errors.New("ya hay un proceso de verificación en curso")
+)
+
+// ErrNoEnVerificacion indica que se intentó completar la verificación sin haberla iniciado.
+var ErrNoEnVerificacion = errors.New("el productor no está en proceso de verificación")
+
+// ErrTransicionInvalida indica que el estado de actividad actual no permite pasar al solicitado.
+var ErrTransicionInvalida = errors.New("transición de estado de actividad no permitida")
+
+// MaxEventosPendientes es la cantidad máxima de eventos sin publicar que acumula un productor.
+const MaxEventosPendientes = 32
+
+// ErrDemasiadosEventosPendientes indica que el productor alcanzó MaxEventosPendientes.
+var ErrDemasiadosEventosPendientes = errors.New("el productor tiene demasiados eventos pendientes de publicar")
+
+// ErrProductorDuplicado indica que ya hay un productor guardado con el mismo ID.
+var ErrProductorDuplicado = errors.New("ya existe un productor con ese id")
+
 type Productor struct {
 	ID               ProductorID
 	Nombre           NombreProductor
@@ -15,6 +44,9 @@ type Productor struct {
 	EstadoActividad  EstadoActividad
 	Reputacion       Reputacion
 	PracticasCultivo PracticasDeCultivo
+	FechaRegistro    time.Time
+	UltimaActividad  time.Time // última vez que el productor ejecutó una operación de dominio
+	PreferenciasNotificacion PreferenciasNotificacion
 	    // Agregar eventos pendientes
     eventsPending      []interface{}
 }
@@ -31,10 +63,12 @@ func NewProductor(
 ) (*Productor, error) {
 
 	if id == "" {
-		return nil, errors.New("el ID del productor no puede estar vacío")
+		return nil, shared.NewErrorValidacion("id", "el ID del productor no puede estar vacío")
 	}
 
-	return &Productor{
+	ahora := time.Now()
+
+	p := &Productor{
 		ID:                id,
 		Nombre:            nombre,
 		Ubicacion:         ubicacion,
@@ -42,7 +76,18 @@ func NewProductor(
 		EstadoActividad:   estadoActividad,
 		Reputacion:        reputacion,
 		PracticasCultivo:  practicasCultivo,
-	}, nil
+		FechaRegistro:     ahora,
+		UltimaActividad:   ahora,
+		PreferenciasNotificacion: PreferenciasNotificacionPorDefecto(),
+	}
+
+	// Generar evento de productor creado
+	p.addEvent(ProductorCreado{
+		ProductorID: id,
+		At:          ahora,
+	})
+
+	return p, nil
 }
 
 // PuedePublicar determina si el productor puede publicar productos
@@ -53,11 +98,16 @@ func (p *Productor) PuedePublicar(minReputacion Reputacion) bool {
 // ActualizarReputacion permite actualizar la reputacion del productor basándose en cálculos derivados de historial
 func (p *Productor) ActualizarReputacion(nuevaReputacion Reputacion) error {
 	if (nuevaReputacion < 0 || nuevaReputacion > 5)  && p.EstadoActividad.IsActivo() {
-		return errors.New("reputacion fuera de rango permitido")
+		return shared.NewErrorValidacion("reputacion", "reputacion fuera de rango permitido")
 	}
 
+    if err := p.verificarCupoEventos(); err != nil {
+        return err
+    }
+
     reputacionAnterior := p.Reputacion
     p.Reputacion = nuevaReputacion
+    p.registrarActividad()
     
     // Generar evento solo si cambió
     if reputacionAnterior != nuevaReputacion {
@@ -74,17 +124,22 @@ func (p *Productor) ActualizarReputacion(nuevaReputacion Reputacion) error {
 
 func (p *Productor) IniciarProcesosVerificacion() error {
     if !p.EstadoActividad.IsActivo() {
-        return errors.New("el productor no está activo")
+        return ErrProductorNoActivo
     }
 
     if p.EstadoVerificacion.IsVerificado() {
-        return errors.New("el productor ya está verificado")
+        return ErrProductorYaVerificado
     }
     if p.EstadoVerificacion.Value == "En Proceso" {
-        return errors.New("ya hay un proceso de verificación en curso")
+        return ErrVerificacionEnCurso
     }
     
+    if err := p.verificarCupoEventos(); err != nil {
+        return err
+    }
+
     p.EstadoVerificacion = EstadoVerificacion{Value: "En Proceso"}
+    p.registrarActividad()
     
     // Generar evento
     p.addEvent(ProductorEnVerificacion{
@@ -96,11 +151,19 @@ func (p *Productor) IniciarProcesosVerificacion() error {
 }
 
 func (p *Productor) VerificarProductor() error {
+	if p.EstadoVerificacion.IsVerificado() {
+		return ErrSinCambios
+	}
 	if !p.EstadoVerificacion.IsEnProceso() {
-		return errors.New("el productor no está en proceso de verificación")
+		return ErrNoEnVerificacion
+	}
+
+	if err := p.verificarCupoEventos(); err != nil {
+		return err
 	}
 
 	p.EstadoVerificacion = EstadoVerificacion{Value: "Verificado"}
+	p.registrarActividad()
 
 	// Generar evento
 	p.addEvent(ProductorVerificado{
@@ -111,12 +174,124 @@ func (p *Productor) VerificarProductor() error {
 	return nil
 }
 
+// CambiarEstadoActividad centraliza las transiciones de actividad del productor.
+// Transiciones válidas: Activo→Suspendido, Activo→Inactivo, Suspendido→Activo, Inactivo→Activo.
+func (p *Productor) CambiarEstadoActividad(nuevo EstadoActividad, motivo string) error {
+	if p.EstadoActividad == nuevo {
+		return ErrSinCambios
+	}
+	if !p.EstadoActividad.CanTransitionTo(nuevo) {
+		return fmt.Errorf("%w: no se puede pasar de '%s' a '%s'", ErrTransicionInvalida, p.EstadoActividad.Value, nuevo.Value)
+	}
+
+	if err := p.verificarCupoEventos(); err != nil {
+		return err
+	}
+
+	p.EstadoActividad = nuevo
+	p.registrarActividad()
+
+	switch nuevo.Value {
+	case Suspendido:
+		p.registrarSuspension(motivo)
+	case Inactivo:
+		p.registrarDesactivacion(motivo)
+	case Activo:
+		p.registrarReactivacion()
+	}
+
+	return nil
+}
+
+// Suspender suspende al productor por una violación de políticas
+func (p *Productor) Suspender(motivo string) error {
+	return p.CambiarEstadoActividad(EstadoActividad{Value: Suspendido}, motivo)
+}
+
+// Reactivar devuelve al productor suspendido o inactivo al estado activo
+func (p *Productor) Reactivar() error {
+	return p.CambiarEstadoActividad(EstadoActividad{Value: Activo}, "")
+}
+
+// Desactivar marca al productor como inactivo
+func (p *Productor) Desactivar(motivo string) error {
+	return p.CambiarEstadoActividad(EstadoActividad{Value: Inactivo}, motivo)
+}
+
+func (p *Productor) registrarSuspension(motivo string) {
+	p.addEvent(ProductorSuspendido{
+		ProductorID: p.ID,
+		Motivo:      motivo,
+		At:          time.Now(),
+	})
+}
+
+func (p *Productor) registrarDesactivacion(motivo string) {
+	p.addEvent(ProductorDesactivado{
+		ProductorID: p.ID,
+		Motivo:      motivo,
+		At:          time.Now(),
+	})
+}
+
+func (p *Productor) registrarReactivacion() {
+	p.addEvent(ProductorReactivado{
+		ProductorID: p.ID,
+		At:          time.Now(),
+	})
+}
+
+// ActualizarPreferenciasNotificacion reemplaza las preferencias de notificación del productor
+// y genera un evento para auditoría
+func (p *Productor) ActualizarPreferenciasNotificacion(preferencias PreferenciasNotificacion) error {
+	if err := p.verificarCupoEventos(); err != nil {
+		return err
+	}
+
+	p.PreferenciasNotificacion = preferencias
+	p.registrarActividad()
+
+	p.addEvent(PreferenciasNotificacionActualizadas{
+		ProductorID:  p.ID,
+		Preferencias: preferencias.Copia(),
+		At:           time.Now(),
+	})
+
+	return nil
+}
+
+// registrarActividad marca el momento de la última operación de dominio del productor
+func (p *Productor) registrarActividad() {
+	p.UltimaActividad = time.Now()
+}
+
+// InactivoPorMasDe indica si el productor no registra actividad desde hace más de d
+func (p *Productor) InactivoPorMasDe(d time.Duration) bool {
+	return time.Since(p.UltimaActividad) > d
+}
 
 // Métodos para manejar eventos
 func (p *Productor) addEvent(event interface{}) {
     p.eventsPending = append(p.eventsPending, event)
 }
 
+// verificarCupoEventos impide nuevas transiciones cuando el agregado acumula demasiados eventos
+// sin publicar, lo que indica que algún camino muta el agregado sin publicar sus eventos.
+func (p *Productor) verificarCupoEventos() error {
+    if len(p.eventsPending) >= MaxEventosPendientes {
+        return ErrDemasiadosEventosPendientes
+    }
+    return nil
+}
+
+// TomarEventos retorna los eventos pendientes y los elimina del agregado en una sola operación,
+// de modo que un reintento posterior no vuelva a publicarlos.
+func (p *Productor) TomarEventos() []interface{} {
+    eventos := p.eventsPending
+    p.eventsPending = make([]interface{}, 0)
+    return eventos
+}
+
 func (p *Productor) GetPendingEvents() []interface{} {
     return p.eventsPending
 }
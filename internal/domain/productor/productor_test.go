@@ -0,0 +1,222 @@
+package productor
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCambiarEstadoActividad_TransicionesValidas(t *testing.T) {
+	casos := []struct {
+		desde, hacia string
+		evento       any
+	}{
+		{Activo, Suspendido, ProductorSuspendido{}},
+		{Activo, Inactivo, ProductorDesactivado{}},
+		{Suspendido, Activo, ProductorReactivado{}},
+		{Inactivo, Activo, ProductorReactivado{}},
+	}
+	for _, tc := range casos {
+		t.Run(tc.desde+"→"+tc.hacia, func(t *testing.T) {
+			p := nuevoProductorPrueba(t, tc.desde)
+
+			if err := p.CambiarEstadoActividad(EstadoActividad{Value: tc.hacia}, "motivo de prueba"); err != nil {
+				t.Fatalf("CambiarEstadoActividad: %v", err)
+			}
+			if p.EstadoActividad.Value != tc.hacia {
+				t.Errorf("EstadoActividad = %s, se esperaba %s", p.EstadoActividad.Value, tc.hacia)
+			}
+
+			eventos := p.GetPendingEvents()
+			if len(eventos) != 1 {
+				t.Fatalf("eventos = %d, se esperaba 1: %#v", len(eventos), eventos)
+			}
+			switch e := eventos[0].(type) {
+			case ProductorSuspendido:
+				if _, ok := tc.evento.(ProductorSuspendido); !ok || e.Motivo != "motivo de prueba" {
+					t.Errorf("evento = %#v, se esperaba %T con el motivo", e, tc.evento)
+				}
+			case ProductorDesactivado:
+				if _, ok := tc.evento.(ProductorDesactivado); !ok || e.Motivo != "motivo de prueba" {
+					t.Errorf("evento = %#v, se esperaba %T con el motivo", e, tc.evento)
+				}
+			case ProductorReactivado:
+				if _, ok := tc.evento.(ProductorReactivado); !ok {
+					t.Errorf("evento = %#v, se esperaba %T", e, tc.evento)
+				}
+			default:
+				t.Errorf("evento inesperado %#v", e)
+			}
+		})
+	}
+}
+
+func TestCambiarEstadoActividad_TransicionesInvalidas(t *testing.T) {
+	casos := []struct {
+		desde, hacia string
+		sinCambios   bool
+	}{
+		{Suspendido, Inactivo, false},
+		{Inactivo, Suspendido, false},
+		{Activo, "Desconocido", false},
+		{Activo, Activo, true},
+		{Suspendido, Suspendido, true},
+		{Inactivo, Inactivo, true},
+	}
+	for _, tc := range casos {
+		t.Run(tc.desde+"→"+tc.hacia, func(t *testing.T) {
+			p := nuevoProductorPrueba(t, tc.desde)
+
+			err := p.CambiarEstadoActividad(EstadoActividad{Value: tc.hacia}, "")
+			if err == nil {
+				t.Fatal("se esperaba error")
+			}
+			if errors.Is(err, ErrSinCambios) != tc.sinCambios {
+				t.Errorf("err = %v, ErrSinCambios se esperaba solo para la misma transición", err)
+			}
+			if p.EstadoActividad.Value != tc.desde {
+				t.Errorf("EstadoActividad = %s, no debía cambiar de %s", p.EstadoActividad.Value, tc.desde)
+			}
+			if n := len(p.GetPendingEvents()); n != 0 {
+				t.Errorf("una transición rechazada generó %d eventos", n)
+			}
+		})
+	}
+}
+
+func TestSuspenderReactivarDesactivar_DelegaEnCambiarEstadoActividad(t *testing.T) {
+	p := nuevoProductorPrueba(t, Activo)
+
+	pasos := []struct {
+		nombre string
+		accion func() error
+		estado string
+	}{
+		{"Suspender", func() error { return p.Suspender("fraude") }, Suspendido},
+		{"Reactivar", p.Reactivar, Activo},
+		{"Desactivar", func() error { return p.Desactivar("vacaciones") }, Inactivo},
+		{"Reactivar", p.Reactivar, Activo},
+	}
+	for _, paso := range pasos {
+		if err := paso.accion(); err != nil {
+			t.Fatalf("%s: %v", paso.nombre, err)
+		}
+		if p.EstadoActividad.Value != paso.estado {
+			t.Fatalf("después de %s EstadoActividad = %s, se esperaba %s", paso.nombre, p.EstadoActividad.Value, paso.estado)
+		}
+	}
+	if n := len(p.GetPendingEvents()); n != len(pasos) {
+		t.Errorf("eventos = %d, se esperaba uno por transición (%d)", n, len(pasos))
+	}
+
+	// Desde Inactivo no se puede suspender directamente
+	if err := p.Desactivar(""); err != nil {
+		t.Fatalf("Desactivar: %v", err)
+	}
+	if err := p.Suspender(""); err == nil {
+		t.Error("Suspender desde Inactivo: se esperaba error")
+	}
+}
+
+func TestEstadoActividad_CanTransitionTo(t *testing.T) {
+	estados := []string{Activo, Inactivo, Suspendido}
+	validas := map[[2]string]bool{
+		{Activo, Suspendido}: true,
+		{Activo, Inactivo}:   true,
+		{Suspendido, Activo}: true,
+		{Inactivo, Activo}:   true,
+	}
+	for _, desde := range estados {
+		for _, hacia := range estados {
+			got := EstadoActividad{Value: desde}.CanTransitionTo(EstadoActividad{Value: hacia})
+			if want := validas[[2]string{desde, hacia}]; got != want {
+				t.Errorf("CanTransitionTo(%s→%s) = %v, se esperaba %v", desde, hacia, got, want)
+			}
+		}
+	}
+}
+
+func TestNewProductor_FechaRegistroEsElMomentoDeCreacion(t *testing.T) {
+	antes := time.Now()
+	p := nuevoProductorPrueba(t, Activo)
+	despues := time.Now()
+
+	if p.FechaRegistro.Before(antes) || p.FechaRegistro.After(despues) {
+		t.Errorf("FechaRegistro = %v, se esperaba entre %v y %v", p.FechaRegistro, antes, despues)
+	}
+	if !p.UltimaActividad.Equal(p.FechaRegistro) {
+		t.Errorf("UltimaActividad = %v, se esperaba igual a FechaRegistro", p.UltimaActividad)
+	}
+}
+
+func TestNewProductor_EmiteProductorCreado(t *testing.T) {
+	nombre, _ := NewNombreProducto("Juan Pérez")
+	ubicacion, _ := NewUbicacion("Vereda El Paraíso", "Finca La Esperanza")
+	practicas, _ := NuevaPracticasDeCultivo("Rotación de cultivos y abonos orgánicos")
+	p, err := NewProductor("p-1", nombre, ubicacion, EstadoVerificacion{Value: NoVerificado},
+		EstadoActividad{Value: Activo}, 0, practicas)
+	if err != nil {
+		t.Fatalf("NewProductor: %v", err)
+	}
+
+	eventos := p.TomarEventos()
+	if len(eventos) != 1 {
+		t.Fatalf("eventos = %v, se esperaba solo ProductorCreado", eventos)
+	}
+	creado, ok := eventos[0].(ProductorCreado)
+	if !ok || creado.ProductorID != "p-1" || !creado.At.Equal(p.FechaRegistro) {
+		t.Errorf("evento = %+v, se esperaba ProductorCreado de p-1 al registrarse", eventos[0])
+	}
+}
+
+func TestUltimaActividad_SeRenuevaConCadaOperacion(t *testing.T) {
+	operaciones := []struct {
+		nombre string
+		op     func(p *Productor) error
+	}{
+		{"ActualizarReputacion", func(p *Productor) error { return p.ActualizarReputacion(3.0) }},
+		{"CambiarEstadoActividad", func(p *Productor) error {
+			return p.CambiarEstadoActividad(EstadoActividad{Value: Suspendido}, "revisión")
+		}},
+	}
+	for _, tc := range operaciones {
+		t.Run(tc.nombre, func(t *testing.T) {
+			p := nuevoProductorPrueba(t, Activo)
+			p.UltimaActividad = time.Now().AddDate(0, 0, -100)
+			if !p.InactivoPorMasDe(90 * 24 * time.Hour) {
+				t.Fatal("con 100 días sin actividad se esperaba inactivo por más de 90")
+			}
+
+			if err := tc.op(p); err != nil {
+				t.Fatalf("%s: %v", tc.nombre, err)
+			}
+			if p.InactivoPorMasDe(time.Minute) {
+				t.Errorf("UltimaActividad = %v, se esperaba renovada por %s", p.UltimaActividad, tc.nombre)
+			}
+		})
+	}
+}
+
+func TestVerificarCupoEventos_LimitaLosEventosPendientes(t *testing.T) {
+	p := nuevoProductorPrueba(t, Activo)
+	for len(p.GetPendingEvents()) < MaxEventosPendientes {
+		p.addEvent(ProductorReactivado{ProductorID: p.ID, At: time.Now()})
+	}
+
+	if err := p.Suspender("prueba"); !errors.Is(err, ErrDemasiadosEventosPendientes) {
+		t.Fatalf("Suspender err = %v, se esperaba ErrDemasiadosEventosPendientes", err)
+	}
+	if p.EstadoActividad.Value != Activo {
+		t.Errorf("EstadoActividad = %q, la transición rechazada no debía aplicarse", p.EstadoActividad.Value)
+	}
+
+	if n := len(p.TomarEventos()); n != MaxEventosPendientes {
+		t.Errorf("TomarEventos retornó %d eventos, se esperaba %d", n, MaxEventosPendientes)
+	}
+	if err := p.Suspender("prueba"); err != nil {
+		t.Fatalf("Suspender tras vaciar los eventos: %v", err)
+	}
+	if n := len(p.TomarEventos()); n != 1 {
+		t.Errorf("eventos tras Suspender = %d, se esperaba 1", n)
+	}
+}
@@ -0,0 +1,30 @@
+package productor
+
+import "time"
+
+// Códigos de motivo de rechazo de una publicación. Solo se registran rechazos por reglas
+// de negocio; los errores de validación del request no generan registro.
+const (
+	RechazoNoAutorizado         = "no_autorizado"          // el productor no cumple las condiciones para publicar
+	RechazoContenidoNoPermitido = "contenido_no_permitido" // el nombre o la descripción no pasaron la moderación
+	RechazoNombreDuplicado      = "nombre_duplicado"       // ya existe un producto del productor con ese nombre
+	RechazoLimiteProductos      = "limite_productos"       // el productor alcanzó el máximo de productos publicados
+)
+
+// RechazoPublicacion registra un intento de publicación rechazado para que el productor
+// pueda consultar después por qué no se publicó su producto.
+type RechazoPublicacion struct {
+	ProductorID    ProductorID
+	NombreProducto string
+	Codigo         string
+	Mensaje        string
+	Fecha          time.Time
+}
+
+// RechazoRepositoryInterface almacena el historial de rechazos. Las implementaciones pueden
+// limitar la cantidad de registros por productor descartando los más antiguos.
+type RechazoRepositoryInterface interface {
+	Save(rechazo RechazoPublicacion) error
+	GetByProductor(id ProductorID) ([]RechazoPublicacion, error)
+	GetByCodigo(codigo string) ([]RechazoPublicacion, error) // codigo vacío retorna todos
+}
@@ -1,9 +1,11 @@
 package productor
 
 import (
-	"errors"
-	"regexp"
+	"Product_Catalog_Microservice/internal/domain/shared"
+	"Product_Catalog_Microservice/internal/domain/ubicacion"
+	"math"
 	"strings"
+	"unicode/utf8"
 )
 
 // NombreProducto representa el nombre de un producto como value object.
@@ -22,67 +24,41 @@ type NombreProductor struct {
 //   - NombreProducto: instancia válida del value object
 //   - error: error de validación si el nombre es inválido
 func NewNombreProducto(value string) (NombreProductor, error) {
-	if value == "" {
-		return NombreProductor{}, errors.New("el nombre del productor no puede estar vacío")
+	if strings.TrimSpace(value) == "" {
+		return NombreProductor{}, shared.NewErrorValidacion("nombre", "el nombre del productor no puede estar vacío")
 	}
-	if len(value) > 80 {
-		return NombreProductor{}, errors.New("el nombre del productor no puede superar 80 caracteres")
+	if !utf8.ValidString(value) {
+		return NombreProductor{}, shared.NewErrorValidacion("nombre", "el nombre del productor contiene caracteres inválidos")
+	}
+	if utf8.RuneCountInString(value) > 80 {
+		return NombreProductor{}, shared.NewErrorValidacion("nombre", "el nombre del productor no puede superar 80 caracteres")
 	}
 	return NombreProductor{Value: value}, nil
 }
 
 // Ubicacion representa la ubicación geográfica donde se produce el producto.
-// Incluye información sobre la zona veredal y la finca específica.
-type Ubicacion struct {
-	ZonaVeredal string // Zona veredal donde se encuentra la finca
-	Finca       string // Nombre de la finca productora
-}
+// Es un alias del value object compartido ubicacion.Ubicacion, de modo que
+// productos y productores usan el mismo tipo y la misma representación JSON.
+type Ubicacion = ubicacion.Ubicacion
 
 // NewUbicacion crea una nueva instancia de Ubicacion.
 // Valida que tanto la zona veredal como la finca estén especificadas,
 // que no excedan la longitud máxima y que no contengan caracteres prohibidos.
 //
 // Parámetros:
-//   - zona: nombre de la zona veredal (máximo 50 caracteres)
-//   - finca: nombre de la finca (máximo 80 caracteres)
+//   - zona: nombre de la zona veredal (máximo 40 caracteres)
+//   - finca: nombre de la finca (máximo 50 caracteres)
 //
 // Retorna:
 //   - Ubicacion: instancia válida del value object
 //   - error: error de validación si algún campo es inválido
 func NewUbicacion(zona, finca string) (Ubicacion, error) {
-    // Validar campos vacíos
-    if zona == "" || finca == "" {
-        return Ubicacion{}, errors.New("zona veredal y finca no pueden estar vacíos")
-    }
-
-    // Validar longitud máxima
-    if len(zona) > 40 {
-        return Ubicacion{}, errors.New("la zona veredal no puede superar 40 caracteres")
-    }
-    if len(finca) > 50 {
-        return Ubicacion{}, errors.New("el nombre de la finca no puede superar 50 caracteres")
-    }
-
-    // Validar caracteres prohibidos
-    if err := validarCaracteresProhibidos(zona, "zona veredal"); err != nil {
-        return Ubicacion{}, err
-    }
-    if err := validarCaracteresProhibidos(finca, "finca"); err != nil {
-        return Ubicacion{}, err
-    }
-
-    return Ubicacion{ZonaVeredal: zona, Finca: finca}, nil
+    return ubicacion.New(zona, finca)
 }
 
-// validarCaracteresProhibidos valida que el texto solo contenga caracteres permitidos
-// para nombres de ubicaciones (letras, números, espacios, guiones, apostrofes, puntos).
-func validarCaracteresProhibidos(texto, campo string) error {
-    // Permite letras (incluye acentos), números, espacios, guiones, apostrofes y puntos
-    patron := regexp.MustCompile(`^[a-zA-ZáéíóúñüÁÉÍÓÚÑÜ0-9\s\-'\.]+$`)
-    if !patron.MatchString(texto) {
-        return errors.New("el campo " + campo + " contiene caracteres no permitidos")
-    }
-    return nil
+// ValidarZona valida una zona veredal sin finca con las mismas reglas de NewUbicacion.
+func ValidarZona(zona string) error {
+    return ubicacion.ValidarZona(zona)
 }
 
 // EstadoVerificacion representa si el productor esta verificado por la plataforma.
@@ -112,7 +88,7 @@ func NewEstadoVerificacion(value string) (EstadoVerificacion, error) {
 	case Verificado, NoVerificado, EnProceso:
 		return EstadoVerificacion{Value: value}, nil
 	default:
-		return EstadoVerificacion{}, errors.New("estado de verificación inválido")
+		return EstadoVerificacion{}, shared.NewErrorValidacion("estado_verificacion", "estado de verificación inválido")
 	}	
 }
 
@@ -137,8 +113,8 @@ type Reputacion float32
 //   - Reputacion: instancia válida del value object
 //   - error: error de validación si el valor es inválido
 func NuevaReputacion(valor float32) (Reputacion, error) {
-	if valor < 0 || valor > 5 {
-		return 0, errors.New("reputacion debe estar entre 0 y 5")
+	if math.IsNaN(float64(valor)) || valor < 0 || valor > 5 {
+		return 0, shared.NewErrorValidacion("reputacion", "reputacion debe estar entre 0 y 5")
 	}
 	return Reputacion(valor), nil
 }
@@ -161,15 +137,21 @@ type PracticasDeCultivo struct {
 func NuevaPracticasDeCultivo(descripcion string) (PracticasDeCultivo, error) {
 	descripcion = strings.TrimSpace(descripcion)
 	if descripcion == "" {
-		return PracticasDeCultivo{}, errors.New("descripcion de prácticas no puede estar vacía")
+		return PracticasDeCultivo{}, shared.NewErrorValidacion("practicas_cultivo", "descripcion de prácticas no puede estar vacía")
 	}
-	if len(descripcion) > 500 {
-		return PracticasDeCultivo{}, errors.New("descripcion de prácticas demasiado larga")
+	if utf8.RuneCountInString(descripcion) > 500 {
+		return PracticasDeCultivo{}, shared.NewErrorValidacion("practicas_cultivo", "descripcion de prácticas demasiado larga")
 	}
 
 	return PracticasDeCultivo{Descripcion: descripcion}, nil
 }
 
+// HasKeyword indica si la descripción de las prácticas contiene la palabra clave,
+// sin distinguir mayúsculas de minúsculas.
+func (p PracticasDeCultivo) HasKeyword(kw string) bool {
+	return strings.Contains(strings.ToLower(p.Descripcion), strings.ToLower(kw))
+}
+
 // EstadoActividad representa si el productor está activo en la plataforma.
 // Un productor puede estar activo, inactivo o suspendido.
 type EstadoActividad struct {
@@ -197,11 +179,85 @@ func NewEstadoActividad(value string) (EstadoActividad, error) {
     case Activo, Inactivo, Suspendido:
         return EstadoActividad{Value: value}, nil
     default:
-        return EstadoActividad{}, errors.New("estado de actividad inválido")
+        return EstadoActividad{}, shared.NewErrorValidacion("estado_actividad", "estado de actividad inválido")
     }
 }
 
 // IsActivo verifica si el productor está activo
 func (e EstadoActividad) IsActivo() bool {
     return e.Value == Activo
-}
\ No newline at end of file
+}
+
+// transicionesActividad define las transiciones válidas entre estados de actividad
+var transicionesActividad = map[string][]string{
+    Activo:     {Suspendido, Inactivo},
+    Suspendido: {Activo},
+    Inactivo:   {Activo},
+}
+
+// CanTransitionTo indica si es válido pasar del estado actual al estado indicado
+func (e EstadoActividad) CanTransitionTo(nuevo EstadoActividad) bool {
+    for _, destino := range transicionesActividad[e.Value] {
+        if destino == nuevo.Value {
+            return true
+        }
+    }
+    return false
+}
+
+// PreferenciasNotificacion indica, por categoría de notificación, si el productor
+// desea recibir avisos de esa categoría. Las categorías ausentes se consideran activas.
+type PreferenciasNotificacion map[string]bool
+
+// Categorías de notificación que el productor puede desactivar
+const (
+    NotificacionAgotado      string = "agotado"       // El producto se marcó como agotado
+    NotificacionExcedente    string = "excedente"     // El producto se marcó como excedente
+    NotificacionFinTemporada string = "fin_temporada" // La temporada del producto terminó
+    NotificacionStockBajo    string = "stock_bajo"    // La cantidad disponible bajó del umbral
+)
+
+// PreferenciasNotificacionPorDefecto retorna las preferencias iniciales: todas las categorías activas.
+func PreferenciasNotificacionPorDefecto() PreferenciasNotificacion {
+    return PreferenciasNotificacion{
+        NotificacionAgotado:      true,
+        NotificacionExcedente:    true,
+        NotificacionFinTemporada: true,
+        NotificacionStockBajo:    true,
+    }
+}
+
+// NuevasPreferenciasNotificacion crea una nueva instancia de PreferenciasNotificacion.
+// Parte de los valores por defecto y aplica los cambios indicados.
+//
+// Parámetros:
+//   - cambios: categorías a activar o desactivar
+//
+// Retorna:
+//   - PreferenciasNotificacion: instancia válida del value object
+//   - error: error de validación si alguna categoría no existe
+func NuevasPreferenciasNotificacion(cambios map[string]bool) (PreferenciasNotificacion, error) {
+    preferencias := PreferenciasNotificacionPorDefecto()
+    for categoria, activa := range cambios {
+        if _, ok := preferencias[categoria]; !ok {
+            return nil, shared.NewErrorValidacion(categoria, "categoría de notificación inválida: "+categoria)
+        }
+        preferencias[categoria] = activa
+    }
+    return preferencias, nil
+}
+
+// Permite indica si el productor acepta notificaciones de la categoría
+func (p PreferenciasNotificacion) Permite(categoria string) bool {
+    activa, ok := p[categoria]
+    return !ok || activa
+}
+
+// Copia retorna una copia independiente de las preferencias, útil para adjuntarlas a eventos
+func (p PreferenciasNotificacion) Copia() map[string]bool {
+    copia := make(map[string]bool, len(p))
+    for categoria, activa := range p {
+        copia[categoria] = activa
+    }
+    return copia
+}
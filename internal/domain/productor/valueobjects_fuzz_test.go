@@ -0,0 +1,29 @@
+package productor
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// FuzzNewUbicacion parte del corpus de testdata/fuzz. Para explorar más entradas:
+//
+//	go test ./internal/domain/productor -run '^$' -fuzz FuzzNewUbicacion -fuzztime 30s
+func FuzzNewUbicacion(f *testing.F) {
+	f.Add("Vereda El Paraíso", "Finca La Esperanza")
+	f.Fuzz(func(t *testing.T, zona, finca string) {
+		u, err := NewUbicacion(zona, finca)
+		if err != nil {
+			return
+		}
+		if u.ZonaVeredal != zona || u.Finca != finca {
+			t.Fatalf("Ubicacion = %+v, se esperaban los valores recibidos", u)
+		}
+		if zona == "" || finca == "" || utf8.RuneCountInString(zona) > 40 || utf8.RuneCountInString(finca) > 50 {
+			t.Fatalf("se aceptó una ubicación inválida: %q / %q", zona, finca)
+		}
+		if strings.ContainsAny(zona+finca, "<>@$%&\"") {
+			t.Fatalf("se aceptaron caracteres prohibidos: %q / %q", zona, finca)
+		}
+	})
+}
@@ -0,0 +1,58 @@
+package productor
+
+import "testing"
+
+func TestPracticasDeCultivo_HasKeyword(t *testing.T) {
+	practicas, err := NuevaPracticasDeCultivo("Labranza cero, compostaje y control biológico de plagas")
+	if err != nil {
+		t.Fatalf("NuevaPracticasDeCultivo: %v", err)
+	}
+
+	casos := []struct {
+		nombre string
+		kw     string
+		espera bool
+	}{
+		{"palabra completa", "compostaje", true},
+		{"prefijo", "compost", true},
+		{"sin distinguir mayúsculas", "LABRANZA CERO", true},
+		{"con tilde", "biológico", true},
+		{"sin coincidencia", "biodinámica", false},
+		{"tilde distinta", "biologico", false},
+	}
+	for _, tc := range casos {
+		t.Run(tc.nombre, func(t *testing.T) {
+			if got := practicas.HasKeyword(tc.kw); got != tc.espera {
+				t.Errorf("HasKeyword(%q) = %v, se esperaba %v", tc.kw, got, tc.espera)
+			}
+		})
+	}
+}
+
+func TestNuevasPreferenciasNotificacion(t *testing.T) {
+	preferencias, err := NuevasPreferenciasNotificacion(map[string]bool{NotificacionAgotado: false})
+	if err != nil {
+		t.Fatalf("NuevasPreferenciasNotificacion: %v", err)
+	}
+	if preferencias.Permite(NotificacionAgotado) {
+		t.Error("agotado debía quedar desactivada")
+	}
+	// Las categorías no mencionadas conservan el valor por defecto
+	if !preferencias.Permite(NotificacionExcedente) || !preferencias.Permite(NotificacionFinTemporada) {
+		t.Errorf("preferencias = %v, excedente y fin_temporada debían seguir activas", preferencias)
+	}
+
+	if _, err := NuevasPreferenciasNotificacion(map[string]bool{"promociones": true}); err == nil {
+		t.Error("se esperaba error por categoría desconocida")
+	}
+}
+
+func TestPreferenciasNotificacion_Copia(t *testing.T) {
+	preferencias := PreferenciasNotificacionPorDefecto()
+	copia := preferencias.Copia()
+	copia[NotificacionAgotado] = false
+
+	if !preferencias.Permite(NotificacionAgotado) {
+		t.Error("modificar la copia no debe alterar las preferencias originales")
+	}
+}
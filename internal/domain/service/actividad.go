@@ -0,0 +1,132 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+	"Product_Catalog_Microservice/internal/domain/productor"
+)
+
+// SuspenderProductor suspende a un productor activo por una violación de políticas y agota
+// todos sus productos. Si el productor ya está suspendido retorna sinCambios=true, salvo que
+// la transición sea estricta, y aun así agota los productos que hayan quedado disponibles,
+// de modo que repetir la petición completa una cascada que falló a medias.
+func (s *CatalogoService) SuspenderProductor(productorID productor.ProductorID, motivo string) (sinCambios bool, err error) {
+	sinCambios, err = s.cambiarActividadProductor(productorID, TransicionSuspender, func(prod *productor.Productor) error {
+		// Esto genera el evento ProductorSuspendido
+		return prod.Suspender(motivo)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return sinCambios, s.agotarProductosDeProductor(productorID)
+}
+
+// ReactivarProductor devuelve a un productor suspendido o inactivo al estado activo y recalcula
+// la disponibilidad de sus productos, que vuelven a estar disponibles si están en temporada.
+// Si el productor ya está activo retorna sinCambios=true, salvo que la transición sea estricta.
+func (s *CatalogoService) ReactivarProductor(productorID productor.ProductorID) (sinCambios bool, err error) {
+	sinCambios, err = s.cambiarActividadProductor(productorID, TransicionReactivar, func(prod *productor.Productor) error {
+		// Esto genera el evento ProductorReactivado
+		return prod.Reactivar()
+	})
+	if err != nil || sinCambios {
+		return sinCambios, err
+	}
+
+	productos, err := s.productoRepo.GetByProductorID(string(productorID))
+	if err != nil {
+		return false, err
+	}
+	now := time.Now()
+	for _, prod := range productos {
+		s.recalcularDisponibilidad(prod.ID, now)
+	}
+	return false, nil
+}
+
+// cambiarActividadProductor aplica una transición de actividad bajo el bloqueo del productor
+// y la persiste. El bloqueo se libera antes de retornar para que los cambios en cascada sobre
+// los productos no lo mantengan tomado.
+func (s *CatalogoService) cambiarActividadProductor(
+	productorID productor.ProductorID,
+	transicion string,
+	cambiar func(*productor.Productor) error,
+) (sinCambios bool, err error) {
+	defer s.bloqueos.bloquear(claveProductor(productorID))()
+
+	prod, err := s.cargarProductor(productorID)
+	if err != nil {
+		return false, err
+	}
+
+	if err := cambiar(prod); err != nil {
+		return s.resolverSinCambios(transicion, err)
+	}
+
+	if err := s.productorRepo.UpdateEstadoActividad(productorID, prod.EstadoActividad); err != nil {
+		s.descartarEventos(prod)
+		return false, err
+	}
+	if err := s.productorRepo.UpdateUltimaActividad(productorID, prod.UltimaActividad); err != nil {
+		s.descartarEventos(prod)
+		return false, err
+	}
+
+	s.publishPendingEvents(prod)
+
+	return false, nil
+}
+
+// agotarProductosDeProductor agota cada producto del productor bajo su propio bloqueo.
+// Un producto que falla no detiene a los demás; se retorna el primer error.
+func (s *CatalogoService) agotarProductosDeProductor(productorID productor.ProductorID) error {
+	productos, err := s.productoRepo.GetByProductorID(string(productorID))
+	if err != nil {
+		return err
+	}
+
+	var primerError error
+	for _, prod := range productos {
+		if err := s.agotarPorSuspension(prod.ID); err != nil && primerError == nil {
+			primerError = err
+		}
+	}
+	return primerError
+}
+
+// productorSuspendido indica si el productor existe y está suspendido
+func (s *CatalogoService) productorSuspendido(id string) bool {
+	prod, err := s.productorRepo.GetByID(productor.ProductorID(id))
+	return err == nil && prod.EstadoActividad.Value == productor.Suspendido
+}
+
+// agotarPorSuspension relee el producto dentro de su bloqueo y lo agota; un producto
+// que ya estaba agotado no es un error.
+func (s *CatalogoService) agotarPorSuspension(id producto.ProductoID) error {
+	defer s.bloqueos.bloquear(claveProducto(id))()
+
+	prod, err := s.cargarProducto(id)
+	if err != nil {
+		return err
+	}
+
+	// Esto genera el evento ProductoAgotado
+	if err := prod.AgotarPorSuspension(); err != nil {
+		if errors.Is(err, producto.ErrSinCambios) {
+			return nil
+		}
+		return err
+	}
+
+	if err := s.productoRepo.UpdateEstadoDisponibilidad(id, prod.Estado); err != nil {
+		s.descartarEventos(prod)
+		return err
+	}
+
+	s.publishPendingEvents(prod)
+
+	return nil
+}
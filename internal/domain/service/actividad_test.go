@@ -0,0 +1,82 @@
+package service_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+	"Product_Catalog_Microservice/internal/domain/productor"
+)
+
+// exigirEstado comprueba el estado guardado de un producto
+func (e *escenario) exigirEstado(t *testing.T, id producto.ProductoID, estado string) {
+	t.Helper()
+	prod, err := e.productoRepo.GetByID(id)
+	if err != nil {
+		t.Fatalf("GetByID(%s): %v", id, err)
+	}
+	if prod.Estado.Value != estado {
+		t.Errorf("%s: Estado = %q, se esperaba %q", id, prod.Estado.Value, estado)
+	}
+}
+
+func TestSuspenderProductor_AgotaSusProductos(t *testing.T) {
+	e := nuevoEscenario(t)
+	e.publicarValido(t, e.semilla1, "p-1", "Fresa")
+	e.publicarValido(t, e.semilla1, "p-2", "Mora")
+	e.publicarValido(t, e.semilla2, "p-3", "Lulo")
+	if _, err := e.catalogo.MarcarProductoComoExcedente("p-2", time.Now()); err != nil {
+		t.Fatalf("MarcarProductoComoExcedente: %v", err)
+	}
+
+	if _, err := e.catalogo.SuspenderProductor(e.semilla1, "uso de agroquímicos"); err != nil {
+		t.Fatalf("SuspenderProductor: %v", err)
+	}
+
+	prod, err := e.productorRepo.GetByID(e.semilla1)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if prod.EstadoActividad.Value != productor.Suspendido {
+		t.Errorf("EstadoActividad = %q, se esperaba Suspendido", prod.EstadoActividad.Value)
+	}
+	// El excedente también se agota; los productos de otro productor no cambian
+	e.exigirEstado(t, "p-1", producto.Agotado)
+	e.exigirEstado(t, "p-2", producto.Agotado)
+	e.exigirEstado(t, "p-3", producto.Disponible)
+	if n := contarEventos[producto.ProductoAgotado](e.eventos); n != 2 {
+		t.Errorf("ProductoAgotado publicados = %d, se esperaba 2", n)
+	}
+
+	// Mientras siga suspendido, la actualización por temporada no los vuelve a ofrecer
+	if err := e.catalogo.ActualizarDisponibilidadPorTemporada(time.Now()); err != nil {
+		t.Fatalf("ActualizarDisponibilidadPorTemporada: %v", err)
+	}
+	e.exigirEstado(t, "p-1", producto.Agotado)
+
+	if _, err := e.catalogo.ReactivarProductor(e.semilla1); err != nil {
+		t.Fatalf("ReactivarProductor: %v", err)
+	}
+	e.exigirEstado(t, "p-1", producto.Disponible)
+	e.exigirEstado(t, "p-2", producto.Disponible)
+}
+
+// Solo un productor activo puede suspenderse
+func TestSuspenderProductor_DesdeInactivo(t *testing.T) {
+	e := nuevoEscenario(t)
+	e.publicarValido(t, e.semilla1, "p-1", "Fresa")
+	if err := e.productorRepo.UpdateEstadoActividad(e.semilla1, productor.EstadoActividad{Value: productor.Inactivo}); err != nil {
+		t.Fatalf("UpdateEstadoActividad: %v", err)
+	}
+
+	if _, err := e.catalogo.SuspenderProductor(e.semilla1, "prueba"); !errors.Is(err, productor.ErrTransicionInvalida) {
+		t.Fatalf("err = %v, se esperaba ErrTransicionInvalida", err)
+	}
+	e.exigirEstado(t, "p-1", producto.Disponible)
+
+	// Un productor inactivo sí puede reactivarse
+	if sinCambios, err := e.catalogo.ReactivarProductor(e.semilla1); err != nil || sinCambios {
+		t.Fatalf("ReactivarProductor = (%v, %v), se esperaba (false, nil)", sinCambios, err)
+	}
+}
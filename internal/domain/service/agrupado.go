@@ -0,0 +1,198 @@
+package service
+
+import (
+	"encoding/base64"
+	"errors"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+)
+
+// Criterios de agrupación del catálogo
+const (
+	AgruparPorCategoria = "categoria"
+	AgruparPorZona      = "zona"
+)
+
+// Errores de GetCatalogoAgrupado y GetGrupoCatalogo
+var (
+	ErrAgrupacionInvalida = errors.New("agrupación inválida, valores permitidos: categoria, zona")
+	ErrCursorInvalido     = errors.New("cursor inválido; pida de nuevo la primera página sin cursor")
+)
+
+// GrupoCatalogo es una página de productos de un grupo del catálogo.
+// SiguienteCursor permite pedir la página siguiente del mismo grupo; vacío si no hay más.
+type GrupoCatalogo struct {
+	Clave           string                            `json:"clave"`
+	Total           int                               `json:"total"`
+	Productos       []*producto.ProductoAgroecologico `json:"productos"`
+	SiguienteCursor string                            `json:"siguiente_cursor,omitempty"`
+}
+
+// catalogoOrdenado guarda en caché los productos ya ordenados por disponibilidad y recencia
+type catalogoOrdenado struct {
+	mu         sync.Mutex
+	productos  []*producto.ProductoAgroecologico
+	generadoEn time.Time
+}
+
+// prioridadEstado ordena primero lo que se puede comprar
+var prioridadEstado = map[string]int{
+	producto.Disponible: 0,
+	producto.Excedente:  1,
+	producto.Agotado:    2,
+}
+
+// productosOrdenados retorna todos los productos ordenados por disponibilidad y luego por
+// publicación más reciente. El resultado se reutiliza durante el TTL del catálogo.
+func (s *CatalogoService) productosOrdenados() ([]*producto.ProductoAgroecologico, error) {
+	s.ordenado.mu.Lock()
+	defer s.ordenado.mu.Unlock()
+
+	if s.ordenado.productos != nil && time.Since(s.ordenado.generadoEn) < s.cacheTTL {
+		return s.ordenado.productos, nil
+	}
+
+	productos, err := s.productoRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(productos, func(i, j int) bool {
+		pi, pj := prioridadEstado[productos[i].Estado.Value], prioridadEstado[productos[j].Estado.Value]
+		if pi != pj {
+			return pi < pj
+		}
+		if !productos[i].PublicadoEn().Equal(productos[j].PublicadoEn()) {
+			return productos[i].PublicadoEn().After(productos[j].PublicadoEn())
+		}
+		return productos[i].ID < productos[j].ID
+	})
+
+	s.ordenado.productos = productos
+	s.ordenado.generadoEn = time.Now()
+	return productos, nil
+}
+
+// claveDeGrupo retorna el grupo al que pertenece el producto según el criterio
+func claveDeGrupo(por string, prod *producto.ProductoAgroecologico) string {
+	if por == AgruparPorZona {
+		return prod.Ubicacion.ZonaVeredal
+	}
+	return string(prod.Categoria)
+}
+
+// agrupar reparte los productos que cumplen el filtro en sus grupos, conservando el orden.
+// Retorna también las claves en orden: categorías en orden de declaración, zonas alfabéticas.
+func (s *CatalogoService) agrupar(por string, filtro producto.ProductoFiltro) ([]string, map[string][]*producto.ProductoAgroecologico, error) {
+	if por != AgruparPorCategoria && por != AgruparPorZona {
+		return nil, nil, ErrAgrupacionInvalida
+	}
+
+	productos, err := s.productosOrdenados()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	grupos := make(map[string][]*producto.ProductoAgroecologico)
+	var claves []string
+	if por == AgruparPorCategoria {
+		for _, categoria := range producto.Categorias() {
+			claves = append(claves, string(categoria))
+		}
+	} else {
+		zonas := make(map[string]bool)
+		for _, prod := range productos {
+			if !zonas[prod.Ubicacion.ZonaVeredal] {
+				zonas[prod.Ubicacion.ZonaVeredal] = true
+				claves = append(claves, prod.Ubicacion.ZonaVeredal)
+			}
+		}
+		sort.Strings(claves)
+	}
+
+	for _, prod := range productos {
+		if filtro.Cumple(prod) {
+			clave := claveDeGrupo(por, prod)
+			grupos[clave] = append(grupos[clave], prod)
+		}
+	}
+	return claves, grupos, nil
+}
+
+// paginarGrupo arma la página del grupo que empieza en desde
+func paginarGrupo(clave string, productos []*producto.ProductoAgroecologico, desde, porGrupo int) GrupoCatalogo {
+	grupo := GrupoCatalogo{Clave: clave, Total: len(productos), Productos: []*producto.ProductoAgroecologico{}}
+	if desde < len(productos) {
+		hasta := min(desde+porGrupo, len(productos))
+		grupo.Productos = productos[desde:hasta]
+		if hasta < len(productos) {
+			grupo.SiguienteCursor = codificarCursor(hasta)
+		}
+	}
+	return grupo
+}
+
+// GetCatalogoAgrupado retorna el catálogo agrupado por categoría o zona, con como máximo
+// porGrupo productos por grupo. Los grupos sin productos se omiten salvo que incluirVacios sea true.
+func (s *CatalogoService) GetCatalogoAgrupado(
+	por string,
+	filtro producto.ProductoFiltro,
+	porGrupo int,
+	incluirVacios bool,
+) ([]GrupoCatalogo, error) {
+	claves, grupos, err := s.agrupar(por, filtro)
+	if err != nil {
+		return nil, err
+	}
+
+	resultado := make([]GrupoCatalogo, 0, len(claves))
+	for _, clave := range claves {
+		if len(grupos[clave]) == 0 && !incluirVacios {
+			continue
+		}
+		resultado = append(resultado, paginarGrupo(clave, grupos[clave], 0, porGrupo))
+	}
+	return resultado, nil
+}
+
+// GetGrupoCatalogo retorna la página de un solo grupo a partir del cursor de una respuesta anterior
+func (s *CatalogoService) GetGrupoCatalogo(
+	por string,
+	clave string,
+	filtro producto.ProductoFiltro,
+	porGrupo int,
+	cursor string,
+) (GrupoCatalogo, error) {
+	desde, err := decodificarCursor(cursor)
+	if err != nil {
+		return GrupoCatalogo{}, err
+	}
+
+	_, grupos, err := s.agrupar(por, filtro)
+	if err != nil {
+		return GrupoCatalogo{}, err
+	}
+	return paginarGrupo(clave, grupos[clave], desde, porGrupo), nil
+}
+
+func codificarCursor(desde int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(desde)))
+}
+
+func decodificarCursor(cursor string) (int, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, ErrCursorInvalido
+	}
+	desde, err := strconv.Atoi(string(data))
+	if err != nil || desde < 0 {
+		return 0, ErrCursorInvalido
+	}
+	return desde, nil
+}
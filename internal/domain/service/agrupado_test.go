@@ -0,0 +1,104 @@
+package service_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+	"Product_Catalog_Microservice/internal/domain/service"
+)
+
+// publicarEn publica productoID en la categoría y zona indicadas
+func (e *escenario) publicarEn(t *testing.T, productoID producto.ProductoID, nombre string, categoria producto.Categoria, zona string) {
+	t.Helper()
+	d := nuevosDatosProducto(t, nombre)
+	d.categoria = categoria
+	ubicacion, err := producto.NewUbicacion(zona, "Finca La Esperanza")
+	if err != nil {
+		t.Fatalf("ubicacion: %v", err)
+	}
+	d.ubicacion = ubicacion
+	if _, err := e.publicar(e.semilla1, productoID, d); err != nil {
+		t.Fatalf("PublicarProducto(%s): %v", productoID, err)
+	}
+}
+
+// idsDeGrupo retorna los IDs de los productos del grupo en orden
+func idsDeGrupo(g service.GrupoCatalogo) []producto.ProductoID {
+	ids := make([]producto.ProductoID, 0, len(g.Productos))
+	for _, p := range g.Productos {
+		ids = append(ids, p.ID)
+	}
+	return ids
+}
+
+func TestGetCatalogoAgrupado(t *testing.T) {
+	e := nuevoEscenario(t)
+	e.publicarEn(t, "fresa", "Fresa", producto.CategoriaFruta, "Vereda El Placer")
+	time.Sleep(time.Millisecond) // orden de publicación estable
+	e.publicarEn(t, "mora", "Mora", producto.CategoriaFruta, "Vereda La Cumbre")
+	time.Sleep(time.Millisecond)
+	e.publicarEn(t, "lulo", "Lulo", producto.CategoriaFruta, "Vereda El Placer")
+	e.publicarEn(t, "papa", "Papa", producto.CategoriaTuberculo, "Vereda La Cumbre")
+	if _, err := e.catalogo.AgotarProducto("lulo"); err != nil {
+		t.Fatalf("AgotarProducto: %v", err)
+	}
+
+	t.Run("por categoría", func(t *testing.T) {
+		grupos, err := e.catalogo.GetCatalogoAgrupado(service.AgruparPorCategoria, producto.ProductoFiltro{}, 2, false)
+		if err != nil {
+			t.Fatalf("GetCatalogoAgrupado: %v", err)
+		}
+		if len(grupos) != 2 || grupos[0].Clave != string(producto.CategoriaFruta) || grupos[1].Clave != string(producto.CategoriaTuberculo) {
+			t.Fatalf("grupos = %+v, se esperaban Fruta y Tubérculo", grupos)
+		}
+		// Disponibles primero, la publicación más reciente antes; lulo está agotado
+		fruta := grupos[0]
+		if ids := idsDeGrupo(fruta); fruta.Total != 3 || len(ids) != 2 || ids[0] != "mora" || ids[1] != "fresa" {
+			t.Errorf("Fruta = total %d, %v; se esperaba total 3, [mora fresa]", fruta.Total, ids)
+		}
+		if fruta.SiguienteCursor == "" {
+			t.Fatal("Fruta no trae cursor pese a tener más productos")
+		}
+
+		pagina, err := e.catalogo.GetGrupoCatalogo(service.AgruparPorCategoria, "Fruta", producto.ProductoFiltro{}, 2, fruta.SiguienteCursor)
+		if err != nil {
+			t.Fatalf("GetGrupoCatalogo: %v", err)
+		}
+		if ids := idsDeGrupo(pagina); len(ids) != 1 || ids[0] != "lulo" || pagina.SiguienteCursor != "" {
+			t.Errorf("página siguiente = %v (cursor %q), se esperaba [lulo] sin cursor", ids, pagina.SiguienteCursor)
+		}
+	})
+
+	t.Run("por zona con filtro", func(t *testing.T) {
+		filtro := producto.ProductoFiltro{Categoria: producto.CategoriaFruta}
+		grupos, err := e.catalogo.GetCatalogoAgrupado(service.AgruparPorZona, filtro, 12, false)
+		if err != nil {
+			t.Fatalf("GetCatalogoAgrupado: %v", err)
+		}
+		if len(grupos) != 2 || grupos[0].Clave != "Vereda El Placer" || grupos[0].Total != 2 ||
+			grupos[1].Clave != "Vereda La Cumbre" || grupos[1].Total != 1 {
+			t.Errorf("grupos = %+v, se esperaban El Placer (2) y La Cumbre (1)", grupos)
+		}
+	})
+
+	t.Run("incluir vacíos", func(t *testing.T) {
+		grupos, err := e.catalogo.GetCatalogoAgrupado(service.AgruparPorCategoria, producto.ProductoFiltro{}, 12, true)
+		if err != nil {
+			t.Fatalf("GetCatalogoAgrupado: %v", err)
+		}
+		if len(grupos) != len(producto.Categorias()) {
+			t.Errorf("grupos = %d, se esperaba uno por categoría (%d)", len(grupos), len(producto.Categorias()))
+		}
+	})
+
+	t.Run("errores", func(t *testing.T) {
+		if _, err := e.catalogo.GetCatalogoAgrupado("productor", producto.ProductoFiltro{}, 12, false); !errors.Is(err, service.ErrAgrupacionInvalida) {
+			t.Errorf("por=productor: err = %v, se esperaba ErrAgrupacionInvalida", err)
+		}
+		if _, err := e.catalogo.GetGrupoCatalogo(service.AgruparPorCategoria, "Fruta", producto.ProductoFiltro{}, 2, "no-es-un-cursor"); !errors.Is(err, service.ErrCursorInvalido) {
+			t.Errorf("cursor inválido: err = %v, se esperaba ErrCursorInvalido", err)
+		}
+	})
+}
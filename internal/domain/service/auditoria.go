@@ -0,0 +1,161 @@
+package service
+
+import (
+	"time"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+	"Product_Catalog_Microservice/internal/domain/productor"
+)
+
+// Severidades de una violación de invariantes
+const (
+	SeveridadError       = "error"       // el agregado está corrupto
+	SeveridadAdvertencia = "advertencia" // el agregado es válido pero su estado es inconsistente
+)
+
+// ViolacionInvariante describe un agregado que no cumple las reglas del dominio
+type ViolacionInvariante struct {
+	Agregado  string `json:"agregado"`
+	ID        string `json:"id"`
+	Campo     string `json:"campo"`
+	Mensaje   string `json:"mensaje"`
+	Severidad string `json:"severidad"`
+	Reparado  bool   `json:"reparado"`
+}
+
+// ReporteInvariantes es el resultado de auditar todos los agregados del catálogo
+type ReporteInvariantes struct {
+	ProductosRevisados   int                   `json:"productos_revisados"`
+	ProductoresRevisados int                   `json:"productores_revisados"`
+	Violaciones          []ViolacionInvariante `json:"violaciones"`
+	GeneradoEn           time.Time             `json:"generado_en"`
+}
+
+// AuditarInvariantes recorre todos los productos y productores, vuelve a validar cada campo con
+// los constructores de los value objects, revisa las referencias entre agregados y la consistencia
+// de los estados. Con reparar=true corrige los problemas seguros (p. ej. un estado de
+// disponibilidad inválido se recalcula según la temporada).
+func (s *CatalogoService) AuditarInvariantes(reparar bool) (*ReporteInvariantes, error) {
+	productores, err := s.productorRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	productos, err := s.productoRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	reporte := &ReporteInvariantes{
+		ProductosRevisados:   len(productos),
+		ProductoresRevisados: len(productores),
+		Violaciones:          make([]ViolacionInvariante, 0),
+		GeneradoEn:           time.Now(),
+	}
+
+	existentes := make(map[productor.ProductorID]bool, len(productores))
+	for _, prod := range productores {
+		existentes[prod.ID] = true
+		reporte.Violaciones = append(reporte.Violaciones, auditarProductor(prod)...)
+	}
+
+	for _, prod := range productos {
+		violaciones := auditarProducto(prod, existentes, reporte.GeneradoEn)
+		for i, v := range violaciones {
+			if reparar && v.Campo == "estado" && v.Severidad == SeveridadError {
+				violaciones[i].Reparado = s.repararEstado(prod.ID, reporte.GeneradoEn)
+			}
+		}
+		reporte.Violaciones = append(reporte.Violaciones, violaciones...)
+	}
+
+	return reporte, nil
+}
+
+func auditarProductor(prod *productor.Productor) []ViolacionInvariante {
+	var violaciones []ViolacionInvariante
+	agregar := func(campo string, err error) {
+		if err != nil {
+			violaciones = append(violaciones, ViolacionInvariante{
+				Agregado:  "productor",
+				ID:        string(prod.ID),
+				Campo:     campo,
+				Mensaje:   err.Error(),
+				Severidad: SeveridadError,
+			})
+		}
+	}
+
+	_, err := productor.NewNombreProducto(prod.Nombre.Value)
+	agregar("nombre", err)
+	_, err = productor.NewUbicacion(prod.Ubicacion.ZonaVeredal, prod.Ubicacion.Finca)
+	agregar("ubicacion", err)
+	_, err = productor.NewEstadoVerificacion(prod.EstadoVerificacion.Value)
+	agregar("estado_verificacion", err)
+	_, err = productor.NewEstadoActividad(prod.EstadoActividad.Value)
+	agregar("estado_actividad", err)
+	_, err = productor.NuevaReputacion(float32(prod.Reputacion))
+	agregar("reputacion", err)
+	_, err = productor.NuevaPracticasDeCultivo(prod.PracticasCultivo.Descripcion)
+	agregar("practicas_cultivo", err)
+
+	return violaciones
+}
+
+func auditarProducto(prod *producto.ProductoAgroecologico, productores map[productor.ProductorID]bool, now time.Time) []ViolacionInvariante {
+	var violaciones []ViolacionInvariante
+	agregar := func(campo, mensaje, severidad string) {
+		violaciones = append(violaciones, ViolacionInvariante{
+			Agregado:  "producto",
+			ID:        string(prod.ID),
+			Campo:     campo,
+			Mensaje:   mensaje,
+			Severidad: severidad,
+		})
+	}
+	validar := func(campo string, err error) {
+		if err != nil {
+			agregar(campo, err.Error(), SeveridadError)
+		}
+	}
+
+	_, err := producto.NewNombreProducto(prod.Nombre.Value)
+	validar("nombre", err)
+	_, err = producto.NewDescripcionProducto(prod.Descripcion.Value)
+	validar("descripcion", err)
+	_, err = producto.NewCategoria(string(prod.Categoria))
+	validar("categoria", err)
+	_, err = producto.NewEstadoDisponibilidad(prod.Estado.Value)
+	validar("estado", err)
+	_, err = producto.NewUbicacion(prod.Ubicacion.ZonaVeredal, prod.Ubicacion.Finca)
+	validar("ubicacion", err)
+	_, err = producto.NewImagen(prod.Imagen.URL, prod.Imagen.DescripcionCorta)
+	validar("imagen", err)
+
+	// La temporada puede haber vencido legítimamente, solo se revisa su orden
+	if prod.Temporada.Fin.Before(prod.Temporada.Inicio) {
+		agregar("temporada", "la fecha de fin es anterior al inicio", SeveridadError)
+	}
+
+	if !productores[productor.ProductorID(prod.ProductorID)] {
+		agregar("productor_id", "el productor referenciado no existe", SeveridadError)
+	}
+
+	if prod.Estado.Value == producto.Excedente && !prod.Temporada.IsInSeason(now) {
+		agregar("estado", "un producto fuera de temporada no debería estar marcado como 'Excedente'", SeveridadAdvertencia)
+	}
+
+	return violaciones
+}
+
+// repararEstado relee el producto bajo su bloqueo, recalcula su estado y retorna si se pudo guardar
+func (s *CatalogoService) repararEstado(id producto.ProductoID, now time.Time) bool {
+	defer s.bloqueos.bloquear(claveProducto(id))()
+
+	prod, err := s.cargarProducto(id)
+	if err != nil {
+		return false
+	}
+	prod.RecalcularDisponibilidad(now)
+	return s.productoRepo.UpdateEstadoDisponibilidad(prod.ID, prod.Estado) == nil
+}
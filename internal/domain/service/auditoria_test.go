@@ -0,0 +1,97 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+	"Product_Catalog_Microservice/internal/domain/service"
+)
+
+func TestAuditarInvariantes_CatalogoSano(t *testing.T) {
+	e := nuevoEscenario(t)
+	e.publicarValido(t, e.semilla1, "p-1", "Fresa")
+
+	reporte, err := e.catalogo.AuditarInvariantes(false)
+	if err != nil {
+		t.Fatalf("AuditarInvariantes: %v", err)
+	}
+	if len(reporte.Violaciones) != 0 {
+		t.Errorf("violaciones = %+v, se esperaba ninguna", reporte.Violaciones)
+	}
+	if reporte.ProductosRevisados != 1 || reporte.ProductoresRevisados != 2 {
+		t.Errorf("revisados = %d productos y %d productores, se esperaban 1 y 2",
+			reporte.ProductosRevisados, reporte.ProductoresRevisados)
+	}
+}
+
+func TestAuditarInvariantes_DetectaYReparaEstado(t *testing.T) {
+	e := nuevoEscenario(t)
+	corrupto := e.publicarValido(t, e.semilla1, "p-corrupto", "Fresa")
+	huerfano := e.publicarValido(t, e.semilla1, "p-huerfano", "Mora")
+
+	// Update guarda el agregado tal como llega, sin validarlo, así que sirve para corromperlo
+	corromper := func(id producto.ProductoID, f func(*producto.ProductoAgroecologico)) {
+		t.Helper()
+		guardado, err := e.productoRepo.GetByID(id)
+		if err != nil {
+			t.Fatalf("GetByID: %v", err)
+		}
+		f(guardado)
+		if err := e.productoRepo.Update(guardado); err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+	}
+	corromper(corrupto.ID, func(p *producto.ProductoAgroecologico) { p.Estado = producto.EstadoDisponibilidad{Value: "Vendido"} })
+	corromper(huerfano.ID, func(p *producto.ProductoAgroecologico) { p.ProductorID = "no-existe" })
+
+	reporte, err := e.catalogo.AuditarInvariantes(true)
+	if err != nil {
+		t.Fatalf("AuditarInvariantes: %v", err)
+	}
+
+	esperadas := map[string]bool{"p-corrupto/estado": true, "p-huerfano/productor_id": false}
+	if len(reporte.Violaciones) != len(esperadas) {
+		t.Fatalf("violaciones = %+v, se esperaban %d", reporte.Violaciones, len(esperadas))
+	}
+	for _, v := range reporte.Violaciones {
+		reparado, ok := esperadas[v.ID+"/"+v.Campo]
+		if !ok || v.Severidad != service.SeveridadError || v.Reparado != reparado {
+			t.Errorf("violación inesperada: %+v", v)
+		}
+	}
+
+	reparado, err := e.productoRepo.GetByID(corrupto.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if reparado.Estado.Value != producto.Disponible {
+		t.Errorf("Estado = %q, se esperaba %q recalculado desde la temporada", reparado.Estado.Value, producto.Disponible)
+	}
+}
+
+func TestAuditarInvariantes_ExcedenteFueraDeTemporadaEsAdvertencia(t *testing.T) {
+	e := nuevoEscenario(t)
+	d := nuevosDatosProducto(t, "Fresa")
+	ahora := time.Now()
+	temporada, err := producto.NewTemporadaLocal(ahora.AddDate(0, 2, 0), ahora.AddDate(0, 3, 0))
+	if err != nil {
+		t.Fatalf("temporada: %v", err)
+	}
+	d.temporada = temporada
+	prod, err := e.publicar(e.semilla1, "p-1", d)
+	if err != nil {
+		t.Fatalf("PublicarProducto: %v", err)
+	}
+	if err := e.productoRepo.UpdateEstadoDisponibilidad(prod.ID, producto.EstadoDisponibilidad{Value: producto.Excedente}); err != nil {
+		t.Fatalf("UpdateEstadoDisponibilidad: %v", err)
+	}
+
+	reporte, err := e.catalogo.AuditarInvariantes(true)
+	if err != nil {
+		t.Fatalf("AuditarInvariantes: %v", err)
+	}
+	if len(reporte.Violaciones) != 1 || reporte.Violaciones[0].Severidad != service.SeveridadAdvertencia || reporte.Violaciones[0].Reparado {
+		t.Errorf("violaciones = %+v, se esperaba una advertencia sin reparar", reporte.Violaciones)
+	}
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"hash/fnv"
+	"sort"
+	"sync"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+	"Product_Catalog_Microservice/internal/domain/productor"
+)
+
+// franjasBloqueo es la cantidad de mutex compartidos entre todos los agregados.
+// Dos agregados distintos pueden caer en la misma franja; eso solo serializa más de la cuenta.
+const franjasBloqueo = 64
+
+// bloqueoPorAgregado serializa la secuencia leer-modificar-guardar de cada agregado sin
+// mantener un mutex por ID. Las franjas se toman siempre en orden ascendente, de modo que
+// un método que bloquea un producto y su productor no puede quedar en deadlock con otro
+// que bloquee los mismos agregados en orden inverso.
+type bloqueoPorAgregado struct {
+	franjas [franjasBloqueo]sync.Mutex
+}
+
+func claveProducto(id producto.ProductoID) string {
+	return "producto:" + string(id)
+}
+
+func claveProductor(id productor.ProductorID) string {
+	return "productor:" + string(id)
+}
+
+// bloquear toma las franjas de las claves indicadas y retorna la función que las libera
+func (b *bloqueoPorAgregado) bloquear(claves ...string) (liberar func()) {
+	indices := make([]int, 0, len(claves))
+	vistos := make(map[int]bool, len(claves))
+	for _, clave := range claves {
+		h := fnv.New32a()
+		h.Write([]byte(clave))
+		indice := int(h.Sum32() % franjasBloqueo)
+		if !vistos[indice] {
+			vistos[indice] = true
+			indices = append(indices, indice)
+		}
+	}
+	sort.Ints(indices)
+
+	for _, indice := range indices {
+		b.franjas[indice].Lock()
+	}
+	return func() {
+		for i := len(indices) - 1; i >= 0; i-- {
+			b.franjas[indices[i]].Unlock()
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+)
+
+// Puntajes usados para ordenar los resultados de búsqueda por relevancia
+const (
+	puntajeNombreExacto   = 10
+	puntajeNombreContiene = 5
+	puntajeDescripcion    = 3
+	puntajeEnTemporada    = 2
+)
+
+// ScoredProducto es un producto acompañado de su puntaje de relevancia
+type ScoredProducto struct {
+	Producto *producto.ProductoAgroecologico
+	Score    int
+}
+
+// SearchScore calcula la relevancia de un producto frente a una consulta de texto.
+// Coincidencia exacta del nombre = 10, nombre contiene = 5, descripción contiene = 3
+// y bonificación de 2 si el producto está en temporada en la fecha indicada.
+// Retorna 0 si la consulta no coincide con el texto del producto.
+func SearchScore(p *producto.ProductoAgroecologico, query string, now time.Time) int {
+	query = strings.ToLower(strings.TrimSpace(query))
+	nombre := strings.ToLower(p.Nombre.Value)
+
+	score := 0
+	if query != "" {
+		switch {
+		case nombre == query:
+			score += puntajeNombreExacto
+		case strings.Contains(nombre, query):
+			score += puntajeNombreContiene
+		}
+		if strings.Contains(strings.ToLower(p.Descripcion.Value), query) {
+			score += puntajeDescripcion
+		}
+		if score == 0 {
+			return 0
+		}
+	}
+
+	if p.Temporada.IsInSeason(now) {
+		score += puntajeEnTemporada
+	}
+	return score
+}
+
+// BuscarProductosConFiltroAvanzado retorna los productos que cumplen el filtro y coinciden con la
+// consulta, ordenados por relevancia descendente. Con una consulta vacía se incluyen todos los
+// productos filtrados. Los empates se resuelven por cantidad de compras, si hay un
+// PurchaseHistoryClient configurado, y luego por nombre.
+func (s *CatalogoService) BuscarProductosConFiltroAvanzado(query string, filtro producto.ProductoFiltro) ([]ScoredProducto, error) {
+	productos, err := s.productoRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	resultados := make([]ScoredProducto, 0)
+	for _, prod := range productos {
+		if !filtro.Cumple(prod) {
+			continue
+		}
+
+		score := SearchScore(prod, query, now)
+		if strings.TrimSpace(query) != "" && score == 0 {
+			continue
+		}
+		resultados = append(resultados, ScoredProducto{Producto: prod, Score: score})
+	}
+
+	compras := s.comprasPorProducto(resultados)
+	sort.SliceStable(resultados, func(i, j int) bool {
+		if resultados[i].Score != resultados[j].Score {
+			return resultados[i].Score > resultados[j].Score
+		}
+		ci, cj := compras[resultados[i].Producto.ID], compras[resultados[j].Producto.ID]
+		if ci != cj {
+			return ci > cj
+		}
+		return resultados[i].Producto.Nombre.Value < resultados[j].Producto.Nombre.Value
+	})
+
+	return resultados, nil
+}
+
+// comprasPorProducto consulta el historial de compras de los resultados. Sin cliente configurado,
+// o si la consulta falla, retorna nil y la búsqueda desempata solo por nombre.
+func (s *CatalogoService) comprasPorProducto(resultados []ScoredProducto) map[producto.ProductoID]int {
+	if s.historialCompras == nil || len(resultados) < 2 {
+		return nil
+	}
+
+	ids := make([]producto.ProductoID, len(resultados))
+	for i, r := range resultados {
+		ids[i] = r.Producto.ID
+	}
+	compras, err := s.historialCompras.ComprasPorProducto(ids)
+	if err != nil {
+		s.logger.Warn("no se pudo consultar el historial de compras", "error", err)
+		return nil
+	}
+	return compras
+}
+
+// BuscarProductosConFiltros retorna los productos que cumplen todos los criterios del filtro,
+// ordenados por nombre. Un filtro vacío retorna todos los productos.
+func (s *CatalogoService) BuscarProductosConFiltros(filtro producto.ProductoFiltro) ([]*producto.ProductoAgroecologico, error) {
+	productos, err := s.productoRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	resultados := make([]*producto.ProductoAgroecologico, 0)
+	for _, prod := range productos {
+		if filtro.Cumple(prod) {
+			resultados = append(resultados, prod)
+		}
+	}
+
+	sort.Slice(resultados, func(i, j int) bool {
+		if resultados[i].Nombre.Value != resultados[j].Nombre.Value {
+			return resultados[i].Nombre.Value < resultados[j].Nombre.Value
+		}
+		return resultados[i].ID < resultados[j].ID
+	})
+
+	return resultados, nil
+}
@@ -0,0 +1,89 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+	"Product_Catalog_Microservice/internal/domain/service"
+)
+
+func TestSearchScore(t *testing.T) {
+	e := nuevoEscenario(t)
+	// Descripción: "Cosecha fresca sin agroquímicos"
+	prod := e.publicarValido(t, e.semilla1, "p-1", "Tomate Cherry")
+	enTemporada := time.Now()
+	fueraDeTemporada := enTemporada.AddDate(1, 0, 0)
+
+	casos := []struct {
+		nombre string
+		query  string
+		now    time.Time
+		score  int
+	}{
+		{"nombre exacto sin distinguir mayúsculas", "tomate cherry", fueraDeTemporada, 10},
+		{"nombre contiene", "cherry", fueraDeTemporada, 5},
+		{"descripción contiene", "fresca", fueraDeTemporada, 3},
+		{"en temporada suma 2", "cherry", enTemporada, 7},
+		{"sin coincidencia no suma temporada", "lulo", enTemporada, 0},
+		{"consulta vacía solo puntúa la temporada", "  ", enTemporada, 2},
+	}
+	for _, tc := range casos {
+		t.Run(tc.nombre, func(t *testing.T) {
+			if got := service.SearchScore(prod, tc.query, tc.now); got != tc.score {
+				t.Errorf("SearchScore(%q) = %d, se esperaba %d", tc.query, got, tc.score)
+			}
+		})
+	}
+}
+
+func TestBuscarProductosConFiltroAvanzado(t *testing.T) {
+	e := nuevoEscenario(t)
+	e.publicarValido(t, e.semilla1, "p-1", "Tomate")
+	e.publicarValido(t, e.semilla1, "p-2", "Tomate Cherry")
+	e.publicarValido(t, e.semilla1, "p-3", "Lulo")
+
+	d := nuevosDatosProducto(t, "Tomate de Árbol")
+	var err error
+	if d.categoria, err = producto.NewCategoria(string(producto.CategoriaHortaliza)); err != nil {
+		t.Fatalf("categoria: %v", err)
+	}
+	if _, err := e.publicar(e.semilla1, "p-4", d); err != nil {
+		t.Fatalf("PublicarProducto: %v", err)
+	}
+
+	resultados, err := e.catalogo.BuscarProductosConFiltroAvanzado("tomate", producto.ProductoFiltro{Categoria: "Fruta"})
+	if err != nil {
+		t.Fatalf("BuscarProductosConFiltroAvanzado: %v", err)
+	}
+
+	// El nombre exacto va primero; Lulo no coincide y Tomate de Árbol es Hortaliza
+	esperado := []struct {
+		id    producto.ProductoID
+		score int
+	}{{"p-1", 12}, {"p-2", 7}}
+	if len(resultados) != len(esperado) {
+		t.Fatalf("se obtuvieron %d resultados, se esperaban %d: %+v", len(resultados), len(esperado), resultados)
+	}
+	for i, want := range esperado {
+		if resultados[i].Producto.ID != want.id || resultados[i].Score != want.score {
+			t.Errorf("resultados[%d] = (%s, %d), se esperaba (%s, %d)",
+				i, resultados[i].Producto.ID, resultados[i].Score, want.id, want.score)
+		}
+	}
+}
+
+func TestBuscarProductosConFiltroAvanzado_ConsultaVaciaIncluyeTodoElFiltro(t *testing.T) {
+	e := nuevoEscenario(t)
+	e.publicarValido(t, e.semilla1, "p-1", "Mora")
+	e.publicarValido(t, e.semilla1, "p-2", "Fresa")
+
+	resultados, err := e.catalogo.BuscarProductosConFiltroAvanzado("", producto.ProductoFiltro{})
+	if err != nil {
+		t.Fatalf("BuscarProductosConFiltroAvanzado: %v", err)
+	}
+	// Con el mismo puntaje se ordena por nombre
+	if len(resultados) != 2 || resultados[0].Producto.ID != "p-2" || resultados[1].Producto.ID != "p-1" {
+		t.Errorf("resultados = %+v, se esperaba [p-2 p-1]", resultados)
+	}
+}
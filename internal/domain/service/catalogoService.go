@@ -1,38 +1,124 @@
 package service
 
 import (
+    "context"
     "errors"
+    "fmt"
+    "log/slog"
+    "slices"
+    "sort"
+    "strings"
+    "sync"
     "time"
+    "unicode/utf8"
 
     "Product_Catalog_Microservice/internal/domain/producto"
     "Product_Catalog_Microservice/internal/domain/productor"
+    "Product_Catalog_Microservice/internal/domain/shared"
 )
 
+// ErrNombreDuplicado indica que el productor ya publicó un producto con el mismo nombre.
+// La unicidad del nombre es por productor: dos productores distintos sí pueden
+// vender un producto llamado igual (p. ej. "Tomate Cherry").
+var ErrNombreDuplicado = errors.New("el productor ya tiene un producto publicado con ese nombre")
+
+// ErrLimiteProductosAlcanzado indica que el productor ya tiene la cantidad máxima de productos
+// configurada con WithMaxProductosPorProductor
+var ErrLimiteProductosAlcanzado = errors.New("el productor alcanzó el máximo de productos publicados")
+
+// ErrProductoNoEncontrado indica que no existe un producto con el ID solicitado.
+var ErrProductoNoEncontrado = errors.New("producto no encontrado")
+
+// ErrProductorNoAutorizado indica que el productor existe pero hoy no puede publicar
+// (no está verificado, no está activo o no alcanza la reputación mínima).
+var ErrProductorNoAutorizado = errors.New("el productor no está autorizado para publicar productos")
+
+// ErrProductorNoEncontrado indica que no existe un productor con el ID solicitado.
+var ErrProductorNoEncontrado = errors.New("productor no encontrado")
+
 // EventPublisher define la interfaz para publicar eventos de dominio
 type EventPublisher interface {
     Publish(event any) error
 }
 
+// EventStore guarda los eventos de dominio antes de publicarlos, para conservar la historia
+// del catálogo aunque el publicador falle
+type EventStore interface {
+    Append(event any) error
+}
+
+// PurchaseHistoryClient consulta cuántas veces se ha comprado cada producto. La búsqueda lo usa
+// para desempatar productos con la misma relevancia.
+type PurchaseHistoryClient interface {
+    ComprasPorProducto(ids []producto.ProductoID) (map[producto.ProductoID]int, error)
+}
+
+// cacheTTLPorDefecto es el tiempo que se reutilizan las vistas agregadas antes de recalcularlas
+const cacheTTLPorDefecto = 10 * time.Minute
+
 type CatalogoService struct {
     productorRepo  productor.ProductorRepositoryInterface
     productoRepo   producto.ProductoRepositoryInterface
     eventPublisher EventPublisher
+    logger         *slog.Logger
+    metricas       Metricas
+    cacheTTL       time.Duration
+
+    eventStore               EventStore
+    maxProductosPorProductor int
+    moderacion               ContentModerationConfig
+    historialCompras         PurchaseHistoryClient
+
+    transicionesEstrictas map[string]bool
+    alCargarConEventos    func(agregado any, pendientes int)
+    bloqueos              bloqueoPorAgregado
+    catalogoEstricto      bool
+    alDegradar            func(motivo string)
+    rechazoRepo           productor.RechazoRepositoryInterface
+    vistas                vistasCatalogo
+
+    idempotenciaRepo      IdempotenciaRepositoryInterface
+    bloqueosIdempotencia  bloqueoPorAgregado
+
+    ordenado               catalogoOrdenado
+
+    resumenZonasMu         sync.Mutex
+    resumenZonas           []ResumenZona
+    resumenZonasGeneradoEn time.Time
 }
 
 func NewCatalogoService(
     productorRepo productor.ProductorRepositoryInterface,
     productoRepo producto.ProductoRepositoryInterface,
     eventPublisher EventPublisher,
+    opts ...CatalogoServiceOption,
 ) *CatalogoService {
-    return &CatalogoService{
+    s := &CatalogoService{
         productorRepo:  productorRepo,
         productoRepo:   productoRepo,
         eventPublisher: eventPublisher,
+        logger:         slog.Default(),
+        metricas:       sinMetricas{},
+        cacheTTL:       cacheTTLPorDefecto,
+        vistas: vistasCatalogo{
+            definicion: make(map[string]VistaCatalogo),
+            cache:      make(map[string]vistaEnCache),
+        },
     }
+    
+    for _, opt := range opts {
+        opt(s)
+    }
+    // Las vistas se validan al final para reportar los errores en el logger configurado
+    s.registrarVistas()
+    
+    return s
 }
 
-// PublicarProducto valida que el productor pueda publicar y crea el producto
+// PublicarProducto valida que el productor pueda publicar y crea el producto.
+// Si ctx lleva un Cronometro, registra la duración de cada etapa.
 func (s *CatalogoService) PublicarProducto(
+    ctx context.Context,
     productorID productor.ProductorID,
     productoID producto.ProductoID,
     nombre producto.NombreProducto,
@@ -42,20 +128,27 @@ func (s *CatalogoService) PublicarProducto(
     temporada producto.TemporadaLocal,
     ubicacion producto.Ubicacion,
     imagen producto.Imagen,
+    precio producto.Precio,
+    cantidad producto.CantidadDisponible,
     minReputacion productor.Reputacion,
 ) (*producto.ProductoAgroecologico, error) {
+    // Se bloquea también al productor para que dos publicaciones simultáneas no superen
+    // juntas la verificación de nombre duplicado
+    defer s.bloqueos.bloquear(claveProductor(productorID), claveProducto(productoID))()
     
-    // Verificar que el productor existe y puede publicar
-    prod, err := s.productorRepo.GetByID(productorID)
-    if err != nil {
-        return nil, errors.New("productor no encontrado")
-    }
+    crono := CronometroDe(ctx)
     
-    if !prod.PuedePublicar(minReputacion) {
-        return nil, errors.New("el productor no está autorizado para publicar productos")
+    // Verificar que el productor existe, puede publicar, el contenido es aceptable,
+    // no repite el nombre y no superó su límite de productos
+    terminar := crono.Etapa("autorizacion")
+    err := s.autorizarPublicacion(productorID, nombre, desc, minReputacion)
+    terminar()
+    if err != nil {
+        return nil, err
     }
     
     // Crear el producto (esto genera el evento ProductoPublicado)
+    terminar = crono.Etapa("agregado")
     nuevoProducto, err := producto.NewProductoAgroecologico(
         productoID,
         nombre,
@@ -65,28 +158,100 @@ func (s *CatalogoService) PublicarProducto(
         temporada,
         ubicacion,
         imagen,
+        precio,
+        cantidad,
         string(productorID),
     )
+    terminar()
     if err != nil {
         return nil, err
     }
     
     // Guardar el producto
-    if err := s.productoRepo.Save(nuevoProducto); err != nil {
+    terminar = crono.Etapa("guardado")
+    err = s.productoRepo.Save(nuevoProducto)
+    terminar()
+    if err != nil {
         return nil, err
     }
     
     // Publicar eventos generados por el agregado
+    terminar = crono.Etapa("eventos")
     s.publishPendingEvents(nuevoProducto)
+    terminar()
     
     return nuevoProducto, nil
 }
 
+// autorizarPublicacion verifica que el productor exista, pueda publicar, que el contenido
+// pase la moderación, que no tenga ya un producto con el mismo nombre y que no haya alcanzado
+// su límite de productos. Los rechazos por reglas de negocio quedan registrados.
+func (s *CatalogoService) autorizarPublicacion(
+    productorID productor.ProductorID,
+    nombre producto.NombreProducto,
+    desc producto.DescripcionProducto,
+    minReputacion productor.Reputacion,
+) error {
+    prod, err := s.productorRepo.GetByID(productorID)
+    if err != nil {
+        return errorProductor(err)
+    }
+    
+    if !prod.PuedePublicar(minReputacion) {
+        s.registrarRechazo(productorID, nombre, productor.RechazoNoAutorizado, ErrProductorNoAutorizado)
+        return ErrProductorNoAutorizado
+    }
+    
+    if err := s.moderacion.Revisar(nombre.Value, desc.Value); err != nil {
+        s.registrarRechazo(productorID, nombre, productor.RechazoContenidoNoPermitido, err)
+        return err
+    }
+    
+    // Evitar que el mismo productor publique dos productos con el mismo nombre
+    existe, err := s.productoRepo.ExisteNombreParaProductor(nombre, string(productorID))
+    if err != nil {
+        return err
+    }
+    if existe {
+        s.registrarRechazo(productorID, nombre, productor.RechazoNombreDuplicado, ErrNombreDuplicado)
+        return ErrNombreDuplicado
+    }
+    
+    if s.maxProductosPorProductor > 0 {
+        publicados, err := s.productoRepo.GetByProductorID(string(productorID))
+        if err != nil {
+            return err
+        }
+        if len(publicados) >= s.maxProductosPorProductor {
+            s.registrarRechazo(productorID, nombre, productor.RechazoLimiteProductos, ErrLimiteProductosAlcanzado)
+            return ErrLimiteProductosAlcanzado
+        }
+    }
+    
+    return nil
+}
+
+// RegistrarProductor guarda un productor nuevo. El productor debe llegar con su ID ya asignado.
+func (s *CatalogoService) RegistrarProductor(prod *productor.Productor) error {
+    defer s.bloqueos.bloquear(claveProductor(prod.ID))()
+    
+    if err := s.productorRepo.Save(prod); err != nil {
+        return err
+    }
+    
+    // Publicar eventos generados por el agregado
+    s.publishPendingEvents(prod)
+    
+    return nil
+}
+
 // IniciarVerificacionProductor inicia el proceso de verificación de un productor
 func (s *CatalogoService) IniciarVerificacionProductor(productorID productor.ProductorID) error {
-    prod, err := s.productorRepo.GetByID(productorID)
+    defer s.bloqueos.bloquear(claveProductor(productorID))()
+    
+    prod, err := s.cargarProductor(productorID)
     if err != nil {
-        return errors.New("productor no encontrado")
+        return err
     }
     
     // Esto genera el evento ProductorEnVerificacion
@@ -96,6 +261,11 @@ func (s *CatalogoService) IniciarVerificacionProductor(productorID productor.Pro
     
     // Actualizar el estado en el repositorio
     if err := s.productorRepo.UpdateEstadoVerificacion(productorID, prod.EstadoVerificacion); err != nil {
+        s.descartarEventos(prod)
+        return err
+    }
+    if err := s.productorRepo.UpdateUltimaActividad(productorID, prod.UltimaActividad); err != nil {
+        s.descartarEventos(prod)
         return err
     }
     
@@ -106,26 +276,34 @@ func (s *CatalogoService) IniciarVerificacionProductor(productorID productor.Pro
 }
 
 // CompletarVerificacionProductor completa la verificación de un productor
-func (s *CatalogoService) CompletarVerificacionProductor(productorID productor.ProductorID) error {
-    prod, err := s.productorRepo.GetByID(productorID)
+// Si el productor ya está verificado retorna sinCambios=true, salvo que la transición sea estricta.
+func (s *CatalogoService) CompletarVerificacionProductor(productorID productor.ProductorID) (sinCambios bool, err error) {
+    defer s.bloqueos.bloquear(claveProductor(productorID))()
+    
+    prod, err := s.cargarProductor(productorID)
     if err != nil {
-        return errors.New("productor no encontrado")
+        return false, err
     }
     
     // Esto genera el evento ProductorVerificado
     if err := prod.VerificarProductor(); err != nil {
-        return err
+        return s.resolverSinCambios(TransicionVerificar, err)
     }
     
     // Actualizar el estado en el repositorio
     if err := s.productorRepo.UpdateEstadoVerificacion(productorID, prod.EstadoVerificacion); err != nil {
-        return err
+        s.descartarEventos(prod)
+        return false, err
+    }
+    if err := s.productorRepo.UpdateUltimaActividad(productorID, prod.UltimaActividad); err != nil {
+        s.descartarEventos(prod)
+        return false, err
     }
     
     // Publicar eventos generados por el agregado
     s.publishPendingEvents(prod)
     
-    return nil
+    return false, nil
 }
 
 // ActualizarReputacionProductor actualiza la reputación de un productor
@@ -133,9 +311,11 @@ func (s *CatalogoService) ActualizarReputacionProductor(
     productorID productor.ProductorID, 
     nuevaReputacion productor.Reputacion,
 ) error {
-    prod, err := s.productorRepo.GetByID(productorID)
+    defer s.bloqueos.bloquear(claveProductor(productorID))()
+    
+    prod, err := s.cargarProductor(productorID)
     if err != nil {
-        return errors.New("productor no encontrado")
+        return err
     }
     
     // Esto genera el evento ReputacionActualizada si la reputación cambia
@@ -145,6 +325,11 @@ func (s *CatalogoService) ActualizarReputacionProductor(
     
     // Actualizar la reputación en el repositorio
     if err := s.productorRepo.UpdateReputacion(productorID, nuevaReputacion); err != nil {
+        s.descartarEventos(prod)
+        return err
+    }
+    if err := s.productorRepo.UpdateUltimaActividad(productorID, prod.UltimaActividad); err != nil {
+        s.descartarEventos(prod)
         return err
     }
     
@@ -154,99 +339,233 @@ func (s *CatalogoService) ActualizarReputacionProductor(
     return nil
 }
 
-// MarcarProductoComoExcedente marca un producto como excedente
+// MarcarProductoComoExcedente marca un producto como excedente.
+// Si el producto ya es excedente retorna sinCambios=true, salvo que la transición sea estricta.
 func (s *CatalogoService) MarcarProductoComoExcedente(
     productoID producto.ProductoID, 
     now time.Time,
-) error {
-    prod, err := s.productoRepo.GetByID(productoID)
+) (sinCambios bool, err error) {
+    defer s.bloqueos.bloquear(claveProducto(productoID))()
+    
+    prod, err := s.cargarProducto(productoID)
     if err != nil {
-        return errors.New("producto no encontrado")
+        return false, err
     }
     
     // Esto genera el evento ProductoMarcadoComoExcedente
     if err := prod.MarcarComoExcedente(now); err != nil {
-        return err
+        return s.resolverSinCambios(TransicionExcedente, err)
     }
     
     // Actualizar el estado en el repositorio
     if err := s.productoRepo.UpdateEstadoDisponibilidad(productoID, prod.Estado); err != nil {
-        return err
+        s.descartarEventos(prod)
+        return false, err
     }
     
     // Publicar eventos generados por el agregado
     s.publishPendingEvents(prod)
     
-    return nil
+    return false, nil
 }
 
-// AgotarProducto marca un producto como agotado
-func (s *CatalogoService) AgotarProducto(productoID producto.ProductoID) error {
-    prod, err := s.productoRepo.GetByID(productoID)
+// AgotarProducto marca un producto como agotado.
+// Si el producto ya está agotado retorna sinCambios=true, salvo que la transición sea estricta.
+func (s *CatalogoService) AgotarProducto(productoID producto.ProductoID) (sinCambios bool, err error) {
+    defer s.bloqueos.bloquear(claveProducto(productoID))()
+    
+    prod, err := s.cargarProducto(productoID)
     if err != nil {
-        return errors.New("producto no encontrado")
+        return false, err
     }
     
     // Esto genera el evento ProductoAgotado
     if err := prod.Agotar(); err != nil {
-        return err
+        return s.resolverSinCambios(TransicionAgotar, err)
     }
     
     // Actualizar el estado en el repositorio
     if err := s.productoRepo.UpdateEstadoDisponibilidad(productoID, prod.Estado); err != nil {
-        return err
+        s.descartarEventos(prod)
+        return false, err
     }
     
     // Publicar eventos generados por el agregado
     s.publishPendingEvents(prod)
     
-    return nil
+    return false, nil
 }
 
-// ActualizarInformacionProducto actualiza la información básica de un producto
+// ActualizarInformacionProducto actualiza la información básica de un producto y retorna
+// el producto actualizado
 func (s *CatalogoService) ActualizarInformacionProducto(
     productoID producto.ProductoID,
     nombre producto.NombreProducto,
     desc producto.DescripcionProducto,
     imagen producto.Imagen,
-) error {
-    prod, err := s.productoRepo.GetByID(productoID)
+) (*producto.ProductoAgroecologico, error) {
+    if err := s.moderacion.Revisar(nombre.Value, desc.Value); err != nil {
+        return nil, err
+    }
+    defer s.bloqueos.bloquear(claveProducto(productoID))()
+    
+    prod, err := s.cargarProducto(productoID)
     if err != nil {
-        return errors.New("producto no encontrado")
+        return nil, err
     }
     
     if err := prod.ActualizarInformacion(nombre, desc, imagen); err != nil {
-        return err
+        return nil, err
     }
     
      if err := s.productoRepo.Update(prod); err != nil {
-        return err
+        s.descartarEventos(prod)
+        return nil, err
      }
     
+    // No genera eventos propios, pero vacía cualquier evento acumulado en la instancia
+    s.publishPendingEvents(prod)
+
+    return prod, nil
+}
+
+// ActualizarPrecio cambia el precio de un producto. Actualizar al mismo precio no es un error.
+func (s *CatalogoService) ActualizarPrecio(productoID producto.ProductoID, nuevoPrecio producto.Precio) error {
+    defer s.bloqueos.bloquear(claveProducto(productoID))()
+
+    prod, err := s.cargarProducto(productoID)
+    if err != nil {
+        return err
+    }
+
+    if err := prod.ActualizarPrecio(nuevoPrecio); err != nil {
+        if errors.Is(err, producto.ErrSinCambios) {
+            return nil
+        }
+        return err
+    }
+
+    if err := s.productoRepo.Update(prod); err != nil {
+        s.descartarEventos(prod)
+        return err
+    }
+
+    // No genera eventos propios, pero vacía cualquier evento acumulado en la instancia
+    s.publishPendingEvents(prod)
+
+    return nil
+}
+
+// DecrementarStock descuenta cantidad de la cantidad disponible del producto y publica
+// ProductoStockBajo si cruza el umbral. Retorna producto.ErrStockInsuficiente si no alcanza.
+func (s *CatalogoService) DecrementarStock(productoID producto.ProductoID, cantidad int) error {
+    defer s.bloqueos.bloquear(claveProducto(productoID))()
+
+    prod, err := s.cargarProducto(productoID)
+    if err != nil {
+        return err
+    }
+
+    if err := prod.ReducirCantidad(cantidad); err != nil {
+        return err
+    }
+
+    if err := s.productoRepo.Update(prod); err != nil {
+        s.descartarEventos(prod)
+        return err
+    }
+
+    // Publicar eventos generados por el agregado
+    s.publishPendingEvents(prod)
 
     return nil
 }
 
+// GetProducto obtiene un producto por su ID. Retorna ErrProductoNoEncontrado si no existe.
+func (s *CatalogoService) GetProducto(productoID producto.ProductoID) (*producto.ProductoAgroecologico, error) {
+    prod, err := s.productoRepo.GetByID(productoID)
+    if err != nil {
+        return nil, errorProducto(err)
+    }
+    return prod, nil
+}
+
+// GetProductor obtiene un productor por su ID. Retorna ErrProductorNoEncontrado si no existe.
+func (s *CatalogoService) GetProductor(productorID productor.ProductorID) (*productor.Productor, error) {
+    prod, err := s.productorRepo.GetByID(productorID)
+    if err != nil {
+        return nil, errorProductor(err)
+    }
+    return prod, nil
+}
+
 // GetProductosByProductor obtiene todos los productos de un productor
 func (s *CatalogoService) GetProductosByProductor(productorID productor.ProductorID) ([]*producto.ProductoAgroecologico, error) {
     // Verificar que el productor existe
     _, err := s.productorRepo.GetByID(productorID)
     if err != nil {
-        return nil, errors.New("productor no encontrado")
+        return nil, errorProductor(err)
     }
     
     return s.productoRepo.GetByProductorID(string(productorID))
 }
 
-// GetProductosDisponiblesEnZona obtiene productos disponibles de productores verificados en una zona
+// GetProductosDeProductorPorCategoria obtiene los productos de un productor filtrados por categoría
+func (s *CatalogoService) GetProductosDeProductorPorCategoria(
+    productorID productor.ProductorID,
+    categoria producto.Categoria,
+) ([]*producto.ProductoAgroecologico, error) {
+    // Verificar que el productor existe
+    _, err := s.productorRepo.GetByID(productorID)
+    if err != nil {
+        return nil, errorProductor(err)
+    }
+    
+    return s.productoRepo.GetByProductorIDAndCategoria(string(productorID), categoria)
+}
+
+// GetProductosByCategoria obtiene todos los productos de una categoría, en cualquier estado
+func (s *CatalogoService) GetProductosByCategoria(cat producto.Categoria) ([]*producto.ProductoAgroecologico, error) {
+    return s.productoRepo.GetByCategoria(cat)
+}
+
+// GetProductosByTipoProduccion obtiene todos los productos de un tipo de producción, en cualquier estado
+func (s *CatalogoService) GetProductosByTipoProduccion(tipo producto.TipoProduccion) ([]*producto.ProductoAgroecologico, error) {
+    return s.productoRepo.GetByTipoProduccion(tipo)
+}
+
+// GetProductosEnTemporada obtiene los productos disponibles cuya temporada incluye la fecha indicada
+func (s *CatalogoService) GetProductosEnTemporada(now time.Time) ([]*producto.ProductoAgroecologico, error) {
+    enTemporada, err := s.productoRepo.GetProductsInSeason(now)
+    if err != nil {
+        return nil, err
+    }
+
+    productos := make([]*producto.ProductoAgroecologico, 0, len(enTemporada))
+    for _, prod := range enTemporada {
+        if prod.Estado.Value == producto.Disponible {
+            productos = append(productos, prod)
+        }
+    }
+    return productos, nil
+}
+
+// GetProductosDisponiblesEnZona obtiene productos disponibles de productores verificados en una zona.
+// Si la ubicación no trae finca se consideran todas las fincas de la zona veredal.
 func (s *CatalogoService) GetProductosDisponiblesEnZona(ubicacion productor.Ubicacion) ([]*producto.ProductoAgroecologico, error) {
     // Obtener productores verificados en la zona
-    productoresZona, err := s.productorRepo.GetByUbicacion(ubicacion)
+    var productoresZona []*productor.Productor
+    var err error
+    if ubicacion.Finca == "" {
+        productoresZona, err = s.productorRepo.GetByZonaVeredal(ubicacion.ZonaVeredal)
+    } else {
+        productoresZona, err = s.productorRepo.GetByUbicacion(ubicacion)
+    }
     if err != nil {
         return nil, err
     }
     
-    var todosProductos []*producto.ProductoAgroecologico
+    todosProductos := make([]*producto.ProductoAgroecologico, 0)
     
     for _, prod := range productoresZona {
         if prod.EstadoVerificacion.IsVerificado() && prod.EstadoActividad.IsActivo() {
@@ -275,25 +594,45 @@ func (s *CatalogoService) ActualizarDisponibilidadPorTemporada(now time.Time) er
     }
     
     for _, prod := range productos {
-        estadoAnterior := prod.Estado.Value
-        prod.RecalcularDisponibilidad(now)
-        
-        // Solo actualizar si el estado cambió
-        if prod.Estado.Value != estadoAnterior {
-            if err := s.productoRepo.UpdateEstadoDisponibilidad(prod.ID, prod.Estado); err != nil {
-                // Log el error pero continúa con los demás productos
-                continue
-            }
-            
-            // Publicar eventos si los hay (RecalcularDisponibilidad podría generar eventos)
-            s.publishPendingEvents(prod)
-        }
+        s.recalcularDisponibilidad(prod.ID, now)
     }
     
     return nil
 }
 
-// GetCatalogoCompleto obtiene el catálogo completo con información de productores
+// recalcularDisponibilidad recalcula y persiste el estado de un producto bajo su bloqueo
+// Relee el producto dentro del bloqueo para no trabajar sobre una copia desactualizada.
+func (s *CatalogoService) recalcularDisponibilidad(id producto.ProductoID, now time.Time) {
+    defer s.bloqueos.bloquear(claveProducto(id))()
+    
+    prod, err := s.cargarProducto(id)
+    if err != nil {
+        return
+    }
+    // Los productos de un productor suspendido siguen agotados hasta que se reactive
+    if s.productorSuspendido(prod.ProductorID) {
+        return
+    }
+    
+    estadoAnterior := prod.Estado.Value
+    prod.RecalcularDisponibilidad(now)
+    
+    // Solo actualizar si el estado cambió
+    if prod.Estado.Value != estadoAnterior {
+        if err := s.productoRepo.UpdateEstadoDisponibilidad(prod.ID, prod.Estado); err != nil {
+            // Log el error pero continúa con los demás productos
+            s.descartarEventos(prod)
+            return
+        }
+        
+        // Publicar eventos si los hay (RecalcularDisponibilidad podría generar eventos)
+        s.publishPendingEvents(prod)
+    }
+}
+
+// GetCatalogoCompleto obtiene el catálogo completo con información de productores.
+// Si no se pueden obtener los productores retorna los productos con Degradado=true,
+// salvo que el servicio se haya configurado con WithCatalogoEstricto.
 func (s *CatalogoService) GetCatalogoCompleto() (*CatalogoCompleto, error) {
     productos, err := s.productoRepo.GetAvailableProducts()
     if err != nil {
@@ -302,7 +641,21 @@ func (s *CatalogoService) GetCatalogoCompleto() (*CatalogoCompleto, error) {
     
     productores, err := s.productorRepo.GetVerificados()
     if err != nil {
-        return nil, err
+        if s.catalogoEstricto {
+            return nil, err
+        }
+        // Los productos siguen siendo útiles sin los productores: se responde degradado
+        s.logger.Warn("catálogo completo degradado", "motivo", MotivoProductoresNoDisponibles, "error", err)
+        if s.alDegradar != nil {
+            s.alDegradar(MotivoProductoresNoDisponibles)
+        }
+        return &CatalogoCompleto{
+            Productos:         productos,
+            Productores:       nil,
+            GeneradoEn:        time.Now(),
+            Degradado:         true,
+            MotivoDegradacion: MotivoProductoresNoDisponibles,
+        }, nil
     }
     
     return &CatalogoCompleto{
@@ -312,6 +665,11 @@ func (s *CatalogoService) GetCatalogoCompleto() (*CatalogoCompleto, error) {
     }, nil
 }
 
+// GetProductores obtiene todos los productores registrados
+func (s *CatalogoService) GetProductores() ([]*productor.Productor, error) {
+    return s.productorRepo.GetAll()
+}
+
 // GetProductoresAptosParaPublicar obtiene productores que pueden publicar productos
 func (s *CatalogoService) GetProductoresAptosParaPublicar(minReputacion productor.Reputacion) ([]*productor.Productor, error) {
     productores, err := s.productorRepo.GetByReputacionMinima(minReputacion)
@@ -329,31 +687,288 @@ func (s *CatalogoService) GetProductoresAptosParaPublicar(minReputacion producto
     return productoresAptos, nil
 }
 
+// GetProductoresPorPractica obtiene los productores cuyas prácticas de cultivo mencionan la palabra clave
+func (s *CatalogoService) GetProductoresPorPractica(keyword string) ([]*productor.Productor, error) {
+    keyword = strings.TrimSpace(keyword)
+    if utf8.RuneCountInString(keyword) < 3 {
+        return nil, shared.NewErrorValidacion("q", "la palabra clave debe tener al menos 3 caracteres")
+    }
+    
+    return s.productorRepo.GetByPracticaKeyword(keyword)
+}
+
+// GetResumenCatalogoPorZona agrupa productores y productos por zona veredal y calcula estadísticas.
+// El resultado se guarda en caché durante el TTL configurado; cada llamada recibe su propia copia.
+func (s *CatalogoService) GetResumenCatalogoPorZona() ([]ResumenZona, error) {
+    s.resumenZonasMu.Lock()
+    defer s.resumenZonasMu.Unlock()
+    
+    if s.resumenZonas != nil && time.Since(s.resumenZonasGeneradoEn) < s.cacheTTL {
+        return slices.Clone(s.resumenZonas), nil
+    }
+    
+    productores, err := s.productorRepo.GetAll()
+    if err != nil {
+        return nil, err
+    }
+    
+    productos, err := s.productoRepo.GetAll()
+    if err != nil {
+        return nil, err
+    }
+    
+    zonas := make(map[string]*ResumenZona)
+    sumaReputacion := make(map[string]float32)
+    resumenDe := func(zona string) *ResumenZona {
+        if _, ok := zonas[zona]; !ok {
+            zonas[zona] = &ResumenZona{ZonaVeredal: zona}
+        }
+        return zonas[zona]
+    }
+    
+    for _, prod := range productores {
+        resumen := resumenDe(prod.Ubicacion.ZonaVeredal)
+        resumen.TotalProductores++
+        if prod.EstadoVerificacion.IsVerificado() {
+            resumen.ProductoresVerificados++
+            sumaReputacion[resumen.ZonaVeredal] += float32(prod.Reputacion)
+        }
+    }
+    
+    for _, prod := range productos {
+        resumen := resumenDe(prod.Ubicacion.ZonaVeredal)
+        resumen.TotalProductos++
+        if prod.Estado.Value == producto.Disponible {
+            resumen.ProductosDisponibles++
+        }
+    }
+    
+    resultado := make([]ResumenZona, 0, len(zonas))
+    for zona, resumen := range zonas {
+        if resumen.ProductoresVerificados > 0 {
+            resumen.ReputacionPromedioZona = sumaReputacion[zona] / float32(resumen.ProductoresVerificados)
+        }
+        resultado = append(resultado, *resumen)
+    }
+    sort.Slice(resultado, func(i, j int) bool {
+        return resultado[i].ZonaVeredal < resultado[j].ZonaVeredal
+    })
+    
+    s.resumenZonas = resultado
+    s.resumenZonasGeneradoEn = time.Now()
+    
+    return slices.Clone(resultado), nil
+}
+
+// GetProductoresRegistradosMes obtiene los productores registrados en el mes indicado,
+// tomando los límites del mes en la zona horaria del despliegue
+func (s *CatalogoService) GetProductoresRegistradosMes(year, month int) ([]*productor.Productor, error) {
+    if month < 1 || month > 12 {
+        return nil, shared.NewErrorValidacion("mes", "mes inválido")
+    }
+    
+    desde := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, producto.ZonaHoraria())
+    hasta := desde.AddDate(0, 1, 0)
+    
+    return s.productorRepo.GetRegistradosEnRango(desde, hasta)
+}
+
+// ActualizarPreferenciasNotificacion actualiza las preferencias de notificación de un productor
+func (s *CatalogoService) ActualizarPreferenciasNotificacion(
+    productorID productor.ProductorID,
+    preferencias productor.PreferenciasNotificacion,
+) error {
+    defer s.bloqueos.bloquear(claveProductor(productorID))()
+    
+    prod, err := s.cargarProductor(productorID)
+    if err != nil {
+        return err
+    }
+    
+    // Esto genera el evento PreferenciasNotificacionActualizadas
+    if err := prod.ActualizarPreferenciasNotificacion(preferencias); err != nil {
+        return err
+    }
+    
+    if err := s.productorRepo.UpdatePreferenciasNotificacion(productorID, prod.PreferenciasNotificacion); err != nil {
+        s.descartarEventos(prod)
+        return err
+    }
+    if err := s.productorRepo.UpdateUltimaActividad(productorID, prod.UltimaActividad); err != nil {
+        s.descartarEventos(prod)
+        return err
+    }
+    
+    // Publicar eventos generados por el agregado
+    s.publishPendingEvents(prod)
+    
+    return nil
+}
+
+// GetProductoresInactivos obtiene los productores sin actividad desde hace más de los días indicados
+func (s *CatalogoService) GetProductoresInactivos(dias int) ([]*productor.Productor, error) {
+    if dias < 1 {
+        return nil, shared.NewErrorValidacion("dias", "la cantidad de días debe ser mayor que cero")
+    }
+    
+    return s.productorRepo.GetInactivosPorMasDe(time.Duration(dias) * 24 * time.Hour)
+}
+
+// resolverSinCambios decide cómo tratar una transición hacia el estado actual del agregado:
+// en modo idempotente es un éxito sin cambios, en modo estricto se retorna el error.
+func (s *CatalogoService) resolverSinCambios(transicion string, err error) (sinCambios bool, _ error) {
+    if errors.Is(err, producto.ErrSinCambios) || errors.Is(err, productor.ErrSinCambios) {
+        if s.transicionesEstrictas[transicion] {
+            return false, err
+        }
+        return true, nil
+    }
+    return false, err
+}
+
 // Método auxiliar para publicar eventos pendientes de cualquier agregado
 func (s *CatalogoService) publishPendingEvents(aggregate any) {
     var events []interface{}
     
     // Type assertion para obtener eventos según el tipo de agregado
+    // TomarEventos vacía el agregado antes de publicar para que un reintento no duplique eventos
     switch agg := aggregate.(type) {
     case *producto.ProductoAgroecologico:
-        events = agg.GetPendingEvents()
-        agg.ClearEvents()
+        events = s.conPreferenciasProductor(agg, agg.TomarEventos())
     case *productor.Productor:
-        events = agg.GetPendingEvents()
-        agg.ClearEvents()
+        events = agg.TomarEventos()
     }
     
-    // Publicar cada evento
+    // Contar, guardar y publicar cada evento
     for _, event := range events {
+        s.registrarMetrica(event)
+        if s.eventStore != nil {
+            if err := s.eventStore.Append(event); err != nil {
+                s.logger.Warn("no se pudo guardar el evento de dominio",
+                    "evento", fmt.Sprintf("%T", event),
+                    "error", err,
+                )
+            }
+        }
         if err := s.eventPublisher.Publish(event); err != nil {
-			//TODO: IDK what the hell put here, but is a recommended validation
+            s.logger.Warn("no se pudo publicar el evento de dominio",
+                "evento", fmt.Sprintf("%T", event),
+                "error", err,
+            )
         }
     }
 }
 
+// descartarEventos elimina los eventos de un agregado cuyo cambio no se pudo persistir,
+// para que no se publiquen en una operación posterior sobre la misma instancia.
+func (s *CatalogoService) descartarEventos(aggregate any) {
+    var descartados []interface{}
+    switch agg := aggregate.(type) {
+    case *producto.ProductoAgroecologico:
+        descartados = agg.TomarEventos()
+    case *productor.Productor:
+        descartados = agg.TomarEventos()
+    }
+    if len(descartados) > 0 {
+        s.logger.Debug("eventos descartados por falla al persistir", "cantidad", len(descartados))
+    }
+}
+
+// cargarProducto obtiene un producto del repositorio y verifica que no traiga eventos sin publicar
+func (s *CatalogoService) cargarProducto(id producto.ProductoID) (*producto.ProductoAgroecologico, error) {
+    prod, err := s.productoRepo.GetByID(id)
+    if err != nil {
+        return nil, errorProducto(err)
+    }
+    if s.alCargarConEventos != nil {
+        if pendientes := len(prod.GetPendingEvents()); pendientes > 0 {
+            s.alCargarConEventos(prod, pendientes)
+        }
+    }
+    return prod, nil
+}
+
+// cargarProductor obtiene un productor del repositorio y verifica que no traiga eventos sin publicar
+func (s *CatalogoService) cargarProductor(id productor.ProductorID) (*productor.Productor, error) {
+    prod, err := s.productorRepo.GetByID(id)
+    if err != nil {
+        return nil, errorProductor(err)
+    }
+    if s.alCargarConEventos != nil {
+        if pendientes := len(prod.GetPendingEvents()); pendientes > 0 {
+            s.alCargarConEventos(prod, pendientes)
+        }
+    }
+    return prod, nil
+}
+
+// errorProducto traduce el "no encontrado" del repositorio a ErrProductoNoEncontrado, conservando
+// el detalle original. Cualquier otra falla del repositorio se retorna sin cambios.
+func errorProducto(err error) error {
+    if errors.Is(err, producto.ErrNoEncontrado) {
+        return fmt.Errorf("%w: %v", ErrProductoNoEncontrado, err)
+    }
+    return err
+}
+
+// errorProductor traduce el "no encontrado" del repositorio a ErrProductorNoEncontrado, conservando
+// el detalle original. Cualquier otra falla del repositorio se retorna sin cambios.
+func errorProductor(err error) error {
+    if errors.Is(err, productor.ErrNoEncontrado) {
+        return fmt.Errorf("%w: %v", ErrProductorNoEncontrado, err)
+    }
+    return err
+}
+
+// conPreferenciasProductor adjunta a los eventos de producto notificables una copia de las
+// preferencias de notificación del productor propietario. Si el productor no se encuentra, los
+// eventos se publican sin preferencias.
+func (s *CatalogoService) conPreferenciasProductor(prod *producto.ProductoAgroecologico, events []interface{}) []interface{} {
+    propietario, err := s.productorRepo.GetByID(productor.ProductorID(prod.ProductorID))
+    if err != nil {
+        return events
+    }
+    
+    enriquecidos := make([]interface{}, 0, len(events))
+    for _, event := range events {
+        switch e := event.(type) {
+        case producto.ProductoAgotado:
+            e.PreferenciasProductor = propietario.PreferenciasNotificacion.Copia()
+            event = e
+        case producto.ProductoMarcadoComoExcedente:
+            e.PreferenciasProductor = propietario.PreferenciasNotificacion.Copia()
+            event = e
+        case producto.ProductoStockBajo:
+            e.PreferenciasProductor = propietario.PreferenciasNotificacion.Copia()
+            event = e
+        }
+        enriquecidos = append(enriquecidos, event)
+    }
+    return enriquecidos
+}
+
 // CatalogoCompleto representa una vista completa del catálogo
 type CatalogoCompleto struct {
     Productos   []*producto.ProductoAgroecologico
     Productores []*productor.Productor
     GeneradoEn  time.Time
-}
\ No newline at end of file
+
+    // Degradado indica que falta parte de la información; MotivoDegradacion dice cuál
+    Degradado         bool
+    MotivoDegradacion string `json:",omitempty"`
+}
+
+// Motivos por los que una respuesta del catálogo puede venir degradada
+const (
+    MotivoProductoresNoDisponibles = "productores_no_disponibles"
+)
+
+// ResumenZona representa las estadísticas del catálogo para una zona veredal
+type ResumenZona struct {
+    ZonaVeredal            string
+    TotalProductores       int
+    ProductoresVerificados int
+    TotalProductos         int
+    ProductosDisponibles   int
+    ReputacionPromedioZona float32 // promedio entre los productores verificados de la zona
+}
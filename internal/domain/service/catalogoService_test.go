@@ -0,0 +1,42 @@
+package service_test
+
+import (
+	"errors"
+	"testing"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+	"Product_Catalog_Microservice/internal/domain/service"
+)
+
+func TestPublicarProducto_NombreDuplicadoMismoProductor(t *testing.T) {
+	e := nuevoEscenario(t)
+	e.publicarValido(t, e.semilla1, "p-1", "Tomate Cherry")
+
+	// La comparación del nombre no distingue mayúsculas
+	_, err := e.publicar(e.semilla1, "p-2", nuevosDatosProducto(t, "tomate cherry"))
+	if !errors.Is(err, service.ErrNombreDuplicado) {
+		t.Fatalf("err = %v, se esperaba ErrNombreDuplicado", err)
+	}
+	if _, err := e.productoRepo.GetByID("p-2"); err == nil {
+		t.Error("el producto duplicado no debía guardarse")
+	}
+	if n := contarEventos[producto.ProductoPublicado](e.eventos); n != 1 {
+		t.Errorf("ProductoPublicado publicados = %d, se esperaba 1", n)
+	}
+}
+
+func TestPublicarProducto_MismoNombreOtroProductor(t *testing.T) {
+	e := nuevoEscenario(t)
+	e.publicarValido(t, e.semilla1, "p-1", "Tomate Cherry")
+
+	prod, err := e.publicar(e.semilla2, "p-2", nuevosDatosProducto(t, "Tomate Cherry"))
+	if err != nil {
+		t.Fatalf("PublicarProducto: %v", err)
+	}
+	if prod.ProductorID != string(e.semilla2) {
+		t.Errorf("ProductorID = %q, se esperaba %q", prod.ProductorID, e.semilla2)
+	}
+	if n := contarEventos[producto.ProductoPublicado](e.eventos); n != 2 {
+		t.Errorf("ProductoPublicado publicados = %d, se esperaba 2", n)
+	}
+}
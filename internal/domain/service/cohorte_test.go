@@ -0,0 +1,64 @@
+package service_test
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+	"Product_Catalog_Microservice/internal/domain/productor"
+)
+
+// registrarEn guarda un productor con la fecha de registro indicada. Su ID es el nombre y
+// su nombre "Productor <nombre>".
+func (e *escenario) registrarEn(t *testing.T, nombre string, fecha time.Time) {
+	t.Helper()
+	prod := nuevoProductor(t, productor.ProductorID(nombre), "Vereda El Paraíso", true, 4)
+	prod.FechaRegistro = fecha
+	if err := e.productorRepo.Save(prod); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+}
+
+func TestGetProductoresRegistradosMes(t *testing.T) {
+	e := nuevoEscenario(t)
+	bogota := producto.ZonaHoraria()
+
+	e.registrarEn(t, "mayo-ultimo-instante", time.Date(2025, time.June, 1, 0, 0, 0, 0, bogota).Add(-time.Nanosecond))
+	e.registrarEn(t, "junio-primer-instante", time.Date(2025, time.June, 1, 0, 0, 0, 0, bogota))
+	e.registrarEn(t, "junio-medio", time.Date(2025, time.June, 15, 12, 0, 0, 0, bogota))
+	// 1 de julio a las 03:00 UTC sigue siendo 30 de junio en Bogotá
+	e.registrarEn(t, "junio-ultima-noche", time.Date(2025, time.July, 1, 3, 0, 0, 0, time.UTC))
+	e.registrarEn(t, "julio", time.Date(2025, time.July, 1, 0, 0, 0, 0, bogota))
+
+	productores, err := e.catalogo.GetProductoresRegistradosMes(2025, 6)
+	if err != nil {
+		t.Fatalf("GetProductoresRegistradosMes: %v", err)
+	}
+
+	nombres := make([]string, 0, len(productores))
+	for _, p := range productores {
+		nombres = append(nombres, p.Nombre.Value)
+	}
+	sort.Strings(nombres)
+	esperado := []string{"Productor junio-medio", "Productor junio-primer-instante", "Productor junio-ultima-noche"}
+	if len(nombres) != len(esperado) {
+		t.Fatalf("cohorte = %v, se esperaba %v", nombres, esperado)
+	}
+	for i := range esperado {
+		if nombres[i] != esperado[i] {
+			t.Errorf("cohorte = %v, se esperaba %v", nombres, esperado)
+			break
+		}
+	}
+}
+
+func TestGetProductoresRegistradosMes_MesInvalido(t *testing.T) {
+	e := nuevoEscenario(t)
+
+	for _, mes := range []int{0, 13, -1} {
+		if _, err := e.catalogo.GetProductoresRegistradosMes(2025, mes); err == nil {
+			t.Errorf("mes %d: se esperaba error", mes)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package service_test
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+	"Product_Catalog_Microservice/internal/domain/service"
+	"Product_Catalog_Microservice/internal/repository"
+)
+
+// Estas pruebas tienen sentido con el detector de carreras:
+//
+//	go test -race ./internal/domain/service -run Concurrencia
+
+// productoRepoLento entrega una copia en cada lectura y la demora para que, sin bloqueo por
+// agregado, las secuencias leer-modificar-escribir concurrentes se intercalen y una escritura
+// revierta a otra
+type productoRepoLento struct {
+	*repository.ProductoRepository
+}
+
+func (r productoRepoLento) GetByID(id producto.ProductoID) (*producto.ProductoAgroecologico, error) {
+	prod, err := r.ProductoRepository.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	copia := *prod
+	time.Sleep(time.Millisecond)
+	return &copia, nil
+}
+
+// nuevoEscenarioLento crea un escenario sobre productoRepoLento con p-1 publicado
+func nuevoEscenarioLento(t *testing.T) *escenario {
+	t.Helper()
+	e := &escenario{
+		productoRepo:  repository.NewProductoRepository(0),
+		productorRepo: repository.NewProductorRepository(0),
+		eventos:       &publicadorRegistro{},
+	}
+	e.catalogo = service.NewCatalogoService(e.productorRepo, productoRepoLento{e.productoRepo}, e.eventos)
+	e.semilla1 = productorSemilla1
+	e.publicarValido(t, e.semilla1, "p-1", "Fresa")
+	return e
+}
+
+// concurrentes ejecuta f desde n goroutines que arrancan a la vez y espera a que terminen
+func concurrentes(n int, f func(i int)) {
+	var (
+		wg     sync.WaitGroup
+		inicio = make(chan struct{})
+	)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-inicio
+			f(i)
+		}(i)
+	}
+	close(inicio)
+	wg.Wait()
+}
+
+func TestConcurrencia_AgotarUnaSolaVez(t *testing.T) {
+	e := nuevoEscenarioLento(t)
+
+	var sinCambios atomic.Int64
+	concurrentes(20, func(int) {
+		repetido, err := e.catalogo.AgotarProducto("p-1")
+		if err != nil {
+			t.Errorf("AgotarProducto: %v", err)
+		}
+		if repetido {
+			sinCambios.Add(1)
+		}
+	})
+
+	if n := contarEventos[producto.ProductoAgotado](e.eventos); n != 1 {
+		t.Errorf("ProductoAgotado publicados = %d, se esperaba 1", n)
+	}
+	if sinCambios.Load() != 19 {
+		t.Errorf("agotados sin cambios = %d, se esperaba 19", sinCambios.Load())
+	}
+}
+
+// Marcar como excedente y actualizar la información compiten sobre el mismo producto; Update
+// escribe el agregado completo, así que sin bloqueo podría revertir el estado a Disponible
+func TestConcurrencia_MutacionesMixtasSinActualizacionesPerdidas(t *testing.T) {
+	const n = 10
+	e := nuevoEscenarioLento(t)
+
+	nombre, _ := producto.NewNombreProducto("Fresa de montaña")
+	desc, _ := producto.NewDescripcionProducto("Fresas cultivadas sobre los 2.500 metros")
+	imagen, _ := producto.NewImagen("https://img.example.com/fresa-montana.jpg", "Fresas de montaña")
+	enTemporada := time.Now()
+
+	var sinCambios atomic.Int64
+	concurrentes(2*n, func(i int) {
+		var err error
+		if i%2 == 0 {
+			var repetido bool
+			repetido, err = e.catalogo.MarcarProductoComoExcedente("p-1", enTemporada)
+			if repetido {
+				sinCambios.Add(1)
+			}
+		} else {
+			_, err = e.catalogo.ActualizarInformacionProducto("p-1", nombre, desc, imagen)
+		}
+		if err != nil {
+			t.Errorf("goroutine %d: %v", i, err)
+		}
+	})
+
+	prod, err := e.productoRepo.GetByID("p-1")
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if prod.Estado.Value != producto.Excedente {
+		t.Errorf("Estado = %q, se esperaba Excedente", prod.Estado.Value)
+	}
+	if prod.Nombre != nombre || prod.Imagen != imagen {
+		t.Errorf("Nombre = %q, Imagen = %q; se perdió la actualización de información", prod.Nombre.Value, prod.Imagen.URL)
+	}
+	if got := contarEventos[producto.ProductoMarcadoComoExcedente](e.eventos); got != 1 {
+		t.Errorf("ProductoMarcadoComoExcedente publicados = %d, se esperaba 1", got)
+	}
+	if sinCambios.Load() != n-1 {
+		t.Errorf("marcas sin cambios = %d, se esperaba %d", sinCambios.Load(), n-1)
+	}
+}
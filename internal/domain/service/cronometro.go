@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EtapaMedida es la duración de una etapa de una operación
+type EtapaMedida struct {
+	Nombre   string
+	Duracion time.Duration
+}
+
+// Cronometro registra la duración de las etapas de una operación para detectar cuál
+// domina la latencia. Un *Cronometro nil es válido y no mide nada.
+type Cronometro struct {
+	mu     sync.Mutex
+	inicio time.Time
+	etapas []EtapaMedida
+}
+
+// NuevoCronometro crea un cronómetro que empieza a contar desde ahora
+func NuevoCronometro() *Cronometro {
+	return &Cronometro{inicio: time.Now()}
+}
+
+// Etapa empieza a medir la etapa indicada y retorna la función que la termina
+func (c *Cronometro) Etapa(nombre string) (terminar func()) {
+	if c == nil {
+		return func() {}
+	}
+	inicio := time.Now()
+	return func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.etapas = append(c.etapas, EtapaMedida{Nombre: nombre, Duracion: time.Since(inicio)})
+	}
+}
+
+// Etapas retorna las etapas medidas en el orden en que terminaron
+func (c *Cronometro) Etapas() []EtapaMedida {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]EtapaMedida(nil), c.etapas...)
+}
+
+// Total retorna el tiempo transcurrido desde que se creó el cronómetro
+func (c *Cronometro) Total() time.Duration {
+	if c == nil {
+		return 0
+	}
+	return time.Since(c.inicio)
+}
+
+// Dominante retorna la etapa más lenta
+func (c *Cronometro) Dominante() EtapaMedida {
+	var dominante EtapaMedida
+	for _, etapa := range c.Etapas() {
+		if etapa.Duracion > dominante.Duracion {
+			dominante = etapa
+		}
+	}
+	return dominante
+}
+
+// ServerTiming formatea las etapas para el header Server-Timing
+func (c *Cronometro) ServerTiming() string {
+	etapas := c.Etapas()
+	partes := make([]string, 0, len(etapas))
+	for _, etapa := range etapas {
+		partes = append(partes, fmt.Sprintf("%s;dur=%.2f", etapa.Nombre, float64(etapa.Duracion.Microseconds())/1000))
+	}
+	return strings.Join(partes, ", ")
+}
+
+type claveCronometro struct{}
+
+// ConCronometro retorna un contexto que lleva el cronómetro indicado
+func ConCronometro(ctx context.Context, c *Cronometro) context.Context {
+	return context.WithValue(ctx, claveCronometro{}, c)
+}
+
+// CronometroDe retorna el cronómetro del contexto, o nil si no tiene
+func CronometroDe(ctx context.Context) *Cronometro {
+	c, _ := ctx.Value(claveCronometro{}).(*Cronometro)
+	return c
+}
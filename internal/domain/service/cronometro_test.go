@@ -0,0 +1,103 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+	"Product_Catalog_Microservice/internal/domain/productor"
+	"Product_Catalog_Microservice/internal/domain/service"
+	"Product_Catalog_Microservice/internal/repository"
+)
+
+// Demoras artificiales de cada dependencia de la publicación
+const (
+	demoraAutorizacion = 20 * time.Millisecond
+	demoraGuardado     = 80 * time.Millisecond
+	demoraEventos      = 10 * time.Millisecond
+)
+
+type productorRepoDemorado struct {
+	*repository.ProductorRepository
+}
+
+func (r productorRepoDemorado) GetByID(id productor.ProductorID) (*productor.Productor, error) {
+	time.Sleep(demoraAutorizacion)
+	return r.ProductorRepository.GetByID(id)
+}
+
+type productoRepoDemorado struct {
+	*repository.ProductoRepository
+}
+
+func (r productoRepoDemorado) Save(prod *producto.ProductoAgroecologico) error {
+	time.Sleep(demoraGuardado)
+	return r.ProductoRepository.Save(prod)
+}
+
+type publicadorDemorado struct{}
+
+func (publicadorDemorado) Publish(event any) error {
+	time.Sleep(demoraEventos)
+	return nil
+}
+
+func TestPublicarProducto_AtribuyeLasDemorasPorEtapa(t *testing.T) {
+	productorRepo := repository.NewProductorRepository(0)
+	catalogo := service.NewCatalogoService(
+		productorRepoDemorado{productorRepo},
+		productoRepoDemorado{repository.NewProductoRepository(0)},
+		publicadorDemorado{},
+	)
+	d := nuevosDatosProducto(t, "Fresa")
+	crono := service.NuevoCronometro()
+
+	_, err := catalogo.PublicarProducto(service.ConCronometro(context.Background(), crono),
+		productorSemilla1, "p-1", d.nombre, d.desc, d.categoria, d.tipo,
+		d.temporada, d.ubicacion, d.imagen, d.precio, d.cantidad, 0)
+	if err != nil {
+		t.Fatalf("PublicarProducto: %v", err)
+	}
+
+	etapas := crono.Etapas()
+	esperadas := []struct {
+		nombre string
+		minima time.Duration
+	}{
+		{"autorizacion", demoraAutorizacion},
+		{"agregado", 0},
+		{"guardado", demoraGuardado},
+		{"eventos", demoraEventos}, // un solo ProductoPublicado
+	}
+	if len(etapas) != len(esperadas) {
+		t.Fatalf("etapas = %v, se esperaban %d", etapas, len(esperadas))
+	}
+	for i, esperada := range esperadas {
+		if etapas[i].Nombre != esperada.nombre {
+			t.Errorf("etapa %d = %q, se esperaba %q", i, etapas[i].Nombre, esperada.nombre)
+		}
+		if etapas[i].Duracion < esperada.minima {
+			t.Errorf("%s duró %v, menos que la demora inyectada de %v", esperada.nombre, etapas[i].Duracion, esperada.minima)
+		}
+	}
+	if etapas[1].Duracion >= demoraEventos {
+		t.Errorf("agregado duró %v sin demora inyectada", etapas[1].Duracion)
+	}
+	if dominante := crono.Dominante(); dominante.Nombre != "guardado" {
+		t.Errorf("etapa dominante = %q, se esperaba guardado", dominante.Nombre)
+	}
+	if total := crono.Total(); total < demoraAutorizacion+demoraGuardado+demoraEventos {
+		t.Errorf("total = %v, menor que la suma de las demoras", total)
+	}
+}
+
+// Sin cronómetro en el contexto la publicación usa un *Cronometro nil, que no mide nada
+func TestCronometro_Nil(t *testing.T) {
+	crono := service.CronometroDe(context.Background())
+	terminar := crono.Etapa("guardado")
+	terminar()
+	if etapas := crono.Etapas(); etapas != nil || crono.ServerTiming() != "" || crono.Total() != 0 {
+		t.Errorf("un cronómetro nil midió %v", etapas)
+	}
+}
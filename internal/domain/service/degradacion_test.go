@@ -0,0 +1,95 @@
+package service_test
+
+import (
+	"errors"
+	"testing"
+
+	"Product_Catalog_Microservice/internal/domain/productor"
+	"Product_Catalog_Microservice/internal/domain/service"
+	"Product_Catalog_Microservice/internal/domain/shared"
+	"Product_Catalog_Microservice/internal/repository"
+)
+
+// productorRepoCaido simula una caída del almacenamiento de productores al listarlos
+type productorRepoCaido struct {
+	*repository.ProductorRepository
+}
+
+func (productorRepoCaido) GetVerificados() ([]*productor.Productor, error) {
+	return nil, errors.New("conexión rechazada")
+}
+
+func (productorRepoCaido) GetVerificadosPaginated(shared.PaginationParams) (shared.PagedResult[*productor.Productor], error) {
+	return shared.PagedResult[*productor.Productor]{}, errors.New("conexión rechazada")
+}
+
+// catalogoConProductoresCaidos crea un servicio con p-1 publicado y el listado de productores fallando
+func catalogoConProductoresCaidos(t *testing.T, opts ...service.CatalogoServiceOption) *service.CatalogoService {
+	t.Helper()
+	e := nuevoEscenario(t)
+	e.publicarValido(t, e.semilla1, "p-1", "Fresa")
+	return service.NewCatalogoService(productorRepoCaido{e.productorRepo}, e.productoRepo, e.eventos, opts...)
+}
+
+func TestGetCatalogoCompleto_DegradaSinProductores(t *testing.T) {
+	var motivos []string
+	catalogo := catalogoConProductoresCaidos(t, service.WithAlDegradar(func(motivo string) {
+		motivos = append(motivos, motivo)
+	}))
+
+	completo, err := catalogo.GetCatalogoCompleto()
+	if err != nil {
+		t.Fatalf("GetCatalogoCompleto: %v", err)
+	}
+	if !completo.Degradado || completo.MotivoDegradacion != service.MotivoProductoresNoDisponibles {
+		t.Errorf("Degradado = %v, MotivoDegradacion = %q; se esperaba degradado por productores",
+			completo.Degradado, completo.MotivoDegradacion)
+	}
+	if completo.Productores != nil {
+		t.Errorf("Productores = %v, se esperaba nil", completo.Productores)
+	}
+	if len(completo.Productos) != 1 {
+		t.Errorf("productos = %d, se esperaba 1", len(completo.Productos))
+	}
+	if len(motivos) != 1 || motivos[0] != service.MotivoProductoresNoDisponibles {
+		t.Errorf("motivos reportados = %v, se esperaba uno por productores", motivos)
+	}
+}
+
+func TestGetCatalogoCompleto_Estricto(t *testing.T) {
+	catalogo := catalogoConProductoresCaidos(t, service.WithCatalogoEstricto(true))
+
+	if _, err := catalogo.GetCatalogoCompleto(); err == nil {
+		t.Fatal("en modo estricto se esperaba el error del repositorio de productores")
+	}
+}
+
+func TestGetCatalogoCompletoPaginado_DegradaSinProductores(t *testing.T) {
+	var motivos []string
+	catalogo := catalogoConProductoresCaidos(t, service.WithAlDegradar(func(motivo string) {
+		motivos = append(motivos, motivo)
+	}))
+
+	pagina, err := catalogo.GetCatalogoCompletoPaginado(shared.PaginationParams{Page: 1, PageSize: 10})
+	if err != nil {
+		t.Fatalf("GetCatalogoCompletoPaginado: %v", err)
+	}
+	if !pagina.Degradado || pagina.MotivoDegradacion != service.MotivoProductoresNoDisponibles {
+		t.Errorf("Degradado = %v, MotivoDegradacion = %q; se esperaba degradado por productores",
+			pagina.Degradado, pagina.MotivoDegradacion)
+	}
+	if pagina.Productores.Items == nil || len(pagina.Productores.Items) != 0 {
+		t.Errorf("Productores.Items = %v, se esperaba un slice vacío", pagina.Productores.Items)
+	}
+	if len(pagina.Productos.Items) != 1 || pagina.TotalProductos != 1 {
+		t.Errorf("productos = %d (total %d), se esperaba 1", len(pagina.Productos.Items), pagina.TotalProductos)
+	}
+	if len(motivos) != 1 {
+		t.Errorf("motivos reportados = %v, se esperaba uno por productores", motivos)
+	}
+
+	estricto := catalogoConProductoresCaidos(t, service.WithCatalogoEstricto(true))
+	if _, err := estricto.GetCatalogoCompletoPaginado(shared.PaginationParams{Page: 1}); err == nil {
+		t.Fatal("en modo estricto se esperaba el error del repositorio de productores")
+	}
+}
@@ -0,0 +1,45 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+)
+
+func TestActualizarDisponibilidadPorTemporada_TemporadaDeUnDia(t *testing.T) {
+	e := nuevoEscenario(t)
+
+	hoy := time.Now().In(producto.ZonaHoraria())
+	d := nuevosDatosProducto(t, "Fresa")
+	var err error
+	if d.temporada, err = producto.NewTemporadaLocal(hoy, hoy); err != nil {
+		t.Fatalf("NewTemporadaLocal: %v", err)
+	}
+	if _, err := e.publicar(e.semilla1, "fresa", d); err != nil {
+		t.Fatalf("PublicarProducto: %v", err)
+	}
+
+	casos := []struct {
+		nombre string
+		now    time.Time
+		estado string
+	}{
+		{"primer segundo", d.temporada.Inicio, producto.Disponible},
+		{"último segundo", d.temporada.Fin.Add(-time.Second + time.Nanosecond), producto.Disponible},
+		{"día siguiente", d.temporada.Fin.Add(time.Nanosecond), producto.Agotado},
+		{"de vuelta en temporada", d.temporada.Inicio, producto.Disponible},
+	}
+	for _, tc := range casos {
+		if err := e.catalogo.ActualizarDisponibilidadPorTemporada(tc.now); err != nil {
+			t.Fatalf("%s: ActualizarDisponibilidadPorTemporada: %v", tc.nombre, err)
+		}
+		prod, err := e.productoRepo.GetByID("fresa")
+		if err != nil {
+			t.Fatalf("GetByID: %v", err)
+		}
+		if prod.Estado.Value != tc.estado {
+			t.Errorf("%s: Estado = %s, se esperaba %s", tc.nombre, prod.Estado.Value, tc.estado)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package service_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+	"Product_Catalog_Microservice/internal/domain/service"
+	"Product_Catalog_Microservice/internal/repository"
+)
+
+// productoRepoInestable falla las primeras fallas llamadas a UpdateEstadoDisponibilidad. GetByID
+// entrega una copia, como un repositorio persistente, salvo que compartido no sea nil: entonces
+// entrega siempre esa misma instancia, como haría una caché de agregados en memoria.
+type productoRepoInestable struct {
+	*repository.ProductoRepository
+	fallas     int
+	compartido *producto.ProductoAgroecologico
+}
+
+func (r *productoRepoInestable) UpdateEstadoDisponibilidad(id producto.ProductoID, estado producto.EstadoDisponibilidad) error {
+	if r.fallas > 0 {
+		r.fallas--
+		return errors.New("timeout al escribir")
+	}
+	return r.ProductoRepository.UpdateEstadoDisponibilidad(id, estado)
+}
+
+func (r *productoRepoInestable) GetByID(id producto.ProductoID) (*producto.ProductoAgroecologico, error) {
+	if r.compartido != nil {
+		return r.compartido, nil
+	}
+	prod, err := r.ProductoRepository.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	copia := *prod
+	return &copia, nil
+}
+
+// nuevoEscenarioInestable crea un escenario sobre productoRepoInestable con p-1 publicado.
+// fugas cuenta las veces que el repositorio entregó un agregado con eventos sin publicar.
+func nuevoEscenarioInestable(t *testing.T) (e *escenario, repo *productoRepoInestable, fugas *int) {
+	t.Helper()
+	fugas = new(int)
+	repo = &productoRepoInestable{ProductoRepository: repository.NewProductoRepository(0)}
+	e = &escenario{
+		productoRepo:  repo.ProductoRepository,
+		productorRepo: repository.NewProductorRepository(0),
+		eventos:       &publicadorRegistro{},
+	}
+	e.catalogo = service.NewCatalogoService(e.productorRepo, repo, e.eventos,
+		service.WithVerificacionEventosPendientes(func(agregado any, pendientes int) {
+			*fugas++
+			t.Logf("agregado %T cargado con %d eventos pendientes", agregado, pendientes)
+		}))
+	e.semilla1 = productorSemilla1
+	e.publicarValido(t, e.semilla1, "p-1", "Fresa")
+	return e, repo, fugas
+}
+
+func TestEventosPendientes_FallaAlPersistirYReintento(t *testing.T) {
+	e, repo, fugas := nuevoEscenarioInestable(t)
+
+	repo.fallas = 1
+	if _, err := e.catalogo.AgotarProducto("p-1"); err == nil {
+		t.Fatal("AgotarProducto debía fallar al persistir")
+	}
+	if n := contarEventos[producto.ProductoAgotado](e.eventos); n != 0 {
+		t.Fatalf("ProductoAgotado publicados = %d tras la falla, se esperaba 0", n)
+	}
+
+	// El reintento publica el evento una sola vez
+	if sinCambios, err := e.catalogo.AgotarProducto("p-1"); err != nil || sinCambios {
+		t.Fatalf("reintento = (%v, %v), se esperaba (false, nil)", sinCambios, err)
+	}
+	if sinCambios, err := e.catalogo.AgotarProducto("p-1"); err != nil || !sinCambios {
+		t.Fatalf("AgotarProducto repetido = (%v, %v), se esperaba (true, nil)", sinCambios, err)
+	}
+	if n := contarEventos[producto.ProductoAgotado](e.eventos); n != 1 {
+		t.Errorf("ProductoAgotado publicados = %d, se esperaba 1", n)
+	}
+	if prod, _ := e.productoRepo.GetByID("p-1"); prod.Estado.Value != producto.Agotado {
+		t.Errorf("Estado = %q, se esperaba Agotado", prod.Estado.Value)
+	}
+	if *fugas != 0 {
+		t.Errorf("se cargaron %d agregados con eventos sin publicar", *fugas)
+	}
+}
+
+// Con una instancia compartida, los eventos de la transición que no se pudo persistir no deben
+// publicarse en la siguiente operación sobre el mismo agregado
+func TestEventosPendientes_FallaNoSeFiltraAOtraOperacion(t *testing.T) {
+	e, repo, fugas := nuevoEscenarioInestable(t)
+	repo.compartido, _ = e.productoRepo.GetByID("p-1")
+
+	repo.fallas = 1
+	if _, err := e.catalogo.AgotarProducto("p-1"); err == nil {
+		t.Fatal("AgotarProducto debía fallar al persistir")
+	}
+
+	e.marcarExcedente(t, "p-1")
+	if n := contarEventos[producto.ProductoAgotado](e.eventos); n != 0 {
+		t.Errorf("ProductoAgotado publicados = %d, el evento descartado se filtró", n)
+	}
+	if *fugas != 0 {
+		t.Errorf("se cargaron %d agregados con eventos sin publicar", *fugas)
+	}
+}
+
+func TestEventosPendientes_DetectaAgregadoConEventos(t *testing.T) {
+	e, repo, fugas := nuevoEscenarioInestable(t)
+	repo.compartido, _ = e.productoRepo.GetByID("p-1")
+
+	// Una mutación fuera del servicio deja un evento que nadie publicó
+	if err := repo.compartido.Agotar(); err != nil {
+		t.Fatalf("Agotar: %v", err)
+	}
+
+	e.marcarExcedente(t, "p-1")
+	if *fugas != 1 {
+		t.Errorf("verificaciones = %d, se esperaba 1", *fugas)
+	}
+}
+
+// marcarExcedente aplica al producto una transición que se permite aunque esté
+// agotado, y con ella publica lo que el agregado tenga pendiente
+func (e *escenario) marcarExcedente(t *testing.T, id producto.ProductoID) {
+	t.Helper()
+	if _, err := e.catalogo.MarcarProductoComoExcedente(id, time.Now()); err != nil {
+		t.Fatalf("MarcarProductoComoExcedente: %v", err)
+	}
+}
@@ -0,0 +1,41 @@
+package service
+
+import (
+	"Product_Catalog_Microservice/internal/domain/producto"
+	"Product_Catalog_Microservice/internal/domain/productor"
+)
+
+// ProductoConProductor es un producto acompañado del nombre de su productor
+type ProductoConProductor struct {
+	Producto        *producto.ProductoAgroecologico
+	ProductorNombre string
+}
+
+// GetProductosExcedentes obtiene los productos marcados como excedente
+func (s *CatalogoService) GetProductosExcedentes() ([]*producto.ProductoAgroecologico, error) {
+	return s.productoRepo.GetByEstado(producto.EstadoDisponibilidad{Value: producto.Excedente})
+}
+
+// GetProductosExcedentesConProductor obtiene los productos en excedente con el nombre de su
+// productor, para contactarlo sin otra consulta. Cada productor se lee una sola vez; si no se
+// encuentra, el producto se incluye con el nombre vacío. Nunca retorna nil.
+func (s *CatalogoService) GetProductosExcedentesConProductor() ([]ProductoConProductor, error) {
+	excedentes, err := s.GetProductosExcedentes()
+	if err != nil {
+		return nil, err
+	}
+
+	nombres := make(map[string]string)
+	resultado := make([]ProductoConProductor, 0, len(excedentes))
+	for _, prod := range excedentes {
+		nombre, ok := nombres[prod.ProductorID]
+		if !ok {
+			if propietario, err := s.productorRepo.GetByID(productor.ProductorID(prod.ProductorID)); err == nil {
+				nombre = propietario.Nombre.Value
+			}
+			nombres[prod.ProductorID] = nombre
+		}
+		resultado = append(resultado, ProductoConProductor{Producto: prod, ProductorNombre: nombre})
+	}
+	return resultado, nil
+}
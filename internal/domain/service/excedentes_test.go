@@ -0,0 +1,89 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+	"Product_Catalog_Microservice/internal/domain/productor"
+	"Product_Catalog_Microservice/internal/domain/service"
+	"Product_Catalog_Microservice/internal/repository"
+)
+
+// productorRepoContado cuenta las lecturas por ID
+type productorRepoContado struct {
+	*repository.ProductorRepository
+	lecturas map[productor.ProductorID]int
+}
+
+func (r *productorRepoContado) GetByID(id productor.ProductorID) (*productor.Productor, error) {
+	r.lecturas[id]++
+	return r.ProductorRepository.GetByID(id)
+}
+
+func TestGetProductosExcedentesConProductor(t *testing.T) {
+	e := nuevoEscenario(t)
+	contado := &productorRepoContado{ProductorRepository: e.productorRepo, lecturas: make(map[productor.ProductorID]int)}
+	e.catalogo = service.NewCatalogoService(contado, e.productoRepo, e.eventos)
+
+	e.publicarValido(t, e.semilla1, "p-1", "Fresa")
+	e.publicarValido(t, e.semilla1, "p-2", "Mora")
+	e.publicarValido(t, e.semilla2, "p-3", "Lulo")
+	for _, id := range []producto.ProductoID{"p-1", "p-2"} {
+		if _, err := e.catalogo.MarcarProductoComoExcedente(id, time.Now()); err != nil {
+			t.Fatalf("MarcarProductoComoExcedente(%s): %v", id, err)
+		}
+	}
+	// Un productor que no existe no saca a su producto de la lista
+	d := nuevosDatosProducto(t, "Uchuva")
+	huerfano, err := producto.NewProductoAgroecologico("p-4", d.nombre, d.desc, d.categoria, d.tipo,
+		d.temporada, d.ubicacion, d.imagen, d.precio, d.cantidad, "no-existe")
+	if err != nil {
+		t.Fatalf("NewProductoAgroecologico: %v", err)
+	}
+	if err := huerfano.MarcarComoExcedente(time.Now()); err != nil {
+		t.Fatalf("MarcarComoExcedente: %v", err)
+	}
+	if err := e.productoRepo.Save(huerfano); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	clear(contado.lecturas)
+
+	excedentes, err := e.catalogo.GetProductosExcedentesConProductor()
+	if err != nil {
+		t.Fatalf("GetProductosExcedentesConProductor: %v", err)
+	}
+	semilla1, err := e.productorRepo.GetByID(e.semilla1)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	nombres := make(map[string]string)
+	for _, ex := range excedentes {
+		nombres[string(ex.Producto.ID)] = ex.ProductorNombre
+	}
+	esperados := map[string]string{"p-1": semilla1.Nombre.Value, "p-2": semilla1.Nombre.Value, "p-4": ""}
+	if len(nombres) != len(esperados) {
+		t.Fatalf("excedentes = %v, se esperaba %v", nombres, esperados)
+	}
+	for id, nombre := range esperados {
+		if got, ok := nombres[id]; !ok || got != nombre {
+			t.Errorf("%s: productor_nombre = %q, se esperaba %q", id, got, nombre)
+		}
+	}
+	if n := contado.lecturas[e.semilla1]; n != 1 {
+		t.Errorf("lecturas de %s = %d, se esperaba 1", e.semilla1, n)
+	}
+}
+
+func TestGetProductosExcedentesConProductor_SinExcedentes(t *testing.T) {
+	e := nuevoEscenario(t)
+	e.publicarValido(t, e.semilla1, "p-1", "Fresa")
+
+	excedentes, err := e.catalogo.GetProductosExcedentesConProductor()
+	if err != nil {
+		t.Fatalf("GetProductosExcedentesConProductor: %v", err)
+	}
+	if excedentes == nil || len(excedentes) != 0 {
+		t.Errorf("excedentes = %v, se esperaba una lista vacía", excedentes)
+	}
+}
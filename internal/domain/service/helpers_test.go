@@ -0,0 +1,197 @@
+package service_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+	"Product_Catalog_Microservice/internal/domain/productor"
+	"Product_Catalog_Microservice/internal/domain/service"
+	"Product_Catalog_Microservice/internal/repository"
+)
+
+// publicadorRegistro guarda los eventos publicados para inspeccionarlos en las pruebas
+type publicadorRegistro struct {
+	mu      sync.Mutex
+	eventos []any
+}
+
+func (p *publicadorRegistro) Publish(event any) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.eventos = append(p.eventos, event)
+	return nil
+}
+
+// Eventos retorna una copia de los eventos publicados hasta ahora
+func (p *publicadorRegistro) Eventos() []any {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]any(nil), p.eventos...)
+}
+
+// contarEventos retorna cuántos eventos publicados son del tipo T
+func contarEventos[T any](p *publicadorRegistro) int {
+	n := 0
+	for _, e := range p.Eventos() {
+		if _, ok := e.(T); ok {
+			n++
+		}
+	}
+	return n
+}
+
+// escenario agrupa un servicio con repositorios en memoria y el registro de sus eventos
+type escenario struct {
+	catalogo      *service.CatalogoService
+	productoRepo  *repository.ProductoRepository
+	productorRepo *repository.ProductorRepository
+	eventos       *publicadorRegistro
+
+	// IDs de los productores semilla, ambos verificados y activos
+	semilla1, semilla2 productor.ProductorID
+}
+
+func nuevoEscenario(t testing.TB, opts ...service.CatalogoServiceOption) *escenario {
+	t.Helper()
+	e := &escenario{
+		productoRepo:  repository.NewProductoRepository(0),
+		productorRepo: repository.NewProductorRepository(0),
+		eventos:       &publicadorRegistro{},
+	}
+	e.catalogo = service.NewCatalogoService(e.productorRepo, e.productoRepo, e.eventos, opts...)
+	e.semilla1 = productorSemilla1
+	e.semilla2 = productorSemilla2
+	return e
+}
+
+// IDs de los productores semilla que carga NewProductorRepository, ambos verificados y activos
+const (
+	productorSemilla1 productor.ProductorID = "quemado-1"
+	productorSemilla2 productor.ProductorID = "quemado-2"
+)
+
+// datosProducto son los value objects de una publicación válida
+type datosProducto struct {
+	nombre    producto.NombreProducto
+	desc      producto.DescripcionProducto
+	categoria producto.Categoria
+	tipo      producto.TipoProduccion
+	temporada producto.TemporadaLocal
+	ubicacion producto.Ubicacion
+	imagen    producto.Imagen
+	precio    producto.Precio
+	cantidad  producto.CantidadDisponible
+}
+
+// nuevosDatosProducto crea una publicación válida, en temporada desde hoy y durante 30 días
+func nuevosDatosProducto(t testing.TB, nombre string) datosProducto {
+	t.Helper()
+	var (
+		d   datosProducto
+		err error
+	)
+	if d.nombre, err = producto.NewNombreProducto(nombre); err != nil {
+		t.Fatalf("nombre: %v", err)
+	}
+	if d.desc, err = producto.NewDescripcionProducto("Cosecha fresca sin agroquímicos"); err != nil {
+		t.Fatalf("descripcion: %v", err)
+	}
+	if d.categoria, err = producto.NewCategoria("Fruta"); err != nil {
+		t.Fatalf("categoria: %v", err)
+	}
+	d.tipo = producto.ProduccionAgroecologica
+	// La temporada cuenta el día calendario de la fecha; en la zona del despliegue hoy puede ser
+	// todavía ayer respecto del reloj del sistema
+	ahora := time.Now().In(producto.ZonaHoraria())
+	if d.temporada, err = producto.NewTemporadaLocal(ahora, ahora.AddDate(0, 0, 30)); err != nil {
+		t.Fatalf("temporada: %v", err)
+	}
+	if d.ubicacion, err = producto.NewUbicacion("Vereda El Paraíso", "Finca La Esperanza"); err != nil {
+		t.Fatalf("ubicacion: %v", err)
+	}
+	if d.imagen, err = producto.NewImagen("https://img.example.com/fresa.jpg", "Fresas"); err != nil {
+		t.Fatalf("imagen: %v", err)
+	}
+	if d.precio, err = producto.NewPrecio(4500, "COP"); err != nil {
+		t.Fatalf("precio: %v", err)
+	}
+	if d.cantidad, err = producto.NewCantidadDisponible(40, producto.UnidadKilogramo); err != nil {
+		t.Fatalf("cantidad: %v", err)
+	}
+	return d
+}
+
+// publicar publica d como productoID del productor indicado, sin reputación mínima
+func (e *escenario) publicar(productorID productor.ProductorID, productoID producto.ProductoID, d datosProducto) (*producto.ProductoAgroecologico, error) {
+	return e.catalogo.PublicarProducto(
+		context.Background(),
+		productorID,
+		productoID,
+		d.nombre,
+		d.desc,
+		d.categoria,
+		d.tipo,
+		d.temporada,
+		d.ubicacion,
+		d.imagen,
+		d.precio,
+		d.cantidad,
+		0,
+	)
+}
+
+// publicarValido publica un producto válido y falla la prueba si no se puede
+func (e *escenario) publicarValido(t testing.TB, productorID productor.ProductorID, productoID producto.ProductoID, nombre string) *producto.ProductoAgroecologico {
+	t.Helper()
+	prod, err := e.publicar(productorID, productoID, nuevosDatosProducto(t, nombre))
+	if err != nil {
+		t.Fatalf("PublicarProducto(%s): %v", productoID, err)
+	}
+	return prod
+}
+
+// registrarProductor guarda un productor activo en la zona indicada con el ID indicado
+func (e *escenario) registrarProductor(t testing.TB, id productor.ProductorID, zona string, verificado bool, reputacion float32) *productor.Productor {
+	t.Helper()
+	prod := nuevoProductor(t, id, zona, verificado, reputacion)
+	if err := e.productorRepo.Save(prod); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	return prod
+}
+
+// nuevoProductor crea, sin guardarlo y sin eventos pendientes, un productor activo en la zona indicada
+func nuevoProductor(t testing.TB, id productor.ProductorID, zona string, verificado bool, reputacion float32) *productor.Productor {
+	t.Helper()
+	nombre, err := productor.NewNombreProducto("Productor " + string(id))
+	if err != nil {
+		t.Fatalf("nombre: %v", err)
+	}
+	ubicacion, err := productor.NewUbicacion(zona, "Finca "+string(id))
+	if err != nil {
+		t.Fatalf("ubicacion: %v", err)
+	}
+	estadoVerificacion := productor.EstadoVerificacion{Value: productor.NoVerificado}
+	if verificado {
+		estadoVerificacion.Value = productor.Verificado
+	}
+	rep, err := productor.NuevaReputacion(reputacion)
+	if err != nil {
+		t.Fatalf("reputacion: %v", err)
+	}
+	practicas, err := productor.NuevaPracticasDeCultivo("Abonos orgánicos")
+	if err != nil {
+		t.Fatalf("practicas: %v", err)
+	}
+
+	prod, err := productor.NewProductor(id, nombre, ubicacion, estadoVerificacion,
+		productor.EstadoActividad{Value: productor.Activo}, rep, practicas)
+	if err != nil {
+		t.Fatalf("NewProductor: %v", err)
+	}
+	prod.ClearEvents()
+	return prod
+}
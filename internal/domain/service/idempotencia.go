@@ -0,0 +1,83 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+)
+
+// ErrIdempotenciaConflicto indica que una Idempotency-Key ya se usó con otro contenido
+var ErrIdempotenciaConflicto = errors.New("la clave de idempotencia ya se usó con una petición distinta")
+
+// RegistroIdempotencia recuerda qué producto creó la primera petición con una clave
+type RegistroIdempotencia struct {
+	Clave      string
+	Huella     string // resumen del contenido de la petición original
+	ProductoID producto.ProductoID
+	CreadoEn   time.Time
+}
+
+// IdempotenciaRepositoryInterface guarda los registros de idempotencia. Las implementaciones
+// descartan los registros vencidos: Get no los encuentra pasado su tiempo de vida.
+type IdempotenciaRepositoryInterface interface {
+	Save(registro RegistroIdempotencia) error
+	Get(clave string) (registro RegistroIdempotencia, encontrado bool, err error)
+}
+
+func claveIdempotencia(clave string) string {
+	return "idempotencia:" + clave
+}
+
+// PublicarConIdempotencia ejecuta publicar solo la primera vez que llega una clave. Un reintento
+// con la misma clave y la misma huella retorna el producto creado originalmente con repetido=true;
+// con otra huella retorna ErrIdempotenciaConflicto. Sin clave, o sin WithIdempotenciaRepository,
+// simplemente publica.
+//
+// Dos reintentos simultáneos con la misma clave se serializan, de modo que solo uno publica.
+// Una publicación fallida no consume la clave: el cliente puede reintentar con ella.
+func (s *CatalogoService) PublicarConIdempotencia(
+	clave string,
+	huella string,
+	publicar func() (*producto.ProductoAgroecologico, error),
+) (prod *producto.ProductoAgroecologico, repetido bool, err error) {
+	if clave == "" || s.idempotenciaRepo == nil {
+		prod, err = publicar()
+		return prod, false, err
+	}
+
+	// Bloqueo propio y no el de los agregados: publicar toma los de productor y producto,
+	// y una franja compartida con ellos podría bloquearse a sí misma
+	defer s.bloqueosIdempotencia.bloquear(claveIdempotencia(clave))()
+
+	registro, encontrado, err := s.idempotenciaRepo.Get(clave)
+	if err != nil {
+		return nil, false, err
+	}
+	if encontrado {
+		if registro.Huella != huella {
+			return nil, false, ErrIdempotenciaConflicto
+		}
+		original, err := s.productoRepo.GetByID(registro.ProductoID)
+		if err != nil {
+			return nil, false, errorProducto(err)
+		}
+		return original, true, nil
+	}
+
+	prod, err = publicar()
+	if err != nil {
+		return nil, false, err
+	}
+
+	if err := s.idempotenciaRepo.Save(RegistroIdempotencia{
+		Clave:      clave,
+		Huella:     huella,
+		ProductoID: prod.ID,
+		CreadoEn:   time.Now(),
+	}); err != nil {
+		// El producto ya se creó; solo se pierde la protección frente a un reintento
+		s.logger.Warn("no se pudo guardar la clave de idempotencia", "producto_id", prod.ID, "error", err)
+	}
+	return prod, false, nil
+}
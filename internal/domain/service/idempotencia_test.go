@@ -0,0 +1,106 @@
+package service_test
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+	"Product_Catalog_Microservice/internal/domain/service"
+	"Product_Catalog_Microservice/internal/repository"
+)
+
+// publicarIdempotente publica p-{i} con la clave y la huella dadas. La publicación se demora
+// para que los reintentos simultáneos lleguen mientras la primera sigue en curso.
+func (e *escenario) publicarIdempotente(t *testing.T, clave, huella string, i int) (*producto.ProductoAgroecologico, bool, error) {
+	return e.catalogo.PublicarConIdempotencia(clave, huella, func() (*producto.ProductoAgroecologico, error) {
+		time.Sleep(time.Millisecond)
+		id := producto.ProductoID(fmt.Sprintf("p-%d", i))
+		return e.publicar(productorSemilla1, id, nuevosDatosProducto(t, "Fresa "+string(id)))
+	})
+}
+
+func TestPublicarConIdempotencia_ReintentosSimultaneosCreanUnSoloProducto(t *testing.T) {
+	e := nuevoEscenario(t, service.WithIdempotenciaRepository(repository.NewIdempotenciaRepository(time.Hour)))
+
+	const intentos = 20
+	var (
+		creados, repetidos atomic.Int64
+		ids                [intentos]producto.ProductoID
+	)
+	concurrentes(intentos, func(i int) {
+		prod, repetido, err := e.publicarIdempotente(t, "clave-1", "huella", i)
+		if err != nil {
+			t.Errorf("intento %d: %v", i, err)
+			return
+		}
+		ids[i] = prod.ID
+		if repetido {
+			repetidos.Add(1)
+		} else {
+			creados.Add(1)
+		}
+	})
+
+	if creados.Load() != 1 || repetidos.Load() != intentos-1 {
+		t.Fatalf("creados = %d, repetidos = %d; se esperaba 1 y %d", creados.Load(), repetidos.Load(), intentos-1)
+	}
+	for i, id := range ids {
+		if id != ids[0] {
+			t.Errorf("intento %d recibió %s, se esperaba el producto original %s", i, id, ids[0])
+		}
+	}
+	if todos, _ := e.productoRepo.GetAll(); len(todos) != 1 {
+		t.Errorf("productos guardados = %d, se esperaba 1", len(todos))
+	}
+	if n := contarEventos[producto.ProductoPublicado](e.eventos); n != 1 {
+		t.Errorf("ProductoPublicado = %d, se esperaba 1", n)
+	}
+}
+
+func TestPublicarConIdempotencia_MismaClaveOtraHuella(t *testing.T) {
+	e := nuevoEscenario(t, service.WithIdempotenciaRepository(repository.NewIdempotenciaRepository(time.Hour)))
+
+	if _, _, err := e.publicarIdempotente(t, "clave-1", "huella-a", 0); err != nil {
+		t.Fatalf("primera publicación: %v", err)
+	}
+	if _, _, err := e.publicarIdempotente(t, "clave-1", "huella-b", 1); !errors.Is(err, service.ErrIdempotenciaConflicto) {
+		t.Errorf("err = %v, se esperaba ErrIdempotenciaConflicto", err)
+	}
+	if todos, _ := e.productoRepo.GetAll(); len(todos) != 1 {
+		t.Errorf("productos guardados = %d, se esperaba 1", len(todos))
+	}
+}
+
+func TestPublicarConIdempotencia_SimultaneosConOtraHuella(t *testing.T) {
+	e := nuevoEscenario(t, service.WithIdempotenciaRepository(repository.NewIdempotenciaRepository(time.Hour)))
+
+	// Todos usan la misma clave, la mitad con otra huella: solo uno crea y los de la otra
+	// huella que lleguen después reciben conflicto, nunca un segundo producto
+	const intentos = 20
+	var creados, conflictos atomic.Int64
+	concurrentes(intentos, func(i int) {
+		huella := "huella-a"
+		if i%2 == 1 {
+			huella = "huella-b"
+		}
+		_, repetido, err := e.publicarIdempotente(t, "clave-1", huella, i)
+		switch {
+		case errors.Is(err, service.ErrIdempotenciaConflicto):
+			conflictos.Add(1)
+		case err != nil:
+			t.Errorf("intento %d: %v", i, err)
+		case !repetido:
+			creados.Add(1)
+		}
+	})
+
+	if creados.Load() != 1 || conflictos.Load() != intentos/2 {
+		t.Errorf("creados = %d, conflictos = %d; se esperaba 1 y %d", creados.Load(), conflictos.Load(), intentos/2)
+	}
+	if todos, _ := e.productoRepo.GetAll(); len(todos) != 1 {
+		t.Errorf("productos guardados = %d, se esperaba 1", len(todos))
+	}
+}
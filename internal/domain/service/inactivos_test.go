@@ -0,0 +1,55 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetProductoresInactivos(t *testing.T) {
+	e := nuevoEscenario(t)
+	if err := e.productorRepo.UpdateUltimaActividad(e.semilla2, time.Now().AddDate(0, 0, -100)); err != nil {
+		t.Fatalf("UpdateUltimaActividad: %v", err)
+	}
+
+	casos := []struct {
+		dias      int
+		inactivos int
+	}{
+		{90, 1},
+		{120, 0},
+	}
+	for _, tc := range casos {
+		inactivos, err := e.catalogo.GetProductoresInactivos(tc.dias)
+		if err != nil {
+			t.Fatalf("GetProductoresInactivos(%d): %v", tc.dias, err)
+		}
+		if len(inactivos) != tc.inactivos {
+			t.Errorf("GetProductoresInactivos(%d) = %d productores, se esperaban %d", tc.dias, len(inactivos), tc.inactivos)
+		}
+		if tc.inactivos == 1 && inactivos[0].ID != e.semilla2 {
+			t.Errorf("inactivo = %s, se esperaba %s", inactivos[0].ID, e.semilla2)
+		}
+	}
+
+	if _, err := e.catalogo.GetProductoresInactivos(0); err == nil {
+		t.Error("se esperaba error con 0 días")
+	}
+}
+
+func TestActualizarReputacionProductor_RenuevaActividad(t *testing.T) {
+	e := nuevoEscenario(t)
+	if err := e.productorRepo.UpdateUltimaActividad(e.semilla2, time.Now().AddDate(0, 0, -100)); err != nil {
+		t.Fatalf("UpdateUltimaActividad: %v", err)
+	}
+
+	if err := e.catalogo.ActualizarReputacionProductor(e.semilla2, 4.0); err != nil {
+		t.Fatalf("ActualizarReputacionProductor: %v", err)
+	}
+	inactivos, err := e.catalogo.GetProductoresInactivos(90)
+	if err != nil {
+		t.Fatalf("GetProductoresInactivos: %v", err)
+	}
+	if len(inactivos) != 0 {
+		t.Errorf("inactivos = %d, se esperaba 0 tras actualizar la reputación", len(inactivos))
+	}
+}
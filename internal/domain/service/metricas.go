@@ -0,0 +1,33 @@
+package service
+
+import "Product_Catalog_Microservice/internal/domain/producto"
+
+// Metricas recibe los cambios de estado de los productos que cuenta CatalogoService, para que
+// los tableros de negocio no tengan que extraerlos de los logs. Las implementaciones deben ser
+// seguras para uso concurrente.
+type Metricas interface {
+	ProductoPublicado()
+	ProductoMarcadoComoExcedente()
+	ProductoAgotado()
+}
+
+// sinMetricas descarta todas las métricas; es el valor por defecto sin WithMetricas
+type sinMetricas struct{}
+
+func (sinMetricas) ProductoPublicado()            {}
+func (sinMetricas) ProductoMarcadoComoExcedente() {}
+func (sinMetricas) ProductoAgotado()              {}
+
+// registrarMetrica cuenta el evento si corresponde a una métrica de negocio. Contar a partir de
+// los eventos cubre todos los caminos que producen el cambio, como agotar los productos de un
+// productor suspendido, y solo cuando el cambio ya se guardó.
+func (s *CatalogoService) registrarMetrica(event any) {
+	switch event.(type) {
+	case producto.ProductoPublicado:
+		s.metricas.ProductoPublicado()
+	case producto.ProductoMarcadoComoExcedente:
+		s.metricas.ProductoMarcadoComoExcedente()
+	case producto.ProductoAgotado:
+		s.metricas.ProductoAgotado()
+	}
+}
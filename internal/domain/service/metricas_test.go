@@ -0,0 +1,64 @@
+package service_test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"Product_Catalog_Microservice/internal/domain/service"
+)
+
+// metricasRegistro cuenta los cambios de estado reportados por el servicio
+type metricasRegistro struct {
+	publicados, excedentes, agotados atomic.Int64
+}
+
+func (m *metricasRegistro) ProductoPublicado()            { m.publicados.Add(1) }
+func (m *metricasRegistro) ProductoMarcadoComoExcedente() { m.excedentes.Add(1) }
+func (m *metricasRegistro) ProductoAgotado()              { m.agotados.Add(1) }
+
+func TestMetricas_CuentanLosCambiosDeEstado(t *testing.T) {
+	metricas := &metricasRegistro{}
+	e := nuevoEscenario(t, service.WithMetricas(metricas))
+	e.publicarValido(t, e.semilla1, "p-1", "Fresa")
+	e.publicarValido(t, e.semilla1, "p-2", "Mora")
+	e.publicarValido(t, e.semilla2, "p-3", "Lulo")
+
+	if _, err := e.catalogo.MarcarProductoComoExcedente("p-1", time.Now()); err != nil {
+		t.Fatalf("MarcarProductoComoExcedente: %v", err)
+	}
+	if _, err := e.catalogo.AgotarProducto("p-3"); err != nil {
+		t.Fatalf("AgotarProducto: %v", err)
+	}
+	// Repetir la transición no produce evento y no se cuenta de nuevo
+	if _, err := e.catalogo.AgotarProducto("p-3"); err != nil {
+		t.Fatalf("AgotarProducto: %v", err)
+	}
+	// La suspensión agota p-1 y p-2 sin pasar por AgotarProducto
+	if _, err := e.catalogo.SuspenderProductor(e.semilla1, "uso de agroquímicos"); err != nil {
+		t.Fatalf("SuspenderProductor: %v", err)
+	}
+
+	if got := metricas.publicados.Load(); got != 3 {
+		t.Errorf("publicados = %d, se esperaba 3", got)
+	}
+	if got := metricas.excedentes.Load(); got != 1 {
+		t.Errorf("excedentes = %d, se esperaba 1", got)
+	}
+	if got := metricas.agotados.Load(); got != 3 {
+		t.Errorf("agotados = %d, se esperaba 3", got)
+	}
+}
+
+// Una publicación rechazada no llega a guardarse y no se cuenta
+func TestMetricas_PublicacionRechazadaNoSeCuenta(t *testing.T) {
+	metricas := &metricasRegistro{}
+	e := nuevoEscenario(t, service.WithMetricas(metricas))
+	e.publicarValido(t, e.semilla1, "p-1", "Fresa")
+	if _, err := e.publicar(e.semilla1, "p-1", nuevosDatosProducto(t, "Mora")); err == nil {
+		t.Fatal("publicar con un ID repetido no falló")
+	}
+	if got := metricas.publicados.Load(); got != 1 {
+		t.Errorf("publicados = %d, se esperaba 1", got)
+	}
+}
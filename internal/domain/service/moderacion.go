@@ -0,0 +1,45 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ErrContenidoNoPermitido indica que el nombre o la descripción de un producto contienen una
+// palabra prohibida por la moderación de contenido
+var ErrContenidoNoPermitido = errors.New("el producto contiene palabras no permitidas")
+
+// ContentModerationConfig define las palabras que no pueden aparecer en el nombre ni en la
+// descripción de un producto. La comparación es por palabra completa y no distingue mayúsculas.
+type ContentModerationConfig struct {
+	PalabrasProhibidas []string
+}
+
+// Revisar retorna ErrContenidoNoPermitido, con la palabra encontrada, si alguno de los textos
+// contiene una palabra prohibida. Sin palabras configuradas no rechaza nada.
+func (c ContentModerationConfig) Revisar(textos ...string) error {
+	if len(c.PalabrasProhibidas) == 0 {
+		return nil
+	}
+
+	prohibidas := make(map[string]bool, len(c.PalabrasProhibidas))
+	for _, p := range c.PalabrasProhibidas {
+		if p = strings.ToLower(strings.TrimSpace(p)); p != "" {
+			prohibidas[p] = true
+		}
+	}
+
+	for _, texto := range textos {
+		palabras := strings.FieldsFunc(strings.ToLower(texto), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		for _, palabra := range palabras {
+			if prohibidas[palabra] {
+				return fmt.Errorf("%w: %q", ErrContenidoNoPermitido, palabra)
+			}
+		}
+	}
+	return nil
+}
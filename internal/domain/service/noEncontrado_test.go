@@ -0,0 +1,125 @@
+package service_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+	"Product_Catalog_Microservice/internal/domain/productor"
+	"Product_Catalog_Microservice/internal/domain/service"
+	"Product_Catalog_Microservice/internal/repository"
+)
+
+// errConexion simula una falla de infraestructura del repositorio, distinta de un registro inexistente
+var errConexion = errors.New("conexión rechazada")
+
+// productoRepoSinConexion falla toda lectura por ID con errConexion
+type productoRepoSinConexion struct {
+	*repository.ProductoRepository
+}
+
+func (productoRepoSinConexion) GetByID(producto.ProductoID) (*producto.ProductoAgroecologico, error) {
+	return nil, errConexion
+}
+
+// productorRepoSinConexion falla toda lectura por ID con errConexion
+type productorRepoSinConexion struct {
+	*repository.ProductorRepository
+}
+
+func (productorRepoSinConexion) GetByID(productor.ProductorID) (*productor.Productor, error) {
+	return nil, errConexion
+}
+
+// operacionPorID es una operación del servicio que lee el agregado id y el error del servicio
+// que corresponde cuando ese agregado no existe
+type operacionPorID struct {
+	nombre       string
+	id           string
+	noEncontrado error
+	llamar       func(*service.CatalogoService) error
+}
+
+func operacionesPorID(t *testing.T) []operacionPorID {
+	return []operacionPorID{
+		{"GetProducto", "p-1", service.ErrProductoNoEncontrado, func(c *service.CatalogoService) error {
+			_, err := c.GetProducto("p-1")
+			return err
+		}},
+		{"AgotarProducto", "p-1", service.ErrProductoNoEncontrado, func(c *service.CatalogoService) error {
+			_, err := c.AgotarProducto("p-1")
+			return err
+		}},
+		{"MarcarProductoComoExcedente", "p-1", service.ErrProductoNoEncontrado, func(c *service.CatalogoService) error {
+			_, err := c.MarcarProductoComoExcedente("p-1", time.Now())
+			return err
+		}},
+		{"GetProductor", "x-1", service.ErrProductorNoEncontrado, func(c *service.CatalogoService) error {
+			_, err := c.GetProductor("x-1")
+			return err
+		}},
+		{"GetProductosByProductor", "x-1", service.ErrProductorNoEncontrado, func(c *service.CatalogoService) error {
+			_, err := c.GetProductosByProductor("x-1")
+			return err
+		}},
+		{"IniciarVerificacionProductor", "x-1", service.ErrProductorNoEncontrado, func(c *service.CatalogoService) error {
+			return c.IniciarVerificacionProductor("x-1")
+		}},
+		{"SuspenderProductor", "x-1", service.ErrProductorNoEncontrado, func(c *service.CatalogoService) error {
+			_, err := c.SuspenderProductor("x-1", "prueba")
+			return err
+		}},
+		{"ReactivarProductor", "x-1", service.ErrProductorNoEncontrado, func(c *service.CatalogoService) error {
+			_, err := c.ReactivarProductor("x-1")
+			return err
+		}},
+		{"DecrementarStock", "p-1", service.ErrProductoNoEncontrado, func(c *service.CatalogoService) error {
+			return c.DecrementarStock("p-1", 1)
+		}},
+		{"PublicarProducto", "x-1", service.ErrProductorNoEncontrado, func(c *service.CatalogoService) error {
+			d := nuevosDatosProducto(t, "Fresa")
+			_, err := c.PublicarProducto(t.Context(), "x-1", "p-1", d.nombre, d.desc, d.categoria, d.tipo,
+				d.temporada, d.ubicacion, d.imagen, d.precio, d.cantidad, 0)
+			return err
+		}},
+	}
+}
+
+func TestNoEncontrado_SeTraduceAlErrorDelServicio(t *testing.T) {
+	for _, op := range operacionesPorID(t) {
+		t.Run(op.nombre, func(t *testing.T) {
+			e := nuevoEscenario(t)
+
+			err := op.llamar(e.catalogo)
+			if !errors.Is(err, op.noEncontrado) {
+				t.Fatalf("err = %v, se esperaba %v", err, op.noEncontrado)
+			}
+			// El detalle del repositorio se conserva en el mensaje
+			if !strings.Contains(err.Error(), op.id) {
+				t.Errorf("err = %q, se esperaba el ID %s en el detalle", err, op.id)
+			}
+		})
+	}
+}
+
+func TestNoEncontrado_OtrasFallasDelRepositorioPasanSinTraducir(t *testing.T) {
+	for _, op := range operacionesPorID(t) {
+		t.Run(op.nombre, func(t *testing.T) {
+			catalogo := service.NewCatalogoService(
+				productorRepoSinConexion{repository.NewProductorRepository(0)},
+				productoRepoSinConexion{repository.NewProductoRepository(0)},
+				&publicadorRegistro{},
+			)
+
+			err := op.llamar(catalogo)
+			if !errors.Is(err, errConexion) {
+				t.Errorf("err = %v, se esperaba la falla original del repositorio", err)
+			}
+			if errors.Is(err, service.ErrProductoNoEncontrado) || errors.Is(err, service.ErrProductorNoEncontrado) {
+				t.Errorf("err = %v, una falla de conexión no es un registro inexistente", err)
+			}
+		})
+	}
+}
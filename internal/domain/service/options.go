@@ -0,0 +1,148 @@
+package service
+
+import (
+	"log/slog"
+	"time"
+
+	"Product_Catalog_Microservice/internal/domain/productor"
+)
+
+// CatalogoServiceOption configura dependencias opcionales de CatalogoService
+type CatalogoServiceOption func(*CatalogoService)
+
+// WithLogger define el logger usado para reportar errores que no se devuelven al llamador,
+// como las fallas al publicar eventos. Por defecto se usa slog.Default().
+func WithLogger(l *slog.Logger) CatalogoServiceOption {
+	return func(s *CatalogoService) {
+		if l != nil {
+			s.logger = l
+		}
+	}
+}
+
+// WithCatalogoCacheTTL define cuánto tiempo se reutilizan las vistas agregadas del catálogo
+// (p. ej. el resumen por zona) antes de recalcularlas. Por defecto son 10 minutos.
+func WithCatalogoCacheTTL(d time.Duration) CatalogoServiceOption {
+	return func(s *CatalogoService) {
+		if d > 0 {
+			s.cacheTTL = d
+		}
+	}
+}
+
+// WithEventStore guarda cada evento de dominio en es antes de publicarlo. Una falla al guardar
+// se registra en el logger y no impide la publicación. Por defecto no se guardan eventos.
+func WithEventStore(es EventStore) CatalogoServiceOption {
+	return func(s *CatalogoService) {
+		s.eventStore = es
+	}
+}
+
+// WithMaxProductosPorProductor limita cuántos productos puede tener publicados un productor;
+// al superarlo PublicarProducto retorna ErrLimiteProductosAlcanzado. Con n <= 0 no hay límite,
+// que es el comportamiento por defecto.
+func WithMaxProductosPorProductor(n int) CatalogoServiceOption {
+	return func(s *CatalogoService) {
+		s.maxProductosPorProductor = max(n, 0)
+	}
+}
+
+// WithContentModeration rechaza con ErrContenidoNoPermitido la publicación o actualización de
+// productos cuyo nombre o descripción contienen alguna de las palabras prohibidas de cfg.
+// Por defecto no se modera el contenido.
+func WithContentModeration(cfg ContentModerationConfig) CatalogoServiceOption {
+	return func(s *CatalogoService) {
+		s.moderacion = cfg
+	}
+}
+
+// WithPurchaseHistoryClient usa c para desempatar por cantidad de compras los resultados de
+// búsqueda con la misma relevancia. Por defecto se desempata solo por nombre.
+func WithPurchaseHistoryClient(c PurchaseHistoryClient) CatalogoServiceOption {
+	return func(s *CatalogoService) {
+		s.historialCompras = c
+	}
+}
+
+// WithCatalogoEstricto hace que GetCatalogoCompleto falle si no puede obtener los productores,
+// en lugar de responder solo con los productos marcados como degradados.
+func WithCatalogoEstricto(estricto bool) CatalogoServiceOption {
+	return func(s *CatalogoService) {
+		s.catalogoEstricto = estricto
+	}
+}
+
+// WithAlDegradar registra una función que se invoca con el motivo cada vez que el servicio
+// responde degradado, p. ej. para contarlo en una métrica. Por defecto solo se registra en el logger.
+func WithAlDegradar(f func(motivo string)) CatalogoServiceOption {
+	return func(s *CatalogoService) {
+		s.alDegradar = f
+	}
+}
+
+// WithMetricas define dónde se cuentan los productos publicados, marcados como excedente y
+// agotados. Por defecto no se cuentan.
+func WithMetricas(m Metricas) CatalogoServiceOption {
+	return func(s *CatalogoService) {
+		if m != nil {
+			s.metricas = m
+		}
+	}
+}
+
+// WithRechazoRepository habilita el registro de publicaciones rechazadas por reglas de negocio.
+// Sin esta opción los rechazos no se guardan.
+func WithRechazoRepository(repo productor.RechazoRepositoryInterface) CatalogoServiceOption {
+	return func(s *CatalogoService) {
+		s.rechazoRepo = repo
+	}
+}
+
+// WithIdempotenciaRepository habilita PublicarConIdempotencia. Sin esta opción la
+// Idempotency-Key se ignora y cada petición publica un producto nuevo.
+func WithIdempotenciaRepository(repo IdempotenciaRepositoryInterface) CatalogoServiceOption {
+	return func(s *CatalogoService) {
+		s.idempotenciaRepo = repo
+	}
+}
+
+// Transiciones de estado que pueden configurarse como estrictas
+const (
+	TransicionAgotar    = "agotar"
+	TransicionExcedente = "excedente"
+	TransicionReactivar = "reactivar"
+	TransicionSuspender = "suspender"
+	TransicionVerificar = "verificar"
+)
+
+// WithTransicionesEstrictas hace que las transiciones indicadas fallen con ErrSinCambios cuando el
+// agregado ya está en el estado destino. Por defecto todas son idempotentes: repetir la transición
+// es un éxito sin cambios y sin eventos duplicados.
+func WithTransicionesEstrictas(transiciones ...string) CatalogoServiceOption {
+	return func(s *CatalogoService) {
+		if s.transicionesEstrictas == nil {
+			s.transicionesEstrictas = make(map[string]bool)
+		}
+		for _, t := range transiciones {
+			s.transicionesEstrictas[t] = true
+		}
+	}
+}
+
+// WithVistasCatalogo registra las vistas con nombre del catálogo. NewCatalogoService las valida
+// después de aplicar todas las opciones: las vistas con valores inválidos se descartan y se
+// reportan en el logger configurado, sin importar el orden de WithLogger.
+func WithVistasCatalogo(vistas []VistaCatalogo) CatalogoServiceOption {
+	return func(s *CatalogoService) {
+		s.vistas.configuradas = append(s.vistas.configuradas, vistas...)
+	}
+}
+
+// WithVerificacionEventosPendientes registra una función que se invoca cuando el repositorio
+// entrega un agregado con eventos sin publicar, señal de que algún camino lo mutó sin publicar.
+// Pensada para pruebas; por defecto no se verifica nada.
+func WithVerificacionEventosPendientes(f func(agregado any, pendientes int)) CatalogoServiceOption {
+	return func(s *CatalogoService) {
+		s.alCargarConEventos = f
+	}
+}
@@ -0,0 +1,264 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+	"Product_Catalog_Microservice/internal/domain/service"
+	"Product_Catalog_Microservice/internal/repository"
+)
+
+// publicadorFallido rechaza todos los eventos
+type publicadorFallido struct{}
+
+func (publicadorFallido) Publish(event any) error {
+	return errors.New("broker caído")
+}
+
+// almacenEventos guarda los eventos recibidos, o falla si se le indica
+type almacenEventos struct {
+	mu      sync.Mutex
+	eventos []any
+	fallar  bool
+}
+
+func (a *almacenEventos) Append(event any) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.fallar {
+		return errors.New("disco lleno")
+	}
+	a.eventos = append(a.eventos, event)
+	return nil
+}
+
+// historialFijo retorna compras fijas por producto, o un error si se le indica
+type historialFijo struct {
+	compras map[producto.ProductoID]int
+	err     error
+}
+
+func (h historialFijo) ComprasPorProducto(ids []producto.ProductoID) (map[producto.ProductoID]int, error) {
+	return h.compras, h.err
+}
+
+func TestNewCatalogoService_SinOpciones(t *testing.T) {
+	e := nuevoEscenario(t)
+
+	// Sin opciones no hay límite de productos ni moderación de contenido
+	for _, nombre := range []string{"Fresa", "Mora", "Lulo", "Uchuva"} {
+		e.publicarValido(t, e.semilla1, producto.ProductoID("p-"+strings.ToLower(nombre)), nombre)
+	}
+	if _, err := e.publicar(e.semilla1, "p-5", nuevosDatosProducto(t, "Fresa")); !errors.Is(err, service.ErrNombreDuplicado) {
+		t.Fatalf("err = %v, se esperaba ErrNombreDuplicado", err)
+	}
+}
+
+func TestWithLogger(t *testing.T) {
+	var salida bytes.Buffer
+	productorRepo := repository.NewProductorRepository(0)
+	catalogo := service.NewCatalogoService(
+		productorRepo,
+		repository.NewProductoRepository(0),
+		publicadorFallido{},
+		service.WithLogger(slog.New(slog.NewJSONHandler(&salida, nil))),
+	)
+
+	// La falla al publicar no se devuelve al llamador: queda en el logger configurado
+	d := nuevosDatosProducto(t, "Fresa")
+	_, err := catalogo.PublicarProducto(context.Background(), productorSemilla1, "p-1", d.nombre, d.desc,
+		d.categoria, d.tipo, d.temporada, d.ubicacion, d.imagen, d.precio, d.cantidad, 0)
+	if err != nil {
+		t.Fatalf("PublicarProducto: %v", err)
+	}
+	if !strings.Contains(salida.String(), "no se pudo publicar el evento de dominio") ||
+		!strings.Contains(salida.String(), "broker caído") {
+		t.Errorf("el logger no registró la falla de publicación: %s", salida.String())
+	}
+}
+
+func TestWithLogger_NilConservaElPorDefecto(t *testing.T) {
+	productorRepo := repository.NewProductorRepository(0)
+	catalogo := service.NewCatalogoService(
+		productorRepo,
+		repository.NewProductoRepository(0),
+		&publicadorRegistro{},
+		service.WithLogger(nil),
+	)
+	d := nuevosDatosProducto(t, "Fresa")
+	if _, err := catalogo.PublicarProducto(context.Background(), productorSemilla1, "p-1", d.nombre, d.desc,
+		d.categoria, d.tipo, d.temporada, d.ubicacion, d.imagen, d.precio, d.cantidad, 0); err != nil {
+		t.Fatalf("PublicarProducto: %v", err)
+	}
+}
+
+func TestWithCatalogoCacheTTL_NoPositivoConservaElPorDefecto(t *testing.T) {
+	e := nuevoEscenario(t, service.WithCatalogoCacheTTL(0), service.WithCatalogoCacheTTL(-1))
+	e.publicarValido(t, e.semilla1, "p-1", "Fresa")
+
+	if _, err := e.catalogo.GetResumenCatalogoPorZona(); err != nil {
+		t.Fatalf("GetResumenCatalogoPorZona: %v", err)
+	}
+	e.publicarValido(t, e.semilla1, "p-2", "Mora")
+
+	// Con el TTL por defecto (10 minutos) el segundo resumen sale de caché
+	resumen, err := e.catalogo.GetResumenCatalogoPorZona()
+	if err != nil {
+		t.Fatalf("GetResumenCatalogoPorZona: %v", err)
+	}
+	if resumen[0].TotalProductos != 1 {
+		t.Errorf("TotalProductos = %d, se esperaba 1 desde la caché", resumen[0].TotalProductos)
+	}
+}
+
+func TestWithEventStore(t *testing.T) {
+	almacen := &almacenEventos{}
+	e := nuevoEscenario(t, service.WithEventStore(almacen))
+	e.publicarValido(t, e.semilla1, "p-1", "Fresa")
+
+	if len(almacen.eventos) != 1 {
+		t.Fatalf("eventos guardados = %d, se esperaba 1", len(almacen.eventos))
+	}
+	if _, ok := almacen.eventos[0].(producto.ProductoPublicado); !ok {
+		t.Errorf("evento guardado = %T, se esperaba ProductoPublicado", almacen.eventos[0])
+	}
+	if n := contarEventos[producto.ProductoPublicado](e.eventos); n != 1 {
+		t.Errorf("ProductoPublicado publicados = %d, se esperaba 1", n)
+	}
+}
+
+func TestWithEventStore_FallaNoImpidePublicar(t *testing.T) {
+	var salida bytes.Buffer
+	e := nuevoEscenario(t,
+		service.WithEventStore(&almacenEventos{fallar: true}),
+		service.WithLogger(slog.New(slog.NewJSONHandler(&salida, nil))),
+	)
+	e.publicarValido(t, e.semilla1, "p-1", "Fresa")
+
+	if n := contarEventos[producto.ProductoPublicado](e.eventos); n != 1 {
+		t.Errorf("ProductoPublicado publicados = %d, se esperaba 1", n)
+	}
+	if !strings.Contains(salida.String(), "no se pudo guardar el evento de dominio") {
+		t.Errorf("el logger no registró la falla del almacén: %s", salida.String())
+	}
+}
+
+func TestWithMaxProductosPorProductor(t *testing.T) {
+	e := nuevoEscenario(t, service.WithMaxProductosPorProductor(2))
+	e.publicarValido(t, e.semilla1, "p-1", "Fresa")
+	e.publicarValido(t, e.semilla1, "p-2", "Mora")
+
+	if _, err := e.publicar(e.semilla1, "p-3", nuevosDatosProducto(t, "Lulo")); !errors.Is(err, service.ErrLimiteProductosAlcanzado) {
+		t.Fatalf("err = %v, se esperaba ErrLimiteProductosAlcanzado", err)
+	}
+	if _, err := e.productoRepo.GetByID("p-3"); err == nil {
+		t.Error("el producto rechazado no debía guardarse")
+	}
+
+	// El límite es por productor
+	e.publicarValido(t, e.semilla2, "p-4", "Lulo")
+}
+
+func TestWithMaxProductosPorProductor_NoPositivoSinLimite(t *testing.T) {
+	e := nuevoEscenario(t, service.WithMaxProductosPorProductor(0))
+	e.publicarValido(t, e.semilla1, "p-1", "Fresa")
+	e.publicarValido(t, e.semilla1, "p-2", "Mora")
+}
+
+func TestWithContentModeration(t *testing.T) {
+	e := nuevoEscenario(t, service.WithContentModeration(service.ContentModerationConfig{
+		PalabrasProhibidas: []string{"milagroso", " Transgénico "},
+	}))
+
+	casos := []struct {
+		nombre      string
+		producto    string
+		descripcion string
+		prohibido   bool
+	}{
+		{"nombre prohibido", "Tomate Milagroso", "Cosecha fresca", true},
+		{"descripción prohibida sin distinguir mayúsculas", "Maíz", "Maíz TRANSGÉNICO de la región", true},
+		{"palabra dentro de otra no cuenta", "Milagrosos", "Cosecha fresca", false},
+		{"texto limpio", "Fresa", "Cosecha fresca", false},
+	}
+	for i, tc := range casos {
+		t.Run(tc.nombre, func(t *testing.T) {
+			d := nuevosDatosProducto(t, tc.producto)
+			var err error
+			if d.desc, err = producto.NewDescripcionProducto(tc.descripcion); err != nil {
+				t.Fatalf("descripcion: %v", err)
+			}
+			_, err = e.publicar(e.semilla1, producto.ProductoID("p-"+string(rune('a'+i))), d)
+			if got := errors.Is(err, service.ErrContenidoNoPermitido); got != tc.prohibido {
+				t.Errorf("err = %v, prohibido = %v, se esperaba %v", err, got, tc.prohibido)
+			}
+		})
+	}
+}
+
+func TestWithContentModeration_Actualizacion(t *testing.T) {
+	e := nuevoEscenario(t, service.WithContentModeration(service.ContentModerationConfig{
+		PalabrasProhibidas: []string{"milagroso"},
+	}))
+	e.publicarValido(t, e.semilla1, "p-1", "Fresa")
+
+	d := nuevosDatosProducto(t, "Fresa")
+	desc, err := producto.NewDescripcionProducto("Fresa milagroso para todo")
+	if err != nil {
+		t.Fatalf("descripcion: %v", err)
+	}
+	_, err = e.catalogo.ActualizarInformacionProducto("p-1", d.nombre, desc, d.imagen)
+	if !errors.Is(err, service.ErrContenidoNoPermitido) {
+		t.Fatalf("err = %v, se esperaba ErrContenidoNoPermitido", err)
+	}
+	guardado, err := e.productoRepo.GetByID("p-1")
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if guardado.Descripcion.Value == desc.Value {
+		t.Error("la descripción rechazada no debía guardarse")
+	}
+}
+
+func TestWithPurchaseHistoryClient(t *testing.T) {
+	casos := []struct {
+		nombre   string
+		opts     []service.CatalogoServiceOption
+		esperado []producto.ProductoID
+	}{
+		{"sin cliente desempata por nombre", nil, []producto.ProductoID{"p-fresa", "p-mora"}},
+		{"con cliente desempata por compras", []service.CatalogoServiceOption{
+			service.WithPurchaseHistoryClient(historialFijo{compras: map[producto.ProductoID]int{"p-mora": 5, "p-fresa": 1}}),
+		}, []producto.ProductoID{"p-mora", "p-fresa"}},
+		{"si el cliente falla desempata por nombre", []service.CatalogoServiceOption{
+			service.WithPurchaseHistoryClient(historialFijo{err: errors.New("timeout")}),
+			service.WithLogger(slog.New(slog.DiscardHandler)),
+		}, []producto.ProductoID{"p-fresa", "p-mora"}},
+	}
+	for _, tc := range casos {
+		t.Run(tc.nombre, func(t *testing.T) {
+			e := nuevoEscenario(t, tc.opts...)
+			e.publicarValido(t, e.semilla1, "p-mora", "Mora")
+			e.publicarValido(t, e.semilla1, "p-fresa", "Fresa")
+
+			// Ambos están en temporada, así que con la consulta vacía empatan en relevancia
+			resultados, err := e.catalogo.BuscarProductosConFiltroAvanzado("", producto.ProductoFiltro{})
+			if err != nil {
+				t.Fatalf("BuscarProductosConFiltroAvanzado: %v", err)
+			}
+			var ids []producto.ProductoID
+			for _, r := range resultados {
+				ids = append(ids, r.Producto.ID)
+			}
+			if len(ids) != len(tc.esperado) || ids[0] != tc.esperado[0] || ids[1] != tc.esperado[1] {
+				t.Errorf("orden = %v, se esperaba %v", ids, tc.esperado)
+			}
+		})
+	}
+}
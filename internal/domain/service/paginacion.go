@@ -0,0 +1,155 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+	"Product_Catalog_Microservice/internal/domain/productor"
+	"Product_Catalog_Microservice/internal/domain/shared"
+)
+
+// CatalogoCompletoPaginado es una página del catálogo completo. Productos y productores se
+// paginan por separado con los mismos parámetros, cada uno con su propio total.
+type CatalogoCompletoPaginado struct {
+	Productos   shared.PagedResult[*producto.ProductoAgroecologico]
+	Productores shared.PagedResult[*productor.Productor]
+	GeneradoEn  time.Time
+
+	// Metadatos de la página, repetidos al nivel superior para los clientes que no leen cada listado
+	TotalProductos   int `json:"total_productos"`
+	TotalProductores int `json:"total_productores"`
+	Page             int `json:"page"`
+
+	Degradado         bool
+	MotivoDegradacion string `json:",omitempty"`
+}
+
+// GetCatalogoCompletoPaginado retorna la página indicada del catálogo completo. La paginación
+// se hace en los repositorios, con productos y productores ordenados por ID para que las
+// páginas sean estables entre peticiones. Se degrada igual que GetCatalogoCompleto.
+func (s *CatalogoService) GetCatalogoCompletoPaginado(params shared.PaginationParams) (*CatalogoCompletoPaginado, error) {
+	params = shared.NewPaginationParams(params.Page, params.PageSize)
+
+	productos, err := s.productoRepo.GetAvailableProductsPaginated(params)
+	if err != nil {
+		return nil, err
+	}
+
+	pagina := &CatalogoCompletoPaginado{
+		Productos:      productos,
+		GeneradoEn:     time.Now(),
+		TotalProductos: productos.TotalCount,
+		Page:           params.Page,
+	}
+
+	productores, err := s.productorRepo.GetVerificadosPaginated(params)
+	if err != nil {
+		if s.catalogoEstricto {
+			return nil, err
+		}
+		s.logger.Warn("catálogo completo degradado", "motivo", MotivoProductoresNoDisponibles, "error", err)
+		if s.alDegradar != nil {
+			s.alDegradar(MotivoProductoresNoDisponibles)
+		}
+		pagina.Productores = shared.PagedResult[*productor.Productor]{
+			Items:    make([]*productor.Productor, 0),
+			Page:     params.Page,
+			PageSize: params.PageSize,
+		}
+		pagina.Degradado = true
+		pagina.MotivoDegradacion = MotivoProductoresNoDisponibles
+		return pagina, nil
+	}
+
+	pagina.Productores = productores
+	pagina.TotalProductores = productores.TotalCount
+	return pagina, nil
+}
+
+// VigenciaCursorProductos es el tiempo durante el que se acepta un cursor de
+// GetProductosDesdeCursor desde que se emitió
+const VigenciaCursorProductos = 24 * time.Hour
+
+// ErrCursorExpirado indica un cursor de productos emitido hace más de VigenciaCursorProductos
+var ErrCursorExpirado = errors.New("cursor expirado; pida de nuevo la primera página sin cursor")
+
+// PaginaProductos es una página de productos recorridos por cursor. SiguienteCursor permite
+// pedir la página siguiente; vacío si no hay más.
+type PaginaProductos struct {
+	Productos       []*producto.ProductoAgroecologico
+	Limite          int
+	SiguienteCursor string
+}
+
+// cursorProductos es el contenido del cursor opaco de GetProductosDesdeCursor
+type cursorProductos struct {
+	PublicadoEn time.Time           `json:"p"`
+	ID          producto.ProductoID `json:"id"`
+	EmitidoEn   int64               `json:"e"`
+}
+
+// GetProductosDesdeCursor retorna hasta limite productos que cumplen el filtro, posteriores al
+// cursor de una respuesta anterior en el orden de publicación; un cursor vacío empieza desde el
+// primero. A diferencia de la paginación por offset, los productos publicados entre una página
+// y la siguiente no provocan repetidos ni omisiones. El límite se normaliza como el tamaño de
+// página de shared.NewPaginationParams.
+func (s *CatalogoService) GetProductosDesdeCursor(filtro producto.ProductoFiltro, cursor string, limite int) (PaginaProductos, error) {
+	desde, err := decodificarCursorProductos(cursor, time.Now())
+	if err != nil {
+		return PaginaProductos{}, err
+	}
+	limite = shared.NewPaginationParams(1, limite).PageSize
+
+	// Se pide uno más del límite para saber si hay otra página. El filtro se aplica sobre los
+	// lotes del repositorio, así que se siguen pidiendo lotes hasta completar la página.
+	productos := make([]*producto.ProductoAgroecologico, 0, limite+1)
+	for len(productos) <= limite {
+		lote, err := s.productoRepo.GetPageAfter(desde, limite+1)
+		if err != nil {
+			return PaginaProductos{}, err
+		}
+		for _, prod := range lote {
+			if filtro.Cumple(prod) && len(productos) <= limite {
+				productos = append(productos, prod)
+			}
+		}
+		if len(lote) < limite+1 {
+			break
+		}
+		ultimo := producto.CursorDe(lote[len(lote)-1])
+		desde = &ultimo
+	}
+
+	pagina := PaginaProductos{Productos: productos, Limite: limite}
+	if len(productos) > limite {
+		pagina.Productos = productos[:limite]
+		pagina.SiguienteCursor = codificarCursorProductos(producto.CursorDe(productos[limite-1]), time.Now())
+	}
+	return pagina, nil
+}
+
+func codificarCursorProductos(c producto.CursorProducto, emitidoEn time.Time) string {
+	data, _ := json.Marshal(cursorProductos{PublicadoEn: c.PublicadoEn, ID: c.ID, EmitidoEn: emitidoEn.Unix()})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodificarCursorProductos(cursor string, ahora time.Time) (*producto.CursorProducto, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrCursorInvalido
+	}
+	var contenido cursorProductos
+	if err := json.Unmarshal(data, &contenido); err != nil || contenido.ID == "" || contenido.EmitidoEn == 0 {
+		return nil, ErrCursorInvalido
+	}
+	if ahora.Sub(time.Unix(contenido.EmitidoEn, 0)) > VigenciaCursorProductos {
+		return nil, ErrCursorExpirado
+	}
+	return &producto.CursorProducto{PublicadoEn: contenido.PublicadoEn, ID: contenido.ID}, nil
+}
@@ -0,0 +1,112 @@
+package service_test
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+	"Product_Catalog_Microservice/internal/domain/service"
+)
+
+// recorrerPorCursor pide páginas de limite productos hasta agotar el cursor. antesDePagina se
+// llama antes de pedir cada página, con su número empezando en 0.
+func recorrerPorCursor(t *testing.T, e *escenario, filtro producto.ProductoFiltro, limite int, antesDePagina func(int)) []producto.ProductoID {
+	t.Helper()
+	var (
+		ids    []producto.ProductoID
+		cursor string
+	)
+	for pagina := 0; pagina < 20; pagina++ {
+		antesDePagina(pagina)
+		resultado, err := e.catalogo.GetProductosDesdeCursor(filtro, cursor, limite)
+		if err != nil {
+			t.Fatalf("GetProductosDesdeCursor: %v", err)
+		}
+		if len(resultado.Productos) > limite {
+			t.Fatalf("página %d con %d productos, se esperaban a lo sumo %d", pagina, len(resultado.Productos), limite)
+		}
+		for _, p := range resultado.Productos {
+			ids = append(ids, p.ID)
+		}
+		if resultado.SiguienteCursor == "" {
+			return ids
+		}
+		cursor = resultado.SiguienteCursor
+	}
+	t.Fatal("el cursor no terminó")
+	return nil
+}
+
+// Los productos publicados durante el recorrido no provocan repetidos ni omisiones
+func TestGetProductosDesdeCursor_EstableAnteNuevasPublicaciones(t *testing.T) {
+	e := nuevoEscenario(t)
+	for i := 1; i <= 5; i++ {
+		e.publicarValido(t, e.semilla1, producto.ProductoID(fmt.Sprintf("p-%d", i)), fmt.Sprintf("Fresa %d", i))
+	}
+
+	ids := recorrerPorCursor(t, e, producto.ProductoFiltro{}, 2, func(pagina int) {
+		if pagina == 1 {
+			e.publicarValido(t, e.semilla2, "p-6", "Mora")
+		}
+	})
+	if esperado := []producto.ProductoID{"p-1", "p-2", "p-3", "p-4", "p-5", "p-6"}; !slices.Equal(ids, esperado) {
+		t.Errorf("productos recorridos = %v, se esperaba %v", ids, esperado)
+	}
+}
+
+// El filtro se aplica aunque los productos que lo cumplen estén lejos entre sí
+func TestGetProductosDesdeCursor_ConFiltro(t *testing.T) {
+	e := nuevoEscenario(t)
+	for i := 1; i <= 7; i++ {
+		id := producto.ProductoID(fmt.Sprintf("p-%d", i))
+		e.publicarValido(t, e.semilla1, id, fmt.Sprintf("Fresa %d", i))
+		if i != 1 && i != 6 {
+			if err := e.productoRepo.UpdateEstadoDisponibilidad(id, producto.EstadoDisponibilidad{Value: producto.Agotado}); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	filtro := producto.ProductoFiltro{Estado: producto.EstadoDisponibilidad{Value: producto.Disponible}}
+	ids := recorrerPorCursor(t, e, filtro, 1, func(int) {})
+	if esperado := []producto.ProductoID{"p-1", "p-6"}; !slices.Equal(ids, esperado) {
+		t.Errorf("productos recorridos = %v, se esperaba %v", ids, esperado)
+	}
+
+	pagina, err := e.catalogo.GetProductosDesdeCursor(producto.ProductoFiltro{ZonaVeredal: "Vereda La Cumbre"}, "", 5)
+	if err != nil || len(pagina.Productos) != 0 || pagina.SiguienteCursor != "" {
+		t.Errorf("sin coincidencias = %+v, %v; se esperaba una página vacía y sin cursor", pagina, err)
+	}
+}
+
+func TestGetProductosDesdeCursor_Limite(t *testing.T) {
+	e := nuevoEscenario(t)
+	e.publicarValido(t, e.semilla1, "p-1", "Fresa")
+
+	for limite, esperado := range map[int]int{0: 20, -3: 20, 7: 7, 500: 100} {
+		pagina, err := e.catalogo.GetProductosDesdeCursor(producto.ProductoFiltro{}, "", limite)
+		if err != nil || pagina.Limite != esperado {
+			t.Errorf("límite %d = %d, %v; se esperaba %d", limite, pagina.Limite, err, esperado)
+		}
+	}
+}
+
+func TestGetProductosDesdeCursor_CursorInvalidoOExpirado(t *testing.T) {
+	e := nuevoEscenario(t)
+
+	for _, cursor := range []string{"no-es-un-cursor", base64.RawURLEncoding.EncodeToString([]byte("7")), base64.RawURLEncoding.EncodeToString([]byte(`{"id":""}`))} {
+		if _, err := e.catalogo.GetProductosDesdeCursor(producto.ProductoFiltro{}, cursor, 5); !errors.Is(err, service.ErrCursorInvalido) {
+			t.Errorf("cursor %q: err = %v, se esperaba ErrCursorInvalido", cursor, err)
+		}
+	}
+
+	emitido := time.Now().Add(-service.VigenciaCursorProductos - time.Minute).Unix()
+	viejo := base64.RawURLEncoding.EncodeToString(fmt.Appendf(nil, `{"p":"2025-03-01T08:00:00Z","id":"p-1","e":%d}`, emitido))
+	if _, err := e.catalogo.GetProductosDesdeCursor(producto.ProductoFiltro{}, viejo, 5); !errors.Is(err, service.ErrCursorExpirado) {
+		t.Errorf("cursor vencido: err = %v, se esperaba ErrCursorExpirado", err)
+	}
+}
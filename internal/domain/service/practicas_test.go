@@ -0,0 +1,49 @@
+package service_test
+
+import (
+	"testing"
+
+	"Product_Catalog_Microservice/internal/domain/productor"
+)
+
+func TestGetProductoresPorPractica(t *testing.T) {
+	e := nuevoEscenario(t)
+
+	// semilla1: "Rotación de cultivos y abonos orgánicos"; semilla2: "Uso mínimo de pesticidas"
+	casos := []struct {
+		nombre  string
+		keyword string
+		ids     []productor.ProductorID
+	}{
+		{"palabra clave", "abonos", []productor.ProductorID{e.semilla1}},
+		{"sin distinguir mayúsculas", "PESTICIDAS", []productor.ProductorID{e.semilla2}},
+		{"espacios alrededor", "  rotación  ", []productor.ProductorID{e.semilla1}},
+		{"sin coincidencia", "biodinámica", nil},
+	}
+	for _, tc := range casos {
+		t.Run(tc.nombre, func(t *testing.T) {
+			productores, err := e.catalogo.GetProductoresPorPractica(tc.keyword)
+			if err != nil {
+				t.Fatalf("GetProductoresPorPractica(%q): %v", tc.keyword, err)
+			}
+			if len(productores) != len(tc.ids) {
+				t.Fatalf("se obtuvieron %d productores, se esperaban %v", len(productores), tc.ids)
+			}
+			for i, p := range productores {
+				if p.ID != tc.ids[i] {
+					t.Errorf("productores[%d] = %s, se esperaba %s", i, p.ID, tc.ids[i])
+				}
+			}
+		})
+	}
+}
+
+func TestGetProductoresPorPractica_PalabraCorta(t *testing.T) {
+	e := nuevoEscenario(t)
+
+	for _, kw := range []string{"", "ab", "  ab  ", "ñú"} {
+		if _, err := e.catalogo.GetProductoresPorPractica(kw); err == nil {
+			t.Errorf("GetProductoresPorPractica(%q): se esperaba error", kw)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+	"Product_Catalog_Microservice/internal/domain/productor"
+)
+
+func TestActualizarPreferenciasNotificacion_SeAdjuntanALosEventos(t *testing.T) {
+	e := nuevoEscenario(t)
+	e.publicarValido(t, e.semilla1, "p-1", "Fresa")
+	e.publicarValido(t, e.semilla1, "p-2", "Mora")
+
+	preferencias, err := productor.NuevasPreferenciasNotificacion(map[string]bool{productor.NotificacionAgotado: false})
+	if err != nil {
+		t.Fatalf("NuevasPreferenciasNotificacion: %v", err)
+	}
+	if err := e.catalogo.ActualizarPreferenciasNotificacion(e.semilla1, preferencias); err != nil {
+		t.Fatalf("ActualizarPreferenciasNotificacion: %v", err)
+	}
+	if n := contarEventos[productor.PreferenciasNotificacionActualizadas](e.eventos); n != 1 {
+		t.Errorf("PreferenciasNotificacionActualizadas = %d, se esperaba 1", n)
+	}
+
+	if _, err := e.catalogo.AgotarProducto("p-1"); err != nil {
+		t.Fatalf("AgotarProducto: %v", err)
+	}
+	// Solo se puede marcar como excedente dentro de la temporada
+	if _, err := e.catalogo.MarcarProductoComoExcedente("p-2", time.Now()); err != nil {
+		t.Fatalf("MarcarProductoComoExcedente: %v", err)
+	}
+
+	for _, ev := range e.eventos.Eventos() {
+		var adjuntas map[string]bool
+		switch ev := ev.(type) {
+		case producto.ProductoAgotado:
+			adjuntas = ev.PreferenciasProductor
+		case producto.ProductoMarcadoComoExcedente:
+			adjuntas = ev.PreferenciasProductor
+		default:
+			continue
+		}
+		if adjuntas[productor.NotificacionAgotado] || !adjuntas[productor.NotificacionExcedente] {
+			t.Errorf("%T lleva las preferencias %v, se esperaban las del productor", ev, adjuntas)
+		}
+	}
+}
+
+func TestActualizarPreferenciasNotificacion_ProductorInexistente(t *testing.T) {
+	e := nuevoEscenario(t)
+	if err := e.catalogo.ActualizarPreferenciasNotificacion("no-existe", productor.PreferenciasNotificacionPorDefecto()); err == nil {
+		t.Fatal("se esperaba error por productor inexistente")
+	}
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+	"Product_Catalog_Microservice/internal/domain/shared"
+)
+
+// horizontePronosticoMeses es el máximo de meses hacia adelante que se puede pronosticar
+const horizontePronosticoMeses = 12
+
+// avisoPronostico acompaña cada pronóstico para que el comprador no lo tome como una oferta firme
+const avisoPronostico = "Cifras declaradas por los productores según sus temporadas publicadas; no constituyen un compromiso de entrega."
+
+// ErrHorizonteExcedido indica que el mes solicitado está fuera del horizonte de pronóstico
+var ErrHorizonteExcedido = errors.New("el pronóstico solo cubre hasta 12 meses hacia adelante")
+
+// CantidadPronosticada es la suma de las cantidades declaradas en una misma unidad de medida
+type CantidadPronosticada struct {
+	Unidad string `json:"unidad"`
+	Valor  int    `json:"valor"`
+}
+
+// PronosticoProductor agrupa los productos de un productor cuya temporada cubre el mes, con la
+// suma de sus cantidades por unidad
+type PronosticoProductor struct {
+	ProductorID string                 `json:"productor_id"`
+	Productos   []producto.ProductoID  `json:"productos"`
+	Cantidades  []CantidadPronosticada `json:"cantidades"`
+}
+
+// Pronostico resume la oferta esperada para un mes, categoría y zona
+type Pronostico struct {
+	Mes            string                 `json:"mes"`
+	Categoria      producto.Categoria     `json:"categoria,omitempty"`
+	ZonaVeredal    string                 `json:"zona_veredal,omitempty"`
+	TotalProductos int                    `json:"total_productos"`
+	Cantidades     []CantidadPronosticada `json:"cantidades"`
+	Productores    []PronosticoProductor  `json:"productores"`
+	Aviso          string                 `json:"aviso"`
+}
+
+// GetPronostico retorna los productos cuya temporada se cruza con el mes indicado,
+// agrupados por productor, y suma sus cantidades disponibles por unidad de medida, en total
+// y por productor. Las unidades no se convierten entre sí (kg y g se reportan por separado).
+// Retorna ErrHorizonteExcedido si el mes está a más de 12 meses del mes actual.
+func (s *CatalogoService) GetPronostico(filtro producto.ProductoFiltro, year, month int) (Pronostico, error) {
+	if month < 1 || month > 12 {
+		return Pronostico{}, shared.NewErrorValidacion("mes", "mes inválido")
+	}
+
+	desde := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, producto.ZonaHoraria())
+	hasta := desde.AddDate(0, 1, 0)
+
+	ahora := time.Now().In(producto.ZonaHoraria())
+	mesActual := time.Date(ahora.Year(), ahora.Month(), 1, 0, 0, 0, 0, producto.ZonaHoraria())
+	if desde.After(mesActual.AddDate(0, horizontePronosticoMeses, 0)) {
+		return Pronostico{}, ErrHorizonteExcedido
+	}
+
+	productos, err := s.productoRepo.GetAll()
+	if err != nil {
+		return Pronostico{}, err
+	}
+
+	porProductor := make(map[string][]producto.ProductoID)
+	cantidadesPorProductor := make(map[string]map[string]int)
+	cantidades := make(map[string]int)
+	total := 0
+	for _, prod := range productos {
+		if !filtro.Cumple(prod) {
+			continue
+		}
+		// La temporada se cruza con el mes si empieza antes de que termine y termina después de que empiece
+		if !prod.Temporada.Inicio.Before(hasta) || prod.Temporada.Fin.Before(desde) {
+			continue
+		}
+		porProductor[prod.ProductorID] = append(porProductor[prod.ProductorID], prod.ID)
+		if cantidadesPorProductor[prod.ProductorID] == nil {
+			cantidadesPorProductor[prod.ProductorID] = make(map[string]int)
+		}
+		cantidadesPorProductor[prod.ProductorID][prod.Cantidad.Unidad] += prod.Cantidad.Valor
+		cantidades[prod.Cantidad.Unidad] += prod.Cantidad.Valor
+		total++
+	}
+
+	productores := make([]PronosticoProductor, 0, len(porProductor))
+	for productorID, ids := range porProductor {
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+		productores = append(productores, PronosticoProductor{
+			ProductorID: productorID,
+			Productos:   ids,
+			Cantidades:  cantidadesOrdenadas(cantidadesPorProductor[productorID]),
+		})
+	}
+	sort.Slice(productores, func(i, j int) bool { return productores[i].ProductorID < productores[j].ProductorID })
+
+	return Pronostico{
+		Mes:            desde.Format("2006-01"),
+		Categoria:      filtro.Categoria,
+		ZonaVeredal:    filtro.ZonaVeredal,
+		TotalProductos: total,
+		Cantidades:     cantidadesOrdenadas(cantidades),
+		Productores:    productores,
+		Aviso:          avisoPronostico,
+	}, nil
+}
+
+// cantidadesOrdenadas convierte las sumas por unidad en una lista ordenada por unidad; nunca
+// retorna nil
+func cantidadesOrdenadas(porUnidad map[string]int) []CantidadPronosticada {
+	resultado := make([]CantidadPronosticada, 0, len(porUnidad))
+	for unidad, valor := range porUnidad {
+		resultado = append(resultado, CantidadPronosticada{Unidad: unidad, Valor: valor})
+	}
+	sort.Slice(resultado, func(i, j int) bool { return resultado[i].Unidad < resultado[j].Unidad })
+	return resultado
+}
@@ -0,0 +1,149 @@
+package service_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+	"Product_Catalog_Microservice/internal/domain/productor"
+	"Product_Catalog_Microservice/internal/domain/service"
+)
+
+// publicarEnTemporada publica un producto con temporada entre los días indicados del mes que
+// empieza en mes
+func (e *escenario) publicarEnTemporada(t *testing.T, productorID productor.ProductorID, id producto.ProductoID,
+	categoria producto.Categoria, mes time.Time, diaInicio, diaFin int) {
+	t.Helper()
+	d := nuevosDatosProducto(t, "Producto "+string(id))
+	d.categoria = categoria
+	var err error
+	if d.temporada, err = producto.NewTemporadaLocal(mes.AddDate(0, 0, diaInicio-1), mes.AddDate(0, 0, diaFin-1)); err != nil {
+		t.Fatalf("temporada: %v", err)
+	}
+	if _, err := e.publicar(productorID, id, d); err != nil {
+		t.Fatalf("PublicarProducto(%s): %v", id, err)
+	}
+}
+
+func TestGetPronostico(t *testing.T) {
+	e := nuevoEscenario(t)
+	ahora := time.Now().In(producto.ZonaHoraria())
+	mesActual := time.Date(ahora.Year(), ahora.Month(), 1, 0, 0, 0, 0, producto.ZonaHoraria())
+	dentroDeDos := mesActual.AddDate(0, 2, 0)
+	dentroDeTres := mesActual.AddDate(0, 3, 0)
+
+	// p-2 cruza el cambio de mes y aparece en ambos pronósticos
+	e.publicarEnTemporada(t, e.semilla1, "p-1", producto.CategoriaFruta, dentroDeDos, 1, 10)
+	e.publicarEnTemporada(t, e.semilla2, "p-2", producto.CategoriaFruta, dentroDeDos, 20, 40)
+	e.publicarEnTemporada(t, e.semilla1, "p-3", producto.CategoriaHortaliza, dentroDeDos, 5, 15)
+	e.publicarEnTemporada(t, e.semilla1, "p-4", producto.CategoriaFruta, dentroDeTres, 15, 20)
+
+	frutas := producto.ProductoFiltro{Categoria: producto.CategoriaFruta}
+	casos := []struct {
+		nombre      string
+		mes         time.Time
+		porProducto map[producto.ProductoID]productor.ProductorID
+	}{
+		{"dentro de dos meses", dentroDeDos, map[producto.ProductoID]productor.ProductorID{"p-1": e.semilla1, "p-2": e.semilla2}},
+		{"dentro de tres meses", dentroDeTres, map[producto.ProductoID]productor.ProductorID{"p-2": e.semilla2, "p-4": e.semilla1}},
+		{"sin temporadas", mesActual.AddDate(0, 6, 0), map[producto.ProductoID]productor.ProductorID{}},
+	}
+	for _, tc := range casos {
+		t.Run(tc.nombre, func(t *testing.T) {
+			pronostico, err := e.catalogo.GetPronostico(frutas, tc.mes.Year(), int(tc.mes.Month()))
+			if err != nil {
+				t.Fatalf("GetPronostico: %v", err)
+			}
+			if pronostico.Aviso == "" {
+				t.Error("el pronóstico debe incluir el aviso de cifras declaradas")
+			}
+			if pronostico.TotalProductos != len(tc.porProducto) {
+				t.Errorf("TotalProductos = %d, se esperaba %d", pronostico.TotalProductos, len(tc.porProducto))
+			}
+			obtenido := map[producto.ProductoID]productor.ProductorID{}
+			for _, p := range pronostico.Productores {
+				for _, id := range p.Productos {
+					obtenido[id] = productor.ProductorID(p.ProductorID)
+				}
+			}
+			if len(obtenido) != len(tc.porProducto) {
+				t.Fatalf("productos = %v, se esperaba %v", obtenido, tc.porProducto)
+			}
+			for id, productorID := range tc.porProducto {
+				if obtenido[id] != productorID {
+					t.Errorf("%s agrupado en %q, se esperaba %q", id, obtenido[id], productorID)
+				}
+			}
+		})
+	}
+}
+
+// Las cantidades se suman por unidad, en total y por productor, sin convertir entre unidades
+func TestGetPronostico_Cantidades(t *testing.T) {
+	e := nuevoEscenario(t)
+	ahora := time.Now().In(producto.ZonaHoraria())
+	mes := time.Date(ahora.Year(), ahora.Month(), 1, 0, 0, 0, 0, producto.ZonaHoraria()).AddDate(0, 2, 0)
+
+	publicar := func(productorID productor.ProductorID, id producto.ProductoID, valor int, unidad string) {
+		t.Helper()
+		d := nuevosDatosProducto(t, "Producto "+string(id))
+		var err error
+		if d.temporada, err = producto.NewTemporadaLocal(mes, mes.AddDate(0, 0, 20)); err != nil {
+			t.Fatalf("temporada: %v", err)
+		}
+		if d.cantidad, err = producto.NewCantidadDisponible(valor, unidad); err != nil {
+			t.Fatalf("cantidad: %v", err)
+		}
+		if _, err := e.publicar(productorID, id, d); err != nil {
+			t.Fatalf("PublicarProducto(%s): %v", id, err)
+		}
+	}
+	publicar(e.semilla1, "p-1", 40, producto.UnidadKilogramo)
+	publicar(e.semilla1, "p-2", 15, producto.UnidadKilogramo)
+	publicar(e.semilla1, "p-3", 500, producto.UnidadGramo)
+	publicar(e.semilla2, "p-4", 25, producto.UnidadKilogramo)
+
+	pronostico, err := e.catalogo.GetPronostico(producto.ProductoFiltro{}, mes.Year(), int(mes.Month()))
+	if err != nil {
+		t.Fatalf("GetPronostico: %v", err)
+	}
+	total := []service.CantidadPronosticada{{Unidad: "g", Valor: 500}, {Unidad: "kg", Valor: 80}}
+	if !slices.Equal(pronostico.Cantidades, total) {
+		t.Errorf("Cantidades = %v, se esperaba %v", pronostico.Cantidades, total)
+	}
+	porProductor := map[string][]service.CantidadPronosticada{
+		string(e.semilla1): {{Unidad: "g", Valor: 500}, {Unidad: "kg", Valor: 55}},
+		string(e.semilla2): {{Unidad: "kg", Valor: 25}},
+	}
+	if len(pronostico.Productores) != len(porProductor) {
+		t.Fatalf("productores = %+v, se esperaban %d", pronostico.Productores, len(porProductor))
+	}
+	for _, p := range pronostico.Productores {
+		if !slices.Equal(p.Cantidades, porProductor[p.ProductorID]) {
+			t.Errorf("%s: Cantidades = %v, se esperaba %v", p.ProductorID, p.Cantidades, porProductor[p.ProductorID])
+		}
+	}
+
+	// Sin productos las cantidades son una lista vacía, no null
+	vacio, err := e.catalogo.GetPronostico(producto.ProductoFiltro{}, mes.AddDate(0, 3, 0).Year(), int(mes.AddDate(0, 3, 0).Month()))
+	if err != nil || vacio.Cantidades == nil || len(vacio.Cantidades) != 0 {
+		t.Errorf("sin productos: Cantidades = %#v, %v; se esperaba una lista vacía", vacio.Cantidades, err)
+	}
+}
+
+func TestGetPronostico_Horizonte(t *testing.T) {
+	e := nuevoEscenario(t)
+	ahora := time.Now().In(producto.ZonaHoraria())
+	mesActual := time.Date(ahora.Year(), ahora.Month(), 1, 0, 0, 0, 0, producto.ZonaHoraria())
+
+	limite := mesActual.AddDate(0, 12, 0)
+	if _, err := e.catalogo.GetPronostico(producto.ProductoFiltro{}, limite.Year(), int(limite.Month())); err != nil {
+		t.Errorf("a 12 meses: %v, se esperaba un pronóstico", err)
+	}
+	fuera := mesActual.AddDate(0, 13, 0)
+	if _, err := e.catalogo.GetPronostico(producto.ProductoFiltro{}, fuera.Year(), int(fuera.Month())); !errors.Is(err, service.ErrHorizonteExcedido) {
+		t.Errorf("a 13 meses: err = %v, se esperaba ErrHorizonteExcedido", err)
+	}
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"time"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+	"Product_Catalog_Microservice/internal/domain/productor"
+)
+
+// registrarRechazo guarda el rechazo de una publicación por una regla de negocio.
+// Una falla al guardar no cambia el resultado de la publicación; solo se reporta en el logger.
+func (s *CatalogoService) registrarRechazo(
+	productorID productor.ProductorID,
+	nombre producto.NombreProducto,
+	codigo string,
+	motivo error,
+) {
+	if s.rechazoRepo == nil {
+		return
+	}
+	rechazo := productor.RechazoPublicacion{
+		ProductorID:    productorID,
+		NombreProducto: nombre.Value,
+		Codigo:         codigo,
+		Mensaje:        motivo.Error(),
+		Fecha:          time.Now(),
+	}
+	if err := s.rechazoRepo.Save(rechazo); err != nil {
+		s.logger.Warn("no se pudo registrar el rechazo de publicación", "productor_id", productorID, "error", err)
+	}
+}
+
+// GetRechazosDeProductor retorna los rechazos de publicación del productor, del más reciente al más antiguo
+func (s *CatalogoService) GetRechazosDeProductor(productorID productor.ProductorID) ([]productor.RechazoPublicacion, error) {
+	if s.rechazoRepo == nil {
+		return []productor.RechazoPublicacion{}, nil
+	}
+	return s.rechazoRepo.GetByProductor(productorID)
+}
+
+// GetRechazosPorCodigo retorna los rechazos de todos los productores con el código indicado;
+// un código vacío retorna todos
+func (s *CatalogoService) GetRechazosPorCodigo(codigo string) ([]productor.RechazoPublicacion, error) {
+	if s.rechazoRepo == nil {
+		return []productor.RechazoPublicacion{}, nil
+	}
+	return s.rechazoRepo.GetByCodigo(codigo)
+}
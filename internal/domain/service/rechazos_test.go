@@ -0,0 +1,54 @@
+package service_test
+
+import (
+	"testing"
+
+	"Product_Catalog_Microservice/internal/domain/productor"
+	"Product_Catalog_Microservice/internal/domain/service"
+	"Product_Catalog_Microservice/internal/repository"
+)
+
+func TestPublicarProducto_RegistraRechazosDeNegocio(t *testing.T) {
+	e := nuevoEscenario(t,
+		service.WithRechazoRepository(repository.NewRechazoRepository(10)),
+		service.WithMaxProductosPorProductor(2),
+		service.WithContentModeration(service.ContentModerationConfig{PalabrasProhibidas: []string{"milagroso"}}),
+	)
+	noVerificado := e.registrarProductor(t, "nv", "Vereda El Placer", false, 4)
+
+	e.publicarValido(t, e.semilla1, "p-1", "Fresa")
+	e.publicar(e.semilla1, "p-2", nuevosDatosProducto(t, "Fresa"))
+	e.publicar(e.semilla1, "p-3", nuevosDatosProducto(t, "Tomate milagroso"))
+	e.publicarValido(t, e.semilla1, "p-4", "Mora")
+	e.publicar(e.semilla1, "p-5", nuevosDatosProducto(t, "Lulo"))
+	e.publicar(noVerificado.ID, "p-6", nuevosDatosProducto(t, "Papa"))
+
+	// Un productor inexistente no deja registro: no hay a quién mostrárselo
+	e.publicar("no-existe", "p-7", nuevosDatosProducto(t, "Uchuva"))
+
+	rechazos, err := e.catalogo.GetRechazosDeProductor(e.semilla1)
+	if err != nil {
+		t.Fatalf("GetRechazosDeProductor: %v", err)
+	}
+	esperados := []struct{ nombre, codigo string }{
+		{"Lulo", productor.RechazoLimiteProductos},
+		{"Tomate milagroso", productor.RechazoContenidoNoPermitido},
+		{"Fresa", productor.RechazoNombreDuplicado},
+	}
+	if len(rechazos) != len(esperados) {
+		t.Fatalf("rechazos = %+v, se esperaban %d", rechazos, len(esperados))
+	}
+	for i, esperado := range esperados {
+		if rechazos[i].NombreProducto != esperado.nombre || rechazos[i].Codigo != esperado.codigo || rechazos[i].Mensaje == "" {
+			t.Errorf("rechazo %d = %+v, se esperaba %s con código %s", i, rechazos[i], esperado.nombre, esperado.codigo)
+		}
+	}
+
+	noAutorizados, _ := e.catalogo.GetRechazosPorCodigo(productor.RechazoNoAutorizado)
+	if len(noAutorizados) != 1 || noAutorizados[0].ProductorID != noVerificado.ID {
+		t.Errorf("rechazos no_autorizado = %+v, se esperaba solo el del productor sin verificar", noAutorizados)
+	}
+	if todos, _ := e.catalogo.GetRechazosPorCodigo(""); len(todos) != 4 {
+		t.Errorf("rechazos totales = %d, se esperaban 4", len(todos))
+	}
+}
@@ -0,0 +1,135 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+	"Product_Catalog_Microservice/internal/domain/service"
+)
+
+// sembrarDosZonas deja dos zonas con conteos distintos:
+//   - Vereda El Paraíso: semilla1 (4.5) y p-verif (3.5) verificados, p-sin-verif (1.0) sin verificar;
+//     dos productos, uno de ellos agotado
+//   - Vereda La Pradera: semilla2 (3.8) verificado; un producto disponible
+func sembrarDosZonas(t *testing.T, e *escenario) {
+	t.Helper()
+	e.registrarProductor(t, "p-verif", "Vereda El Paraíso", true, 3.5)
+	e.registrarProductor(t, "p-sin-verif", "Vereda El Paraíso", false, 1.0)
+
+	e.publicarValido(t, e.semilla1, "fresa", "Fresa")
+	e.publicarValido(t, e.semilla1, "mora", "Mora")
+	mora, err := e.productoRepo.GetByID("mora")
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if err := mora.Agotar(); err != nil {
+		t.Fatalf("Agotar: %v", err)
+	}
+	if err := e.productoRepo.Update(mora); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	d := nuevosDatosProducto(t, "Lulo")
+	if d.ubicacion, err = producto.NewUbicacion("Vereda La Pradera", "Finca El Sol"); err != nil {
+		t.Fatalf("ubicacion: %v", err)
+	}
+	if _, err := e.publicar(e.semilla2, "lulo", d); err != nil {
+		t.Fatalf("PublicarProducto: %v", err)
+	}
+}
+
+func TestGetResumenCatalogoPorZona(t *testing.T) {
+	e := nuevoEscenario(t)
+	sembrarDosZonas(t, e)
+
+	resumen, err := e.catalogo.GetResumenCatalogoPorZona()
+	if err != nil {
+		t.Fatalf("GetResumenCatalogoPorZona: %v", err)
+	}
+
+	// Ordenado por zona
+	esperado := []service.ResumenZona{
+		{
+			ZonaVeredal:            "Vereda El Paraíso",
+			TotalProductores:       3,
+			ProductoresVerificados: 2,
+			TotalProductos:         2,
+			ProductosDisponibles:   1,
+			ReputacionPromedioZona: 4.0,
+		},
+		{
+			ZonaVeredal:            "Vereda La Pradera",
+			TotalProductores:       1,
+			ProductoresVerificados: 1,
+			TotalProductos:         1,
+			ProductosDisponibles:   1,
+			ReputacionPromedioZona: 3.8,
+		},
+	}
+	if len(resumen) != len(esperado) {
+		t.Fatalf("se obtuvieron %d zonas, se esperaban %d: %+v", len(resumen), len(esperado), resumen)
+	}
+	for i := range esperado {
+		if resumen[i] != esperado[i] {
+			t.Errorf("zona %d = %+v, se esperaba %+v", i, resumen[i], esperado[i])
+		}
+	}
+}
+
+func TestGetResumenCatalogoPorZona_UsaCacheDuranteTTL(t *testing.T) {
+	e := nuevoEscenario(t)
+	sembrarDosZonas(t, e)
+
+	antes, err := e.catalogo.GetResumenCatalogoPorZona()
+	if err != nil {
+		t.Fatalf("GetResumenCatalogoPorZona: %v", err)
+	}
+	e.publicarValido(t, e.semilla2, "papa", "Papa")
+
+	despues, err := e.catalogo.GetResumenCatalogoPorZona()
+	if err != nil {
+		t.Fatalf("GetResumenCatalogoPorZona: %v", err)
+	}
+	if despues[0].TotalProductos != antes[0].TotalProductos {
+		t.Errorf("dentro del TTL el resumen debía salir de caché: antes %+v, después %+v", antes[0], despues[0])
+	}
+}
+
+func TestGetResumenCatalogoPorZona_TTLVencidoRecalcula(t *testing.T) {
+	e := nuevoEscenario(t, service.WithCatalogoCacheTTL(time.Nanosecond))
+	sembrarDosZonas(t, e)
+
+	if _, err := e.catalogo.GetResumenCatalogoPorZona(); err != nil {
+		t.Fatalf("GetResumenCatalogoPorZona: %v", err)
+	}
+	// La semilla 2 publica en la zona por defecto de nuevosDatosProducto (El Paraíso)
+	e.publicarValido(t, e.semilla2, "papa", "Papa")
+
+	resumen, err := e.catalogo.GetResumenCatalogoPorZona()
+	if err != nil {
+		t.Fatalf("GetResumenCatalogoPorZona: %v", err)
+	}
+	if resumen[0].TotalProductos != 3 {
+		t.Errorf("TotalProductos en El Paraíso = %d, se esperaba 3", resumen[0].TotalProductos)
+	}
+}
+
+func TestGetResumenCatalogoPorZona_RetornaCopia(t *testing.T) {
+	e := nuevoEscenario(t)
+	sembrarDosZonas(t, e)
+
+	primero, err := e.catalogo.GetResumenCatalogoPorZona()
+	if err != nil {
+		t.Fatalf("GetResumenCatalogoPorZona: %v", err)
+	}
+	primero[0].TotalProductos = 99
+
+	segundo, err := e.catalogo.GetResumenCatalogoPorZona()
+	if err != nil {
+		t.Fatalf("GetResumenCatalogoPorZona: %v", err)
+	}
+	if segundo[0].TotalProductos != 2 {
+		t.Errorf("TotalProductos = %d, se esperaba 2: modificar un resultado no debe alterar la caché", segundo[0].TotalProductos)
+	}
+}
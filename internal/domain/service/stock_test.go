@@ -0,0 +1,56 @@
+package service_test
+
+import (
+	"errors"
+	"testing"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+	"Product_Catalog_Microservice/internal/domain/productor"
+	"Product_Catalog_Microservice/internal/domain/service"
+)
+
+func TestDecrementarStock(t *testing.T) {
+	e := nuevoEscenario(t)
+	e.publicarValido(t, e.semilla1, "p-1", "Fresa")
+
+	preferencias, err := productor.NuevasPreferenciasNotificacion(map[string]bool{productor.NotificacionStockBajo: false})
+	if err != nil {
+		t.Fatalf("NuevasPreferenciasNotificacion: %v", err)
+	}
+	if err := e.catalogo.ActualizarPreferenciasNotificacion(e.semilla1, preferencias); err != nil {
+		t.Fatalf("ActualizarPreferenciasNotificacion: %v", err)
+	}
+
+	if err := e.catalogo.DecrementarStock("p-1", 30); err != nil {
+		t.Fatalf("DecrementarStock: %v", err)
+	}
+	if n := contarEventos[producto.ProductoStockBajo](e.eventos); n != 0 {
+		t.Errorf("ProductoStockBajo = %d con 10 kg, se esperaba 0", n)
+	}
+	if err := e.catalogo.DecrementarStock("p-1", 8); err != nil {
+		t.Fatalf("DecrementarStock: %v", err)
+	}
+	if n := contarEventos[producto.ProductoStockBajo](e.eventos); n != 1 {
+		t.Fatalf("ProductoStockBajo = %d con 2 kg, se esperaba 1", n)
+	}
+	for _, ev := range e.eventos.Eventos() {
+		if ev, ok := ev.(producto.ProductoStockBajo); ok && ev.PreferenciasProductor[productor.NotificacionStockBajo] {
+			t.Errorf("ProductoStockBajo lleva las preferencias %v, se esperaban las del productor", ev.PreferenciasProductor)
+		}
+	}
+
+	if err := e.catalogo.DecrementarStock("p-1", 3); !errors.Is(err, producto.ErrStockInsuficiente) {
+		t.Errorf("err = %v, se esperaba ErrStockInsuficiente", err)
+	}
+	prod, err := e.productoRepo.GetByID("p-1")
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if prod.Cantidad.Valor != 2 {
+		t.Errorf("Cantidad = %d, se esperaba 2", prod.Cantidad.Valor)
+	}
+
+	if err := e.catalogo.DecrementarStock("no-existe", 1); !errors.Is(err, service.ErrProductoNoEncontrado) {
+		t.Errorf("err = %v, se esperaba ErrProductoNoEncontrado", err)
+	}
+}
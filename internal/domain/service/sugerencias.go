@@ -0,0 +1,91 @@
+package service
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+)
+
+// muestraMinimaSugerencia es la cantidad mínima de productos similares para sugerir una temporada
+const muestraMinimaSugerencia = 3
+
+// duracionMaximaTemporada es la duración máxima que permite NewTemporadaLocal; los registros
+// más largos se consideran errores de captura y se excluyen de la muestra
+const duracionMaximaTemporada = 365 * 24 * time.Hour
+
+// ErrMuestraInsuficiente indica que no hay suficientes productos similares para sugerir una temporada
+var ErrMuestraInsuficiente = errors.New("no hay suficientes productos similares para sugerir una temporada")
+
+// SugerenciaTemporada es la temporada típica de los productos similares ya publicados
+type SugerenciaTemporada struct {
+	Inicio  time.Time `json:"inicio"`
+	Fin     time.Time `json:"fin"`
+	Muestra int       `json:"muestra"`
+}
+
+// normalizadorNombre quita tildes para comparar nombres escritos de distintas formas
+var normalizadorNombre = strings.NewReplacer("á", "a", "é", "e", "í", "i", "ó", "o", "ú", "u", "ü", "u")
+
+func normalizarNombre(nombre string) string {
+	return normalizadorNombre.Replace(strings.ToLower(strings.TrimSpace(nombre)))
+}
+
+// SugerirTemporada calcula la temporada típica de los productos de la categoría cuyo nombre
+// normalizado coincide o contiene al indicado, opcionalmente restringidos a una zona veredal.
+// El inicio sugerido es el día del año mediano de la muestra, en su próxima ocurrencia a partir
+// de now, y el fin suma la duración mediana. Retorna ErrMuestraInsuficiente si hay menos de
+// muestraMinimaSugerencia productos válidos.
+func (s *CatalogoService) SugerirTemporada(
+	categoria producto.Categoria,
+	nombre string,
+	zona string,
+	now time.Time,
+) (SugerenciaTemporada, error) {
+	productos, err := s.productoRepo.GetByCategoria(categoria)
+	if err != nil {
+		return SugerenciaTemporada{}, err
+	}
+
+	buscado := normalizarNombre(nombre)
+	var diasInicio []int
+	var duraciones []time.Duration
+	for _, prod := range productos {
+		if zona != "" && prod.Ubicacion.ZonaVeredal != zona {
+			continue
+		}
+		actual := normalizarNombre(prod.Nombre.Value)
+		if !strings.Contains(actual, buscado) && !strings.Contains(buscado, actual) {
+			continue
+		}
+		duracion := prod.Temporada.Fin.Sub(prod.Temporada.Inicio)
+		if duracion <= 0 || duracion > duracionMaximaTemporada {
+			continue
+		}
+		diasInicio = append(diasInicio, prod.Temporada.Inicio.In(producto.ZonaHoraria()).YearDay())
+		duraciones = append(duraciones, duracion)
+	}
+
+	if len(diasInicio) < muestraMinimaSugerencia {
+		return SugerenciaTemporada{}, ErrMuestraInsuficiente
+	}
+
+	sort.Ints(diasInicio)
+	sort.Slice(duraciones, func(i, j int) bool { return duraciones[i] < duraciones[j] })
+	diaInicio := diasInicio[len(diasInicio)/2]
+	duracion := duraciones[len(duraciones)/2]
+
+	hoy := now.In(producto.ZonaHoraria())
+	inicio := time.Date(hoy.Year(), 1, diaInicio, 0, 0, 0, 0, producto.ZonaHoraria())
+	if inicio.Before(time.Date(hoy.Year(), hoy.Month(), hoy.Day(), 0, 0, 0, 0, producto.ZonaHoraria())) {
+		inicio = time.Date(hoy.Year()+1, 1, diaInicio, 0, 0, 0, 0, producto.ZonaHoraria())
+	}
+
+	return SugerenciaTemporada{
+		Inicio:  inicio,
+		Fin:     inicio.Add(duracion),
+		Muestra: len(diasInicio),
+	}, nil
+}
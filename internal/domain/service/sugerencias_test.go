@@ -0,0 +1,72 @@
+package service_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+	"Product_Catalog_Microservice/internal/domain/productor"
+	"Product_Catalog_Microservice/internal/domain/service"
+)
+
+// publicarConTemporada publica nombre con la temporada [inicio, inicio+dias] en la zona indicada
+func (e *escenario) publicarConTemporada(t *testing.T, productorID productor.ProductorID, id producto.ProductoID,
+	nombre string, categoria producto.Categoria, zona string, inicio time.Time, dias int) {
+	t.Helper()
+	d := nuevosDatosProducto(t, nombre)
+	d.categoria = categoria
+	var err error
+	if d.temporada, err = producto.NewTemporadaLocal(inicio, inicio.AddDate(0, 0, dias)); err != nil {
+		t.Fatalf("temporada: %v", err)
+	}
+	if d.ubicacion, err = producto.NewUbicacion(zona, "Finca La Esperanza"); err != nil {
+		t.Fatalf("ubicacion: %v", err)
+	}
+	if _, err := e.publicar(productorID, id, d); err != nil {
+		t.Fatalf("PublicarProducto(%s): %v", id, err)
+	}
+}
+
+func TestSugerirTemporada(t *testing.T) {
+	e := nuevoEscenario(t)
+	anio := time.Now().Year() + 1
+	marzo := func(dia int) time.Time { return time.Date(anio, time.March, dia, 0, 0, 0, 0, producto.ZonaHoraria()) }
+
+	// Las medianas de inicio y de duración se calculan por separado: 5 de marzo y 40 días
+	e.publicarConTemporada(t, e.semilla1, "p-1", "Mango", producto.CategoriaFruta, "El Placer", marzo(1), 60)
+	e.publicarConTemporada(t, e.semilla2, "p-2", "mango de azúcar", producto.CategoriaFruta, "El Placer", marzo(5), 30)
+	e.publicarConTemporada(t, e.semilla1, "p-3", "Mángo Tommy", producto.CategoriaFruta, "La Cumbre", marzo(10), 40)
+	// Fuera de la muestra: otra categoría y otro nombre
+	e.publicarConTemporada(t, e.semilla2, "p-4", "Mango", producto.CategoriaHortaliza, "El Placer", marzo(20), 10)
+	e.publicarConTemporada(t, e.semilla2, "p-5", "Aguacate", producto.CategoriaFruta, "El Placer", marzo(20), 10)
+
+	ahora := time.Date(anio, time.January, 1, 0, 0, 0, 0, producto.ZonaHoraria())
+	sugerencia, err := e.catalogo.SugerirTemporada(producto.CategoriaFruta, "  MANGO ", "", ahora)
+	if err != nil {
+		t.Fatalf("SugerirTemporada: %v", err)
+	}
+	if sugerencia.Muestra != 3 {
+		t.Errorf("Muestra = %d, se esperaba 3", sugerencia.Muestra)
+	}
+	if got := sugerencia.Inicio.Format("2006-01-02"); got != marzo(5).Format("2006-01-02") {
+		t.Errorf("Inicio = %s, se esperaba el 5 de marzo", got)
+	}
+	if got := sugerencia.Fin.Format("2006-01-02"); got != marzo(5).AddDate(0, 0, 40).Format("2006-01-02") {
+		t.Errorf("Fin = %s, se esperaba 40 días después del inicio", got)
+	}
+
+	// Pasado el inicio mediano, la sugerencia es para el año siguiente
+	despues, err := e.catalogo.SugerirTemporada(producto.CategoriaFruta, "mango", "", marzo(6))
+	if err != nil {
+		t.Fatalf("SugerirTemporada: %v", err)
+	}
+	if despues.Inicio.Year() != anio+1 {
+		t.Errorf("Inicio = %s, se esperaba en %d", despues.Inicio.Format("2006-01-02"), anio+1)
+	}
+
+	// Restringida a una zona la muestra no alcanza el mínimo
+	if _, err := e.catalogo.SugerirTemporada(producto.CategoriaFruta, "mango", "El Placer", ahora); !errors.Is(err, service.ErrMuestraInsuficiente) {
+		t.Errorf("err = %v, se esperaba ErrMuestraInsuficiente", err)
+	}
+}
@@ -0,0 +1,140 @@
+package service_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+	"Product_Catalog_Microservice/internal/domain/productor"
+	"Product_Catalog_Microservice/internal/domain/service"
+)
+
+// transicionCaso describe una transición de estado: preparar deja el agregado en el estado
+// anterior, ejecutar aplica la transición y eventos cuenta los eventos que la transición genera
+type transicionCaso struct {
+	nombre     string
+	transicion string
+	preparar   func(t *testing.T, e *escenario)
+	ejecutar   func(e *escenario) (bool, error)
+	eventos    func(e *escenario) int
+	errSin     error
+}
+
+func casosTransicion() []transicionCaso {
+	const verificando productor.ProductorID = "nuevo"
+	publicar := func(t *testing.T, e *escenario) {
+		e.publicarValido(t, e.semilla1, "p-1", "Fresa")
+	}
+	return []transicionCaso{
+		{
+			nombre:     "agotar",
+			transicion: service.TransicionAgotar,
+			preparar:   publicar,
+			ejecutar:   func(e *escenario) (bool, error) { return e.catalogo.AgotarProducto("p-1") },
+			eventos:    func(e *escenario) int { return contarEventos[producto.ProductoAgotado](e.eventos) },
+			errSin:     producto.ErrSinCambios,
+		},
+		{
+			nombre:     "excedente",
+			transicion: service.TransicionExcedente,
+			preparar:   publicar,
+			ejecutar: func(e *escenario) (bool, error) {
+				// Solo se puede marcar como excedente dentro de la temporada
+				return e.catalogo.MarcarProductoComoExcedente("p-1", time.Now())
+			},
+			eventos: func(e *escenario) int {
+				return contarEventos[producto.ProductoMarcadoComoExcedente](e.eventos)
+			},
+			errSin: producto.ErrSinCambios,
+		},
+		{
+			nombre:     "verificar",
+			transicion: service.TransicionVerificar,
+			preparar: func(t *testing.T, e *escenario) {
+				e.registrarProductor(t, verificando, "Vereda El Paraíso", false, 4)
+				if err := e.catalogo.IniciarVerificacionProductor(verificando); err != nil {
+					t.Fatalf("IniciarVerificacionProductor: %v", err)
+				}
+			},
+			ejecutar: func(e *escenario) (bool, error) { return e.catalogo.CompletarVerificacionProductor(verificando) },
+			eventos:  func(e *escenario) int { return contarEventos[productor.ProductorVerificado](e.eventos) },
+			errSin:   productor.ErrSinCambios,
+		},
+		{
+			nombre:     "suspender",
+			transicion: service.TransicionSuspender,
+			preparar:   publicar,
+			ejecutar:   func(e *escenario) (bool, error) { return e.catalogo.SuspenderProductor(e.semilla1, "prueba") },
+			eventos:    func(e *escenario) int { return contarEventos[productor.ProductorSuspendido](e.eventos) },
+			errSin:     productor.ErrSinCambios,
+		},
+		{
+			nombre:     "reactivar",
+			transicion: service.TransicionReactivar,
+			preparar: func(t *testing.T, e *escenario) {
+				publicar(t, e)
+				if _, err := e.catalogo.SuspenderProductor(e.semilla1, "prueba"); err != nil {
+					t.Fatalf("SuspenderProductor: %v", err)
+				}
+			},
+			ejecutar: func(e *escenario) (bool, error) { return e.catalogo.ReactivarProductor(e.semilla1) },
+			eventos:  func(e *escenario) int { return contarEventos[productor.ProductorReactivado](e.eventos) },
+			errSin:   productor.ErrSinCambios,
+		},
+	}
+}
+
+func TestTransiciones_Idempotentes(t *testing.T) {
+	for _, tc := range casosTransicion() {
+		t.Run(tc.nombre, func(t *testing.T) {
+			e := nuevoEscenario(t)
+			tc.preparar(t, e)
+
+			if sinCambios, err := tc.ejecutar(e); err != nil || sinCambios {
+				t.Fatalf("primera transición = (%v, %v), se esperaba (false, nil)", sinCambios, err)
+			}
+			if sinCambios, err := tc.ejecutar(e); err != nil || !sinCambios {
+				t.Fatalf("transición repetida = (%v, %v), se esperaba (true, nil)", sinCambios, err)
+			}
+			if n := tc.eventos(e); n != 1 {
+				t.Errorf("eventos = %d, se esperaba 1: la repetición no debe publicar otro", n)
+			}
+		})
+	}
+}
+
+func TestTransiciones_Estrictas(t *testing.T) {
+	for _, tc := range casosTransicion() {
+		t.Run(tc.nombre, func(t *testing.T) {
+			e := nuevoEscenario(t, service.WithTransicionesEstrictas(tc.transicion))
+			tc.preparar(t, e)
+
+			if sinCambios, err := tc.ejecutar(e); err != nil || sinCambios {
+				t.Fatalf("primera transición = (%v, %v), se esperaba (false, nil)", sinCambios, err)
+			}
+			sinCambios, err := tc.ejecutar(e)
+			if !errors.Is(err, tc.errSin) {
+				t.Fatalf("err = %v, se esperaba %v", err, tc.errSin)
+			}
+			if sinCambios {
+				t.Error("sinCambios = true junto con un error")
+			}
+			if n := tc.eventos(e); n != 1 {
+				t.Errorf("eventos = %d, se esperaba 1", n)
+			}
+		})
+	}
+}
+
+// Una transición estricta no afecta a las demás
+func TestTransiciones_EstrictaSoloLaConfigurada(t *testing.T) {
+	e := nuevoEscenario(t, service.WithTransicionesEstrictas(service.TransicionExcedente))
+	e.publicarValido(t, e.semilla1, "p-1", "Fresa")
+
+	for i := 0; i < 2; i++ {
+		if _, err := e.catalogo.AgotarProducto("p-1"); err != nil {
+			t.Fatalf("AgotarProducto #%d: %v", i+1, err)
+		}
+	}
+}
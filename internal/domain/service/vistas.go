@@ -0,0 +1,166 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+	"Product_Catalog_Microservice/internal/domain/productor"
+)
+
+// VistaCatalogo es una vista con nombre del catálogo definida en configuración, pensada para
+// compradores institucionales que solo necesitan un subconjunto de productos. Los criterios
+// vacíos no filtran; los presentes se combinan con AND.
+type VistaCatalogo struct {
+	Nombre                     string   `json:"nombre"`
+	Descripcion                string   `json:"descripcion"`
+	Estado                     string   `json:"estado,omitempty"`
+	Categorias                 []string `json:"categorias,omitempty"`
+	Zonas                      []string `json:"zonas,omitempty"`
+	SoloProductoresVerificados bool     `json:"solo_productores_verificados"`
+}
+
+// ErrVistaNoEncontrada indica que no existe una vista con el nombre solicitado
+type ErrVistaNoEncontrada struct {
+	Disponibles []string
+}
+
+func (e ErrVistaNoEncontrada) Error() string {
+	return fmt.Sprintf("vista no encontrada, vistas disponibles: %s", strings.Join(e.Disponibles, ", "))
+}
+
+// validar revisa que los valores de la vista sean válidos según los value objects
+func (v VistaCatalogo) validar() error {
+	if strings.TrimSpace(v.Nombre) == "" {
+		return fmt.Errorf("la vista debe tener nombre")
+	}
+	if v.Estado != "" {
+		if _, err := producto.NewEstadoDisponibilidad(v.Estado); err != nil {
+			return fmt.Errorf("vista %s: %w", v.Nombre, err)
+		}
+	}
+	for _, categoria := range v.Categorias {
+		if _, err := producto.NewCategoria(categoria); err != nil {
+			return fmt.Errorf("vista %s: %w", v.Nombre, err)
+		}
+	}
+	return nil
+}
+
+// cumple indica si el producto pertenece a la vista
+func (v VistaCatalogo) cumple(p *producto.ProductoAgroecologico, verificados map[productor.ProductorID]bool) bool {
+	filtro := producto.ProductoFiltro{Estado: producto.EstadoDisponibilidad{Value: v.Estado}}
+	if !filtro.Cumple(p) {
+		return false
+	}
+	if len(v.Categorias) > 0 && !contiene(v.Categorias, string(p.Categoria)) {
+		return false
+	}
+	if len(v.Zonas) > 0 && !contiene(v.Zonas, p.Ubicacion.ZonaVeredal) {
+		return false
+	}
+	if v.SoloProductoresVerificados && !verificados[productor.ProductorID(p.ProductorID)] {
+		return false
+	}
+	return true
+}
+
+func contiene(valores []string, buscado string) bool {
+	for _, valor := range valores {
+		if valor == buscado {
+			return true
+		}
+	}
+	return false
+}
+
+// vistaEnCache guarda el resultado calculado de una vista
+type vistaEnCache struct {
+	productos  []*producto.ProductoAgroecologico
+	generadoEn time.Time
+}
+
+// vistasCatalogo agrupa las vistas configuradas y su caché por nombre
+type vistasCatalogo struct {
+	mu         sync.Mutex
+	definicion map[string]VistaCatalogo
+	cache      map[string]vistaEnCache
+
+	// configuradas son las vistas recibidas por WithVistasCatalogo, aún sin validar
+	configuradas []VistaCatalogo
+}
+
+// registrarVistas valida las vistas configuradas y registra las válidas; las inválidas se
+// descartan y se reportan en el logger
+func (s *CatalogoService) registrarVistas() {
+	for _, vista := range s.vistas.configuradas {
+		if err := vista.validar(); err != nil {
+			s.logger.Warn("vista de catálogo inválida", "error", err)
+			continue
+		}
+		s.vistas.definicion[vista.Nombre] = vista
+	}
+	s.vistas.configuradas = nil
+}
+
+// GetVistasCatalogo retorna las definiciones de las vistas configuradas, ordenadas por nombre
+func (s *CatalogoService) GetVistasCatalogo() []VistaCatalogo {
+	vistas := make([]VistaCatalogo, 0, len(s.vistas.definicion))
+	for _, vista := range s.vistas.definicion {
+		vistas = append(vistas, vista)
+	}
+	sort.Slice(vistas, func(i, j int) bool { return vistas[i].Nombre < vistas[j].Nombre })
+	return vistas
+}
+
+// GetProductosDeVista evalúa la vista indicada. El resultado se guarda en caché por vista
+// durante el TTL configurado. Retorna ErrVistaNoEncontrada si la vista no existe.
+func (s *CatalogoService) GetProductosDeVista(nombre string) ([]*producto.ProductoAgroecologico, time.Time, error) {
+	vista, ok := s.vistas.definicion[nombre]
+	if !ok {
+		disponibles := make([]string, 0, len(s.vistas.definicion))
+		for _, v := range s.GetVistasCatalogo() {
+			disponibles = append(disponibles, v.Nombre)
+		}
+		return nil, time.Time{}, ErrVistaNoEncontrada{Disponibles: disponibles}
+	}
+
+	s.vistas.mu.Lock()
+	defer s.vistas.mu.Unlock()
+
+	if enCache, ok := s.vistas.cache[nombre]; ok && time.Since(enCache.generadoEn) < s.cacheTTL {
+		return enCache.productos, enCache.generadoEn, nil
+	}
+
+	productos, err := s.productoRepo.GetAll()
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	verificados := make(map[productor.ProductorID]bool)
+	if vista.SoloProductoresVerificados {
+		productores, err := s.productorRepo.GetVerificados()
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+		for _, prod := range productores {
+			verificados[prod.ID] = true
+		}
+	}
+
+	resultado := make([]*producto.ProductoAgroecologico, 0)
+	for _, prod := range productos {
+		if vista.cumple(prod, verificados) {
+			resultado = append(resultado, prod)
+		}
+	}
+	sort.Slice(resultado, func(i, j int) bool { return resultado[i].ID < resultado[j].ID })
+
+	generadoEn := time.Now()
+	s.vistas.cache[nombre] = vistaEnCache{productos: resultado, generadoEn: generadoEn}
+
+	return resultado, generadoEn, nil
+}
@@ -0,0 +1,112 @@
+package service_test
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+	"Product_Catalog_Microservice/internal/domain/service"
+)
+
+func TestGetProductosDeVista(t *testing.T) {
+	e := nuevoEscenario(t, service.WithVistasCatalogo([]service.VistaCatalogo{
+		{Nombre: "frutas", Categorias: []string{"Fruta"}},
+		{Nombre: "verificados", SoloProductoresVerificados: true},
+		{Nombre: "invalida", Categorias: []string{"Desconocida"}},
+	}))
+	e.publicarValido(t, e.semilla1, "p-1", "Fresa")
+
+	hortaliza := nuevosDatosProducto(t, "Lechuga")
+	hortaliza.categoria = producto.CategoriaHortaliza
+	if _, err := e.publicar(e.semilla1, "p-2", hortaliza); err != nil {
+		t.Fatalf("PublicarProducto(p-2): %v", err)
+	}
+
+	// Un productor sin verificar no puede publicar, así que se guarda su producto directamente
+	noVerificado := e.registrarProductor(t, "nuevo", "Vereda El Paraíso", false, 4)
+	d := nuevosDatosProducto(t, "Mora")
+	prod, err := producto.NewProductoAgroecologico("p-3", d.nombre, d.desc, d.categoria, d.tipo,
+		d.temporada, d.ubicacion, d.imagen, d.precio, d.cantidad, string(noVerificado.ID))
+	if err != nil {
+		t.Fatalf("NewProductoAgroecologico: %v", err)
+	}
+	if err := e.productoRepo.Save(prod); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	casos := []struct {
+		vista string
+		ids   []producto.ProductoID
+	}{
+		{"frutas", []producto.ProductoID{"p-1", "p-3"}},
+		{"verificados", []producto.ProductoID{"p-1", "p-2"}},
+	}
+	for _, tc := range casos {
+		t.Run(tc.vista, func(t *testing.T) {
+			productos, _, err := e.catalogo.GetProductosDeVista(tc.vista)
+			if err != nil {
+				t.Fatalf("GetProductosDeVista(%q): %v", tc.vista, err)
+			}
+			if len(productos) != len(tc.ids) {
+				t.Fatalf("se obtuvieron %d productos, se esperaban %v", len(productos), tc.ids)
+			}
+			for i, p := range productos {
+				if p.ID != tc.ids[i] {
+					t.Errorf("productos[%d] = %s, se esperaba %s", i, p.ID, tc.ids[i])
+				}
+			}
+		})
+	}
+
+	// La vista con una categoría inválida se descarta al configurar el servicio
+	var noEncontrada service.ErrVistaNoEncontrada
+	if _, _, err := e.catalogo.GetProductosDeVista("invalida"); !errors.As(err, &noEncontrada) {
+		t.Fatalf("err = %v, se esperaba ErrVistaNoEncontrada", err)
+	}
+	if len(noEncontrada.Disponibles) != 2 || noEncontrada.Disponibles[0] != "frutas" || noEncontrada.Disponibles[1] != "verificados" {
+		t.Errorf("Disponibles = %v, se esperaba [frutas verificados]", noEncontrada.Disponibles)
+	}
+}
+
+// Las vistas inválidas se reportan en el logger inyectado aunque WithLogger venga después
+func TestWithVistasCatalogo_ReportaEnElLoggerConfigurado(t *testing.T) {
+	var salida bytes.Buffer
+	e := nuevoEscenario(t,
+		service.WithVistasCatalogo([]service.VistaCatalogo{
+			{Nombre: "frutas", Categorias: []string{"Fruta"}},
+			{Nombre: "invalida", Categorias: []string{"Desconocida"}},
+		}),
+		service.WithLogger(slog.New(slog.NewJSONHandler(&salida, nil))),
+	)
+
+	if !strings.Contains(salida.String(), "vista de catálogo inválida") || !strings.Contains(salida.String(), "vista invalida") {
+		t.Errorf("el logger configurado no registró la vista inválida: %s", salida.String())
+	}
+	if vistas := e.catalogo.GetVistasCatalogo(); len(vistas) != 1 || vistas[0].Nombre != "frutas" {
+		t.Errorf("vistas = %+v, se esperaba solo frutas", vistas)
+	}
+}
+
+// Dentro del TTL la vista se sirve desde la caché aunque el catálogo cambie
+func TestGetProductosDeVista_Cache(t *testing.T) {
+	e := nuevoEscenario(t, service.WithVistasCatalogo([]service.VistaCatalogo{{Nombre: "todo"}}))
+	e.publicarValido(t, e.semilla1, "p-1", "Fresa")
+
+	primero, generadoEn, err := e.catalogo.GetProductosDeVista("todo")
+	if err != nil {
+		t.Fatalf("GetProductosDeVista: %v", err)
+	}
+	e.publicarValido(t, e.semilla1, "p-2", "Mora")
+
+	segundo, generadoEn2, err := e.catalogo.GetProductosDeVista("todo")
+	if err != nil {
+		t.Fatalf("GetProductosDeVista: %v", err)
+	}
+	if len(primero) != 1 || len(segundo) != 1 || !generadoEn.Equal(generadoEn2) {
+		t.Errorf("se esperaba el resultado en caché: %d y %d productos, generados en %v y %v",
+			len(primero), len(segundo), generadoEn, generadoEn2)
+	}
+}
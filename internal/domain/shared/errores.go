@@ -0,0 +1,25 @@
+package shared
+
+// ErrorValidacion indica que un dato no cumple las reglas de su value object. Campo nombra
+// el dato como llega en las peticiones (p. ej. "zona_veredal") para que la capa HTTP pueda
+// señalarlo sin interpretar el mensaje.
+type ErrorValidacion struct {
+	Campo   string
+	Mensaje string
+}
+
+func (e *ErrorValidacion) Error() string {
+	return e.Mensaje
+}
+
+// NewErrorValidacion crea el error de validación del campo indicado.
+//
+// Parámetros:
+//   - campo: nombre del dato inválido en las peticiones
+//   - mensaje: descripción del problema para el cliente
+//
+// Retorna:
+//   - error: un *ErrorValidacion
+func NewErrorValidacion(campo, mensaje string) error {
+	return &ErrorValidacion{Campo: campo, Mensaje: mensaje}
+}
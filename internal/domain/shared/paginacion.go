@@ -0,0 +1,72 @@
+// Package shared contiene tipos transversales a los dominios de producto y productor
+// que no pertenecen a ninguno de los dos agregados.
+package shared
+
+// Límites del tamaño de página
+const (
+	DefaultPageSize = 20  // tamaño de página cuando no se indica
+	MaxPageSize     = 100 // tamaño de página máximo permitido
+)
+
+// PaginationParams indica qué página de un listado se pide. Page empieza en 1.
+type PaginationParams struct {
+	Page     int
+	PageSize int
+}
+
+// NewPaginationParams normaliza los parámetros de paginación: una página menor que 1 se
+// convierte en la primera, un tamaño no positivo en DefaultPageSize y uno mayor que
+// MaxPageSize se recorta a MaxPageSize.
+//
+// Parámetros:
+//   - page: número de página, empezando en 1
+//   - pageSize: cantidad de elementos por página
+//
+// Retorna:
+//   - PaginationParams: parámetros dentro de los límites permitidos
+func NewPaginationParams(page, pageSize int) PaginationParams {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+	return PaginationParams{Page: page, PageSize: pageSize}
+}
+
+// Offset es la cantidad de elementos que preceden a la página pedida
+func (p PaginationParams) Offset() int {
+	return (p.Page - 1) * p.PageSize
+}
+
+// PagedResult es una página de un listado junto con el total de elementos del listado completo
+type PagedResult[T any] struct {
+	Items      []T `json:"items"`
+	TotalCount int `json:"total_count"`
+	Page       int `json:"page"`
+	PageSize   int `json:"page_size"`
+}
+
+// Paginar extrae de items, ya ordenados, la página indicada por params. Una página fuera de
+// rango retorna Items vacío (nunca nil) con el total del listado.
+func Paginar[T any](items []T, params PaginationParams) PagedResult[T] {
+	params = NewPaginationParams(params.Page, params.PageSize)
+	resultado := PagedResult[T]{
+		Items:      make([]T, 0),
+		TotalCount: len(items),
+		Page:       params.Page,
+		PageSize:   params.PageSize,
+	}
+	// Se compara en páginas y no en offset para que una página enorme no desborde el entero
+	paginas := (len(items) + params.PageSize - 1) / params.PageSize
+	if params.Page > paginas {
+		return resultado
+	}
+	desde := params.Offset()
+	hasta := min(desde+params.PageSize, len(items))
+	resultado.Items = append(resultado.Items, items[desde:hasta]...)
+	return resultado
+}
@@ -0,0 +1,62 @@
+package shared
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestNewPaginationParams(t *testing.T) {
+	casos := []struct {
+		page, pageSize int
+		esperado       PaginationParams
+	}{
+		{1, 20, PaginationParams{Page: 1, PageSize: 20}},
+		{3, 50, PaginationParams{Page: 3, PageSize: 50}},
+		{0, 0, PaginationParams{Page: 1, PageSize: DefaultPageSize}},
+		{-2, -5, PaginationParams{Page: 1, PageSize: DefaultPageSize}},
+		{2, 500, PaginationParams{Page: 2, PageSize: MaxPageSize}},
+	}
+	for _, tc := range casos {
+		if got := NewPaginationParams(tc.page, tc.pageSize); got != tc.esperado {
+			t.Errorf("NewPaginationParams(%d, %d) = %+v, se esperaba %+v", tc.page, tc.pageSize, got, tc.esperado)
+		}
+	}
+}
+
+func TestPaginar(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5}
+
+	casos := []struct {
+		nombre   string
+		params   PaginationParams
+		esperado []int
+	}{
+		{"primera página", PaginationParams{Page: 1, PageSize: 2}, []int{1, 2}},
+		{"última página incompleta", PaginationParams{Page: 3, PageSize: 2}, []int{5}},
+		{"fuera de rango", PaginationParams{Page: 4, PageSize: 2}, []int{}},
+		{"página enorme", PaginationParams{Page: math.MaxInt, PageSize: MaxPageSize}, []int{}},
+		{"sin tamaño usa el por defecto", PaginationParams{Page: 1}, items},
+	}
+	for _, tc := range casos {
+		t.Run(tc.nombre, func(t *testing.T) {
+			pagina := Paginar(items, tc.params)
+			if pagina.Items == nil {
+				t.Fatal("Items = nil, se esperaba un slice vacío")
+			}
+			if !slices.Equal(pagina.Items, tc.esperado) {
+				t.Errorf("Items = %v, se esperaba %v", pagina.Items, tc.esperado)
+			}
+			if pagina.TotalCount != len(items) {
+				t.Errorf("TotalCount = %d, se esperaba %d", pagina.TotalCount, len(items))
+			}
+		})
+	}
+
+	// La página no comparte memoria con el listado original
+	pagina := Paginar(items, PaginationParams{Page: 1, PageSize: 2})
+	pagina.Items[0] = 99
+	if items[0] != 1 {
+		t.Error("modificar la página cambió el listado original")
+	}
+}
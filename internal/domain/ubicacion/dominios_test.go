@@ -0,0 +1,37 @@
+package ubicacion_test
+
+import (
+	"strings"
+	"testing"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+	"Product_Catalog_Microservice/internal/domain/productor"
+)
+
+// Producto y productor delegan en el mismo validador: aceptan y rechazan las mismas ubicaciones
+func TestNewUbicacion_MismasReglasEnAmbosDominios(t *testing.T) {
+	casos := []struct{ zona, finca string }{
+		{"Vereda El Paraíso", "Finca La Esperanza"},
+		{strings.Repeat("a", 40), strings.Repeat("b", 50)},
+		{strings.Repeat("a", 41), "Finca"},
+		{"Vereda", strings.Repeat("b", 51)},
+		{"", "Finca"},
+		{"Vereda", ""},
+		{"Vereda $", "Finca"},
+	}
+	for _, tc := range casos {
+		up, errProducto := producto.NewUbicacion(tc.zona, tc.finca)
+		ur, errProductor := productor.NewUbicacion(tc.zona, tc.finca)
+
+		if (errProducto == nil) != (errProductor == nil) {
+			t.Errorf("NewUbicacion(%q, %q): producto err = %v, productor err = %v", tc.zona, tc.finca, errProducto, errProductor)
+			continue
+		}
+		if errProducto != nil && errProducto.Error() != errProductor.Error() {
+			t.Errorf("NewUbicacion(%q, %q): mensajes distintos %q y %q", tc.zona, tc.finca, errProducto, errProductor)
+		}
+		if up.ZonaVeredal != ur.ZonaVeredal || up.Finca != ur.Finca {
+			t.Errorf("NewUbicacion(%q, %q): producto %+v, productor %+v", tc.zona, tc.finca, up, ur)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package ubicacion
+
+import "strings"
+
+// distanciaMaximaMunicipio es la distancia de Levenshtein máxima para
+// considerar que una zona corresponde a un municipio conocido.
+const distanciaMaximaMunicipio = 3
+
+// NearestMunicipio busca el municipio conocido más parecido a la zona veredal.
+// La comparación no distingue mayúsculas y usa la distancia de Levenshtein.
+//
+// Parámetros:
+//   - knownMunicipios: nombres oficiales de municipios
+//
+// Retorna:
+//   - string: el nombre oficial más cercano, o "" si no hay coincidencia confiable
+//   - int: la distancia al municipio encontrado, o -1 si no hay coincidencia confiable
+func (u Ubicacion) NearestMunicipio(knownMunicipios []string) (string, int) {
+	zona := strings.ToLower(strings.TrimSpace(u.ZonaVeredal))
+
+	mejor, mejorDistancia := "", -1
+	for _, municipio := range knownMunicipios {
+		d := levenshtein(zona, strings.ToLower(municipio))
+		if mejorDistancia == -1 || d < mejorDistancia {
+			mejor, mejorDistancia = municipio, d
+		}
+	}
+
+	if mejorDistancia == -1 || mejorDistancia > distanciaMaximaMunicipio {
+		return "", -1
+	}
+	return mejor, mejorDistancia
+}
+
+// levenshtein calcula la distancia de edición entre dos cadenas comparando runas.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	anterior := make([]int, len(rb)+1)
+	actual := make([]int, len(rb)+1)
+	for j := range anterior {
+		anterior[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		actual[0] = i
+		for j := 1; j <= len(rb); j++ {
+			costo := 1
+			if ra[i-1] == rb[j-1] {
+				costo = 0
+			}
+			actual[j] = min(anterior[j]+1, actual[j-1]+1, anterior[j-1]+costo)
+		}
+		anterior, actual = actual, anterior
+	}
+
+	return anterior[len(rb)]
+}
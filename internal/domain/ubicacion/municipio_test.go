@@ -0,0 +1,59 @@
+package ubicacion
+
+import "testing"
+
+var municipiosPrueba = []string{"Popayán", "Timbío", "Silvia", "Piendamó", "El Tambo"}
+
+func TestNearestMunicipio(t *testing.T) {
+	casos := []struct {
+		nombre    string
+		zona      string
+		municipio string
+		distancia int
+	}{
+		{"exacto", "Popayán", "Popayán", 0},
+		{"exacto sin distinguir mayúsculas", "  el tambo ", "El Tambo", 0},
+		{"sin tilde", "Popayan", "Popayán", 1},
+		{"error de digitación", "Piendamo", "Piendamó", 1},
+		{"letras cambiadas", "Slivia", "Silvia", 2},
+		{"distancia límite", "Timbíoxyz", "Timbío", 3},
+		{"una más del límite", "Timbíowxyz", "", -1},
+		{"sin coincidencia", "Bogotá D.C.", "", -1},
+		{"zona vacía lejos de todos", "", "", -1},
+	}
+	for _, tc := range casos {
+		t.Run(tc.nombre, func(t *testing.T) {
+			municipio, distancia := Ubicacion{ZonaVeredal: tc.zona}.NearestMunicipio(municipiosPrueba)
+			if municipio != tc.municipio || distancia != tc.distancia {
+				t.Errorf("NearestMunicipio(%q) = (%q, %d), se esperaba (%q, %d)",
+					tc.zona, municipio, distancia, tc.municipio, tc.distancia)
+			}
+		})
+	}
+}
+
+func TestNearestMunicipio_ListaVacia(t *testing.T) {
+	municipio, distancia := Ubicacion{ZonaVeredal: "Popayán"}.NearestMunicipio(nil)
+	if municipio != "" || distancia != -1 {
+		t.Errorf("NearestMunicipio(nil) = (%q, %d), se esperaba (\"\", -1)", municipio, distancia)
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	casos := []struct {
+		a, b string
+		d    int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"", "abc", 3},
+		{"kitten", "sitting", 3},
+		{"popayán", "popayan", 1}, // compara runas, no bytes
+		{"ñame", "name", 1},
+	}
+	for _, tc := range casos {
+		if d := levenshtein(tc.a, tc.b); d != tc.d {
+			t.Errorf("levenshtein(%q, %q) = %d, se esperaba %d", tc.a, tc.b, d, tc.d)
+		}
+	}
+}
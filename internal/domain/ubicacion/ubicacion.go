@@ -0,0 +1,104 @@
+// Package ubicacion contiene el value object Ubicacion compartido por los dominios
+// de producto y productor, junto con sus reglas de validación, para que no puedan divergir.
+package ubicacion
+
+import (
+	"regexp"
+	"unicode/utf8"
+
+	"Product_Catalog_Microservice/internal/domain/shared"
+)
+
+// Longitudes máximas permitidas para los campos de una ubicación
+const (
+	MaxZonaVeredal = 40 // máximo de caracteres de la zona veredal
+	MaxFinca       = 50 // máximo de caracteres del nombre de la finca
+)
+
+// Ubicacion representa la ubicación geográfica de una finca productora.
+// Incluye información sobre la zona veredal y la finca específica.
+type Ubicacion struct {
+	ZonaVeredal string // Zona veredal donde se encuentra la finca
+	Finca       string // Nombre de la finca productora
+}
+
+// New crea una nueva instancia de Ubicacion validada con Validar.
+//
+// Parámetros:
+//   - zona: nombre de la zona veredal (máximo 40 caracteres)
+//   - finca: nombre de la finca (máximo 50 caracteres)
+//
+// Retorna:
+//   - Ubicacion: instancia válida del value object
+//   - error: error de validación si algún campo es inválido
+func New(zona, finca string) (Ubicacion, error) {
+	if err := Validar(zona, finca); err != nil {
+		return Ubicacion{}, err
+	}
+	return Ubicacion{ZonaVeredal: zona, Finca: finca}, nil
+}
+
+// patron permite letras (incluye acentos), números, espacios, guiones, apostrofes y puntos.
+// Se compila una sola vez para no repetir el costo en cada validación.
+var patron = regexp.MustCompile(`^[a-zA-ZáéíóúñüÁÉÍÓÚÑÜ0-9\s\-'\.]+$`)
+
+// Validar verifica que la zona veredal y la finca estén especificadas,
+// que no excedan la longitud máxima y que no contengan caracteres prohibidos.
+//
+// Parámetros:
+//   - zona: nombre de la zona veredal (máximo 40 caracteres)
+//   - finca: nombre de la finca (máximo 50 caracteres)
+//
+// Retorna:
+//   - error: error de validación si algún campo es inválido
+func Validar(zona, finca string) error {
+	// Validar campos vacíos
+	if zona == "" {
+		return shared.NewErrorValidacion("zona_veredal", "zona veredal y finca no pueden estar vacíos")
+	}
+	if finca == "" {
+		return shared.NewErrorValidacion("finca", "zona veredal y finca no pueden estar vacíos")
+	}
+
+	// Validar longitud máxima
+	if utf8.RuneCountInString(zona) > MaxZonaVeredal {
+		return shared.NewErrorValidacion("zona_veredal", "la zona veredal no puede superar 40 caracteres")
+	}
+	if utf8.RuneCountInString(finca) > MaxFinca {
+		return shared.NewErrorValidacion("finca", "el nombre de la finca no puede superar 50 caracteres")
+	}
+
+	// Validar caracteres prohibidos
+	if err := validarCaracteresProhibidos(zona, "zona_veredal", "zona veredal"); err != nil {
+		return err
+	}
+	return validarCaracteresProhibidos(finca, "finca", "finca")
+}
+
+// ValidarZona aplica a una zona veredal sola las mismas reglas que Validar, para las consultas
+// que filtran por zona sin indicar la finca.
+//
+// Parámetros:
+//   - zona: nombre de la zona veredal (máximo 40 caracteres)
+//
+// Retorna:
+//   - error: error de validación si la zona es inválida
+func ValidarZona(zona string) error {
+	if zona == "" {
+		return shared.NewErrorValidacion("zona_veredal", "la zona veredal no puede estar vacía")
+	}
+	if utf8.RuneCountInString(zona) > MaxZonaVeredal {
+		return shared.NewErrorValidacion("zona_veredal", "la zona veredal no puede superar 40 caracteres")
+	}
+	return validarCaracteresProhibidos(zona, "zona_veredal", "zona veredal")
+}
+
+// validarCaracteresProhibidos valida que el texto solo contenga caracteres permitidos
+// para nombres de ubicaciones (letras, números, espacios, guiones, apostrofes, puntos).
+// campo es el nombre del dato en las peticiones y etiqueta el nombre legible del mensaje.
+func validarCaracteresProhibidos(texto, campo, etiqueta string) error {
+	if !patron.MatchString(texto) {
+		return shared.NewErrorValidacion(campo, "el campo "+etiqueta+" contiene caracteres no permitidos")
+	}
+	return nil
+}
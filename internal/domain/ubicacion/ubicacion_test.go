@@ -0,0 +1,120 @@
+package ubicacion
+
+import (
+	"strings"
+	"testing"
+)
+
+// Los comentarios de producto documentaban 50/80 caracteres mientras el código exigía 40/50.
+// Se conservan los límites que ya se aplicaban (40 para la zona y 50 para la finca) para que
+// los datos guardados sigan siendo válidos; estas pruebas dejan registrada esa decisión.
+func TestLongitudesMaximas(t *testing.T) {
+	if MaxZonaVeredal != 40 || MaxFinca != 50 {
+		t.Fatalf("MaxZonaVeredal/MaxFinca = %d/%d, la decisión registrada es 40/50", MaxZonaVeredal, MaxFinca)
+	}
+
+	casos := []struct {
+		nombre      string
+		zona, finca string
+		valida      bool
+	}{
+		{"zona de 40", strings.Repeat("a", 40), "Finca", true},
+		{"zona de 41", strings.Repeat("a", 41), "Finca", false},
+		{"finca de 50", "Vereda", strings.Repeat("a", 50), true},
+		{"finca de 51", "Vereda", strings.Repeat("a", 51), false},
+	}
+	for _, tc := range casos {
+		t.Run(tc.nombre, func(t *testing.T) {
+			exigirValidez(t, Validar(tc.zona, tc.finca), tc.valida)
+		})
+	}
+}
+
+func TestValidar_Caracteres(t *testing.T) {
+	casos := []struct {
+		nombre      string
+		zona, finca string
+		valida      bool
+	}{
+		{"tildes y eñes", "Vereda Peñón", "Finca Ñañú", true},
+		{"guiones, apóstrofes y puntos", "Km. 5 - Vía al Mar", "Finca D'Alba", true},
+		{"zona vacía", "", "Finca", false},
+		{"finca vacía", "Vereda", "", false},
+		{"zona con símbolos", "Vereda <script>", "Finca", false},
+		{"finca con arroba", "Vereda", "finca@correo", false},
+		{"emoji", "Vereda 🌱", "Finca", false},
+	}
+	for _, tc := range casos {
+		t.Run(tc.nombre, func(t *testing.T) {
+			exigirValidez(t, Validar(tc.zona, tc.finca), tc.valida)
+		})
+	}
+}
+
+func TestValidarZona(t *testing.T) {
+	casos := []struct {
+		nombre string
+		zona   string
+		valida bool
+	}{
+		{"válida", "Vereda El Paraíso", true},
+		{"zona de 40", strings.Repeat("ñ", 40), true},
+		{"zona de 41", strings.Repeat("a", 41), false},
+		{"vacía", "", false},
+		{"con símbolos", "Vereda <script>", false},
+		{"emoji", "Vereda 🌱", false},
+	}
+	for _, tc := range casos {
+		t.Run(tc.nombre, func(t *testing.T) {
+			exigirValidez(t, ValidarZona(tc.zona), tc.valida)
+
+			// Una zona es válida sola si y solo si lo es junto a una finca válida
+			if (ValidarZona(tc.zona) == nil) != (Validar(tc.zona, "Finca") == nil) {
+				t.Errorf("ValidarZona y Validar no coinciden para %q", tc.zona)
+			}
+		})
+	}
+}
+
+// La expresión regular se compila una sola vez por paquete: validar no asigna memoria
+func TestNew(t *testing.T) {
+	u, err := New("Vereda El Paraíso", "Finca La Esperanza")
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if u != (Ubicacion{ZonaVeredal: "Vereda El Paraíso", Finca: "Finca La Esperanza"}) {
+		t.Errorf("New = %+v", u)
+	}
+	if u, err := New("", "Finca"); err == nil || u != (Ubicacion{}) {
+		t.Errorf("New inválida = (%+v, %v), se esperaba la ubicación vacía y un error", u, err)
+	}
+}
+
+func TestValidar_SinAsignaciones(t *testing.T) {
+	asignaciones := testing.AllocsPerRun(100, func() {
+		_ = Validar("Vereda El Paraíso", "Finca La Esperanza")
+	})
+	if asignaciones != 0 {
+		t.Errorf("Validar asigna %v veces por llamada, se esperaba 0", asignaciones)
+	}
+}
+
+// exigirValidez comprueba que err sea nil si la ubicación es válida, o un error si no lo es
+func exigirValidez(t *testing.T, err error, valida bool) {
+	t.Helper()
+	if valida && err != nil {
+		t.Errorf("err = %v, se esperaba una ubicación válida", err)
+	}
+	if !valida && err == nil {
+		t.Error("se esperaba un error de validación")
+	}
+}
+
+func BenchmarkValidar(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := Validar("Vereda El Paraíso", "Finca La Esperanza"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+
+	"Product_Catalog_Microservice/internal/domain/service"
+	"github.com/gin-gonic/gin"
+)
+
+type AdminHandler struct {
+	Catalogo *service.CatalogoService
+
+	// Configuracion es la configuración efectiva del servicio. No debe contener credenciales.
+	Configuracion any
+
+	// Capacidad reporta la utilización actual de los repositorios; puede ser nil
+	Capacidad func() any
+}
+
+// GET /catalogo/admin/configuracion
+func (h *AdminHandler) GetConfiguracion(c *gin.Context) {
+	data, err := json.Marshal(h.Configuracion)
+	if err != nil {
+		responderError(c, err)
+		return
+	}
+
+	// El hash permite comparar rápidamente si dos instancias corren con la misma configuración.
+	// La utilización cambia con cada escritura, por eso queda fuera del hash.
+	hash := sha256.Sum256(data)
+	respuesta := gin.H{
+		"configuracion": json.RawMessage(data),
+		"config_hash":   hex.EncodeToString(hash[:]),
+	}
+	if h.Capacidad != nil {
+		respuesta["capacidad"] = h.Capacidad()
+	}
+	c.JSON(http.StatusOK, respuesta)
+}
+
+// POST /catalogo/admin/auditar-invariantes
+// Solo reporta: la reparación modifica agregados y queda en el binario (auditar-invariantes --reparar).
+func (h *AdminHandler) AuditarInvariantes(c *gin.Context) {
+	if _, ok := c.GetQuery("reparar"); ok {
+		responderValidacion(c, "reparar", "la reparación solo está disponible en el binario: auditar-invariantes --reparar")
+		return
+	}
+
+	reporte, err := h.Catalogo.AuditarInvariantes(false)
+	if err != nil {
+		responderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, reporte)
+}
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+	"Product_Catalog_Microservice/internal/domain/service"
+)
+
+// configuracionPrueba imita la forma de la configuración de cmd/app
+type configuracionPrueba struct {
+	Version string `json:"version"`
+	Limite  int    `json:"limite"`
+}
+
+func TestGetConfiguracion(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	pedir := func(cfg any) (json.RawMessage, string) {
+		t.Helper()
+		r := gin.New()
+		r.GET("/configuracion", (&AdminHandler{Configuracion: cfg}).GetConfiguracion)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/configuracion", nil))
+		exigirStatus(t, w, http.StatusOK)
+
+		cuerpo := decodificar[struct {
+			Configuracion json.RawMessage `json:"configuracion"`
+			ConfigHash    string          `json:"config_hash"`
+		}](t, w)
+		return cuerpo.Configuracion, cuerpo.ConfigHash
+	}
+
+	cfg := configuracionPrueba{Version: "1.2.0", Limite: 16}
+	configuracion, hash := pedir(cfg)
+	if string(configuracion) != `{"version":"1.2.0","limite":16}` {
+		t.Errorf("configuracion = %s", configuracion)
+	}
+	suma := sha256.Sum256(configuracion)
+	if hash != hex.EncodeToString(suma[:]) {
+		t.Errorf("config_hash = %s, se esperaba el sha256 de la configuración", hash)
+	}
+
+	// Misma configuración, mismo hash; un valor distinto cambia el hash
+	if _, otro := pedir(cfg); otro != hash {
+		t.Errorf("config_hash = %s con la misma configuración, se esperaba %s", otro, hash)
+	}
+	cfg.Limite = 32
+	if _, otro := pedir(cfg); otro == hash {
+		t.Error("config_hash no cambió al cambiar la configuración")
+	}
+}
+
+// Las rutas de administración de la tabla compartida exigen el token
+func TestRutasAdmin_RequierenToken(t *testing.T) {
+	s := nuevoServidorPrueba(t)
+
+	exigirError(t, s.hacer(http.MethodGet, "/catalogo/admin/configuracion", ""), http.StatusUnauthorized, CodigoNoAutenticado)
+	exigirStatus(t, s.hacer(http.MethodGet, "/catalogo/admin/configuracion", "", autorizacionAdmin...), http.StatusOK)
+
+	// Sin guardia las rutas no se registran
+	r := gin.New()
+	RegistrarRutas(r, Rutas{Producto: &ProductoHandler{}, Productor: &ProductorHandler{}, Admin: &AdminHandler{}, Salud: &SaludHandler{}})
+	for _, ruta := range r.Routes() {
+		if strings.HasPrefix(ruta.Path, "/catalogo/admin/") || ruta.Path == "/catalogo/mis-rechazos" {
+			t.Errorf("%s %s registrada sin guardia de administración", ruta.Method, ruta.Path)
+		}
+	}
+}
+
+// La auditoría por HTTP solo reporta; la reparación queda en el binario
+func TestAuditarInvariantes(t *testing.T) {
+	s := nuevoServidorPrueba(t)
+	s.publicar(t, s.semilla1, "Fresa")
+
+	// Un estado inválido es reparable, pero por HTTP solo se reporta
+	corrupto, err := s.productoRepo.GetByID("producto-000001")
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	corrupto.Estado = producto.EstadoDisponibilidad{Value: "Vendido"}
+	if err := s.productoRepo.Update(corrupto); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	exigirError(t, s.hacer(http.MethodPost, "/catalogo/admin/auditar-invariantes", ""), http.StatusUnauthorized, CodigoNoAutenticado)
+
+	w := s.hacer(http.MethodPost, "/catalogo/admin/auditar-invariantes", "", autorizacionAdmin...)
+	exigirStatus(t, w, http.StatusOK)
+	reporte := decodificar[service.ReporteInvariantes](t, w)
+	if len(reporte.Violaciones) != 1 || reporte.Violaciones[0].Campo != "estado" || reporte.Violaciones[0].Reparado {
+		t.Errorf("violaciones = %+v, se esperaba el estado inválido sin reparar", reporte.Violaciones)
+	}
+	if reporte.ProductoresRevisados != 2 {
+		t.Errorf("productores_revisados = %d, se esperaba 2", reporte.ProductoresRevisados)
+	}
+
+	for _, query := range []string{"?reparar=true", "?reparar=false"} {
+		r := exigirError(t, s.hacer(http.MethodPost, "/catalogo/admin/auditar-invariantes"+query, "", autorizacionAdmin...),
+			http.StatusBadRequest, CodigoValidacion)
+		if r.Field != "reparar" {
+			t.Errorf("%s: field = %q, se esperaba reparar", query, r.Field)
+		}
+	}
+	if guardado, _ := s.productoRepo.GetByID("producto-000001"); guardado.Estado.Value != "Vendido" {
+		t.Errorf("Estado = %q; la auditoría por HTTP no debía reparar", guardado.Estado.Value)
+	}
+}
@@ -3,151 +3,467 @@ package handlers
 
 
 import (
+    "crypto/sha256"
+    "encoding/hex"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "log/slog"
     "net/http"
+    "strconv"
+    "strings"
     "time"
 
     "github.com/gin-gonic/gin"
-	"github.com/google/uuid"
+    "github.com/google/uuid"
+    "github.com/prometheus/client_golang/prometheus"
 	"Product_Catalog_Microservice/internal/domain/producto"
 	"Product_Catalog_Microservice/internal/domain/productor"
 	"Product_Catalog_Microservice/internal/domain/service"
+	"Product_Catalog_Microservice/internal/domain/shared"
+	"Product_Catalog_Microservice/internal/handlers/dto"
+	"Product_Catalog_Microservice/internal/ids"
+)
+
+// Límites de productos por grupo en GET /catalogo/agrupado
+const (
+    productosPorGrupoPorDefecto = 12
+    maxProductosPorGrupo        = 100
 )
 
 type ProductoHandler struct {
     Catalogo *service.CatalogoService
+    IDs      ids.IDGenerator
+
+    // PresupuestoPublicacion es la latencia por encima de la cual se registra una publicación lenta.
+    // Cero desactiva el registro.
+    PresupuestoPublicacion time.Duration
+    // Logger recibe los registros de publicaciones lentas; nil usa slog.Default()
+    Logger *slog.Logger
+    // EtapasPublicacion acumula la duración de cada etapa de la publicación; nil no observa nada
+    EtapasPublicacion *prometheus.HistogramVec
+}
+
+// publicarProductoRequest es el cuerpo de POST /catalogo/producto
+type publicarProductoRequest struct {
+    ProductorID     string  `json:"productor_id"`
+    ProductoID      string  `json:"producto_id"`
+    Nombre          string  `json:"nombre"`
+    Descripcion     string  `json:"descripcion"`
+    Categoria       string  `json:"categoria"`
+    TipoProduccion  string  `json:"tipo_produccion"`
+    TemporadaInicio string  `json:"temporada_inicio"` // formato: "2006-01-02" o RFC3339
+    TemporadaFin    string  `json:"temporada_fin"`    // formato: "2006-01-02" o RFC3339
+    ZonaVeredal     string  `json:"zona_veredal"`
+    Finca           string  `json:"finca"`
+    ImagenURL       string  `json:"imagen_url"`
+    ImagenDesc      string  `json:"imagen_desc"`
+    PrecioValor     float64 `json:"precio_valor"`
+    PrecioMoneda    string  `json:"precio_moneda"` // código ISO 4217, p. ej. "COP"
+    CantidadValor   int     `json:"cantidad_valor"`
+    CantidadUnidad  string  `json:"cantidad_unidad"` // kg, g, L, unidad o docena
+    MinReputacion   float32 `json:"min_reputacion"`
 }
 
 // POST /productos/publicar
 func (h *ProductoHandler) PublicarProducto(c *gin.Context) {
-    type requestBody struct {
-        ProductorID     string  `json:"productor_id"`
-        ProductoID      string  `json:"producto_id"`
-        Nombre          string  `json:"nombre"`
-        Descripcion     string  `json:"descripcion"`
-        Categoria       string  `json:"categoria"`
-        TipoProduccion  string  `json:"tipo_produccion"`
-        TemporadaInicio string  `json:"temporada_inicio"` // formato: "2006-01-02"
-        TemporadaFin    string  `json:"temporada_fin"`    // formato: "2006-01-02"
-        ZonaVeredal     string  `json:"zona_veredal"`
-        Finca           string  `json:"finca"`
-        ImagenURL       string  `json:"imagen_url"`
-        ImagenDesc      string  `json:"imagen_desc"`
-        MinReputacion   float32 `json:"min_reputacion"`
-    }
-
-    var req requestBody
-    if err := c.ShouldBindJSON(&req); err != nil {
-        c.JSON(http.StatusBadRequest, gin.H{"error": "JSON inválido: " + err.Error()})
+    crono := service.NuevoCronometro()
+
+    terminar := crono.Etapa("binding")
+    var req publicarProductoRequest
+    if err := bindJSONDialecto[publicarProductoRequest, publicarProductoRequestEn](c, &req); err != nil {
+        responderJSONInvalido(c, err)
         return
     }
+    terminar()
+    anotarAgregadosEnLog(c, req.ProductorID, req.ProductoID)
 
     // Generación de IDs y value objects
+    terminar = crono.Etapa("value_objects")
     productorID := req.ProductorID
-    productoID := producto.ProductoID(uuid.New().String()) // forzado en backend
+    // El cliente puede traer su propio ID (p. ej. al migrar desde otra fuente); si no, lo genera el backend
+    var productoID producto.ProductoID
+    if req.ProductoID == "" {
+        productoID = h.IDs.NewProductoID()
+    } else {
+        id, err := uuid.Parse(req.ProductoID)
+        if err != nil {
+            responderValidacion(c, "producto_id", "producto_id debe ser un UUID")
+            return
+        }
+        productoID = producto.ProductoID(id.String())
+    }
 
     nombre, err := producto.NewNombreProducto(req.Nombre)
     if err != nil {
-        c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+        responderError(c, err)
         return
     }
     desc, err := producto.NewDescripcionProducto(req.Descripcion)
     if err != nil {
-        c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+        responderError(c, err)
         return
     }
     categoria, err := producto.NewCategoria(req.Categoria)
     if err != nil {
-        c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+        responderError(c, err)
+        return
+    }
+    tipo, err := producto.NewTipoProduccion(req.TipoProduccion)
+    if err != nil {
+        responderError(c, err)
         return
     }
-    tipo := producto.TipoProduccion(req.TipoProduccion)
 
-    temporadaInicio, err := time.Parse("2006-01-02", req.TemporadaInicio)
+    temporadaInicio, err := parsearFecha(req.TemporadaInicio, producto.ZonaHoraria())
     if err != nil {
-        c.JSON(http.StatusBadRequest, gin.H{"error": "Formato de fecha de inicio inválido"})
+        responderValidacion(c, "temporada_inicio", "Formato de fecha de inicio inválido, "+formatosFechaAceptados)
         return
     }
-    temporadaFin, err := time.Parse("2006-01-02", req.TemporadaFin)
+    temporadaFin, err := parsearFecha(req.TemporadaFin, producto.ZonaHoraria())
     if err != nil {
-        c.JSON(http.StatusBadRequest, gin.H{"error": "Formato de fecha de fin inválido"})
+        responderValidacion(c, "temporada_fin", "Formato de fecha de fin inválido, "+formatosFechaAceptados)
         return
     }
     temporada, err := producto.NewTemporadaLocal(temporadaInicio, temporadaFin)
     if err != nil {
-        c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+        responderError(c, err)
         return
     }
 
     ubicacion, err := producto.NewUbicacion(req.ZonaVeredal, req.Finca)
     if err != nil {
-        c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+        responderError(c, err)
         return
     }
     imagen, err := producto.NewImagen(req.ImagenURL, req.ImagenDesc)
     if err != nil {
-        c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+        responderError(c, err)
+        return
+    }
+    precio, err := producto.NewPrecio(req.PrecioValor, req.PrecioMoneda)
+    if err != nil {
+        responderError(c, err)
+        return
+    }
+    cantidad, err := producto.NewCantidadDisponible(req.CantidadValor, req.CantidadUnidad)
+    if err != nil {
+        responderError(c, err)
         return
     }
     minReputacion, err := productor.NuevaReputacion(req.MinReputacion)
     if err != nil {
-        c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+        responderError(c, err)
         return
     }
 
-    prod, err := h.Catalogo.PublicarProducto(
-        productor.ProductorID(productorID),
-        producto.ProductoID(productoID),
-        nombre,
-        desc,
-        categoria,
-        tipo,
-        temporada,
-        ubicacion,
-        imagen,
-        minReputacion,
+    terminar()
+
+    clave := c.GetHeader(HeaderIdempotencyKey)
+    if len(clave) > maxLongitudIdempotencyKey {
+        responderValidacion(c, HeaderIdempotencyKey, fmt.Sprintf("%s no puede superar %d caracteres", HeaderIdempotencyKey, maxLongitudIdempotencyKey))
+        return
+    }
+
+    prod, repetido, err := h.Catalogo.PublicarConIdempotencia(clave, huellaPeticion(req), func() (*producto.ProductoAgroecologico, error) {
+        return h.Catalogo.PublicarProducto(
+            service.ConCronometro(c.Request.Context(), crono),
+            productor.ProductorID(productorID),
+            producto.ProductoID(productoID),
+            nombre,
+            desc,
+            categoria,
+            tipo,
+            temporada,
+            ubicacion,
+            imagen,
+            precio,
+            cantidad,
+            minReputacion,
+        )
+    })
+    c.Header("Server-Timing", crono.ServerTiming())
+    h.observarEtapas(crono)
+    h.registrarSiEsLenta(c, crono)
+    if err != nil {
+        responderError(c, err)
+        return
+    }
+    anotarAgregadosEnLog(c, "", string(prod.ID))
+
+    // Un reintento con la misma Idempotency-Key recibe el producto original, sin crear otro
+    if repetido {
+        responderProductoCreado(c, http.StatusOK, prod)
+        return
+    }
+    responderProductoCreado(c, http.StatusCreated, prod)
+}
+
+// observarEtapas acumula en el histograma la duración de cada etapa medida
+func (h *ProductoHandler) observarEtapas(crono *service.Cronometro) {
+    if h.EtapasPublicacion == nil {
+        return
+    }
+    for _, etapa := range crono.Etapas() {
+        h.EtapasPublicacion.WithLabelValues(etapa.Nombre).Observe(etapa.Duracion.Seconds())
+    }
+}
+
+// HeaderIdempotencyKey permite reintentar POST /catalogo/producto sin duplicar el producto
+const HeaderIdempotencyKey = "Idempotency-Key"
+
+// maxLongitudIdempotencyKey es la longitud máxima aceptada de HeaderIdempotencyKey
+const maxLongitudIdempotencyKey = 255
+
+// huellaPeticion resume el cuerpo ya traducido al español, de modo que el mismo producto
+// enviado en cualquiera de los dos dialectos produce la misma huella
+func huellaPeticion(req publicarProductoRequest) string {
+    data, _ := json.Marshal(req)
+    hash := sha256.Sum256(data)
+    return hex.EncodeToString(hash[:])
+}
+
+// registrarSiEsLenta deja un registro estructurado cuando la publicación supera el presupuesto,
+// indicando la etapa que más tiempo consumió
+func (h *ProductoHandler) registrarSiEsLenta(c *gin.Context, crono *service.Cronometro) {
+    total := crono.Total()
+    if h.PresupuestoPublicacion <= 0 || total <= h.PresupuestoPublicacion {
+        return
+    }
+
+    etapas := make([]any, 0, len(crono.Etapas()))
+    for _, etapa := range crono.Etapas() {
+        etapas = append(etapas, slog.Duration(etapa.Nombre, etapa.Duracion))
+    }
+    logger := h.Logger
+    if logger == nil {
+        logger = slog.Default()
+    }
+    dominante := crono.Dominante()
+    logger.Warn("publicación lenta",
+        "request_id", c.GetString("request_id"),
+        "total", total,
+        "presupuesto", h.PresupuestoPublicacion,
+        "etapa_dominante", dominante.Nombre,
+        "duracion_dominante", dominante.Duracion,
+        slog.Group("etapas", etapas...),
     )
+}
+
+// GET /catalogo/productos?categoria=Fruta&estado=Disponible&zona_veredal=El%20Placer&tipo_produccion=Organico
+// Con ?cursor= o ?limit= recorre los productos por publicación, página a página.
+func (h *ProductoHandler) GetProductos(c *gin.Context) {
+    filtro, err := filtroDesdeQuery(c)
+    if err != nil {
+        responderError(c, err)
+        return
+    }
+
+    if limite, ok, err := limiteCursorDesdeQuery(c); err != nil {
+        responderError(c, err)
+        return
+    } else if ok {
+        pagina, err := h.Catalogo.GetProductosDesdeCursor(filtro, c.Query("cursor"), limite)
+        if err != nil {
+            responderError(c, err)
+            return
+        }
+        responderPaginaProductos(c, pagina)
+        return
+    }
+
+    productos, err := h.Catalogo.BuscarProductosConFiltros(filtro)
     if err != nil {
-        c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+        responderError(c, err)
         return
     }
 
-    c.JSON(http.StatusCreated, prod)
+    responderProductos(c, productos)
+}
+
+// limiteCursorDesdeQuery lee ?limit= para recorrer GET /catalogo/productos por cursor. ok es
+// false si no vienen ni cursor ni limit, y el listado se responde completo.
+func limiteCursorDesdeQuery(c *gin.Context) (limite int, ok bool, err error) {
+    valor, conLimit := c.GetQuery("limit")
+    _, conCursor := c.GetQuery("cursor")
+    if !conLimit {
+        return shared.DefaultPageSize, conCursor, nil
+    }
+    limite, err = strconv.Atoi(valor)
+    if err != nil || limite < 1 || limite > shared.MaxPageSize {
+        return 0, false, shared.NewErrorValidacion("limit", fmt.Sprintf("limit debe ser un entero entre 1 y %d", shared.MaxPageSize))
+    }
+    return limite, true, nil
+}
+
+// GET /catalogo/productos/:id (también /catalogo/producto/:id)
+func (h *ProductoHandler) GetProductoByID(c *gin.Context) {
+    id := strings.TrimSpace(c.Param("id"))
+    if id == "" {
+        responderValidacion(c, "id", "ID de producto inválido")
+        return
+    }
+
+    prod, err := h.Catalogo.GetProducto(producto.ProductoID(id))
+    if err != nil {
+        responderError(c, err)
+        return
+    }
+
+    // X-Productor-ID es aquí un indicio sin autenticar de que quien consulta es el dueño, para
+    // que vea sus cambios al instante: solo saca su respuesta de las cachés compartidas y nunca
+    // cambia el contenido, así que falsificarlo únicamente cuesta al cliente un acierto de caché.
+    // Vary evita que una caché compartida entregue al dueño la copia pública.
+    c.Writer.Header().Add("Vary", HeaderProductorID)
+    if propietario := c.GetHeader(HeaderProductorID); propietario != "" && propietario == prod.ProductorID {
+        c.Header("Cache-Control", CachePrivada)
+    }
+
+    responderProducto(c, http.StatusOK, prod)
+}
+
+// marcarExcedenteRequest es el cuerpo de POST /catalogo/productos/excedente
+type marcarExcedenteRequest struct {
+    ProductoID string `json:"producto_id"`
+    Fecha      string `json:"fecha"` // formato: "2006-01-02" o RFC3339
 }
 
 // POST /productos/excedente
 func (h *ProductoHandler) MarcarProductoComoExcedente(c *gin.Context) {
-    type requestBody struct {
-        ProductoID string `json:"producto_id"`
-        Fecha      string `json:"fecha"` // formato: "2006-01-02"
+    var req marcarExcedenteRequest
+    if err := bindJSONDialecto[marcarExcedenteRequest, marcarExcedenteRequestEn](c, &req); err != nil {
+        responderJSONInvalido(c, err)
+        return
     }
+    anotarAgregadosEnLog(c, "", req.ProductoID)
 
-    var req requestBody
+    productoID := producto.ProductoID(req.ProductoID)
+    fecha, err := parsearFecha(req.Fecha, producto.ZonaHoraria())
+    if err != nil {
+        responderValidacion(c, "fecha", "Formato de fecha inválido, "+formatosFechaAceptados)
+        return
+    }
+
+    sinCambios, err := h.Catalogo.MarcarProductoComoExcedente(productoID, fecha)
+    if err != nil {
+        responderError(c, err)
+        return
+    }
+
+    if sinCambios {
+        c.JSON(http.StatusOK, gin.H{"sin_cambios": true})
+        return
+    }
+    c.Status(http.StatusNoContent)
+}
+
+// PUT /catalogo/productos/:id
+func (h *ProductoHandler) ActualizarInformacionProducto(c *gin.Context) {
+    var req struct {
+        Nombre      string `json:"nombre"`
+        Descripcion string `json:"descripcion"`
+        ImagenURL   string `json:"imagen_url"`
+        ImagenDesc  string `json:"imagen_desc"`
+    }
     if err := c.ShouldBindJSON(&req); err != nil {
-        c.JSON(http.StatusBadRequest, gin.H{"error": "JSON inválido: " + err.Error()})
+        responderJSONInvalido(c, err)
         return
     }
 
-    productoID := producto.ProductoID(req.ProductoID)
-    fecha, err := time.Parse("2006-01-02", req.Fecha)
+    nombre, err := producto.NewNombreProducto(req.Nombre)
+    if err != nil {
+        responderError(c, err)
+        return
+    }
+    desc, err := producto.NewDescripcionProducto(req.Descripcion)
+    if err != nil {
+        responderError(c, err)
+        return
+    }
+    imagen, err := producto.NewImagen(req.ImagenURL, req.ImagenDesc)
+    if err != nil {
+        responderError(c, err)
+        return
+    }
+
+    prod, err := h.Catalogo.ActualizarInformacionProducto(producto.ProductoID(c.Param("id")), nombre, desc, imagen)
+    if err != nil {
+        responderError(c, err)
+        return
+    }
+
+    responderProducto(c, http.StatusOK, prod)
+}
+
+// PUT /catalogo/productos/:id/precio
+func (h *ProductoHandler) ActualizarPrecio(c *gin.Context) {
+    var req struct {
+        Valor  float64 `json:"valor"`
+        Moneda string  `json:"moneda"`
+    }
+    if err := c.ShouldBindJSON(&req); err != nil {
+        responderJSONInvalido(c, err)
+        return
+    }
+
+    precio, err := producto.NewPrecio(req.Valor, req.Moneda)
     if err != nil {
-        c.JSON(http.StatusBadRequest, gin.H{"error": "Formato de fecha inválido"})
+        responderError(c, err)
+        return
+    }
+
+    if err := h.Catalogo.ActualizarPrecio(producto.ProductoID(c.Param("id")), precio); err != nil {
+        responderError(c, err)
+        return
+    }
+
+    c.Status(http.StatusNoContent)
+}
+
+// PUT /catalogo/productos/:id/stock
+// Descuenta {"cantidad": n} de la cantidad disponible del producto.
+func (h *ProductoHandler) DecrementarStock(c *gin.Context) {
+    var req struct {
+        Cantidad int `json:"cantidad"`
+    }
+    if err := c.ShouldBindJSON(&req); err != nil {
+        responderJSONInvalido(c, err)
+        return
+    }
+    if req.Cantidad <= 0 {
+        responderValidacion(c, "cantidad", "la cantidad a descontar debe ser mayor que cero")
         return
     }
 
-    if err := h.Catalogo.MarcarProductoComoExcedente(productoID, fecha); err != nil {
-        c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+    if err := h.Catalogo.DecrementarStock(producto.ProductoID(c.Param("id")), req.Cantidad); err != nil {
+        responderError(c, err)
         return
     }
 
     c.Status(http.StatusNoContent)
 }
 
+// POST /catalogo/productos/:id/agotar
+func (h *ProductoHandler) AgotarProducto(c *gin.Context) {
+    sinCambios, err := h.Catalogo.AgotarProducto(producto.ProductoID(c.Param("id")))
+    if err != nil {
+        responderError(c, err)
+        return
+    }
+
+    if sinCambios {
+        c.JSON(http.StatusOK, gin.H{"sin_cambios": true})
+        return
+    }
+    c.Status(http.StatusNoContent)
+}
+
 // PUT /productos/disponibilidad
 func (h *ProductoHandler) ActualizarDisponibilidadPorTemporada(c *gin.Context) {
     now := time.Now()
 
     if err := h.Catalogo.ActualizarDisponibilidadPorTemporada(now); err != nil {
-        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        responderError(c, err)
         return
     }
 
@@ -155,12 +471,350 @@ func (h *ProductoHandler) ActualizarDisponibilidadPorTemporada(c *gin.Context) {
 }
 // ...existing code...
 
+// GET /catalogo/completo?page=1&page_size=20
+// Sin page ni page_size responde el catálogo entero como siempre.
 func (h *ProductoHandler) GetCatalogoCompleto(c *gin.Context) {
+    if params, ok, err := paginacionDesdeQuery(c); err != nil {
+        responderError(c, err)
+        return
+    } else if ok {
+        pagina, err := h.Catalogo.GetCatalogoCompletoPaginado(params)
+        if err != nil {
+            responderError(c, err)
+            return
+        }
+        c.JSON(http.StatusOK, dto.NewCatalogoCompletoPaginadoResponse(pagina))
+        return
+    }
+
     catalogo, err := h.Catalogo.GetCatalogoCompleto()
     if err != nil {
-        c.JSON(500, gin.H{"error": err.Error()})
+        responderError(c, err)
         return
     }
 
-    c.JSON(200, catalogo)
-}
\ No newline at end of file
+    c.JSON(200, dto.NewCatalogoCompletoResponse(catalogo))
+}
+
+// paginacionDesdeQuery lee page y page_size; ok es false si la petición no trae ninguno.
+// Los valores fuera de rango se normalizan con shared.NewPaginationParams.
+func paginacionDesdeQuery(c *gin.Context) (params shared.PaginationParams, ok bool, err error) {
+    valorPage, conPage := c.GetQuery("page")
+    valorPageSize, conPageSize := c.GetQuery("page_size")
+    if !conPage && !conPageSize {
+        return shared.PaginationParams{}, false, nil
+    }
+
+    page, pageSize := 1, shared.DefaultPageSize
+    if conPage {
+        if page, err = strconv.Atoi(valorPage); err != nil {
+            return shared.PaginationParams{}, false, shared.NewErrorValidacion("page", "page debe ser un entero")
+        }
+    }
+    if conPageSize {
+        if pageSize, err = strconv.Atoi(valorPageSize); err != nil {
+            return shared.PaginationParams{}, false, shared.NewErrorValidacion("page_size", "page_size debe ser un entero")
+        }
+    }
+    return shared.NewPaginationParams(page, pageSize), true, nil
+}
+
+// GET /catalogo/agrupado?por=categoria|zona
+// Con ?grupo= y ?cursor= retorna la siguiente página de un solo grupo.
+func (h *ProductoHandler) GetCatalogoAgrupado(c *gin.Context) {
+    filtro, err := filtroDesdeQuery(c)
+    if err != nil {
+        responderError(c, err)
+        return
+    }
+
+    porGrupo := productosPorGrupoPorDefecto
+    if valor := c.Query("por_grupo"); valor != "" {
+        porGrupo, err = strconv.Atoi(valor)
+        if err != nil || porGrupo < 1 || porGrupo > maxProductosPorGrupo {
+            responderValidacion(c, "por_grupo", fmt.Sprintf("por_grupo debe ser un entero entre 1 y %d", maxProductosPorGrupo))
+            return
+        }
+    }
+
+    por := c.DefaultQuery("por", service.AgruparPorCategoria)
+    if grupo, ok := c.GetQuery("grupo"); ok {
+        pagina, err := h.Catalogo.GetGrupoCatalogo(por, grupo, filtro, porGrupo, c.Query("cursor"))
+        if err != nil {
+            responderError(c, err)
+            return
+        }
+        c.JSON(http.StatusOK, dto.NewGrupoCatalogoResponse(pagina))
+        return
+    }
+
+    grupos, err := h.Catalogo.GetCatalogoAgrupado(por, filtro, porGrupo, c.Query("incluir_vacios") == "true")
+    if err != nil {
+        responderError(c, err)
+        return
+    }
+    c.JSON(http.StatusOK, dto.NewGrupoCatalogoResponses(grupos))
+}
+
+// GET /catalogo/estadisticas/zonas
+func (h *ProductoHandler) GetResumenCatalogoPorZona(c *gin.Context) {
+    resumen, err := h.Catalogo.GetResumenCatalogoPorZona()
+    if err != nil {
+        responderError(c, err)
+        return
+    }
+
+    c.JSON(http.StatusOK, dto.NewResumenZonaResponses(resumen))
+}
+
+// GET /catalogo/pronostico?categoria=Hortaliza&zona=X&mes=2025-08
+func (h *ProductoHandler) GetPronostico(c *gin.Context) {
+    mes, err := time.Parse("2006-01", c.Query("mes"))
+    if err != nil {
+        responderValidacion(c, "mes", "Formato de mes inválido, se espera AAAA-MM")
+        return
+    }
+
+    filtro, err := filtroDesdeQuery(c)
+    if err != nil {
+        responderError(c, err)
+        return
+    }
+    if zona := c.Query("zona"); zona != "" {
+        filtro.ZonaVeredal = zona
+    }
+
+    pronostico, err := h.Catalogo.GetPronostico(filtro, mes.Year(), int(mes.Month()))
+    if err != nil {
+        responderError(c, err)
+        return
+    }
+
+    c.JSON(http.StatusOK, pronostico)
+}
+
+// GET /catalogo/sugerencias/temporada?categoria=Fruta&nombre=mango&zona_veredal=X
+// Responde 204 si no hay suficientes productos similares para sugerir una temporada.
+func (h *ProductoHandler) GetSugerenciaTemporada(c *gin.Context) {
+    categoria, err := producto.NewCategoria(c.Query("categoria"))
+    if err != nil {
+        responderError(c, err)
+        return
+    }
+    nombre := strings.TrimSpace(c.Query("nombre"))
+    if nombre == "" {
+        responderValidacion(c, "nombre", "el parámetro nombre es obligatorio")
+        return
+    }
+
+    sugerencia, err := h.Catalogo.SugerirTemporada(categoria, nombre, c.Query("zona_veredal"), time.Now())
+    if err != nil {
+        if errors.Is(err, service.ErrMuestraInsuficiente) {
+            c.Status(http.StatusNoContent)
+            return
+        }
+        responderError(c, err)
+        return
+    }
+
+    // Mismo formato que temporada_inicio/temporada_fin al publicar
+    c.JSON(http.StatusOK, gin.H{
+        "temporada_inicio": sugerencia.Inicio.Format("2006-01-02"),
+        "temporada_fin":    sugerencia.Fin.Format("2006-01-02"),
+        "muestra":          sugerencia.Muestra,
+    })
+}
+
+// GET /catalogo/productos/zona?zona_veredal=X&finca=Y
+// Productos disponibles de productores verificados y activos de la zona. Sin finca se
+// consideran todas las fincas de la zona veredal.
+func (h *ProductoHandler) GetProductosEnZona(c *gin.Context) {
+    zona := strings.TrimSpace(c.Query("zona_veredal"))
+    if zona == "" {
+        responderValidacion(c, "zona_veredal", "el parámetro zona_veredal es obligatorio")
+        return
+    }
+
+    ubicacion := productor.Ubicacion{ZonaVeredal: zona}
+    if finca := strings.TrimSpace(c.Query("finca")); finca != "" {
+        var err error
+        ubicacion, err = productor.NewUbicacion(zona, finca)
+        if err != nil {
+            responderError(c, err)
+            return
+        }
+    } else if err := productor.ValidarZona(zona); err != nil {
+        responderError(c, err)
+        return
+    }
+
+    productos, err := h.Catalogo.GetProductosDisponiblesEnZona(ubicacion)
+    if err != nil {
+        responderError(c, err)
+        return
+    }
+
+    responderProductos(c, productos)
+}
+
+// GET /catalogo/productos/categoria/:categoria
+func (h *ProductoHandler) GetProductosPorCategoria(c *gin.Context) {
+    categoria, err := producto.NewCategoria(c.Param("categoria"))
+    if err != nil {
+        responderError(c, err)
+        return
+    }
+
+    productos, err := h.Catalogo.GetProductosByCategoria(categoria)
+    if err != nil {
+        responderError(c, err)
+        return
+    }
+
+    if productos == nil {
+        productos = []*producto.ProductoAgroecologico{}
+    }
+    responderProductos(c, productos)
+}
+
+// GET /catalogo/productos/tipo/:tipo
+func (h *ProductoHandler) GetProductosPorTipoProduccion(c *gin.Context) {
+    tipo, err := producto.NewTipoProduccion(c.Param("tipo"))
+    if err != nil {
+        responderError(c, err)
+        return
+    }
+
+    productos, err := h.Catalogo.GetProductosByTipoProduccion(tipo)
+    if err != nil {
+        responderError(c, err)
+        return
+    }
+
+    if productos == nil {
+        productos = []*producto.ProductoAgroecologico{}
+    }
+    responderProductos(c, productos)
+}
+
+// GET /catalogo/productos/temporada?fecha=2006-01-02 (o RFC3339)
+// Sin fecha se usa el día actual.
+func (h *ProductoHandler) GetProductosEnTemporada(c *gin.Context) {
+    fecha := time.Now()
+    if valor := c.Query("fecha"); valor != "" {
+        var err error
+        fecha, err = parsearFecha(valor, producto.ZonaHoraria())
+        if err != nil {
+            responderValidacion(c, "fecha", "Formato de fecha inválido, "+formatosFechaAceptados)
+            return
+        }
+    }
+
+    productos, err := h.Catalogo.GetProductosEnTemporada(fecha)
+    if err != nil {
+        responderError(c, err)
+        return
+    }
+
+    responderProductos(c, productos)
+}
+
+// GET /catalogo/productos/excedentes
+// Incluye el nombre del productor de cada producto para gestionar su venta urgente.
+func (h *ProductoHandler) GetProductosExcedentes(c *gin.Context) {
+    productos, err := h.Catalogo.GetProductosExcedentesConProductor()
+    if err != nil {
+        responderError(c, err)
+        return
+    }
+
+    responderProductosConProductor(c, dto.NewProductoConProductorViews(productos))
+}
+
+// GET /catalogo/vistas
+func (h *ProductoHandler) GetVistas(c *gin.Context) {
+    c.JSON(http.StatusOK, h.Catalogo.GetVistasCatalogo())
+}
+
+// GET /catalogo/vistas/:nombre
+// Responde con ETag; si coincide con If-None-Match retorna 304 sin cuerpo.
+func (h *ProductoHandler) GetVista(c *gin.Context) {
+    productos, generadoEn, err := h.Catalogo.GetProductosDeVista(c.Param("nombre"))
+    if err != nil {
+        responderError(c, err)
+        return
+    }
+
+    data, err := json.Marshal(gin.H{
+        "vista":       c.Param("nombre"),
+        "generado_en": generadoEn,
+        "productos":   dto.NewProductoResponses(productos),
+    })
+    if err != nil {
+        responderError(c, err)
+        return
+    }
+
+    hash := sha256.Sum256(data)
+    etag := `"` + hex.EncodeToString(hash[:]) + `"`
+    c.Header("ETag", etag)
+    if c.GetHeader("If-None-Match") == etag {
+        c.Status(http.StatusNotModified)
+        return
+    }
+
+    c.Data(http.StatusOK, "application/json; charset=utf-8", data)
+}
+
+// GET /catalogo/buscar?q=tomate&categoria=Fruta&sort=relevancia
+func (h *ProductoHandler) BuscarProductos(c *gin.Context) {
+    if orden := c.Query("sort"); orden != "" && orden != "relevancia" {
+        responderValidacion(c, "sort", "orden inválido, valores permitidos: relevancia")
+        return
+    }
+
+    filtro, err := filtroDesdeQuery(c)
+    if err != nil {
+        responderError(c, err)
+        return
+    }
+
+    resultados, err := h.Catalogo.BuscarProductosConFiltroAvanzado(c.Query("q"), filtro)
+    if err != nil {
+        responderError(c, err)
+        return
+    }
+
+    c.JSON(http.StatusOK, dto.NewResultadoBusquedaResponses(resultados))
+}
+
+// filtroDesdeQuery construye un ProductoFiltro a partir de los query params opcionales
+// categoria, estado, zona_veredal y tipo_produccion, validando cada valor con su value object.
+func filtroDesdeQuery(c *gin.Context) (producto.ProductoFiltro, error) {
+    var filtro producto.ProductoFiltro
+
+    if valor := c.Query("categoria"); valor != "" {
+        categoria, err := producto.NewCategoria(valor)
+        if err != nil {
+            return producto.ProductoFiltro{}, err
+        }
+        filtro.Categoria = categoria
+    }
+    if valor := c.Query("estado"); valor != "" {
+        estado, err := producto.NewEstadoDisponibilidad(valor)
+        if err != nil {
+            return producto.ProductoFiltro{}, err
+        }
+        filtro.Estado = estado
+    }
+    if valor := c.Query("tipo_produccion"); valor != "" {
+        tipo, err := producto.NewTipoProduccion(valor)
+        if err != nil {
+            return producto.ProductoFiltro{}, err
+        }
+        filtro.TipoProduccion = tipo
+    }
+    filtro.ZonaVeredal = c.Query("zona_veredal")
+
+    return filtro, nil
+}
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+)
+
+// FuzzPublicarProducto envía publicaciones con campos arbitrarios. Toda respuesta debe ser un
+// 4xx con un error JSON, sin guardar nada, o un 201 con un producto cuyos campos pasan de nuevo
+// los validadores del dominio. Nunca un 5xx.
+//
+//	go test ./internal/handlers -run '^$' -fuzz FuzzPublicarProducto -fuzztime 30s
+func FuzzPublicarProducto(f *testing.F) {
+	s := nuevoServidorPrueba(f)
+	base := solicitudPublicacion(s.semilla1, "Tomate Cherry")
+	campo := func(nombre string) string { return base[nombre].(string) }
+	f.Add(campo("nombre"), campo("descripcion"), campo("categoria"), campo("tipo_produccion"), campo("temporada_inicio"), campo("temporada_fin"),
+		campo("zona_veredal"), campo("finca"), campo("imagen_url"), campo("imagen_desc"), float32(0))
+
+	f.Fuzz(func(t *testing.T, nombre, descripcion, categoria, tipo, inicio, fin, zona, finca, imagenURL, imagenDesc string,
+		minReputacion float32) {
+		req := solicitudPublicacion(s.semilla1, nombre)
+		req["descripcion"] = descripcion
+		req["categoria"] = categoria
+		req["tipo_produccion"] = tipo
+		req["temporada_inicio"] = inicio
+		req["temporada_fin"] = fin
+		req["zona_veredal"] = zona
+		req["finca"] = finca
+		req["imagen_url"] = imagenURL
+		req["imagen_desc"] = imagenDesc
+		req["min_reputacion"] = minReputacion
+
+		cuerpo, err := json.Marshal(req)
+		if err != nil {
+			// NaN e infinitos no tienen representación JSON: ningún cliente puede enviarlos
+			t.Skip()
+		}
+		antes := contarProductos(t, s)
+		w := s.hacer(http.MethodPost, "/catalogo/producto", string(cuerpo))
+
+		switch {
+		case w.Code == http.StatusCreated:
+			creado := decodificar[struct{ ID producto.ProductoID }](t, w)
+			exigirProductoValido(t, s, creado.ID)
+			if despues := contarProductos(t, s); despues != antes+1 {
+				t.Fatalf("productos = %d tras un 201, se esperaba %d", despues, antes+1)
+			}
+		case w.Code >= 400 && w.Code < 500:
+			if r := decodificar[respuestaError](t, w); r.Code == "" || r.Message == "" {
+				t.Fatalf("error sin código o sin mensaje: %s", w.Body.String())
+			}
+			if despues := contarProductos(t, s); despues != antes {
+				t.Fatalf("productos = %d tras un %d, se esperaba %d", despues, w.Code, antes)
+			}
+		default:
+			t.Fatalf("status = %d; cuerpo: %s", w.Code, w.Body.String())
+		}
+	})
+}
+
+func contarProductos(t *testing.T, s *servidorPrueba) int {
+	t.Helper()
+	todos, err := s.productoRepo.GetAll()
+	if err != nil {
+		t.Fatalf("GetAll: %v", err)
+	}
+	return len(todos)
+}
+
+// exigirProductoValido pasa cada campo del producto guardado por el validador de su value object
+func exigirProductoValido(t *testing.T, s *servidorPrueba, id producto.ProductoID) {
+	t.Helper()
+	p, err := s.productoRepo.GetByID(id)
+	if err != nil {
+		t.Fatalf("el producto %q del 201 no quedó guardado: %v", id, err)
+	}
+	if p.ProductorID != string(s.semilla1) {
+		t.Errorf("ProductorID = %q, se esperaba %q", p.ProductorID, s.semilla1)
+	}
+	validaciones := map[string]error{}
+	_, validaciones["nombre"] = producto.NewNombreProducto(p.Nombre.Value)
+	_, validaciones["descripcion"] = producto.NewDescripcionProducto(p.Descripcion.Value)
+	_, validaciones["categoria"] = producto.NewCategoria(string(p.Categoria))
+	_, validaciones["tipo_produccion"] = producto.NewTipoProduccion(string(p.TipoProduccion))
+	_, validaciones["ubicacion"] = producto.NewUbicacion(p.Ubicacion.ZonaVeredal, p.Ubicacion.Finca)
+	_, validaciones["imagen"] = producto.NewImagen(p.Imagen.URL, p.Imagen.DescripcionCorta)
+	_, validaciones["temporada"] = producto.NewTemporadaLocal(p.Temporada.Inicio, p.Temporada.Fin)
+	_, validaciones["precio"] = producto.NewPrecio(p.Precio.Valor, p.Precio.Moneda)
+	for campo, err := range validaciones {
+		if err != nil {
+			t.Errorf("%s no pasa su validador: %v", campo, err)
+		}
+	}
+}
@@ -0,0 +1,926 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+	"Product_Catalog_Microservice/internal/domain/service"
+	"Product_Catalog_Microservice/internal/handlers/dto"
+	"Product_Catalog_Microservice/internal/ids"
+	"Product_Catalog_Microservice/internal/repository"
+)
+
+func TestGetVista_ETag(t *testing.T) {
+	s := nuevoServidorPrueba(t, service.WithVistasCatalogo([]service.VistaCatalogo{{Nombre: "frutas", Categorias: []string{"Fruta"}}}))
+	s.publicar(t, s.semilla1, "Fresa")
+
+	w := s.hacer(http.MethodGet, "/catalogo/vistas/frutas", "")
+	exigirStatus(t, w, http.StatusOK)
+	etag := w.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("la respuesta no tiene ETag")
+	}
+	if r := decodificar[struct {
+		Productos []any `json:"productos"`
+	}](t, w); len(r.Productos) != 1 {
+		t.Errorf("productos = %d, se esperaba 1", len(r.Productos))
+	}
+
+	w = s.hacer(http.MethodGet, "/catalogo/vistas/frutas", "", "If-None-Match", etag)
+	exigirStatus(t, w, http.StatusNotModified)
+	if w.Body.Len() != 0 {
+		t.Errorf("un 304 no debe tener cuerpo: %s", w.Body.String())
+	}
+}
+
+func TestGetVista_Desconocida(t *testing.T) {
+	s := nuevoServidorPrueba(t, service.WithVistasCatalogo([]service.VistaCatalogo{{Nombre: "frutas"}}))
+
+	w := s.hacer(http.MethodGet, "/catalogo/vistas/escolar", "")
+	exigirStatus(t, w, http.StatusNotFound)
+	if !strings.Contains(w.Body.String(), "frutas") {
+		t.Errorf("cuerpo = %s, se esperaba la lista de vistas disponibles", w.Body.String())
+	}
+
+	w = s.hacer(http.MethodGet, "/catalogo/vistas", "")
+	exigirStatus(t, w, http.StatusOK)
+	if vistas := decodificar[[]service.VistaCatalogo](t, w); len(vistas) != 1 || vistas[0].Nombre != "frutas" {
+		t.Errorf("vistas = %+v, se esperaba [frutas]", vistas)
+	}
+}
+
+// El golden fija los nombres en snake_case del resumen por zona
+func TestGetResumenCatalogoPorZona_Golden(t *testing.T) {
+	s := nuevoServidorPrueba(t)
+	sembrarProductoFijo(t, s)
+	s.publicar(t, s.semilla2, "Mora")
+
+	w := s.hacer(http.MethodGet, "/catalogo/estadisticas/zonas", "")
+	exigirStatus(t, w, http.StatusOK)
+	compararGolden(t, "resumen/zonas.json", w.Body.Bytes())
+}
+
+func TestGetPronostico_Status(t *testing.T) {
+	s := nuevoServidorPrueba(t)
+	ahora := time.Now().In(producto.ZonaHoraria())
+	mesActual := time.Date(ahora.Year(), ahora.Month(), 1, 0, 0, 0, 0, producto.ZonaHoraria())
+
+	casos := []struct {
+		nombre string
+		query  string
+		status int
+	}{
+		{"mes actual", "mes=" + mesActual.Format("2006-01"), http.StatusOK},
+		{"con filtros", "categoria=Fruta&zona=Vereda+El+Para%C3%ADso&mes=" + mesActual.Format("2006-01"), http.StatusOK},
+		{"más de 12 meses", "mes=" + mesActual.AddDate(0, 13, 0).Format("2006-01"), http.StatusUnprocessableEntity},
+		{"sin mes", "", http.StatusBadRequest},
+		{"mes inválido", "mes=2025-13", http.StatusBadRequest},
+		{"categoría inválida", "categoria=Desconocida&mes=" + mesActual.Format("2006-01"), http.StatusBadRequest},
+	}
+	for _, tc := range casos {
+		t.Run(tc.nombre, func(t *testing.T) {
+			exigirStatus(t, s.hacer(http.MethodGet, "/catalogo/pronostico?"+tc.query, ""), tc.status)
+		})
+	}
+}
+
+func TestGetProductoByID(t *testing.T) {
+	s := nuevoServidorPrueba(t)
+	s.publicar(t, s.semilla1, "Fresa")
+
+	// El generador secuencial del handler asigna producto-000001 a la primera publicación
+	w := s.hacer(http.MethodGet, "/catalogo/productos/producto-000001", "")
+	exigirStatus(t, w, http.StatusOK)
+	prod := decodificar[dto.ProductoResponse](t, w)
+	if prod.Nombre != "Fresa" || prod.Estado != producto.Disponible || prod.TemporadaInicio == "" {
+		t.Errorf("producto = %+v, se esperaba Fresa disponible con su temporada", prod)
+	}
+
+	exigirStatus(t, s.hacer(http.MethodGet, "/catalogo/productos/no-existe", ""), http.StatusNotFound)
+	exigirStatus(t, s.hacer(http.MethodGet, "/catalogo/productos/%20", ""), http.StatusBadRequest)
+}
+
+func TestGetProductos_Filtros(t *testing.T) {
+	s := nuevoServidorPrueba(t)
+	publicar := func(nombre, categoria string, tipo producto.TipoProduccion, zona string) {
+		t.Helper()
+		solicitud := solicitudPublicacion(s.semilla1, nombre)
+		solicitud["categoria"] = categoria
+		solicitud["tipo_produccion"] = string(tipo)
+		solicitud["zona_veredal"] = zona
+		exigirStatus(t, s.hacer(http.MethodPost, "/catalogo/producto", aJSON(t, solicitud)), http.StatusCreated)
+	}
+	publicar("Mora", "Fruta", producto.ProduccionOrganica, "El Placer")
+	publicar("Fresa", "Fruta", producto.ProduccionAgroecologica, "El Placer")
+	publicar("Lechuga", "Hortaliza", producto.ProduccionOrganica, "El Placer")
+	publicar("Lulo", "Fruta", producto.ProduccionOrganica, "La Cumbre")
+
+	casos := []struct {
+		nombre   string
+		query    string
+		esperado []string
+	}{
+		{"sin filtros", "", []string{"Fresa", "Lechuga", "Lulo", "Mora"}},
+		{"categoría", "?categoria=Fruta", []string{"Fresa", "Lulo", "Mora"}},
+		{"combinados", "?categoria=Fruta&estado=Disponible&zona_veredal=El%20Placer&tipo_produccion=Organico", []string{"Mora"}},
+		{"sin coincidencias", "?categoria=PlantaMedicinal", []string{}},
+	}
+	for _, tc := range casos {
+		t.Run(tc.nombre, func(t *testing.T) {
+			w := s.hacer(http.MethodGet, "/catalogo/productos"+tc.query, "")
+			exigirStatus(t, w, http.StatusOK)
+			nombres := []string{}
+			for _, p := range decodificar[[]dto.ProductoResponse](t, w) {
+				nombres = append(nombres, p.Nombre)
+			}
+			if strings.Join(nombres, ",") != strings.Join(tc.esperado, ",") {
+				t.Errorf("productos = %v, se esperaba %v", nombres, tc.esperado)
+			}
+		})
+	}
+
+	for _, query := range []string{"?categoria=Carne", "?estado=Vendido", "?tipo_produccion=Industrial"} {
+		w := s.hacer(http.MethodGet, "/catalogo/productos"+query, "")
+		exigirStatus(t, w, http.StatusBadRequest)
+		if !strings.Contains(w.Body.String(), "inválid") {
+			t.Errorf("%s: cuerpo = %s, se esperaba el mensaje de validación", query, w.Body.String())
+		}
+	}
+}
+
+func TestGetProductos_Cursor(t *testing.T) {
+	s := nuevoServidorPrueba(t)
+	for _, nombre := range []string{"Mora", "Fresa", "Lulo"} {
+		s.publicar(t, s.semilla1, nombre)
+	}
+
+	type paginaCursor struct {
+		Items []dto.ProductoResponse `json:"items"`
+		Meta  struct {
+			Limit      int    `json:"limit"`
+			NextCursor string `json:"next_cursor"`
+		} `json:"meta"`
+	}
+	pedir := func(query string) paginaCursor {
+		t.Helper()
+		w := s.hacer(http.MethodGet, "/catalogo/productos"+query, "")
+		exigirStatus(t, w, http.StatusOK)
+		return decodificar[paginaCursor](t, w)
+	}
+
+	// Se recorre en orden de publicación; lo publicado entre páginas aparece al final
+	var nombres []string
+	pagina := pedir("?limit=2")
+	for _, p := range pagina.Items {
+		nombres = append(nombres, p.Nombre)
+	}
+	s.publicar(t, s.semilla2, "Guayaba")
+	for pagina.Meta.NextCursor != "" {
+		pagina = pedir("?limit=2&cursor=" + url.QueryEscape(pagina.Meta.NextCursor))
+		if pagina.Meta.Limit != 2 {
+			t.Errorf("meta.limit = %d, se esperaba 2", pagina.Meta.Limit)
+		}
+		for _, p := range pagina.Items {
+			nombres = append(nombres, p.Nombre)
+		}
+	}
+	if esperado := "Mora,Fresa,Lulo,Guayaba"; strings.Join(nombres, ",") != esperado {
+		t.Errorf("productos recorridos = %v, se esperaba %s", nombres, esperado)
+	}
+
+	// Los filtros se combinan con el cursor y un cursor vacío empieza desde el primero
+	if r := pedir("?cursor=&categoria=Hortaliza"); len(r.Items) != 0 || r.Meta.Limit != 20 || r.Meta.NextCursor != "" {
+		t.Errorf("sin coincidencias = %+v, se esperaba una página vacía de límite 20", r)
+	}
+
+	for _, query := range []string{"?limit=0", "?limit=101", "?limit=dos"} {
+		if r := exigirError(t, s.hacer(http.MethodGet, "/catalogo/productos"+query, ""), http.StatusBadRequest, CodigoValidacion); r.Field != "limit" {
+			t.Errorf("%s: field = %q, se esperaba limit", query, r.Field)
+		}
+	}
+	r := exigirError(t, s.hacer(http.MethodGet, "/catalogo/productos?cursor=no-es-un-cursor", ""), http.StatusBadRequest, CodigoValidacion)
+	if r.Field != "cursor" || !strings.Contains(r.Message, "primera página sin cursor") {
+		t.Errorf("cursor inválido = %+v, se esperaba el campo cursor y la indicación de empezar de nuevo", r)
+	}
+}
+
+func TestGetCatalogoAgrupado_Parametros(t *testing.T) {
+	s := nuevoServidorPrueba(t)
+	s.publicar(t, s.semilla1, "Fresa")
+
+	w := s.hacer(http.MethodGet, "/catalogo/agrupado?por=categoria&por_grupo=1", "")
+	exigirStatus(t, w, http.StatusOK)
+	if grupos := decodificar[[]dto.GrupoCatalogoResponse](t, w); len(grupos) != 1 || grupos[0].Total != 1 {
+		t.Errorf("grupos = %+v, se esperaba solo Fruta con un producto", grupos)
+	}
+
+	for _, query := range []string{"?por=productor", "?por_grupo=0", "?por_grupo=101", "?grupo=Fruta&cursor=%25", "?categoria=Carne"} {
+		exigirStatus(t, s.hacer(http.MethodGet, "/catalogo/agrupado"+query, ""), http.StatusBadRequest)
+	}
+}
+
+// productoRepoDemorado demora cada Save para que la etapa de guardado domine la publicación
+type productoRepoDemorado struct {
+	*repository.ProductoRepository
+	demora time.Duration
+}
+
+func (r productoRepoDemorado) Save(prod *producto.ProductoAgroecologico) error {
+	time.Sleep(r.demora)
+	return r.ProductoRepository.Save(prod)
+}
+
+// publicarConPresupuesto publica un producto válido con el repositorio demorado y retorna la
+// respuesta, los registros del handler y el histograma de etapas
+func publicarConPresupuesto(t *testing.T, demora, presupuesto time.Duration) (*httptest.ResponseRecorder, []map[string]any, *prometheus.HistogramVec) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	var salida bytes.Buffer
+	productorRepo := repository.NewProductorRepository(0)
+	catalogo := service.NewCatalogoService(productorRepo,
+		productoRepoDemorado{repository.NewProductoRepository(0), demora}, &publicadorRegistro{})
+	h := &ProductoHandler{
+		Catalogo:               catalogo,
+		IDs:                    ids.NewSecuencialGenerator(),
+		PresupuestoPublicacion: presupuesto,
+		Logger:                 slog.New(slog.NewJSONHandler(&salida, nil)),
+		EtapasPublicacion:      NuevoHistogramaEtapasPublicacion(prometheus.NewRegistry()),
+	}
+	s := &servidorPrueba{router: gin.New()}
+	s.router.POST("catalogo/producto", h.PublicarProducto)
+
+	w := s.hacer(http.MethodPost, "/catalogo/producto", aJSON(t, solicitudPublicacion(productorSemilla1, "Fresa")))
+	exigirStatus(t, w, http.StatusCreated)
+
+	var registros []map[string]any
+	dec := json.NewDecoder(&salida)
+	for dec.More() {
+		var registro map[string]any
+		if err := dec.Decode(&registro); err != nil {
+			t.Fatalf("registro inválido: %v", err)
+		}
+		registros = append(registros, registro)
+	}
+	return w, registros, h.EtapasPublicacion
+}
+
+func TestPublicarProducto_ServerTimingPorEtapa(t *testing.T) {
+	w, _, histograma := publicarConPresupuesto(t, 0, 0)
+
+	timing := w.Header().Get("Server-Timing")
+	etapas := []string{"binding", "value_objects", "autorizacion", "agregado", "guardado", "eventos"}
+	for _, etapa := range etapas {
+		if !strings.Contains(timing, etapa+";dur=") {
+			t.Errorf("Server-Timing = %q, falta la etapa %s", timing, etapa)
+		}
+	}
+	if n := testutil.CollectAndCount(histograma); n != len(etapas) {
+		t.Errorf("catalogo_publicacion_etapa_segundos tiene %d series, se esperaba una por etapa (%d)", n, len(etapas))
+	}
+}
+
+func TestPublicarProducto_RegistraPublicacionLenta(t *testing.T) {
+	_, registros, _ := publicarConPresupuesto(t, 30*time.Millisecond, 10*time.Millisecond)
+
+	if len(registros) != 1 || registros[0]["msg"] != "publicación lenta" {
+		t.Fatalf("registros = %v, se esperaba una publicación lenta", registros)
+	}
+	registro := registros[0]
+	if registro["etapa_dominante"] != "guardado" {
+		t.Errorf("etapa_dominante = %v, se esperaba guardado", registro["etapa_dominante"])
+	}
+	if d, _ := registro["duracion_dominante"].(float64); time.Duration(d) < 30*time.Millisecond {
+		t.Errorf("duracion_dominante = %v, menor que la demora inyectada", time.Duration(d))
+	}
+	etapas, _ := registro["etapas"].(map[string]any)
+	if _, ok := etapas["guardado"]; !ok || len(etapas) != 6 {
+		t.Errorf("etapas = %v, se esperaban las seis etapas", etapas)
+	}
+}
+
+func TestPublicarProducto_DentroDelPresupuestoNoRegistra(t *testing.T) {
+	for _, presupuesto := range []time.Duration{0, time.Hour} {
+		if _, registros, _ := publicarConPresupuesto(t, 0, presupuesto); len(registros) != 0 {
+			t.Errorf("presupuesto %v: registros = %v, se esperaba ninguno", presupuesto, registros)
+		}
+	}
+}
+
+func TestGetProductoByID_RutaSingular(t *testing.T) {
+	s := nuevoServidorPrueba(t)
+	sembrarProductoFijo(t, s)
+
+	w := s.hacer(http.MethodGet, "/catalogo/producto/"+idProductoFijo, "")
+	exigirStatus(t, w, http.StatusOK)
+	got := decodificar[dto.ProductoResponse](t, w)
+	if got.ID != idProductoFijo || got.ProductorID != string(s.semilla1) || got.Nombre != "Fresa" ||
+		got.Descripcion != "Fresas de la vereda sin agroquímicos" || got.Categoria != string(producto.CategoriaFruta) ||
+		got.TipoProduccion != string(producto.ProduccionAgroecologica) || got.Estado != producto.Disponible {
+		t.Errorf("producto = %+v, no coincide con el sembrado", got)
+	}
+	if got.ZonaVeredal != "Vereda El Paraíso" || got.Finca != "Finca La Esperanza" ||
+		got.ImagenURL != "https://img.example.com/fresa.jpg" || got.ImagenDesc != "Fresas" {
+		t.Errorf("producto = %+v; la ubicación o la imagen no coinciden con las sembradas", got)
+	}
+	if got.TemporadaInicio != "2099-01-01" {
+		t.Errorf("temporada_inicio = %s, se esperaba 2099-01-01", got.TemporadaInicio)
+	}
+
+	exigirError(t, s.hacer(http.MethodGet, "/catalogo/producto/no-existe", ""), http.StatusNotFound, CodigoProductoNoEncontrado)
+}
+
+func TestGetSugerenciaTemporada(t *testing.T) {
+	s := nuevoServidorPrueba(t)
+	for _, nombre := range []string{"Mango", "Mango de azúcar", "Mango Tommy"} {
+		s.publicar(t, s.semilla1, nombre)
+	}
+
+	w := s.hacer(http.MethodGet, "/catalogo/sugerencias/temporada?categoria=Fruta&nombre=mango", "")
+	exigirStatus(t, w, http.StatusOK)
+	sugerencia := decodificar[struct {
+		Inicio  string `json:"temporada_inicio"`
+		Fin     string `json:"temporada_fin"`
+		Muestra int    `json:"muestra"`
+	}](t, w)
+	if sugerencia.Muestra != 3 || sugerencia.Inicio == "" || sugerencia.Fin <= sugerencia.Inicio {
+		t.Errorf("sugerencia = %+v, se esperaba una temporada con muestra 3", sugerencia)
+	}
+
+	exigirStatus(t, s.hacer(http.MethodGet, "/catalogo/sugerencias/temporada?categoria=Fruta&nombre=papaya", ""), http.StatusNoContent)
+	exigirStatus(t, s.hacer(http.MethodGet, "/catalogo/sugerencias/temporada?categoria=Fruta", ""), http.StatusBadRequest)
+	exigirStatus(t, s.hacer(http.MethodGet, "/catalogo/sugerencias/temporada?categoria=Flores&nombre=mango", ""), http.StatusBadRequest)
+}
+
+func TestPublicarProducto_SinCapacidad(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	catalogo := service.NewCatalogoService(repository.NewProductorRepository(0), repository.NewProductoRepository(1), &publicadorRegistro{})
+	s := &servidorPrueba{router: gin.New()}
+	s.router.POST("catalogo/producto", (&ProductoHandler{Catalogo: catalogo, IDs: ids.NewSecuencialGenerator()}).PublicarProducto)
+
+	s.publicar(t, productorSemilla1, "Fresa")
+	w := s.hacer(http.MethodPost, "/catalogo/producto", aJSON(t, solicitudPublicacion(productorSemilla1, "Mora")))
+	exigirStatus(t, w, http.StatusInsufficientStorage)
+}
+
+func TestActualizarInformacionProducto(t *testing.T) {
+	s := nuevoServidorPrueba(t, service.WithContentModeration(service.ContentModerationConfig{PalabrasProhibidas: []string{"milagroso"}}))
+	s.publicar(t, s.semilla1, "Fresa")
+	s.publicar(t, s.semilla1, "Mora")
+	exigirStatus(t, s.hacer(http.MethodPost, "/catalogo/productos/producto-000002/agotar", ""), http.StatusNoContent)
+
+	cuerpo := func(nombre, desc string) string {
+		return aJSON(t, map[string]string{
+			"nombre":      nombre,
+			"descripcion": desc,
+			"imagen_url":  "https://img.example.com/fresa-montana.jpg",
+			"imagen_desc": "Fresas de montaña",
+		})
+	}
+
+	w := s.hacer(http.MethodPut, "/catalogo/productos/producto-000001", cuerpo("Fresa de montaña", "Fresas cultivadas sobre los 2.500 metros"))
+	exigirStatus(t, w, http.StatusOK)
+	if prod := decodificar[dto.ProductoResponse](t, w); prod.Nombre != "Fresa de montaña" || prod.ImagenDesc != "Fresas de montaña" {
+		t.Errorf("respuesta = %s, se esperaba el producto actualizado", w.Body.String())
+	}
+
+	casos := []struct {
+		nombre string
+		ruta   string
+		cuerpo string
+		status int
+	}{
+		{"nombre inválido", "/catalogo/productos/producto-000001", cuerpo("", "Fresas cultivadas sobre los 2.500 metros"), http.StatusBadRequest},
+		{"contenido no permitido", "/catalogo/productos/producto-000001", cuerpo("Fresa", "Fresa milagrosa: un abono milagroso"), http.StatusBadRequest},
+		{"producto agotado", "/catalogo/productos/producto-000002", cuerpo("Mora", "Moras de la vereda"), http.StatusConflict},
+		{"producto inexistente", "/catalogo/productos/no-existe", cuerpo("Mora", "Moras de la vereda"), http.StatusNotFound},
+	}
+	for _, tc := range casos {
+		t.Run(tc.nombre, func(t *testing.T) {
+			exigirStatus(t, s.hacer(http.MethodPut, tc.ruta, tc.cuerpo), tc.status)
+		})
+	}
+}
+
+func TestActualizarPrecio(t *testing.T) {
+	s := nuevoServidorPrueba(t)
+	s.publicar(t, s.semilla1, "Fresa")
+	ruta := "/catalogo/productos/producto-000001/precio"
+
+	exigirStatus(t, s.hacer(http.MethodPut, ruta, `{"valor": 4200, "moneda": "COP"}`), http.StatusNoContent)
+	exigirStatus(t, s.hacer(http.MethodPut, ruta, `{"valor": 4200, "moneda": "COP"}`), http.StatusNoContent)
+	exigirStatus(t, s.hacer(http.MethodPut, ruta, `{"valor": -1, "moneda": "COP"}`), http.StatusBadRequest)
+	exigirStatus(t, s.hacer(http.MethodPut, ruta, `{"valor": 4200, "moneda": "pesos"}`), http.StatusBadRequest)
+	exigirStatus(t, s.hacer(http.MethodPut, "/catalogo/productos/no-existe/precio", `{"valor": 4200, "moneda": "COP"}`), http.StatusNotFound)
+
+	prod, err := s.productoRepo.GetByID("producto-000001")
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if prod.Precio != (producto.Precio{Valor: 4200, Moneda: "COP"}) {
+		t.Errorf("Precio = %+v, se esperaba 4200 COP", prod.Precio)
+	}
+}
+
+func TestGetProductosEnZona(t *testing.T) {
+	s := nuevoServidorPrueba(t)
+	vecino := s.registrarProductorEn(t, "Los Robles", 4, time.Now())
+	s.publicar(t, s.semilla1, "Fresa")
+	s.publicar(t, vecino, "Mora")
+	s.publicar(t, s.semilla2, "Lulo")
+
+	casos := []struct {
+		nombre    string
+		query     string
+		productos int
+	}{
+		{"todas las fincas de la zona", "zona_veredal=Vereda%20El%20Para%C3%ADso", 2},
+		{"una finca", "zona_veredal=Vereda%20El%20Para%C3%ADso&finca=Finca%20Los%20Robles", 1},
+		{"zona sin productores", "zona_veredal=Vereda%20El%20Roble", 0},
+	}
+	for _, tc := range casos {
+		t.Run(tc.nombre, func(t *testing.T) {
+			w := s.hacer(http.MethodGet, "/catalogo/productos/zona?"+tc.query, "")
+			exigirStatus(t, w, http.StatusOK)
+			if strings.TrimSpace(w.Body.String()) == "null" {
+				t.Fatal("sin resultados se esperaba [], no null")
+			}
+			if n := len(decodificar[[]json.RawMessage](t, w)); n != tc.productos {
+				t.Errorf("productos = %d, se esperaba %d", n, tc.productos)
+			}
+		})
+	}
+
+	for _, query := range []string{
+		"",
+		"zona_veredal=%20%20",
+		"zona_veredal=%3Cscript%3E",
+		"zona_veredal=" + strings.Repeat("a", 41),
+		"zona_veredal=%3Cscript%3E&finca=Finca",
+	} {
+		exigirStatus(t, s.hacer(http.MethodGet, "/catalogo/productos/zona?"+query, ""), http.StatusBadRequest)
+	}
+}
+
+func TestGetCatalogoCompleto_Paginado(t *testing.T) {
+	s := nuevoServidorPrueba(t)
+	for _, nombre := range []string{"Fresa", "Mora", "Lulo"} {
+		s.publicar(t, s.semilla1, nombre)
+	}
+
+	type pagina struct {
+		Items      []json.RawMessage `json:"items"`
+		TotalCount int               `json:"total_count"`
+		Page       int               `json:"page"`
+		PageSize   int               `json:"page_size"`
+	}
+	type catalogoPaginado struct {
+		Productos, Productores pagina
+
+		TotalProductos   int `json:"total_productos"`
+		TotalProductores int `json:"total_productores"`
+		Page             int `json:"page"`
+	}
+
+	casos := []struct {
+		nombre                 string
+		query                  string
+		productos, productores int
+		page, pageSize         int
+	}{
+		{"primera página", "?page=1&page_size=2", 2, 2, 1, 2},
+		{"segunda página", "?page=2&page_size=2", 1, 0, 2, 2},
+		{"solo page usa el tamaño por defecto", "?page=1", 3, 2, 1, 20},
+		{"tamaño recortado al máximo", "?page_size=500", 3, 2, 1, 100},
+		{"fuera de rango", "?page=9", 0, 0, 9, 20},
+	}
+	for _, tc := range casos {
+		t.Run(tc.nombre, func(t *testing.T) {
+			w := s.hacer(http.MethodGet, "/catalogo/completo"+tc.query, "")
+			exigirStatus(t, w, http.StatusOK)
+			r := decodificar[catalogoPaginado](t, w)
+			if len(r.Productos.Items) != tc.productos || len(r.Productores.Items) != tc.productores {
+				t.Errorf("productos = %d, productores = %d; se esperaba %d y %d",
+					len(r.Productos.Items), len(r.Productores.Items), tc.productos, tc.productores)
+			}
+			if r.Productos.TotalCount != 3 || r.Productores.TotalCount != 2 {
+				t.Errorf("totales = %d y %d, se esperaba 3 y 2", r.Productos.TotalCount, r.Productores.TotalCount)
+			}
+			if r.TotalProductos != 3 || r.TotalProductores != 2 || r.Page != tc.page {
+				t.Errorf("metadatos = {total_productos: %d, total_productores: %d, page: %d}, se esperaba {3, 2, %d}",
+					r.TotalProductos, r.TotalProductores, r.Page, tc.page)
+			}
+			if r.Productos.Page != tc.page || r.Productos.PageSize != tc.pageSize {
+				t.Errorf("page = %d, page_size = %d; se esperaba %d y %d", r.Productos.Page, r.Productos.PageSize, tc.page, tc.pageSize)
+			}
+		})
+	}
+
+	// Sin parámetros la respuesta es el catálogo entero, sin envolver en páginas
+	w := s.hacer(http.MethodGet, "/catalogo/completo", "")
+	exigirStatus(t, w, http.StatusOK)
+	if n := len(decodificar[struct{ Productos []json.RawMessage }](t, w).Productos); n != 3 {
+		t.Errorf("productos sin paginar = %d, se esperaba 3", n)
+	}
+
+	exigirStatus(t, s.hacer(http.MethodGet, "/catalogo/completo?page=dos", ""), http.StatusBadRequest)
+}
+
+func TestGetProductosPorCategoria(t *testing.T) {
+	s := nuevoServidorPrueba(t)
+	s.publicar(t, s.semilla1, "Fresa")
+	solicitud := solicitudPublicacion(s.semilla1, "Lechuga")
+	solicitud["categoria"] = string(producto.CategoriaHortaliza)
+	exigirStatus(t, s.hacer(http.MethodPost, "/catalogo/producto", aJSON(t, solicitud)), http.StatusCreated)
+
+	casos := []struct {
+		categoria string
+		status    int
+		nombres   []string
+	}{
+		{string(producto.CategoriaFruta), http.StatusOK, []string{"Fresa"}},
+		{string(producto.CategoriaHortaliza), http.StatusOK, []string{"Lechuga"}},
+		{string(producto.CategoriaTuberculo), http.StatusOK, nil},
+		{string(producto.CategoriaMedicinal), http.StatusOK, nil},
+		{string(producto.CategoriaLacteo), http.StatusOK, nil},
+		{"fruta", http.StatusBadRequest, nil},
+		{"Tuberculo", http.StatusBadRequest, nil},
+		{"Fruta ", http.StatusBadRequest, nil},
+		{"Cereal", http.StatusBadRequest, nil},
+	}
+	for _, tc := range casos {
+		t.Run(tc.categoria, func(t *testing.T) {
+			w := s.hacer(http.MethodGet, "/catalogo/productos/categoria/"+url.PathEscape(tc.categoria), "")
+			exigirStatus(t, w, tc.status)
+			if tc.status != http.StatusOK {
+				return
+			}
+			// Sin coincidencias la respuesta es un arreglo vacío, no null
+			if len(tc.nombres) == 0 && strings.TrimSpace(w.Body.String()) != "[]" {
+				t.Errorf("cuerpo = %s, se esperaba []", w.Body.String())
+			}
+			var nombres []string
+			for _, p := range decodificar[[]dto.ProductoResponse](t, w) {
+				nombres = append(nombres, p.Nombre)
+			}
+			if !slices.Equal(nombres, tc.nombres) {
+				t.Errorf("productos = %v, se esperaba %v", nombres, tc.nombres)
+			}
+		})
+	}
+}
+
+func TestGetProductosPorTipoProduccion(t *testing.T) {
+	s := nuevoServidorPrueba(t)
+	s.publicar(t, s.semilla1, "Fresa")
+	solicitud := solicitudPublicacion(s.semilla1, "Mora")
+	solicitud["tipo_produccion"] = string(producto.ProduccionOrganica)
+	exigirStatus(t, s.hacer(http.MethodPost, "/catalogo/producto", aJSON(t, solicitud)), http.StatusCreated)
+
+	casos := []struct {
+		tipo    string
+		status  int
+		nombres []string
+	}{
+		{string(producto.ProduccionAgroecologica), http.StatusOK, []string{"Fresa"}},
+		{string(producto.ProduccionOrganica), http.StatusOK, []string{"Mora"}},
+		{string(producto.ProduccionTradicional), http.StatusOK, nil},
+		{"organico", http.StatusBadRequest, nil},
+		{"Hidroponico", http.StatusBadRequest, nil},
+	}
+	for _, tc := range casos {
+		t.Run(tc.tipo, func(t *testing.T) {
+			w := s.hacer(http.MethodGet, "/catalogo/productos/tipo/"+tc.tipo, "")
+			exigirStatus(t, w, tc.status)
+			if tc.status != http.StatusOK {
+				return
+			}
+			if len(tc.nombres) == 0 && strings.TrimSpace(w.Body.String()) != "[]" {
+				t.Errorf("cuerpo = %s, se esperaba []", w.Body.String())
+			}
+			var nombres []string
+			for _, p := range decodificar[[]dto.ProductoResponse](t, w) {
+				nombres = append(nombres, p.Nombre)
+			}
+			if !slices.Equal(nombres, tc.nombres) {
+				t.Errorf("productos = %v, se esperaba %v", nombres, tc.nombres)
+			}
+		})
+	}
+}
+
+func TestPublicarProducto_TipoProduccion(t *testing.T) {
+	casos := []struct {
+		tipo   string
+		valido bool
+	}{
+		{string(producto.ProduccionAgroecologica), true},
+		{string(producto.ProduccionOrganica), true},
+		{string(producto.ProduccionTradicional), true},
+		{"", false},
+		{"organico", false},
+		{"Hidroponico", false},
+	}
+	for _, tc := range casos {
+		t.Run(tc.tipo, func(t *testing.T) {
+			s := nuevoServidorPrueba(t)
+			solicitud := solicitudPublicacion(s.semilla1, "Fresa")
+			solicitud["tipo_produccion"] = tc.tipo
+			w := s.hacer(http.MethodPost, "/catalogo/producto", aJSON(t, solicitud))
+			if !tc.valido {
+				exigirStatus(t, w, http.StatusBadRequest)
+				if n := contarProductos(t, s); n != 0 {
+					t.Errorf("productos = %d, no se debía guardar nada", n)
+				}
+				return
+			}
+			exigirStatus(t, w, http.StatusCreated)
+			if got := decodificar[dto.ProductoResponse](t, w).TipoProduccion; got != tc.tipo {
+				t.Errorf("TipoProduccion = %q, se esperaba %q", got, tc.tipo)
+			}
+		})
+	}
+}
+
+func TestDecrementarStock(t *testing.T) {
+	s := nuevoServidorPrueba(t)
+	s.publicar(t, s.semilla1, "Fresa")
+	ruta := "/catalogo/productos/producto-000001/stock"
+
+	exigirStatus(t, s.hacer(http.MethodPut, ruta, `{"cantidad": 15}`), http.StatusNoContent)
+	exigirStatus(t, s.hacer(http.MethodPut, ruta, `{"cantidad": 0}`), http.StatusBadRequest)
+	exigirStatus(t, s.hacer(http.MethodPut, ruta, `{"cantidad": "diez"}`), http.StatusBadRequest)
+	exigirStatus(t, s.hacer(http.MethodPut, ruta, `{"cantidad": 26}`), http.StatusConflict)
+	exigirStatus(t, s.hacer(http.MethodPut, "/catalogo/productos/no-existe/stock", `{"cantidad": 1}`), http.StatusNotFound)
+
+	prod, err := s.productoRepo.GetByID("producto-000001")
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if prod.Cantidad != (producto.CantidadDisponible{Valor: 25, Unidad: producto.UnidadKilogramo}) {
+		t.Errorf("Cantidad = %+v, se esperaba 25 kg", prod.Cantidad)
+	}
+}
+
+func TestPublicarProducto_PrecioObligatorio(t *testing.T) {
+	s := nuevoServidorPrueba(t)
+	solicitud := solicitudPublicacion(s.semilla1, "Fresa")
+	delete(solicitud, "precio_moneda")
+	exigirStatus(t, s.hacer(http.MethodPost, "/catalogo/producto", aJSON(t, solicitud)), http.StatusBadRequest)
+}
+
+func TestPublicarProducto_CantidadInvalida(t *testing.T) {
+	s := nuevoServidorPrueba(t)
+	solicitud := solicitudPublicacion(s.semilla1, "Fresa")
+	solicitud["cantidad_unidad"] = "arroba"
+	exigirStatus(t, s.hacer(http.MethodPost, "/catalogo/producto", aJSON(t, solicitud)), http.StatusBadRequest)
+}
+
+func TestGetProductosEnTemporada(t *testing.T) {
+	s := nuevoServidorPrueba(t)
+	publicar := func(nombre, inicio, fin string) string {
+		req := solicitudPublicacion(s.semilla1, nombre)
+		req["temporada_inicio"], req["temporada_fin"] = inicio, fin
+		w := s.hacer(http.MethodPost, "/catalogo/producto", aJSON(t, req))
+		exigirStatus(t, w, http.StatusCreated)
+		return decodificar[dto.ProductoResponse](t, w).ID
+	}
+	publicar("Fresa", "2099-01-01", "2099-03-31")
+	publicar("Mora", "2099-06-01", "2099-06-30")
+	// En temporada pero agotado: no se ofrece
+	agotado := publicar("Lulo", "2099-01-01", "2099-12-31")
+	exigirStatus(t, s.hacer(http.MethodPost, "/catalogo/productos/"+agotado+"/agotar", ""), http.StatusNoContent)
+
+	casos := []struct {
+		fecha   string
+		nombres []string
+	}{
+		{"2099-02-15", []string{"Fresa"}},
+		{"2099-06-15", []string{"Mora"}},
+		{"2099-03-31", []string{"Fresa"}},
+		{"2099-04-01", nil},
+		{"2099-05-15", nil},
+	}
+	for _, tc := range casos {
+		t.Run(tc.fecha, func(t *testing.T) {
+			w := s.hacer(http.MethodGet, "/catalogo/productos/temporada?fecha="+tc.fecha, "")
+			exigirStatus(t, w, http.StatusOK)
+			if len(tc.nombres) == 0 && strings.TrimSpace(w.Body.String()) != "[]" {
+				t.Errorf("cuerpo = %s, se esperaba []", w.Body.String())
+			}
+			var nombres []string
+			for _, p := range decodificar[[]dto.ProductoResponse](t, w) {
+				nombres = append(nombres, p.Nombre)
+			}
+			slices.Sort(nombres)
+			if !slices.Equal(nombres, tc.nombres) {
+				t.Errorf("productos = %v, se esperaba %v", nombres, tc.nombres)
+			}
+		})
+	}
+
+	t.Run("sin fecha usa hoy", func(t *testing.T) {
+		w := s.hacer(http.MethodGet, "/catalogo/productos/temporada", "")
+		exigirStatus(t, w, http.StatusOK)
+		if strings.TrimSpace(w.Body.String()) != "[]" {
+			t.Errorf("cuerpo = %s, ninguna temporada incluye hoy", w.Body.String())
+		}
+	})
+	t.Run("fecha inválida", func(t *testing.T) {
+		r := exigirError(t, s.hacer(http.MethodGet, "/catalogo/productos/temporada?fecha=15-02-2099", ""), http.StatusBadRequest, CodigoValidacion)
+		if r.Field != "fecha" {
+			t.Errorf("field = %q, se esperaba fecha", r.Field)
+		}
+	})
+}
+
+func TestGetProductosExcedentes(t *testing.T) {
+	s := nuevoServidorPrueba(t)
+	s.publicar(t, s.semilla1, "Fresa")
+	s.publicar(t, s.semilla2, "Mora")
+
+	w := s.hacer(http.MethodGet, "/catalogo/productos/excedentes", "")
+	exigirStatus(t, w, http.StatusOK)
+	if strings.TrimSpace(w.Body.String()) != "[]" {
+		t.Errorf("sin excedentes el cuerpo = %s, se esperaba []", w.Body.String())
+	}
+
+	hoy := time.Now().In(producto.ZonaHoraria()).Format("2006-01-02")
+	exigirStatus(t, s.hacer(http.MethodPost, "/catalogo/productos/excedente",
+		aJSON(t, map[string]string{"producto_id": "producto-000002", "fecha": hoy})), http.StatusNoContent)
+
+	w = s.hacer(http.MethodGet, "/catalogo/productos/excedentes", "")
+	exigirStatus(t, w, http.StatusOK)
+	excedentes := decodificar[[]dto.ProductoConProductorView](t, w)
+	propietario, err := s.productorRepo.GetByID(s.semilla2)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if len(excedentes) != 1 || excedentes[0].Nombre != "Mora" || excedentes[0].Estado != producto.Excedente {
+		t.Fatalf("excedentes = %+v, se esperaba solo Mora", excedentes)
+	}
+	if excedentes[0].ProductorNombre != propietario.Nombre.Value {
+		t.Errorf("productor_nombre = %q, se esperaba %q", excedentes[0].ProductorNombre, propietario.Nombre.Value)
+	}
+	// Los campos del producto y productor_nombre van en el mismo nivel
+	if !strings.Contains(w.Body.String(), `"productor_nombre"`) || strings.Contains(w.Body.String(), `"ProductoResponse"`) {
+		t.Errorf("cuerpo = %s, se esperaba un objeto plano con productor_nombre", w.Body.String())
+	}
+}
+
+func TestPublicarProducto_IdempotencyKeyConcurrente(t *testing.T) {
+	s := nuevoServidorPrueba(t, service.WithIdempotenciaRepository(repository.NewIdempotenciaRepository(time.Hour)))
+	cuerpo := aJSON(t, solicitudPublicacion(s.semilla1, "Fresa"))
+
+	const intentos = 10
+	var (
+		wg         sync.WaitGroup
+		respuestas [intentos]*httptest.ResponseRecorder
+	)
+	inicio := make(chan struct{})
+	for i := range intentos {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-inicio
+			respuestas[i] = s.hacer(http.MethodPost, "/catalogo/producto", cuerpo, HeaderIdempotencyKey, "reintento-1")
+		}()
+	}
+	close(inicio)
+	wg.Wait()
+
+	creados, repetidos := 0, 0
+	ids := map[string]bool{}
+	for _, w := range respuestas {
+		switch w.Code {
+		case http.StatusCreated:
+			creados++
+		case http.StatusOK:
+			repetidos++
+		default:
+			t.Fatalf("status = %d; cuerpo: %s", w.Code, w.Body.String())
+		}
+		ids[decodificar[dto.ProductoResponse](t, w).ID] = true
+	}
+	if creados != 1 || repetidos != intentos-1 || len(ids) != 1 {
+		t.Errorf("creados = %d, repetidos = %d, ids distintos = %d; se esperaba 1, %d y 1", creados, repetidos, len(ids), intentos-1)
+	}
+	if n := contarProductos(t, s); n != 1 {
+		t.Errorf("productos = %d, se esperaba 1", n)
+	}
+
+	// La misma clave con otro cuerpo es un error del cliente, no un reintento
+	otro := aJSON(t, solicitudPublicacion(s.semilla1, "Mora"))
+	exigirError(t, s.hacer(http.MethodPost, "/catalogo/producto", otro, HeaderIdempotencyKey, "reintento-1"),
+		http.StatusConflict, CodigoIdempotenciaConflicto)
+	if n := contarProductos(t, s); n != 1 {
+		t.Errorf("productos = %d tras el conflicto, se esperaba 1", n)
+	}
+}
+
+func TestPublicarProducto_IdempotencyKeyRechazada(t *testing.T) {
+	s := nuevoServidorPrueba(t, service.WithIdempotenciaRepository(repository.NewIdempotenciaRepository(time.Hour)))
+
+	r := exigirError(t, s.hacer(http.MethodPost, "/catalogo/producto", aJSON(t, solicitudPublicacion(s.semilla1, "Fresa")),
+		HeaderIdempotencyKey, strings.Repeat("k", maxLongitudIdempotencyKey+1)), http.StatusBadRequest, CodigoValidacion)
+	if r.Field != HeaderIdempotencyKey {
+		t.Errorf("field = %q, se esperaba %s", r.Field, HeaderIdempotencyKey)
+	}
+
+	// Una publicación rechazada no consume la clave: el reintento corregido crea el producto
+	invalida := solicitudPublicacion(s.semilla1, "Fresa")
+	invalida["productor_id"] = "no-existe"
+	exigirStatus(t, s.hacer(http.MethodPost, "/catalogo/producto", aJSON(t, invalida), HeaderIdempotencyKey, "reintento-1"),
+		http.StatusNotFound)
+	exigirStatus(t, s.hacer(http.MethodPost, "/catalogo/producto", aJSON(t, solicitudPublicacion(s.semilla1, "Fresa")),
+		HeaderIdempotencyKey, "reintento-1"), http.StatusCreated)
+}
+
+func TestPublicarProducto_ProductoIDDelCliente(t *testing.T) {
+	s := nuevoServidorPrueba(t)
+	const id = "7C1E4B2A-9D3F-4E8B-A6C5-0F2D1B3A4C5E"
+
+	solicitud := solicitudPublicacion(s.semilla1, "Fresa")
+	solicitud["producto_id"] = id
+	w := s.hacer(http.MethodPost, "/catalogo/producto", aJSON(t, solicitud))
+	exigirStatus(t, w, http.StatusCreated)
+	if creado := decodificar[dto.ProductoResponse](t, w); creado.ID != strings.ToLower(id) {
+		t.Errorf("id = %q, se esperaba el UUID enviado en minúsculas", creado.ID)
+	}
+	exigirStatus(t, s.hacer(http.MethodGet, "/catalogo/productos/"+strings.ToLower(id), ""), http.StatusOK)
+
+	// El mismo ID con otro nombre ya existe
+	solicitud["nombre"] = "Mora"
+	exigirError(t, s.hacer(http.MethodPost, "/catalogo/producto", aJSON(t, solicitud)), http.StatusConflict, CodigoProductoDuplicado)
+
+	solicitud["producto_id"] = "fila-7"
+	if r := exigirError(t, s.hacer(http.MethodPost, "/catalogo/producto", aJSON(t, solicitud)),
+		http.StatusBadRequest, CodigoValidacion); r.Field != "producto_id" {
+		t.Errorf("field = %q, se esperaba producto_id", r.Field)
+	}
+	if n := contarProductos(t, s); n != 1 {
+		t.Errorf("productos = %d, se esperaba 1", n)
+	}
+
+	// Sin producto_id el backend genera el ID
+	delete(solicitud, "producto_id")
+	w = s.hacer(http.MethodPost, "/catalogo/producto", aJSON(t, solicitud))
+	exigirStatus(t, w, http.StatusCreated)
+	if creado := decodificar[dto.ProductoResponse](t, w); creado.ID != "producto-000001" {
+		t.Errorf("id = %q, se esperaba el generado producto-000001", creado.ID)
+	}
+}
+
+func TestPublicarProducto_LocationYURL(t *testing.T) {
+	s := nuevoServidorPrueba(t, service.WithIdempotenciaRepository(repository.NewIdempotenciaRepository(time.Hour)))
+	cuerpo := aJSON(t, solicitudPublicacion(s.semilla1, "Fresa"))
+
+	w := s.hacer(http.MethodPost, "/catalogo/producto", cuerpo, HeaderIdempotencyKey, "clave-1")
+	exigirStatus(t, w, http.StatusCreated)
+	creado := decodificar[dto.ProductoCreadoResponse](t, w)
+	if creado.ID != "producto-000001" || creado.URL != "/catalogo/productos/producto-000001" {
+		t.Errorf("id = %q, url = %q; se esperaba producto-000001 y su ruta canónica", creado.ID, creado.URL)
+	}
+	if got := w.Header().Get("Location"); got != creado.URL {
+		t.Errorf("Location = %q, se esperaba %q", got, creado.URL)
+	}
+	// La URL lleva al producto creado
+	exigirStatus(t, s.hacer(http.MethodGet, creado.URL, ""), http.StatusOK)
+
+	// El reintento recibe los mismos enlaces que la respuesta original
+	w = s.hacer(http.MethodPost, "/catalogo/producto", cuerpo, HeaderIdempotencyKey, "clave-1")
+	exigirStatus(t, w, http.StatusOK)
+	if got := w.Header().Get("Location"); got != creado.URL {
+		t.Errorf("Location del reintento = %q, se esperaba %q", got, creado.URL)
+	}
+	if repetido := decodificar[dto.ProductoCreadoResponse](t, w); repetido.URL != creado.URL {
+		t.Errorf("url del reintento = %q, se esperaba %q", repetido.URL, creado.URL)
+	}
+
+	// En inglés el enlace es el mismo
+	en, err := os.ReadFile(filepath.Join("testdata", "dialecto", "publicar.en.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	w = nuevoServidorPrueba(t).hacer(http.MethodPost, "/catalogo/producto", string(en), HeaderDialecto, DialectoIngles)
+	exigirStatus(t, w, http.StatusCreated)
+	creadoEn := decodificar[map[string]any](t, w)
+	if creadoEn["url"] != creado.URL || w.Header().Get("Location") != creado.URL {
+		t.Errorf("url = %v, Location = %q; se esperaba %s", creadoEn["url"], w.Header().Get("Location"), creado.URL)
+	}
+}
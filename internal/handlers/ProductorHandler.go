@@ -0,0 +1,411 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+	"Product_Catalog_Microservice/internal/domain/productor"
+	"Product_Catalog_Microservice/internal/domain/service"
+	"Product_Catalog_Microservice/internal/handlers/dto"
+	"Product_Catalog_Microservice/internal/ids"
+	"github.com/gin-gonic/gin"
+)
+
+type ProductorHandler struct {
+	Catalogo *service.CatalogoService
+	IDs      ids.IDGenerator
+
+	// MinReputacionAptos es la reputación mínima de GET /catalogo/productores/aptos
+	// cuando la petición no trae min_reputacion
+	MinReputacionAptos productor.Reputacion
+}
+
+// registrarProductorRequest es el cuerpo de POST /catalogo/productor
+type registrarProductorRequest struct {
+	Nombre           string `json:"nombre"`
+	ZonaVeredal      string `json:"zona_veredal"`
+	Finca            string `json:"finca"`
+	PracticasCultivo string `json:"practicas_cultivo"`
+}
+
+// POST /catalogo/productor (también /catalogo/productores)
+func (h *ProductorHandler) RegistrarProductor(c *gin.Context) {
+	var req registrarProductorRequest
+	if err := bindJSONDialecto[registrarProductorRequest, registrarProductorRequestEn](c, &req); err != nil {
+		responderJSONInvalido(c, err)
+		return
+	}
+
+	nombre, err := productor.NewNombreProducto(req.Nombre)
+	if err != nil {
+		responderError(c, err)
+		return
+	}
+	ubicacion, err := productor.NewUbicacion(req.ZonaVeredal, req.Finca)
+	if err != nil {
+		responderError(c, err)
+		return
+	}
+	practicas, err := productor.NuevaPracticasDeCultivo(req.PracticasCultivo)
+	if err != nil {
+		responderError(c, err)
+		return
+	}
+
+	// Todo productor nuevo empieza sin verificar y activo
+	prod, err := productor.NewProductor(
+		h.IDs.NewProductorID(),
+		nombre,
+		ubicacion,
+		productor.EstadoVerificacion{Value: productor.NoVerificado},
+		productor.EstadoActividad{Value: productor.Activo},
+		0,
+		practicas,
+	)
+	if err != nil {
+		responderError(c, err)
+		return
+	}
+
+	if err := h.Catalogo.RegistrarProductor(prod); err != nil {
+		responderError(c, err)
+		return
+	}
+	anotarAgregadosEnLog(c, string(prod.ID), "")
+
+	c.JSON(http.StatusCreated, gin.H{"id": prod.ID})
+}
+
+// GET /catalogo/productores/:id
+func (h *ProductorHandler) GetProductor(c *gin.Context) {
+	prod, err := h.Catalogo.GetProductor(productor.ProductorID(c.Param("id")))
+	if err != nil {
+		responderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewProductorResponse(prod))
+}
+
+// GET /catalogo/productores/practica?q=compost
+func (h *ProductorHandler) GetProductoresPorPractica(c *gin.Context) {
+	productores, err := h.Catalogo.GetProductoresPorPractica(c.Query("q"))
+	if err != nil {
+		responderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewProductorResponses(productores))
+}
+
+// GET /catalogo/productores/aptos?min_reputacion=3.5
+// Lista los productores que hoy pueden publicar (verificados, activos y con la reputación mínima).
+func (h *ProductorHandler) GetProductoresAptos(c *gin.Context) {
+	minReputacion := h.MinReputacionAptos
+	if valor := c.Query("min_reputacion"); valor != "" {
+		numero, err := strconv.ParseFloat(valor, 32)
+		if err != nil {
+			responderValidacion(c, "min_reputacion", "min_reputacion debe ser un número")
+			return
+		}
+		minReputacion, err = productor.NuevaReputacion(float32(numero))
+		if err != nil {
+			responderError(c, err)
+			return
+		}
+	}
+
+	productores, err := h.Catalogo.GetProductoresAptosParaPublicar(minReputacion)
+	if err != nil {
+		responderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewProductorResponses(productores))
+}
+
+// GET /catalogo/productores/:id/productos?categoria=Fruta&estado=Disponible
+func (h *ProductorHandler) GetProductosDeProductor(c *gin.Context) {
+	productorID := productor.ProductorID(c.Param("id"))
+
+	var estado *producto.EstadoDisponibilidad
+	if valor := c.Query("estado"); valor != "" {
+		e, err := producto.NewEstadoDisponibilidad(valor)
+		if err != nil {
+			responderError(c, err)
+			return
+		}
+		estado = &e
+	}
+
+	var (
+		productos []*producto.ProductoAgroecologico
+		err       error
+	)
+	if valor := c.Query("categoria"); valor != "" {
+		categoria, errCategoria := producto.NewCategoria(valor)
+		if errCategoria != nil {
+			responderError(c, errCategoria)
+			return
+		}
+		productos, err = h.Catalogo.GetProductosDeProductorPorCategoria(productorID, categoria)
+	} else {
+		productos, err = h.Catalogo.GetProductosByProductor(productorID)
+	}
+	if err != nil {
+		responderError(c, err)
+		return
+	}
+
+	// Siempre un arreglo, nunca null, aunque el productor no tenga productos
+	resultado := make([]*producto.ProductoAgroecologico, 0, len(productos))
+	for _, prod := range productos {
+		if estado == nil || prod.Estado == *estado {
+			resultado = append(resultado, prod)
+		}
+	}
+	responderProductos(c, resultado)
+}
+
+// productorCohorteView es la vista de un productor dentro de una cohorte de registro
+type productorCohorteView struct {
+	ID                       productor.ProductorID `json:"id"`
+	Nombre                   string                `json:"nombre"`
+	FechaRegistro            time.Time             `json:"fecha_registro"`
+	TotalProductosPublicados int                   `json:"total_productos_publicados"`
+	ReputacionActual         productor.Reputacion  `json:"reputacion_actual"`
+}
+
+// GET /catalogo/admin/productores/cohorte?mes=2025-06
+func (h *ProductorHandler) GetCohorteProductores(c *gin.Context) {
+	mes, err := time.Parse("2006-01", c.Query("mes"))
+	if err != nil {
+		responderValidacion(c, "mes", "Formato de mes inválido, se espera AAAA-MM")
+		return
+	}
+
+	productores, err := h.Catalogo.GetProductoresRegistradosMes(mes.Year(), int(mes.Month()))
+	if err != nil {
+		responderError(c, err)
+		return
+	}
+
+	cohorte := make([]productorCohorteView, 0, len(productores))
+	for _, prod := range productores {
+		productos, err := h.Catalogo.GetProductosByProductor(prod.ID)
+		if err != nil {
+			responderError(c, err)
+			return
+		}
+		cohorte = append(cohorte, productorCohorteView{
+			ID:                       prod.ID,
+			Nombre:                   prod.Nombre.Value,
+			FechaRegistro:            prod.FechaRegistro,
+			TotalProductosPublicados: len(productos),
+			ReputacionActual:         prod.Reputacion,
+		})
+	}
+
+	c.JSON(http.StatusOK, cohorte)
+}
+
+// GET /catalogo/admin/productores/inactivos?dias=90
+func (h *ProductorHandler) GetProductoresInactivos(c *gin.Context) {
+	dias, err := strconv.Atoi(c.DefaultQuery("dias", "90"))
+	if err != nil {
+		responderValidacion(c, "dias", "el parámetro dias debe ser un número entero")
+		return
+	}
+
+	productores, err := h.Catalogo.GetProductoresInactivos(dias)
+	if err != nil {
+		responderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewProductorResponses(productores))
+}
+
+// PUT /catalogo/productores/:id/preferencias
+func (h *ProductorHandler) ActualizarPreferenciasNotificacion(c *gin.Context) {
+	var req map[string]bool
+	if err := c.ShouldBindJSON(&req); err != nil {
+		responderJSONInvalido(c, err)
+		return
+	}
+
+	preferencias, err := productor.NuevasPreferenciasNotificacion(req)
+	if err != nil {
+		responderError(c, err)
+		return
+	}
+
+	if err := h.Catalogo.ActualizarPreferenciasNotificacion(productor.ProductorID(c.Param("id")), preferencias); err != nil {
+		responderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, preferencias)
+}
+
+// PUT /catalogo/productores/:id/reputacion
+// Asignar la misma reputación que ya tiene responde 204 sin publicar ReputacionActualizada.
+func (h *ProductorHandler) ActualizarReputacion(c *gin.Context) {
+	var req struct {
+		Reputacion *float32 `json:"reputacion"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		responderJSONInvalido(c, err)
+		return
+	}
+	if req.Reputacion == nil {
+		responderValidacion(c, "reputacion", "el campo reputacion es obligatorio")
+		return
+	}
+
+	reputacion, err := productor.NuevaReputacion(*req.Reputacion)
+	if err != nil {
+		responderError(c, err)
+		return
+	}
+
+	if err := h.Catalogo.ActualizarReputacionProductor(productor.ProductorID(c.Param("id")), reputacion); err != nil {
+		responderError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// POST /catalogo/productores/:id/verificacion/iniciar
+func (h *ProductorHandler) IniciarVerificacion(c *gin.Context) {
+	if err := h.Catalogo.IniciarVerificacionProductor(productor.ProductorID(c.Param("id"))); err != nil {
+		responderError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// POST /catalogo/productores/:id/verificacion/completar
+func (h *ProductorHandler) CompletarVerificacion(c *gin.Context) {
+	sinCambios, err := h.Catalogo.CompletarVerificacionProductor(productor.ProductorID(c.Param("id")))
+	if err != nil {
+		responderError(c, err)
+		return
+	}
+
+	if sinCambios {
+		c.JSON(http.StatusOK, gin.H{"sin_cambios": true})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// PUT /catalogo/productores/:id/suspender
+// El cuerpo {"motivo": "..."} es opcional. Todos los productos del productor pasan a Agotado.
+func (h *ProductorHandler) SuspenderProductor(c *gin.Context) {
+	var req struct {
+		Motivo string `json:"motivo"`
+	}
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			responderJSONInvalido(c, err)
+			return
+		}
+	}
+
+	sinCambios, err := h.Catalogo.SuspenderProductor(productor.ProductorID(c.Param("id")), req.Motivo)
+	if err != nil {
+		responderError(c, err)
+		return
+	}
+
+	if sinCambios {
+		c.JSON(http.StatusOK, gin.H{"sin_cambios": true})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// PUT /catalogo/productores/:id/reactivar
+// Los productos del productor vuelven a estar disponibles si están en temporada.
+func (h *ProductorHandler) ReactivarProductor(c *gin.Context) {
+	sinCambios, err := h.Catalogo.ReactivarProductor(productor.ProductorID(c.Param("id")))
+	if err != nil {
+		responderError(c, err)
+		return
+	}
+
+	if sinCambios {
+		c.JSON(http.StatusOK, gin.H{"sin_cambios": true})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// HeaderProductorID identifica al productor que hace la petición en las rutas "mis-*"
+const HeaderProductorID = "X-Productor-ID"
+
+// rechazoView es la vista de un rechazo para el propio productor; no incluye su ID
+type rechazoView struct {
+	NombreProducto string    `json:"nombre_producto"`
+	Codigo         string    `json:"codigo"`
+	Mensaje        string    `json:"mensaje"`
+	Fecha          time.Time `json:"fecha"`
+}
+
+// rechazoAdminView es la vista de un rechazo para administración
+type rechazoAdminView struct {
+	ProductorID productor.ProductorID `json:"productor_id"`
+	rechazoView
+}
+
+func nuevoRechazoView(r productor.RechazoPublicacion) rechazoView {
+	return rechazoView{
+		NombreProducto: r.NombreProducto,
+		Codigo:         r.Codigo,
+		Mensaje:        r.Mensaje,
+		Fecha:          r.Fecha,
+	}
+}
+
+// GET /catalogo/mis-rechazos
+// Solo retorna los rechazos del productor indicado en X-Productor-ID. La ruta está detrás de
+// GuardiaAdmin: el header lo envía en nombre del productor quien ya lo autenticó.
+func (h *ProductorHandler) GetMisRechazos(c *gin.Context) {
+	productorID := c.GetHeader(HeaderProductorID)
+	if productorID == "" {
+		escribirError(c, http.StatusUnauthorized, CodigoProductorNoIdentificado, HeaderProductorID, "falta el header "+HeaderProductorID)
+		return
+	}
+
+	rechazos, err := h.Catalogo.GetRechazosDeProductor(productor.ProductorID(productorID))
+	if err != nil {
+		responderError(c, err)
+		return
+	}
+
+	respuesta := make([]rechazoView, 0, len(rechazos))
+	for _, r := range rechazos {
+		respuesta = append(respuesta, nuevoRechazoView(r))
+	}
+	c.JSON(http.StatusOK, respuesta)
+}
+
+// GET /catalogo/admin/rechazos?codigo=nombre_duplicado
+func (h *ProductorHandler) GetRechazos(c *gin.Context) {
+	rechazos, err := h.Catalogo.GetRechazosPorCodigo(c.Query("codigo"))
+	if err != nil {
+		responderError(c, err)
+		return
+	}
+
+	respuesta := make([]rechazoAdminView, 0, len(rechazos))
+	for _, r := range rechazos {
+		respuesta = append(respuesta, rechazoAdminView{ProductorID: r.ProductorID, rechazoView: nuevoRechazoView(r)})
+	}
+	c.JSON(http.StatusOK, respuesta)
+}
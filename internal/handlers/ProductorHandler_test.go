@@ -0,0 +1,480 @@
+package handlers
+
+import (
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+	"Product_Catalog_Microservice/internal/domain/productor"
+	"Product_Catalog_Microservice/internal/domain/service"
+	"Product_Catalog_Microservice/internal/handlers/dto"
+	"Product_Catalog_Microservice/internal/repository"
+)
+
+func TestGetProductoresPorPractica(t *testing.T) {
+	s := nuevoServidorPrueba(t)
+
+	casos := []struct {
+		nombre string
+		q      string
+		ids    []productor.ProductorID
+	}{
+		{"palabra clave", "abonos", []productor.ProductorID{s.semilla1}},
+		{"sin distinguir mayúsculas", "Pesticidas", []productor.ProductorID{s.semilla2}},
+		{"sin coincidencia", "biodinámica", []productor.ProductorID{}},
+	}
+	for _, tc := range casos {
+		t.Run(tc.nombre, func(t *testing.T) {
+			w := s.hacer(http.MethodGet, "/catalogo/productores/practica?q="+url.QueryEscape(tc.q), "")
+			exigirStatus(t, w, http.StatusOK)
+
+			// Sin coincidencias responde [] y no null
+			productores := decodificar[[]dto.ProductorResponse](t, w)
+			if productores == nil || len(productores) != len(tc.ids) {
+				t.Fatalf("respuesta = %s, se esperaban %v", w.Body.String(), tc.ids)
+			}
+			for i, p := range productores {
+				if p.ID != string(tc.ids[i]) {
+					t.Errorf("productores[%d].ID = %s, se esperaba %s", i, p.ID, tc.ids[i])
+				}
+			}
+		})
+	}
+}
+
+func TestGetProductoresPorPractica_PalabraCorta(t *testing.T) {
+	s := nuevoServidorPrueba(t)
+
+	w := s.hacer(http.MethodGet, "/catalogo/productores/practica?q=ab", "")
+	exigirStatus(t, w, http.StatusBadRequest)
+}
+
+func TestRegistrarProductor(t *testing.T) {
+	s := nuevoServidorPrueba(t)
+	solicitud := map[string]any{
+		"nombre":            "Ana Rojas",
+		"zona_veredal":      "Vereda El Placer",
+		"finca":             "Finca Los Naranjos",
+		"practicas_cultivo": "Compostaje y control biológico de plagas",
+	}
+
+	w := s.hacer(http.MethodPost, "/catalogo/productor", aJSON(t, solicitud))
+	exigirStatus(t, w, http.StatusCreated)
+	id := decodificar[struct {
+		ID productor.ProductorID `json:"id"`
+	}](t, w).ID
+	prod, err := s.productorRepo.GetByID(id)
+	if err != nil {
+		t.Fatalf("GetByID(%q): %v", id, err)
+	}
+	if prod.Nombre.Value != "Ana Rojas" || prod.EstadoVerificacion.Value != productor.NoVerificado ||
+		prod.EstadoActividad.Value != productor.Activo || prod.Reputacion != 0 {
+		t.Errorf("productor = %+v, se esperaba Ana Rojas sin verificar, activa y con reputación 0", prod)
+	}
+	var creados []productor.ProductorCreado
+	for _, evento := range s.eventos.eventos {
+		if e, ok := evento.(productor.ProductorCreado); ok {
+			creados = append(creados, e)
+		}
+	}
+	if len(creados) != 1 || creados[0].ProductorID != id {
+		t.Errorf("ProductorCreado publicados = %v, se esperaba uno de %s", creados, id)
+	}
+
+	// Los errores de los value objects llegan con su mensaje
+	casos := []struct {
+		campo, valor, mensaje string
+	}{
+		{"nombre", "", "nombre"},
+		{"zona_veredal", "", "zona"},
+		{"practicas_cultivo", " ", "prácticas"},
+	}
+	for _, tc := range casos {
+		t.Run(tc.campo, func(t *testing.T) {
+			invalida := map[string]any{}
+			for k, v := range solicitud {
+				invalida[k] = v
+			}
+			invalida[tc.campo] = tc.valor
+			w := s.hacer(http.MethodPost, "/catalogo/productor", aJSON(t, invalida))
+			exigirStatus(t, w, http.StatusBadRequest)
+			if !strings.Contains(strings.ToLower(w.Body.String()), tc.mensaje) {
+				t.Errorf("cuerpo = %s, se esperaba el mensaje de validación de %s", w.Body.String(), tc.campo)
+			}
+		})
+	}
+}
+
+func TestGetProductor(t *testing.T) {
+	s := nuevoServidorPrueba(t)
+	w := s.hacer(http.MethodPost, "/catalogo/productores", aJSON(t, map[string]any{
+		"nombre":            "Ana Rojas",
+		"zona_veredal":      "Vereda El Placer",
+		"finca":             "Finca Los Naranjos",
+		"practicas_cultivo": "Compostaje y control biológico de plagas",
+	}))
+	exigirStatus(t, w, http.StatusCreated)
+	id := decodificar[struct {
+		ID string `json:"id"`
+	}](t, w).ID
+
+	w = s.hacer(http.MethodGet, "/catalogo/productores/"+id, "")
+	exigirStatus(t, w, http.StatusOK)
+	prod := decodificar[dto.ProductorResponse](t, w)
+	if prod.ID != id || prod.Nombre != "Ana Rojas" || prod.ZonaVeredal != "Vereda El Placer" {
+		t.Errorf("productor = %+v, se esperaba Ana Rojas de Vereda El Placer con ID %s", prod, id)
+	}
+
+	exigirStatus(t, s.hacer(http.MethodGet, "/catalogo/productores/no-existe", ""), http.StatusNotFound)
+}
+
+func TestGetProductosDeProductor(t *testing.T) {
+	s := nuevoServidorPrueba(t)
+	s.publicar(t, s.semilla1, "Fresa")
+	s.publicar(t, s.semilla1, "Mora")
+	exigirStatus(t, s.hacer(http.MethodPost, "/catalogo/productos/producto-000002/agotar", ""), http.StatusNoContent)
+
+	casos := []struct {
+		nombre  string
+		ruta    string
+		status  int
+		cuantos int
+	}{
+		{"todos", "/catalogo/productores/" + string(s.semilla1) + "/productos", http.StatusOK, 2},
+		{"por estado", "/catalogo/productores/" + string(s.semilla1) + "/productos?estado=Agotado", http.StatusOK, 1},
+		{"estado y categoría", "/catalogo/productores/" + string(s.semilla1) + "/productos?estado=Disponible&categoria=Fruta", http.StatusOK, 1},
+		{"sin productos", "/catalogo/productores/" + string(s.semilla2) + "/productos", http.StatusOK, 0},
+		{"estado inválido", "/catalogo/productores/" + string(s.semilla1) + "/productos?estado=Vendido", http.StatusBadRequest, -1},
+		{"productor inexistente", "/catalogo/productores/no-existe/productos", http.StatusNotFound, -1},
+	}
+	for _, tc := range casos {
+		t.Run(tc.nombre, func(t *testing.T) {
+			w := s.hacer(http.MethodGet, tc.ruta, "")
+			exigirStatus(t, w, tc.status)
+			if tc.cuantos < 0 {
+				return
+			}
+			if strings.TrimSpace(w.Body.String()) == "null" {
+				t.Fatal("se esperaba un arreglo, no null")
+			}
+			if productos := decodificar[[]map[string]any](t, w); len(productos) != tc.cuantos {
+				t.Errorf("productos = %d, se esperaba %d", len(productos), tc.cuantos)
+			}
+		})
+	}
+}
+
+func TestVerificacionProductor(t *testing.T) {
+	s := nuevoServidorPrueba(t)
+	w := s.hacer(http.MethodPost, "/catalogo/productor", aJSON(t, map[string]any{
+		"nombre":            "Ana Rojas",
+		"zona_veredal":      "Vereda El Placer",
+		"finca":             "Finca Los Naranjos",
+		"practicas_cultivo": "Compostaje y control biológico de plagas",
+	}))
+	exigirStatus(t, w, http.StatusCreated)
+	id := decodificar[struct {
+		ID string `json:"id"`
+	}](t, w).ID
+	ruta := func(id, paso string) string { return "/catalogo/productores/" + id + "/verificacion/" + paso }
+
+	pasos := []struct {
+		nombre string
+		ruta   string
+		status int
+	}{
+		{"completar sin iniciar", ruta(id, "completar"), http.StatusConflict},
+		{"iniciar", ruta(id, "iniciar"), http.StatusNoContent},
+		{"iniciar de nuevo", ruta(id, "iniciar"), http.StatusConflict},
+		{"completar", ruta(id, "completar"), http.StatusNoContent},
+		{"completar de nuevo", ruta(id, "completar"), http.StatusOK},
+		{"iniciar ya verificado", ruta(string(s.semilla1), "iniciar"), http.StatusConflict},
+		{"productor inexistente", ruta("no-existe", "iniciar"), http.StatusNotFound},
+	}
+	for _, paso := range pasos {
+		exigirStatus(t, s.hacer(http.MethodPost, paso.ruta, ""), paso.status)
+	}
+
+	enVerificacion, verificados := 0, 0
+	for _, evento := range s.eventos.eventos {
+		switch evento.(type) {
+		case productor.ProductorEnVerificacion:
+			enVerificacion++
+		case productor.ProductorVerificado:
+			verificados++
+		}
+	}
+	if enVerificacion != 1 || verificados != 1 {
+		t.Errorf("eventos ProductorEnVerificacion = %d, ProductorVerificado = %d; se esperaba uno de cada uno", enVerificacion, verificados)
+	}
+}
+
+// registrarProductorEn guarda un productor verificado y activo con la fecha de registro
+// indicada y retorna su ID, derivado del nombre
+func (s *servidorPrueba) registrarProductorEn(t *testing.T, nombre string, reputacion float32, fecha time.Time) productor.ProductorID {
+	t.Helper()
+	n, _ := productor.NewNombreProducto(nombre)
+	ubicacion, _ := productor.NewUbicacion("Vereda El Paraíso", "Finca "+nombre)
+	practicas, _ := productor.NuevaPracticasDeCultivo("Abonos orgánicos")
+	id := productor.ProductorID(strings.ReplaceAll(strings.ToLower(nombre), " ", "-"))
+	prod, err := productor.NewProductor(id, n, ubicacion, productor.EstadoVerificacion{Value: productor.Verificado},
+		productor.EstadoActividad{Value: productor.Activo}, productor.Reputacion(reputacion), practicas)
+	if err != nil {
+		t.Fatalf("NewProductor: %v", err)
+	}
+	prod.FechaRegistro = fecha
+	prod.ClearEvents()
+	if err := s.productorRepo.Save(prod); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	return prod.ID
+}
+
+// publicar publica por HTTP un producto válido del productor indicado
+func (s *servidorPrueba) publicar(t *testing.T, productorID productor.ProductorID, nombre string) {
+	t.Helper()
+	w := s.hacer(http.MethodPost, "/catalogo/producto", aJSON(t, solicitudPublicacion(productorID, nombre)))
+	exigirStatus(t, w, http.StatusCreated)
+}
+
+func TestGetCohorteProductores(t *testing.T) {
+	s := nuevoServidorPrueba(t)
+	bogota := producto.ZonaHoraria()
+
+	junio1 := s.registrarProductorEn(t, "Junio Uno", 4.5, time.Date(2025, time.June, 3, 9, 0, 0, 0, bogota))
+	s.registrarProductorEn(t, "Junio Dos", 3.0, time.Date(2025, time.June, 30, 22, 0, 0, 0, bogota))
+	julio1 := s.registrarProductorEn(t, "Julio Uno", 5.0, time.Date(2025, time.July, 1, 0, 0, 0, 0, bogota))
+	s.publicar(t, junio1, "Fresa")
+	s.publicar(t, junio1, "Mora")
+	s.publicar(t, julio1, "Lulo")
+
+	// Expone datos por productor: solo para administración
+	exigirError(t, s.hacer(http.MethodGet, "/catalogo/admin/productores/cohorte?mes=2025-06", ""), http.StatusUnauthorized, CodigoNoAutenticado)
+
+	w := s.hacer(http.MethodGet, "/catalogo/admin/productores/cohorte?mes=2025-06", "", autorizacionAdmin...)
+	exigirStatus(t, w, http.StatusOK)
+
+	cohorte := decodificar[[]productorCohorteView](t, w)
+	sort.Slice(cohorte, func(i, j int) bool { return cohorte[i].Nombre > cohorte[j].Nombre })
+	esperado := []struct {
+		nombre     string
+		productos  int
+		reputacion productor.Reputacion
+	}{
+		{"Junio Uno", 2, 4.5},
+		{"Junio Dos", 0, 3.0},
+	}
+	if len(cohorte) != len(esperado) {
+		t.Fatalf("cohorte = %s, se esperaban %d productores", w.Body.String(), len(esperado))
+	}
+	for i, e := range esperado {
+		if cohorte[i].Nombre != e.nombre || cohorte[i].TotalProductosPublicados != e.productos || cohorte[i].ReputacionActual != e.reputacion {
+			t.Errorf("cohorte[%d] = %+v, se esperaba %s con %d productos y reputación %v",
+				i, cohorte[i], e.nombre, e.productos, e.reputacion)
+		}
+	}
+}
+
+func TestGetCohorteProductores_MesVacioRespondeArreglo(t *testing.T) {
+	s := nuevoServidorPrueba(t)
+
+	w := s.hacer(http.MethodGet, "/catalogo/admin/productores/cohorte?mes=2001-01", "", autorizacionAdmin...)
+	exigirStatus(t, w, http.StatusOK)
+	if got := strings.TrimSpace(w.Body.String()); got != "[]" {
+		t.Errorf("cuerpo = %s, se esperaba []", got)
+	}
+}
+
+func TestGetCohorteProductores_MesInvalido(t *testing.T) {
+	s := nuevoServidorPrueba(t)
+
+	for _, mes := range []string{"", "2025-13", "06-2025", "2025-6-1"} {
+		w := s.hacer(http.MethodGet, "/catalogo/admin/productores/cohorte?mes="+url.QueryEscape(mes), "", autorizacionAdmin...)
+		exigirStatus(t, w, http.StatusBadRequest)
+	}
+}
+
+func TestActualizarPreferenciasNotificacion(t *testing.T) {
+	s := nuevoServidorPrueba(t)
+	ruta := "/catalogo/productores/" + string(s.semilla1) + "/preferencias"
+
+	w := s.hacer(http.MethodPut, ruta, `{"agotado": false}`)
+	exigirStatus(t, w, http.StatusOK)
+	preferencias := decodificar[map[string]bool](t, w)
+	if preferencias["agotado"] || !preferencias["excedente"] || !preferencias["fin_temporada"] {
+		t.Errorf("preferencias = %v, solo agotado debía quedar desactivada", preferencias)
+	}
+
+	exigirStatus(t, s.hacer(http.MethodPut, ruta, `{"promociones": true}`), http.StatusBadRequest)
+	exigirStatus(t, s.hacer(http.MethodPut, "/catalogo/productores/no-existe/preferencias", `{"agotado": true}`), http.StatusNotFound)
+}
+
+func TestActualizarReputacion(t *testing.T) {
+	s := nuevoServidorPrueba(t)
+	ruta := "/catalogo/productores/" + string(s.semilla1) + "/reputacion"
+
+	pasos := []struct {
+		nombre string
+		ruta   string
+		cuerpo string
+		status int
+	}{
+		{"cambio", ruta, `{"reputacion": 4.0}`, http.StatusNoContent},
+		{"mismo valor", ruta, `{"reputacion": 4.0}`, http.StatusNoContent},
+		{"fuera de rango", ruta, `{"reputacion": 7}`, http.StatusBadRequest},
+		{"sin reputacion", ruta, `{}`, http.StatusBadRequest},
+		{"productor inexistente", "/catalogo/productores/no-existe/reputacion", `{"reputacion": 4.0}`, http.StatusNotFound},
+	}
+	for _, paso := range pasos {
+		w := s.hacer(http.MethodPut, paso.ruta, paso.cuerpo)
+		if w.Code != paso.status {
+			t.Errorf("%s: status = %d, se esperaba %d: %s", paso.nombre, w.Code, paso.status, w.Body.String())
+		}
+	}
+
+	prod, err := s.productorRepo.GetByID(s.semilla1)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if prod.Reputacion != 4.0 {
+		t.Errorf("Reputacion = %v, se esperaba 4", prod.Reputacion)
+	}
+	actualizaciones := 0
+	for _, evento := range s.eventos.eventos {
+		if _, ok := evento.(productor.ReputacionActualizada); ok {
+			actualizaciones++
+		}
+	}
+	if actualizaciones != 1 {
+		t.Errorf("eventos ReputacionActualizada = %d, se esperaba 1: repetir el valor no debía publicar otro", actualizaciones)
+	}
+}
+
+func TestGetProductoresInactivos(t *testing.T) {
+	s := nuevoServidorPrueba(t)
+	if err := s.productorRepo.UpdateUltimaActividad(s.semilla2, time.Now().AddDate(0, 0, -100)); err != nil {
+		t.Fatalf("UpdateUltimaActividad: %v", err)
+	}
+
+	// Expone la actividad de cada productor: solo para administración
+	exigirError(t, s.hacer(http.MethodGet, "/catalogo/admin/productores/inactivos", ""), http.StatusUnauthorized, CodigoNoAutenticado)
+
+	w := s.hacer(http.MethodGet, "/catalogo/admin/productores/inactivos", "", autorizacionAdmin...)
+	exigirStatus(t, w, http.StatusOK)
+	if inactivos := decodificar[[]map[string]any](t, w); len(inactivos) != 1 || inactivos[0]["id"] != string(s.semilla2) {
+		t.Errorf("inactivos = %v, se esperaba solo la semilla 2", inactivos)
+	}
+
+	w = s.hacer(http.MethodGet, "/catalogo/admin/productores/inactivos?dias=120", "", autorizacionAdmin...)
+	exigirStatus(t, w, http.StatusOK)
+	if w.Body.String() != "[]" {
+		t.Errorf("cuerpo = %s, se esperaba []", w.Body.String())
+	}
+
+	exigirStatus(t, s.hacer(http.MethodGet, "/catalogo/admin/productores/inactivos?dias=abc", "", autorizacionAdmin...), http.StatusBadRequest)
+}
+
+func TestGetMisRechazos_SoloLosDelProductor(t *testing.T) {
+	s := nuevoServidorPrueba(t, service.WithRechazoRepository(repository.NewRechazoRepository(10)))
+	s.publicar(t, s.semilla1, "Fresa")
+	s.publicar(t, s.semilla2, "Mora")
+	for _, p := range []struct {
+		productorID productor.ProductorID
+		nombre      string
+	}{{s.semilla1, "Fresa"}, {s.semilla2, "Mora"}, {s.semilla2, "Mora"}} {
+		w := s.hacer(http.MethodPost, "/catalogo/producto", aJSON(t, solicitudPublicacion(p.productorID, p.nombre)))
+		exigirError(t, w, http.StatusConflict, CodigoNombreDuplicado)
+	}
+
+	// El header del productor no basta: se requiere el token de administración
+	exigirError(t, s.hacer(http.MethodGet, "/catalogo/mis-rechazos", "", HeaderProductorID, string(s.semilla1)),
+		http.StatusUnauthorized, CodigoNoAutenticado)
+	exigirError(t, s.hacer(http.MethodGet, "/catalogo/admin/rechazos", ""), http.StatusUnauthorized, CodigoNoAutenticado)
+	exigirError(t, s.hacer(http.MethodGet, "/catalogo/mis-rechazos", "", autorizacionAdmin...),
+		http.StatusUnauthorized, CodigoProductorNoIdentificado)
+
+	w := s.hacer(http.MethodGet, "/catalogo/mis-rechazos", "", append([]string{HeaderProductorID, string(s.semilla1)}, autorizacionAdmin...)...)
+	exigirStatus(t, w, http.StatusOK)
+	if strings.Contains(w.Body.String(), string(s.semilla2)) || strings.Contains(w.Body.String(), "productor_id") {
+		t.Errorf("la respuesta incluye IDs de productores: %s", w.Body.String())
+	}
+	rechazos := decodificar[[]rechazoView](t, w)
+	if len(rechazos) != 1 || rechazos[0].NombreProducto != "Fresa" || rechazos[0].Codigo != productor.RechazoNombreDuplicado {
+		t.Errorf("rechazos = %+v, se esperaba solo el de Fresa", rechazos)
+	}
+
+	w = s.hacer(http.MethodGet, "/catalogo/admin/rechazos?codigo="+productor.RechazoNombreDuplicado, "", autorizacionAdmin...)
+	exigirStatus(t, w, http.StatusOK)
+	if todos := decodificar[[]rechazoAdminView](t, w); len(todos) != 3 {
+		t.Errorf("rechazos para administración = %d, se esperaban 3", len(todos))
+	}
+}
+
+func TestGetProductoresAptos(t *testing.T) {
+	s := nuevoServidorPrueba(t)
+	// Las semillas tienen reputación 4.5 y 3.8; uno nuevo con 2 no alcanza el mínimo por defecto
+	s.registrarProductorEn(t, "Los Robles", 2, time.Now())
+
+	aptos := func(t *testing.T, query string) []productor.ProductorID {
+		t.Helper()
+		w := s.hacer(http.MethodGet, "/catalogo/productores/aptos"+query, "")
+		exigirStatus(t, w, http.StatusOK)
+		var ids []productor.ProductorID
+		for _, p := range decodificar[[]dto.ProductorResponse](t, w) {
+			ids = append(ids, productor.ProductorID(p.ID))
+		}
+		slices.Sort(ids)
+		return ids
+	}
+
+	if got := aptos(t, ""); !slices.Equal(got, []productor.ProductorID{s.semilla1, s.semilla2}) {
+		t.Errorf("aptos con el mínimo por defecto = %v, se esperaban las semillas", got)
+	}
+	if got := aptos(t, "?min_reputacion=4"); !slices.Equal(got, []productor.ProductorID{s.semilla1}) {
+		t.Errorf("aptos con reputación 4 = %v, se esperaba solo %s", got, s.semilla1)
+	}
+	if got := aptos(t, "?min_reputacion=5"); got != nil {
+		t.Errorf("aptos con reputación 5 = %v, se esperaba ninguno", got)
+	}
+	w := s.hacer(http.MethodGet, "/catalogo/productores/aptos?min_reputacion=5", "")
+	if strings.TrimSpace(w.Body.String()) != "[]" {
+		t.Errorf("cuerpo = %s, se esperaba []", w.Body.String())
+	}
+
+	for _, query := range []string{"?min_reputacion=6", "?min_reputacion=-1", "?min_reputacion=alta"} {
+		exigirStatus(t, s.hacer(http.MethodGet, "/catalogo/productores/aptos"+query, ""), http.StatusBadRequest)
+	}
+}
+
+func TestSuspenderYReactivarProductor(t *testing.T) {
+	s := nuevoServidorPrueba(t)
+	s.publicar(t, s.semilla1, "Fresa")
+	ruta := "/catalogo/productores/" + string(s.semilla1)
+
+	exigirStatus(t, s.hacer(http.MethodPut, ruta+"/suspender", `{"motivo": "uso de agroquímicos"}`), http.StatusNoContent)
+	w := s.hacer(http.MethodGet, "/catalogo/productos/producto-000001", "")
+	exigirStatus(t, w, http.StatusOK)
+	if prod := decodificar[dto.ProductoResponse](t, w); prod.Estado != producto.Agotado {
+		t.Errorf("estado = %q tras suspender, se esperaba Agotado", prod.Estado)
+	}
+	// Sin cuerpo también se acepta, y repetir la suspensión no es un error
+	w = s.hacer(http.MethodPut, ruta+"/suspender", "")
+	exigirStatus(t, w, http.StatusOK)
+	if r := decodificar[map[string]bool](t, w); !r["sin_cambios"] {
+		t.Errorf("cuerpo = %s, se esperaba {\"sin_cambios\": true}", w.Body.String())
+	}
+
+	exigirStatus(t, s.hacer(http.MethodPut, ruta+"/reactivar", ""), http.StatusNoContent)
+	w = s.hacer(http.MethodGet, "/catalogo/productos/producto-000001", "")
+	if prod := decodificar[dto.ProductoResponse](t, w); prod.Estado != producto.Disponible {
+		t.Errorf("estado = %q tras reactivar, se esperaba Disponible", prod.Estado)
+	}
+
+	exigirError(t, s.hacer(http.MethodPut, "/catalogo/productores/no-existe/suspender", ""), http.StatusNotFound, CodigoProductorNoEncontrado)
+	exigirError(t, s.hacer(http.MethodPut, ruta+"/suspender", "{"), http.StatusBadRequest, CodigoJSONInvalido)
+}
@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HealthChecker comprueba que una dependencia del servicio (repositorio, publicador de
+// eventos) puede atender peticiones. Retorna nil si está disponible.
+type HealthChecker interface {
+	Check(ctx context.Context) error
+}
+
+// esperaReadiness es el tiempo máximo que GET /readyz espera al conjunto de comprobaciones
+const esperaReadiness = 2 * time.Second
+
+// estadoDependenciaOK es el estado de una dependencia cuya comprobación no falló
+const estadoDependenciaOK = "ok"
+
+// SaludHandler atiende las sondas de liveness y readiness
+type SaludHandler struct {
+	// Dependencias que GET /readyz comprueba, por nombre
+	Dependencias map[string]HealthChecker
+}
+
+// GET /healthz
+// Responde 200 mientras el proceso atiende peticiones; no consulta dependencias.
+func (h *SaludHandler) Healthz(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"estado": estadoDependenciaOK})
+}
+
+// GET /readyz
+// Responde 200 si todas las dependencias están disponibles y 503 si alguna falla. En ambos
+// casos incluye el estado de cada dependencia: "ok" o el error de su comprobación.
+func (h *SaludHandler) Readyz(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), esperaReadiness)
+	defer cancel()
+
+	listo := true
+	estados := make(map[string]string, len(h.Dependencias))
+	for nombre, dependencia := range h.Dependencias {
+		if err := dependencia.Check(ctx); err != nil {
+			listo = false
+			estados[nombre] = err.Error()
+			continue
+		}
+		estados[nombre] = estadoDependenciaOK
+	}
+
+	status := http.StatusOK
+	if !listo {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, gin.H{"listo": listo, "dependencias": estados})
+}
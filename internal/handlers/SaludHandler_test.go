@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"Product_Catalog_Microservice/internal/repository"
+)
+
+// dependenciaFija es un HealthChecker que siempre retorna err
+type dependenciaFija struct{ err error }
+
+func (d dependenciaFija) Check(context.Context) error { return d.err }
+
+func TestSaludHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	pedir := func(h *SaludHandler, ruta string) *httptest.ResponseRecorder {
+		r := gin.New()
+		r.GET("/healthz", h.Healthz)
+		r.GET("/readyz", h.Readyz)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, ruta, nil))
+		return w
+	}
+	type readiness struct {
+		Listo        bool              `json:"listo"`
+		Dependencias map[string]string `json:"dependencias"`
+	}
+
+	caido := &SaludHandler{Dependencias: map[string]HealthChecker{
+		"productos":   repository.NewProductoRepository(0),
+		"productores": repository.NewProductorRepository(0),
+		"eventos":     dependenciaFija{errors.New("kafka: ningún broker disponible")},
+	}}
+
+	// La liveness no consulta las dependencias
+	exigirStatus(t, pedir(caido, "/healthz"), http.StatusOK)
+
+	w := pedir(caido, "/readyz")
+	exigirStatus(t, w, http.StatusServiceUnavailable)
+	r := decodificar[readiness](t, w)
+	if r.Listo || r.Dependencias["productos"] != "ok" || r.Dependencias["productores"] != "ok" ||
+		r.Dependencias["eventos"] != "kafka: ningún broker disponible" {
+		t.Errorf("readyz = %+v, se esperaba eventos caído y los repositorios ok", r)
+	}
+
+	caido.Dependencias["eventos"] = dependenciaFija{}
+	w = pedir(caido, "/readyz")
+	exigirStatus(t, w, http.StatusOK)
+	if r := decodificar[readiness](t, w); !r.Listo || len(r.Dependencias) != 3 {
+		t.Errorf("readyz = %+v, se esperaban las tres dependencias ok", r)
+	}
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Valores de Cache-Control usados por la tabla de políticas
+const (
+	CacheLarga   = "public, max-age=3600, must-revalidate"         // datos de referencia que cambian poco
+	CacheListado = "public, max-age=30, stale-while-revalidate=60" // listados de productos
+	CachePrivada = "private, no-cache"                             // respuestas por cliente, fuera de cachés compartidas
+	CacheNoStore = "no-store"                                      // escrituras y administración
+)
+
+// PoliticasCache asocia cada ruta ("GET /catalogo/completo") con su valor de Cache-Control
+type PoliticasCache map[string]string
+
+func claveRuta(metodo, ruta string) string {
+	return metodo + " " + ruta
+}
+
+// Middleware agrega Cache-Control según la ruta que atendió la petición.
+// Las rutas sin política declarada responden no-store.
+func (p PoliticasCache) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		politica, ok := p[claveRuta(c.Request.Method, c.FullPath())]
+		if !ok {
+			politica = CacheNoStore
+		}
+		c.Header("Cache-Control", politica)
+		c.Next()
+	}
+}
+
+// VerificarRutas retorna error si alguna ruta registrada no tiene política de caché declarada,
+// o si una ruta de escritura declara una política distinta de no-store.
+func (p PoliticasCache) VerificarRutas(rutas gin.RoutesInfo) error {
+	var faltantes []string
+	for _, ruta := range rutas {
+		clave := claveRuta(ruta.Method, ruta.Path)
+		politica, ok := p[clave]
+		if !ok {
+			faltantes = append(faltantes, clave)
+			continue
+		}
+		if ruta.Method != "GET" && ruta.Method != "HEAD" && politica != CacheNoStore {
+			return fmt.Errorf("la ruta de escritura %s debe usar %q", clave, CacheNoStore)
+		}
+	}
+	if len(faltantes) > 0 {
+		sort.Strings(faltantes)
+		return fmt.Errorf("rutas sin política de caché: %s", strings.Join(faltantes, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestPoliticasCache_Middleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	politicas := PoliticasCache{
+		"GET /catalogo/completo":  CacheListado,
+		"POST /catalogo/producto": CacheNoStore,
+	}
+	s := &servidorPrueba{router: gin.New()}
+	s.router.Use(politicas.Middleware())
+	responder := func(c *gin.Context) { c.Status(http.StatusOK) }
+	s.router.GET("catalogo/completo", responder)
+	s.router.POST("catalogo/producto", responder)
+	s.router.GET("catalogo/sin-politica", responder)
+
+	casos := []struct {
+		metodo, ruta, esperado string
+	}{
+		{http.MethodGet, "/catalogo/completo", CacheListado},
+		{http.MethodPost, "/catalogo/producto", CacheNoStore},
+		{http.MethodGet, "/catalogo/sin-politica", CacheNoStore},
+	}
+	for _, tc := range casos {
+		w := s.hacer(tc.metodo, tc.ruta, "")
+		if got := w.Header().Get("Cache-Control"); got != tc.esperado {
+			t.Errorf("%s %s: Cache-Control = %q, se esperaba %q", tc.metodo, tc.ruta, got, tc.esperado)
+		}
+	}
+}
+
+func TestPoliticasCache_VerificarRutas(t *testing.T) {
+	rutas := gin.RoutesInfo{
+		{Method: http.MethodGet, Path: "/catalogo/completo"},
+		{Method: http.MethodPost, Path: "/catalogo/producto"},
+	}
+
+	casos := []struct {
+		nombre    string
+		politicas PoliticasCache
+		error     string
+	}{
+		{"completa", PoliticasCache{"GET /catalogo/completo": CacheListado, "POST /catalogo/producto": CacheNoStore}, ""},
+		{"ruta sin política", PoliticasCache{"GET /catalogo/completo": CacheListado}, "POST /catalogo/producto"},
+		{"escritura cacheable", PoliticasCache{"GET /catalogo/completo": CacheListado, "POST /catalogo/producto": CacheListado}, "no-store"},
+	}
+	for _, tc := range casos {
+		t.Run(tc.nombre, func(t *testing.T) {
+			err := tc.politicas.VerificarRutas(rutas)
+			if tc.error == "" {
+				if err != nil {
+					t.Fatalf("VerificarRutas: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.error) {
+				t.Errorf("err = %v, se esperaba uno que mencione %q", err, tc.error)
+			}
+		})
+	}
+}
+
+func TestGetProductoByID_CachePrivadaConIndicioDePropietario(t *testing.T) {
+	s := nuevoServidorPrueba(t)
+	s.publicar(t, s.semilla1, "Fresa")
+
+	// Mismo orden que cmd/app: la política de la ruta primero, el handler puede restringirla
+	politicas := PoliticasCache{"GET /catalogo/productos/:id": CacheListado}
+	s.router = gin.New()
+	s.router.Use(politicas.Middleware())
+	s.router.GET("catalogo/productos/:id", (&ProductoHandler{Catalogo: s.catalogo}).GetProductoByID)
+
+	publica := s.hacer(http.MethodGet, "/catalogo/productos/producto-000001", "")
+	exigirStatus(t, publica, http.StatusOK)
+
+	casos := []struct {
+		nombre    string
+		productor string
+		esperado  string
+	}{
+		{"anónimo", "", CacheListado},
+		{"otro productor", string(s.semilla2), CacheListado},
+		{"propietario", string(s.semilla1), CachePrivada},
+	}
+	for _, tc := range casos {
+		t.Run(tc.nombre, func(t *testing.T) {
+			var headers []string
+			if tc.productor != "" {
+				headers = []string{HeaderProductorID, tc.productor}
+			}
+			w := s.hacer(http.MethodGet, "/catalogo/productos/producto-000001", "", headers...)
+			exigirStatus(t, w, http.StatusOK)
+			if got := w.Header().Get("Cache-Control"); got != tc.esperado {
+				t.Errorf("Cache-Control = %q, se esperaba %q", got, tc.esperado)
+			}
+			if got := w.Header().Values("Vary"); !slices.Contains(got, HeaderProductorID) {
+				t.Errorf("Vary = %q, se esperaba que incluyera %s", got, HeaderProductorID)
+			}
+			// El header no autentica: solo cambia la política de caché, nunca el contenido
+			if w.Body.String() != publica.Body.String() {
+				t.Errorf("cuerpo = %s, se esperaba el mismo que sin header: %s", w.Body.String(), publica.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"Product_Catalog_Microservice/internal/handlers/dto"
+)
+
+// ParametroCampos es el query param con el que un listado de productos se reduce a algunos
+// campos de primer nivel, p. ej. ?campos=id,nombre,precio_valor,estado
+const ParametroCampos = "campos"
+
+// camposPedidos lee ?campos= y lo valida contra validos. Sin el parámetro retorna nil y la
+// respuesta va completa. Si el parámetro es inválido responde 400 y retorna ok en false.
+// Los nombres son los de la representación en español, así que el parámetro no se combina
+// con X-API-Dialect: en.
+func camposPedidos(c *gin.Context, validos []string) (campos []string, ok bool) {
+	valor, presente := c.GetQuery(ParametroCampos)
+	if !presente {
+		return nil, true
+	}
+	if dialectoIngles(c) {
+		responderValidacion(c, ParametroCampos, fmt.Sprintf("%s no está disponible con %s: %s", ParametroCampos, HeaderDialecto, DialectoIngles))
+		return nil, false
+	}
+
+	for _, campo := range strings.Split(valor, ",") {
+		campo = strings.TrimSpace(campo)
+		if campo == "" || slices.Contains(campos, campo) {
+			continue
+		}
+		if !slices.Contains(validos, campo) {
+			responderValidacion(c, ParametroCampos, fmt.Sprintf("campo desconocido %q; campos válidos: %s", campo, strings.Join(validos, ", ")))
+			return nil, false
+		}
+		campos = append(campos, campo)
+	}
+	if len(campos) == 0 {
+		responderValidacion(c, ParametroCampos, fmt.Sprintf("%s debe nombrar al menos un campo: %s", ParametroCampos, strings.Join(validos, ", ")))
+		return nil, false
+	}
+	return campos, true
+}
+
+// responderProductosConProductor escribe los productos con el nombre de su productor,
+// reducidos a ?campos= si se pidió
+func responderProductosConProductor(c *gin.Context, productos []dto.ProductoConProductorView) {
+	campos, ok := camposPedidos(c, dto.CamposProductoConProductor)
+	if !ok {
+		return
+	}
+	if campos != nil {
+		c.JSON(http.StatusOK, dto.SeleccionarCamposProductoConProductor(productos, campos))
+		return
+	}
+	c.JSON(http.StatusOK, productos)
+}
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+	"Product_Catalog_Microservice/internal/handlers/dto"
+)
+
+// Los golden fijan los nombres de los campos seleccionables y la forma de las respuestas reducidas
+func TestCampos_Golden(t *testing.T) {
+	s := nuevoServidorPrueba(t)
+	sembrarProductoFijo(t, s)
+	s.publicar(t, s.semilla2, "Mora")
+	hoy := time.Now().In(producto.ZonaHoraria()).Format(dto.FormatoFecha)
+	exigirStatus(t, s.hacer(http.MethodPost, "/catalogo/productos/excedente",
+		aJSON(t, map[string]string{"producto_id": "producto-000001", "fecha": hoy})), http.StatusNoContent)
+
+	casos := []struct {
+		golden string
+		ruta   string
+		status int
+	}{
+		{"productos.json", "/catalogo/productos?estado=Disponible&campos=id,nombre,precio_valor,estado", http.StatusOK},
+		{"excedentes.json", "/catalogo/productos/excedentes?campos=id,nombre,productor_nombre", http.StatusOK},
+		{"desconocido.json", "/catalogo/productos?campos=id,precio", http.StatusBadRequest},
+	}
+	for _, tc := range casos {
+		t.Run(tc.golden, func(t *testing.T) {
+			w := s.hacer(http.MethodGet, tc.ruta, "")
+			exigirStatus(t, w, tc.status)
+			compararGolden(t, "campos/"+tc.golden, w.Body.Bytes())
+		})
+	}
+}
+
+// Todos los listados de productos aceptan ?campos=
+func TestCampos_ListadosDeProductos(t *testing.T) {
+	s := nuevoServidorPrueba(t)
+	sembrarProductoFijo(t, s)
+
+	rutas := []string{
+		"/catalogo/productos",
+		"/catalogo/productos/zona?zona_veredal=" + url.QueryEscape("Vereda El Paraíso"),
+		"/catalogo/productos/categoria/Fruta",
+		"/catalogo/productos/tipo/" + url.PathEscape(string(producto.ProduccionAgroecologica)),
+		"/catalogo/productos/temporada?fecha=2099-02-01",
+		"/catalogo/productores/" + string(s.semilla1) + "/productos",
+	}
+	for _, ruta := range rutas {
+		separador := "?"
+		if strings.Contains(ruta, "?") {
+			separador = "&"
+		}
+		w := s.hacer(http.MethodGet, ruta+separador+"campos="+url.QueryEscape(" nombre, id,nombre"), "")
+		exigirStatus(t, w, http.StatusOK)
+		productos := decodificar[[]map[string]any](t, w)
+		if len(productos) != 1 {
+			t.Errorf("%s: productos = %v, se esperaba uno", ruta, productos)
+			continue
+		}
+		var claves []string
+		for clave := range productos[0] {
+			claves = append(claves, clave)
+		}
+		slices.Sort(claves)
+		if !slices.Equal(claves, []string{"id", "nombre"}) {
+			t.Errorf("%s: campos = %v, se esperaba [id nombre]", ruta, claves)
+		}
+	}
+}
+
+func TestCampos_Invalidos(t *testing.T) {
+	s := nuevoServidorPrueba(t)
+
+	// Sin ningún nombre
+	r := exigirError(t, s.hacer(http.MethodGet, "/catalogo/productos?campos=,", ""), http.StatusBadRequest, CodigoValidacion)
+	if r.Field != ParametroCampos {
+		t.Errorf("field = %q, se esperaba %q", r.Field, ParametroCampos)
+	}
+
+	// productor_nombre solo existe en los excedentes
+	r = exigirError(t, s.hacer(http.MethodGet, "/catalogo/productos?campos=productor_nombre", ""), http.StatusBadRequest, CodigoValidacion)
+	if !strings.Contains(r.Message, `"productor_nombre"`) {
+		t.Errorf("message = %q, se esperaba el campo desconocido", r.Message)
+	}
+
+	// Los nombres son los de la representación en español
+	exigirError(t, s.hacer(http.MethodGet, "/catalogo/productos?campos=id", "", HeaderDialecto, DialectoIngles),
+		http.StatusBadRequest, CodigoValidacion)
+}
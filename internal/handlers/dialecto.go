@@ -0,0 +1,239 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+	"Product_Catalog_Microservice/internal/domain/service"
+	"Product_Catalog_Microservice/internal/handlers/dto"
+)
+
+// HeaderDialecto permite a un cliente pedir la representación en inglés de la API.
+// Sin el header (o con cualquier otro valor) se usa la representación en español de siempre.
+const HeaderDialecto = "X-API-Dialect"
+
+// DialectoIngles es el valor de HeaderDialecto que activa los nombres de campo en inglés
+const DialectoIngles = "en"
+
+// dialectoIngles indica si la petición pidió la representación en inglés
+func dialectoIngles(c *gin.Context) bool {
+	return c.GetHeader(HeaderDialecto) == DialectoIngles
+}
+
+// traducible es un cuerpo de petición en inglés que se convierte al cuerpo en español equivalente
+type traducible[T any] interface {
+	espanol() T
+}
+
+// bindJSONDialecto lee el cuerpo en el dialecto de la petición y lo deja en req,
+// de modo que el handler siempre trabaja con la estructura en español.
+func bindJSONDialecto[T any, E traducible[T]](c *gin.Context, req *T) error {
+	if !dialectoIngles(c) {
+		return c.ShouldBindJSON(req)
+	}
+	var en E
+	if err := c.ShouldBindJSON(&en); err != nil {
+		return err
+	}
+	*req = en.espanol()
+	return nil
+}
+
+// publicarProductoRequestEn es el cuerpo de POST /catalogo/producto en inglés
+type publicarProductoRequestEn struct {
+	ProducerID       string  `json:"producer_id"`
+	ProductID        string  `json:"product_id"`
+	Name             string  `json:"name"`
+	Description      string  `json:"description"`
+	Category         string  `json:"category"`
+	ProductionType   string  `json:"production_type"`
+	SeasonStart      string  `json:"season_start"`
+	SeasonEnd        string  `json:"season_end"`
+	Zone             string  `json:"zone"`
+	Farm             string  `json:"farm"`
+	ImageURL         string  `json:"image_url"`
+	ImageDescription string  `json:"image_description"`
+	PriceValue       float64 `json:"price_value"`
+	PriceCurrency    string  `json:"price_currency"`
+	QuantityValue    int     `json:"quantity_value"`
+	QuantityUnit     string  `json:"quantity_unit"`
+	MinReputation    float32 `json:"min_reputation"`
+}
+
+func (r publicarProductoRequestEn) espanol() publicarProductoRequest {
+	return publicarProductoRequest{
+		ProductorID:     r.ProducerID,
+		ProductoID:      r.ProductID,
+		Nombre:          r.Name,
+		Descripcion:     r.Description,
+		Categoria:       r.Category,
+		TipoProduccion:  r.ProductionType,
+		TemporadaInicio: r.SeasonStart,
+		TemporadaFin:    r.SeasonEnd,
+		ZonaVeredal:     r.Zone,
+		Finca:           r.Farm,
+		ImagenURL:       r.ImageURL,
+		ImagenDesc:      r.ImageDescription,
+		PrecioValor:     r.PriceValue,
+		PrecioMoneda:    r.PriceCurrency,
+		CantidadValor:   r.QuantityValue,
+		CantidadUnidad:  r.QuantityUnit,
+		MinReputacion:   r.MinReputation,
+	}
+}
+
+// marcarExcedenteRequestEn es el cuerpo de POST /catalogo/productos/excedente en inglés
+type marcarExcedenteRequestEn struct {
+	ProductID string `json:"product_id"`
+	Date      string `json:"date"`
+}
+
+func (r marcarExcedenteRequestEn) espanol() marcarExcedenteRequest {
+	return marcarExcedenteRequest{ProductoID: r.ProductID, Fecha: r.Date}
+}
+
+// registrarProductorRequestEn es el cuerpo de POST /catalogo/productor en inglés
+type registrarProductorRequestEn struct {
+	Name                 string `json:"name"`
+	Zone                 string `json:"zone"`
+	Farm                 string `json:"farm"`
+	CultivationPractices string `json:"cultivation_practices"`
+}
+
+func (r registrarProductorRequestEn) espanol() registrarProductorRequest {
+	return registrarProductorRequest{
+		Nombre:           r.Name,
+		ZonaVeredal:      r.Zone,
+		Finca:            r.Farm,
+		PracticasCultivo: r.CultivationPractices,
+	}
+}
+
+// productoEnView es la representación en inglés de un producto
+type productoEnView struct {
+	ID                 producto.ProductoID `json:"id"`
+	Name               string              `json:"name"`
+	Description        string              `json:"description"`
+	Category           producto.Categoria  `json:"category"`
+	ProductionType     string              `json:"production_type"`
+	AvailabilityStatus string              `json:"availability_status"`
+	ProducerID         string              `json:"producer_id"`
+	Season             seasonEnView        `json:"season"`
+	Location           locationEnView      `json:"location"`
+	Image              imageEnView         `json:"image"`
+	Price              priceEnView         `json:"price"`
+	Quantity           quantityEnView      `json:"quantity"`
+	PublishedAt        string              `json:"published_at"`
+}
+
+type seasonEnView struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+type locationEnView struct {
+	Zone string `json:"zone"`
+	Farm string `json:"farm"`
+}
+
+type imageEnView struct {
+	URL         string `json:"url"`
+	Description string `json:"description"`
+}
+
+type priceEnView struct {
+	Value    float64 `json:"value"`
+	Currency string  `json:"currency"`
+}
+
+type quantityEnView struct {
+	Value int    `json:"value"`
+	Unit  string `json:"unit"`
+}
+
+func nuevoProductoEnView(prod *producto.ProductoAgroecologico) productoEnView {
+	return productoEnView{
+		ID:                 prod.ID,
+		Name:               prod.Nombre.Value,
+		Description:        prod.Descripcion.Value,
+		Category:           prod.Categoria,
+		ProductionType:     string(prod.TipoProduccion),
+		AvailabilityStatus: prod.Estado.Value,
+		ProducerID:         prod.ProductorID,
+		Season:             seasonEnView{Start: prod.Temporada.Inicio, End: prod.Temporada.Fin},
+		Location:           locationEnView{Zone: prod.Ubicacion.ZonaVeredal, Farm: prod.Ubicacion.Finca},
+		Image:              imageEnView{URL: prod.Imagen.URL, Description: prod.Imagen.DescripcionCorta},
+		Price:              priceEnView{Value: prod.Precio.Valor, Currency: prod.Precio.Moneda},
+		Quantity:           quantityEnView{Value: prod.Cantidad.Valor, Unit: prod.Cantidad.Unidad},
+		PublishedAt:        prod.PublicadoEn().Format(time.RFC3339),
+	}
+}
+
+// responderProducto escribe un producto en el dialecto de la petición
+func responderProducto(c *gin.Context, status int, prod *producto.ProductoAgroecologico) {
+	c.Writer.Header().Add("Vary", HeaderDialecto)
+	if dialectoIngles(c) {
+		c.JSON(status, nuevoProductoEnView(prod))
+		return
+	}
+	c.JSON(status, dto.NewProductoResponse(prod))
+}
+
+// productoCreadoEnView es la respuesta en inglés de POST /catalogo/producto
+type productoCreadoEnView struct {
+	productoEnView
+	URL string `json:"url"`
+}
+
+// responderProductoCreado escribe el producto publicado en el dialecto de la petición, con el
+// header Location y el campo url apuntando a GET /catalogo/productos/{id}
+func responderProductoCreado(c *gin.Context, status int, prod *producto.ProductoAgroecologico) {
+	enlace := dto.Enlace(dto.ColeccionProductos, string(prod.ID))
+	c.Header("Location", enlace)
+	c.Writer.Header().Add("Vary", HeaderDialecto)
+	if dialectoIngles(c) {
+		c.JSON(status, productoCreadoEnView{productoEnView: nuevoProductoEnView(prod), URL: enlace})
+		return
+	}
+	c.JSON(status, dto.NewProductoCreadoResponse(prod))
+}
+
+// responderProductos escribe una lista de productos en el dialecto de la petición, reducida a
+// ?campos= si se pidió
+func responderProductos(c *gin.Context, productos []*producto.ProductoAgroecologico) {
+	if cuerpo, ok := representarProductos(c, productos); ok {
+		c.JSON(http.StatusOK, cuerpo)
+	}
+}
+
+// responderPaginaProductos escribe una página recorrida por cursor con los productos
+// representados como en responderProductos
+func responderPaginaProductos(c *gin.Context, pagina service.PaginaProductos) {
+	if items, ok := representarProductos(c, pagina.Productos); ok {
+		c.JSON(http.StatusOK, dto.NewPaginaProductosResponse(items, pagina))
+	}
+}
+
+// representarProductos convierte los productos al dialecto de la petición y a ?campos=. Si
+// ?campos= es inválido ya respondió 400 y retorna ok en false.
+func representarProductos(c *gin.Context, productos []*producto.ProductoAgroecologico) (cuerpo any, ok bool) {
+	c.Writer.Header().Add("Vary", HeaderDialecto)
+	campos, ok := camposPedidos(c, dto.CamposProducto)
+	if !ok {
+		return nil, false
+	}
+	if campos != nil {
+		return dto.SeleccionarCamposProducto(dto.NewProductoResponses(productos), campos), true
+	}
+	if !dialectoIngles(c) {
+		return dto.NewProductoResponses(productos), true
+	}
+	vistas := make([]productoEnView, 0, len(productos))
+	for _, prod := range productos {
+		vistas = append(vistas, nuevoProductoEnView(prod))
+	}
+	return vistas, true
+}
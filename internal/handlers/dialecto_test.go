@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+)
+
+var actualizarGolden = flag.Bool("update", false, "regenera los archivos golden de testdata")
+
+// instantePublicacion reconoce el instante de publicación, que depende del reloj y no se fija
+var instantePublicacion = regexp.MustCompile(`"(publicado_en|published_at)":"[^"]+"`)
+
+// compararGolden compara el cuerpo JSON con testdata/{ruta}; con -update lo reescribe
+func compararGolden(t *testing.T, ruta string, cuerpo []byte) {
+	t.Helper()
+	cuerpo = instantePublicacion.ReplaceAll(cuerpo, []byte(`"$1":"<instante>"`))
+	var formateado bytes.Buffer
+	if err := json.Indent(&formateado, cuerpo, "", "  "); err != nil {
+		t.Fatalf("cuerpo no es JSON válido: %v\n%s", err, cuerpo)
+	}
+	formateado.WriteByte('\n')
+
+	ruta = filepath.Join("testdata", filepath.FromSlash(ruta))
+	if *actualizarGolden {
+		if err := os.WriteFile(ruta, formateado.Bytes(), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	esperado, err := os.ReadFile(ruta)
+	if err != nil {
+		t.Fatalf("%v; regenerar con go test ./internal/handlers -update", err)
+	}
+	if !bytes.Equal(esperado, formateado.Bytes()) {
+		t.Errorf("%s cambió; si es intencional, regenerar con -update\nesperado:\n%s\nobtenido:\n%s", ruta, esperado, formateado.Bytes())
+	}
+}
+
+// idProductoFijo es el ID del producto que guarda sembrarProductoFijo
+const idProductoFijo = "4f9c2d1e-8b7a-4c3d-9e2f-1a0b9c8d7e6f"
+
+// sembrarProductoFijo guarda un producto con todos sus datos fijos
+func sembrarProductoFijo(t *testing.T, s *servidorPrueba) {
+	t.Helper()
+	nombre, _ := producto.NewNombreProducto("Fresa")
+	desc, _ := producto.NewDescripcionProducto("Fresas de la vereda sin agroquímicos")
+	zona := producto.ZonaHoraria()
+	temporada, err := producto.NewTemporadaLocal(time.Date(2099, time.January, 1, 0, 0, 0, 0, zona), time.Date(2099, time.March, 31, 0, 0, 0, 0, zona))
+	if err != nil {
+		t.Fatalf("NewTemporadaLocal: %v", err)
+	}
+	ubicacion, _ := producto.NewUbicacion("Vereda El Paraíso", "Finca La Esperanza")
+	imagen, _ := producto.NewImagen("https://img.example.com/fresa.jpg", "Fresas")
+	precio, _ := producto.NewPrecio(4500, "COP")
+	cantidad, _ := producto.NewCantidadDisponible(40, producto.UnidadKilogramo)
+
+	p, err := producto.NewProductoAgroecologico(idProductoFijo, nombre, desc, producto.CategoriaFruta,
+		producto.ProduccionAgroecologica, temporada, ubicacion, imagen, precio, cantidad, string(s.semilla1))
+	if err != nil {
+		t.Fatalf("NewProductoAgroecologico: %v", err)
+	}
+	if err := s.productoRepo.Save(p); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+}
+
+// Los golden fijan las dos representaciones para que evolucionen juntas
+func TestDialecto_GoldenRespuestas(t *testing.T) {
+	s := nuevoServidorPrueba(t)
+	sembrarProductoFijo(t, s)
+
+	casos := []struct {
+		golden   string
+		ruta     string
+		dialecto string
+	}{
+		{"producto.es.json", "/catalogo/productos/" + idProductoFijo, ""},
+		{"producto.en.json", "/catalogo/productos/" + idProductoFijo, DialectoIngles},
+		{"productos.es.json", "/catalogo/productos", ""},
+		{"productos.en.json", "/catalogo/productos", DialectoIngles},
+	}
+	for _, tc := range casos {
+		t.Run(tc.golden, func(t *testing.T) {
+			var headers []string
+			if tc.dialecto != "" {
+				headers = []string{HeaderDialecto, tc.dialecto}
+			}
+			w := s.hacer(http.MethodGet, tc.ruta, "", headers...)
+			exigirStatus(t, w, http.StatusOK)
+			compararGolden(t, "dialecto/"+tc.golden, w.Body.Bytes())
+		})
+	}
+}
+
+// Un cuerpo en inglés produce el mismo producto que su equivalente en español
+func TestDialecto_PeticionesEnIngles(t *testing.T) {
+	publicar := func(t *testing.T, archivo string, headers ...string) []byte {
+		t.Helper()
+		cuerpo, err := os.ReadFile(filepath.Join("testdata", "dialecto", archivo))
+		if err != nil {
+			t.Fatal(err)
+		}
+		s := nuevoServidorPrueba(t)
+		exigirStatus(t, s.hacer(http.MethodPost, "/catalogo/producto", string(cuerpo), headers...), http.StatusCreated)
+
+		// Se compara la representación en español de ambos
+		w := s.hacer(http.MethodGet, "/catalogo/productos/producto-000001", "")
+		exigirStatus(t, w, http.StatusOK)
+		return w.Body.Bytes()
+	}
+
+	es := publicar(t, "publicar.es.json")
+	en := publicar(t, "publicar.en.json", HeaderDialecto, DialectoIngles)
+	if !bytes.Equal(es, en) {
+		t.Errorf("publicación en inglés = %s\nse esperaba %s", en, es)
+	}
+
+	// Un cuerpo en español con el header de inglés no se interpreta: sus campos quedan vacíos
+	s := nuevoServidorPrueba(t)
+	cuerpo, _ := os.ReadFile(filepath.Join("testdata", "dialecto", "publicar.es.json"))
+	exigirStatus(t, s.hacer(http.MethodPost, "/catalogo/producto", string(cuerpo), HeaderDialecto, DialectoIngles),
+		http.StatusBadRequest)
+}
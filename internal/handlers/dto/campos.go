@@ -0,0 +1,76 @@
+package dto
+
+// CamposProducto son, en el orden del JSON, los campos de ProductoResponse que se pueden pedir
+// con ?campos= en los listados de productos
+var CamposProducto = []string{
+	"id", "productor_id", "nombre", "descripcion", "categoria", "tipo_produccion", "estado",
+	"temporada_inicio", "temporada_fin", "zona_veredal", "finca", "imagen_url", "imagen_desc",
+	"precio_valor", "precio_moneda", "cantidad_valor", "cantidad_unidad", "publicado_en",
+}
+
+// CamposProductoConProductor son los campos de ProductoConProductorView que se pueden pedir
+// con ?campos=: los del producto más productor_nombre
+var CamposProductoConProductor = append(append([]string(nil), CamposProducto...), "productor_nombre")
+
+// campoProducto lee cada campo de ProductoResponse por su nombre JSON. El mapeo es explícito
+// para no depender de reflexión en cada elemento del listado.
+var campoProducto = map[string]func(*ProductoResponse) any{
+	"id":               func(p *ProductoResponse) any { return p.ID },
+	"productor_id":     func(p *ProductoResponse) any { return p.ProductorID },
+	"nombre":           func(p *ProductoResponse) any { return p.Nombre },
+	"descripcion":      func(p *ProductoResponse) any { return p.Descripcion },
+	"categoria":        func(p *ProductoResponse) any { return p.Categoria },
+	"tipo_produccion":  func(p *ProductoResponse) any { return p.TipoProduccion },
+	"estado":           func(p *ProductoResponse) any { return p.Estado },
+	"temporada_inicio": func(p *ProductoResponse) any { return p.TemporadaInicio },
+	"temporada_fin":    func(p *ProductoResponse) any { return p.TemporadaFin },
+	"zona_veredal":     func(p *ProductoResponse) any { return p.ZonaVeredal },
+	"finca":            func(p *ProductoResponse) any { return p.Finca },
+	"imagen_url":       func(p *ProductoResponse) any { return p.ImagenURL },
+	"imagen_desc":      func(p *ProductoResponse) any { return p.ImagenDesc },
+	"precio_valor":     func(p *ProductoResponse) any { return p.PrecioValor },
+	"precio_moneda":    func(p *ProductoResponse) any { return p.PrecioMoneda },
+	"cantidad_valor":   func(p *ProductoResponse) any { return p.CantidadValor },
+	"cantidad_unidad":  func(p *ProductoResponse) any { return p.CantidadUnidad },
+	"publicado_en":     func(p *ProductoResponse) any { return p.PublicadoEn },
+}
+
+// RespuestaParcial es un elemento reducido a los campos pedidos con ?campos=
+type RespuestaParcial map[string]any
+
+// SeleccionarCamposProducto reduce cada producto a campos, que el handler ya validó contra
+// CamposProducto; nunca retorna nil
+func SeleccionarCamposProducto(productos []ProductoResponse, campos []string) []RespuestaParcial {
+	respuesta := make([]RespuestaParcial, 0, len(productos))
+	for i := range productos {
+		respuesta = append(respuesta, seleccionarProducto(&productos[i], campos))
+	}
+	return respuesta
+}
+
+// SeleccionarCamposProductoConProductor reduce cada producto con su productor a campos, que el
+// handler ya validó contra CamposProductoConProductor; nunca retorna nil
+func SeleccionarCamposProductoConProductor(productos []ProductoConProductorView, campos []string) []RespuestaParcial {
+	respuesta := make([]RespuestaParcial, 0, len(productos))
+	for i := range productos {
+		parcial := seleccionarProducto(&productos[i].ProductoResponse, campos)
+		for _, campo := range campos {
+			if campo == "productor_nombre" {
+				parcial[campo] = productos[i].ProductorNombre
+			}
+		}
+		respuesta = append(respuesta, parcial)
+	}
+	return respuesta
+}
+
+// seleccionarProducto copia de p los campos de producto incluidos en campos e ignora el resto
+func seleccionarProducto(p *ProductoResponse, campos []string) RespuestaParcial {
+	parcial := make(RespuestaParcial, len(campos))
+	for _, campo := range campos {
+		if leer, ok := campoProducto[campo]; ok {
+			parcial[campo] = leer(p)
+		}
+	}
+	return parcial
+}
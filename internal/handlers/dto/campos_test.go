@@ -0,0 +1,77 @@
+package dto
+
+import (
+	"bytes"
+	"encoding/json"
+	"maps"
+	"slices"
+	"testing"
+)
+
+// clavesJSON retorna las claves de primer nivel del objeto JSON en data, en orden
+func clavesJSON(t *testing.T, data []byte) []string {
+	t.Helper()
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if _, err := dec.Token(); err != nil {
+		t.Fatal(err)
+	}
+	var claves []string
+	for dec.More() {
+		clave, err := dec.Token()
+		if err != nil {
+			t.Fatal(err)
+		}
+		claves = append(claves, clave.(string))
+		var valor json.RawMessage
+		if err := dec.Decode(&valor); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return claves
+}
+
+// Los campos seleccionables son exactamente los del JSON completo, y el mapeo explícito
+// entrega el mismo valor que la serialización
+func TestCamposProducto_CoincidenConElJSON(t *testing.T) {
+	completo := NewProductoConProductorViews(nil)
+	completo = append(completo, ProductoConProductorView{
+		ProductoResponse: NewProductoResponse(nuevoProducto(t)),
+		ProductorNombre:  "Juan Pérez",
+	})
+
+	data, err := json.Marshal(completo[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claves := clavesJSON(t, data); !slices.Equal(claves, CamposProductoConProductor) {
+		t.Fatalf("campos del JSON = %v\nCamposProductoConProductor = %v", claves, CamposProductoConProductor)
+	}
+	if !slices.Equal(CamposProductoConProductor[:len(CamposProducto)], CamposProducto) {
+		t.Errorf("CamposProductoConProductor no empieza con CamposProducto")
+	}
+
+	var esperado map[string]any
+	json.Unmarshal(data, &esperado)
+	parcial, err := json.Marshal(SeleccionarCamposProductoConProductor(completo, CamposProductoConProductor)[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var obtenido map[string]any
+	json.Unmarshal(parcial, &obtenido)
+	if !maps.Equal(obtenido, esperado) {
+		t.Errorf("todos los campos = %v\nse esperaba %v", obtenido, esperado)
+	}
+}
+
+func TestSeleccionarCamposProducto(t *testing.T) {
+	productos := NewProductoResponses(nil)
+	if got := SeleccionarCamposProducto(productos, []string{"id"}); got == nil || len(got) != 0 {
+		t.Errorf("sin productos = %v, se esperaba una lista vacía", got)
+	}
+
+	productos = append(productos, NewProductoResponse(nuevoProducto(t)))
+	data, _ := json.Marshal(SeleccionarCamposProducto(productos, []string{"nombre", "precio_valor"}))
+	if string(data) != `[{"nombre":"Fresa","precio_valor":4500}]` {
+		t.Errorf("selección = %s", data)
+	}
+}
@@ -0,0 +1,179 @@
+package dto
+
+import (
+	"time"
+
+	"Product_Catalog_Microservice/internal/domain/service"
+	"Product_Catalog_Microservice/internal/domain/shared"
+)
+
+// CatalogoCompletoResponse es la representación de GET /catalogo/completo
+type CatalogoCompletoResponse struct {
+	Productos         []ProductoResponse  `json:"productos"`
+	Productores       []ProductorResponse `json:"productores"`
+	GeneradoEn        string              `json:"generado_en"` // RFC3339
+	Degradado         bool                `json:"degradado"`
+	MotivoDegradacion string              `json:"motivo_degradacion,omitempty"`
+}
+
+// NewCatalogoCompletoResponse convierte el catálogo completo del servicio. En una respuesta
+// degradada sin productores, productores es null y no una lista vacía.
+func NewCatalogoCompletoResponse(c *service.CatalogoCompleto) CatalogoCompletoResponse {
+	respuesta := CatalogoCompletoResponse{
+		Productos:         NewProductoResponses(c.Productos),
+		GeneradoEn:        c.GeneradoEn.Format(time.RFC3339),
+		Degradado:         c.Degradado,
+		MotivoDegradacion: c.MotivoDegradacion,
+	}
+	if c.Productores != nil {
+		respuesta.Productores = NewProductorResponses(c.Productores)
+	}
+	return respuesta
+}
+
+// CatalogoCompletoPaginadoResponse es la representación de GET /catalogo/completo con page o page_size
+type CatalogoCompletoPaginadoResponse struct {
+	Productos         shared.PagedResult[ProductoResponse]  `json:"productos"`
+	Productores       shared.PagedResult[ProductorResponse] `json:"productores"`
+	TotalProductos    int                                   `json:"total_productos"`
+	TotalProductores  int                                   `json:"total_productores"`
+	Page              int                                   `json:"page"`
+	GeneradoEn        string                                `json:"generado_en"` // RFC3339
+	Degradado         bool                                  `json:"degradado"`
+	MotivoDegradacion string                                `json:"motivo_degradacion,omitempty"`
+}
+
+// NewCatalogoCompletoPaginadoResponse convierte una página del catálogo completo del servicio
+func NewCatalogoCompletoPaginadoResponse(c *service.CatalogoCompletoPaginado) CatalogoCompletoPaginadoResponse {
+	return CatalogoCompletoPaginadoResponse{
+		Productos:         convertirPagina(c.Productos, NewProductoResponses),
+		Productores:       convertirPagina(c.Productores, NewProductorResponses),
+		TotalProductos:    c.TotalProductos,
+		TotalProductores:  c.TotalProductores,
+		Page:              c.Page,
+		GeneradoEn:        c.GeneradoEn.Format(time.RFC3339),
+		Degradado:         c.Degradado,
+		MotivoDegradacion: c.MotivoDegradacion,
+	}
+}
+
+// convertirPagina convierte los elementos de una página conservando sus datos de paginación
+func convertirPagina[T, R any](p shared.PagedResult[T], convertir func([]T) []R) shared.PagedResult[R] {
+	return shared.PagedResult[R]{
+		Items:      convertir(p.Items),
+		TotalCount: p.TotalCount,
+		Page:       p.Page,
+		PageSize:   p.PageSize,
+	}
+}
+
+// PaginaProductosResponse es la representación de GET /catalogo/productos con cursor o limit.
+// Items lleva los productos en el dialecto y con los campos que pidió el cliente.
+type PaginaProductosResponse struct {
+	Items any                `json:"items"`
+	Meta  MetaCursorResponse `json:"meta"`
+}
+
+// MetaCursorResponse son los datos de un recorrido por cursor; NextCursor se omite en la última página
+type MetaCursorResponse struct {
+	Limit      int    `json:"limit"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// NewPaginaProductosResponse arma la página a partir de los productos ya convertidos en items
+func NewPaginaProductosResponse(items any, p service.PaginaProductos) PaginaProductosResponse {
+	return PaginaProductosResponse{
+		Items: items,
+		Meta:  MetaCursorResponse{Limit: p.Limite, NextCursor: p.SiguienteCursor},
+	}
+}
+
+// GrupoCatalogoResponse es la representación de un grupo de GET /catalogo/agrupado
+type GrupoCatalogoResponse struct {
+	Clave           string             `json:"clave"`
+	Total           int                `json:"total"`
+	Productos       []ProductoResponse `json:"productos"`
+	SiguienteCursor string             `json:"siguiente_cursor,omitempty"`
+}
+
+// NewGrupoCatalogoResponse convierte un grupo del catálogo
+func NewGrupoCatalogoResponse(g service.GrupoCatalogo) GrupoCatalogoResponse {
+	return GrupoCatalogoResponse{
+		Clave:           g.Clave,
+		Total:           g.Total,
+		Productos:       NewProductoResponses(g.Productos),
+		SiguienteCursor: g.SiguienteCursor,
+	}
+}
+
+// NewGrupoCatalogoResponses convierte una lista de grupos; nunca retorna nil
+func NewGrupoCatalogoResponses(grupos []service.GrupoCatalogo) []GrupoCatalogoResponse {
+	respuesta := make([]GrupoCatalogoResponse, 0, len(grupos))
+	for _, g := range grupos {
+		respuesta = append(respuesta, NewGrupoCatalogoResponse(g))
+	}
+	return respuesta
+}
+
+// ResultadoBusquedaResponse es un producto encontrado por GET /catalogo/buscar con su relevancia
+type ResultadoBusquedaResponse struct {
+	Producto ProductoResponse `json:"producto"`
+	Score    int              `json:"score"`
+}
+
+// NewResultadoBusquedaResponses convierte los resultados de una búsqueda; nunca retorna nil
+func NewResultadoBusquedaResponses(resultados []service.ScoredProducto) []ResultadoBusquedaResponse {
+	respuesta := make([]ResultadoBusquedaResponse, 0, len(resultados))
+	for _, r := range resultados {
+		respuesta = append(respuesta, ResultadoBusquedaResponse{
+			Producto: NewProductoResponse(r.Producto),
+			Score:    r.Score,
+		})
+	}
+	return respuesta
+}
+
+// ProductoConProductorView es un producto de GET /catalogo/productos/excedentes con el nombre
+// de su productor
+type ProductoConProductorView struct {
+	ProductoResponse
+	ProductorNombre string `json:"productor_nombre"`
+}
+
+// NewProductoConProductorViews convierte los productos con su productor; nunca retorna nil
+func NewProductoConProductorViews(productos []service.ProductoConProductor) []ProductoConProductorView {
+	respuesta := make([]ProductoConProductorView, 0, len(productos))
+	for _, p := range productos {
+		respuesta = append(respuesta, ProductoConProductorView{
+			ProductoResponse: NewProductoResponse(p.Producto),
+			ProductorNombre:  p.ProductorNombre,
+		})
+	}
+	return respuesta
+}
+
+// ResumenZonaResponse es la representación de una zona en GET /catalogo/estadisticas/zonas
+type ResumenZonaResponse struct {
+	ZonaVeredal            string  `json:"zona_veredal"`
+	TotalProductores       int     `json:"total_productores"`
+	ProductoresVerificados int     `json:"productores_verificados"`
+	TotalProductos         int     `json:"total_productos"`
+	ProductosDisponibles   int     `json:"productos_disponibles"`
+	ReputacionPromedioZona float32 `json:"reputacion_promedio_zona"`
+}
+
+// NewResumenZonaResponses convierte el resumen por zona del servicio; nunca retorna nil
+func NewResumenZonaResponses(resumen []service.ResumenZona) []ResumenZonaResponse {
+	respuesta := make([]ResumenZonaResponse, 0, len(resumen))
+	for _, r := range resumen {
+		respuesta = append(respuesta, ResumenZonaResponse{
+			ZonaVeredal:            r.ZonaVeredal,
+			TotalProductores:       r.TotalProductores,
+			ProductoresVerificados: r.ProductoresVerificados,
+			TotalProductos:         r.TotalProductos,
+			ProductosDisponibles:   r.ProductosDisponibles,
+			ReputacionPromedioZona: r.ReputacionPromedioZona,
+		})
+	}
+	return respuesta
+}
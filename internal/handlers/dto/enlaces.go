@@ -0,0 +1,15 @@
+package dto
+
+import "net/url"
+
+// Colecciones de la API con ruta canónica GET /catalogo/{coleccion}/{id}
+const (
+	ColeccionProductos   = "productos"
+	ColeccionProductores = "productores"
+)
+
+// Enlace retorna la ruta canónica de un recurso, p. ej. /catalogo/productos/{id}. Es la que
+// se envía en el header Location al crearlo y en el campo url de la respuesta.
+func Enlace(coleccion, id string) string {
+	return "/catalogo/" + coleccion + "/" + url.PathEscape(id)
+}
@@ -0,0 +1,84 @@
+// Package dto define las representaciones JSON de la API. Los agregados del dominio no se
+// serializan directamente: los handlers los convierten en estos tipos, de modo que un cambio
+// en un value object no cambia el contrato con los clientes.
+package dto
+
+import (
+	"time"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+)
+
+// FormatoFecha es el formato de las fechas sin hora, el mismo con el que se publican
+const FormatoFecha = "2006-01-02"
+
+// ProductoResponse es la representación de un producto en la API
+type ProductoResponse struct {
+	ID              string  `json:"id"`
+	ProductorID     string  `json:"productor_id"`
+	Nombre          string  `json:"nombre"`
+	Descripcion     string  `json:"descripcion"`
+	Categoria       string  `json:"categoria"`
+	TipoProduccion  string  `json:"tipo_produccion"`
+	Estado          string  `json:"estado"`
+	TemporadaInicio string  `json:"temporada_inicio"` // formato FormatoFecha
+	TemporadaFin    string  `json:"temporada_fin"`    // formato FormatoFecha
+	ZonaVeredal     string  `json:"zona_veredal"`
+	Finca           string  `json:"finca"`
+	ImagenURL       string  `json:"imagen_url"`
+	ImagenDesc      string  `json:"imagen_desc"`
+	PrecioValor     float64 `json:"precio_valor"`
+	PrecioMoneda    string  `json:"precio_moneda"`
+	CantidadValor   int     `json:"cantidad_valor"`
+	CantidadUnidad  string  `json:"cantidad_unidad"`
+	PublicadoEn     string  `json:"publicado_en"` // RFC3339
+}
+
+// NewProductoResponse convierte un producto del dominio en su representación de la API.
+// Las fechas de temporada se expresan en la zona horaria del despliegue.
+func NewProductoResponse(p *producto.ProductoAgroecologico) ProductoResponse {
+	return ProductoResponse{
+		ID:              string(p.ID),
+		ProductorID:     p.ProductorID,
+		Nombre:          p.Nombre.Value,
+		Descripcion:     p.Descripcion.Value,
+		Categoria:       string(p.Categoria),
+		TipoProduccion:  string(p.TipoProduccion),
+		Estado:          p.Estado.Value,
+		TemporadaInicio: p.Temporada.Inicio.In(producto.ZonaHoraria()).Format(FormatoFecha),
+		TemporadaFin:    p.Temporada.Fin.In(producto.ZonaHoraria()).Format(FormatoFecha),
+		ZonaVeredal:     p.Ubicacion.ZonaVeredal,
+		Finca:           p.Ubicacion.Finca,
+		ImagenURL:       p.Imagen.URL,
+		ImagenDesc:      p.Imagen.DescripcionCorta,
+		PrecioValor:     p.Precio.Valor,
+		PrecioMoneda:    p.Precio.Moneda,
+		CantidadValor:   p.Cantidad.Valor,
+		CantidadUnidad:  p.Cantidad.Unidad,
+		PublicadoEn:     p.PublicadoEn().Format(time.RFC3339),
+	}
+}
+
+// NewProductoResponses convierte una lista de productos; nunca retorna nil
+func NewProductoResponses(productos []*producto.ProductoAgroecologico) []ProductoResponse {
+	respuesta := make([]ProductoResponse, 0, len(productos))
+	for _, p := range productos {
+		respuesta = append(respuesta, NewProductoResponse(p))
+	}
+	return respuesta
+}
+
+// ProductoCreadoResponse es la respuesta de POST /catalogo/producto: el producto, con su id
+// en el nivel superior, más la URL canónica para consultarlo
+type ProductoCreadoResponse struct {
+	ProductoResponse
+	URL string `json:"url"`
+}
+
+// NewProductoCreadoResponse convierte el producto recién publicado
+func NewProductoCreadoResponse(p *producto.ProductoAgroecologico) ProductoCreadoResponse {
+	return ProductoCreadoResponse{
+		ProductoResponse: NewProductoResponse(p),
+		URL:              Enlace(ColeccionProductos, string(p.ID)),
+	}
+}
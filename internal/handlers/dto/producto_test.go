@@ -0,0 +1,99 @@
+package dto
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+)
+
+func nuevoProducto(t *testing.T) *producto.ProductoAgroecologico {
+	t.Helper()
+	nombre, _ := producto.NewNombreProducto("Fresa")
+	desc, _ := producto.NewDescripcionProducto("Fresas de la vereda sin agroquímicos")
+	zona := producto.ZonaHoraria()
+	temporada, err := producto.NewTemporadaLocal(time.Date(2099, time.January, 1, 0, 0, 0, 0, zona), time.Date(2099, time.March, 31, 0, 0, 0, 0, zona))
+	if err != nil {
+		t.Fatalf("NewTemporadaLocal: %v", err)
+	}
+	ubicacion, _ := producto.NewUbicacion("Vereda El Paraíso", "Finca La Esperanza")
+	imagen, _ := producto.NewImagen("https://img.example.com/fresa.jpg", "Fresas")
+	precio, _ := producto.NewPrecio(4500, "COP")
+	cantidad, _ := producto.NewCantidadDisponible(40, producto.UnidadKilogramo)
+
+	p, err := producto.NewProductoAgroecologico("p-1", nombre, desc, producto.CategoriaFruta,
+		producto.ProduccionAgroecologica, temporada, ubicacion, imagen, precio, cantidad, "quemado-1")
+	if err != nil {
+		t.Fatalf("NewProductoAgroecologico: %v", err)
+	}
+	return p
+}
+
+func TestNewProductoResponse(t *testing.T) {
+	p := nuevoProducto(t)
+	r := NewProductoResponse(p)
+
+	if r.ID != "p-1" || r.ProductorID != "quemado-1" || r.Nombre != "Fresa" || r.Estado != producto.Disponible {
+		t.Errorf("respuesta = %+v, no coincide con el producto", r)
+	}
+	// La temporada se expresa en días, sin la hora de fin de día que guarda el dominio
+	if r.TemporadaInicio != "2099-01-01" || r.TemporadaFin != "2099-03-31" {
+		t.Errorf("temporada = %s a %s, se esperaba 2099-01-01 a 2099-03-31", r.TemporadaInicio, r.TemporadaFin)
+	}
+	publicadoEn, err := time.Parse(time.RFC3339, r.PublicadoEn)
+	if err != nil {
+		t.Fatalf("publicado_en = %q no es RFC3339: %v", r.PublicadoEn, err)
+	}
+	if !publicadoEn.Equal(p.PublicadoEn().Truncate(time.Second)) {
+		t.Errorf("publicado_en = %s, se esperaba %s", publicadoEn, p.PublicadoEn())
+	}
+}
+
+// El JSON es plano: ningún value object aparece anidado
+func TestNewProductoResponse_JSONPlano(t *testing.T) {
+	data, err := json.Marshal(NewProductoResponse(nuevoProducto(t)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var campos map[string]any
+	if err := json.Unmarshal(data, &campos); err != nil {
+		t.Fatal(err)
+	}
+	for clave, valor := range campos {
+		if _, anidado := valor.(map[string]any); anidado {
+			t.Errorf("%s es un objeto anidado: %s", clave, data)
+		}
+	}
+	if _, ok := campos["publicado_en"]; !ok {
+		t.Errorf("falta publicado_en: %s", data)
+	}
+}
+
+func TestNewProductoResponses_NuncaNil(t *testing.T) {
+	data, _ := json.Marshal(NewProductoResponses(nil))
+	if string(data) != "[]" {
+		t.Errorf("sin productos = %s, se esperaba []", data)
+	}
+}
+
+func TestNewProductoCreadoResponse(t *testing.T) {
+	data, err := json.Marshal(NewProductoCreadoResponse(nuevoProducto(t)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var campos map[string]any
+	if err := json.Unmarshal(data, &campos); err != nil {
+		t.Fatal(err)
+	}
+	// id y url quedan en el nivel superior, junto a los demás campos del producto
+	if campos["id"] != "p-1" || campos["url"] != "/catalogo/productos/p-1" || campos["nombre"] != "Fresa" {
+		t.Errorf("respuesta = %s, se esperaba id, url y los campos del producto", data)
+	}
+}
+
+func TestEnlace_EscapaElID(t *testing.T) {
+	if got := Enlace(ColeccionProductores, "finca/la esperanza"); got != "/catalogo/productores/finca%2Fla%20esperanza" {
+		t.Errorf("Enlace = %q, se esperaba el ID escapado", got)
+	}
+}
@@ -0,0 +1,53 @@
+package dto
+
+import (
+	"time"
+
+	"Product_Catalog_Microservice/internal/domain/productor"
+)
+
+// ProductorResponse es la representación de un productor en la API
+type ProductorResponse struct {
+	ID                       string          `json:"id"`
+	Nombre                   string          `json:"nombre"`
+	ZonaVeredal              string          `json:"zona_veredal"`
+	Finca                    string          `json:"finca"`
+	EstadoVerificacion       string          `json:"estado_verificacion"`
+	EstadoActividad          string          `json:"estado_actividad"`
+	Reputacion               float32         `json:"reputacion"`
+	PracticasCultivo         string          `json:"practicas_cultivo"`
+	FechaRegistro            string          `json:"fecha_registro"`   // RFC3339
+	UltimaActividad          string          `json:"ultima_actividad"` // RFC3339
+	PreferenciasNotificacion map[string]bool `json:"preferencias_notificacion"`
+}
+
+// NewProductorResponse convierte un productor del dominio en su representación de la API
+func NewProductorResponse(p *productor.Productor) ProductorResponse {
+	preferencias := make(map[string]bool, len(p.PreferenciasNotificacion))
+	for tipo, activa := range p.PreferenciasNotificacion {
+		preferencias[tipo] = activa
+	}
+
+	return ProductorResponse{
+		ID:                       string(p.ID),
+		Nombre:                   p.Nombre.Value,
+		ZonaVeredal:              p.Ubicacion.ZonaVeredal,
+		Finca:                    p.Ubicacion.Finca,
+		EstadoVerificacion:       p.EstadoVerificacion.Value,
+		EstadoActividad:          p.EstadoActividad.Value,
+		Reputacion:               float32(p.Reputacion),
+		PracticasCultivo:         p.PracticasCultivo.Descripcion,
+		FechaRegistro:            p.FechaRegistro.Format(time.RFC3339),
+		UltimaActividad:          p.UltimaActividad.Format(time.RFC3339),
+		PreferenciasNotificacion: preferencias,
+	}
+}
+
+// NewProductorResponses convierte una lista de productores; nunca retorna nil
+func NewProductorResponses(productores []*productor.Productor) []ProductorResponse {
+	respuesta := make([]ProductorResponse, 0, len(productores))
+	for _, p := range productores {
+		respuesta = append(respuesta, NewProductorResponse(p))
+	}
+	return respuesta
+}
@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+	"Product_Catalog_Microservice/internal/domain/productor"
+	"Product_Catalog_Microservice/internal/domain/service"
+	"Product_Catalog_Microservice/internal/domain/shared"
+)
+
+// Códigos de error de la API. Son estables: los clientes deben decidir con el código y no con
+// el mensaje, que puede cambiar de redacción.
+const (
+	CodigoJSONInvalido            = "INVALID_JSON"
+	CodigoValidacion              = "VALIDATION_ERROR"
+	CodigoProductoNoEncontrado    = "PRODUCTO_NOT_FOUND"
+	CodigoProductorNoEncontrado   = "PRODUCTOR_NOT_FOUND"
+	CodigoVistaNoEncontrada       = "VISTA_NOT_FOUND"
+	CodigoProductoDuplicado       = "PRODUCTO_ALREADY_EXISTS"
+	CodigoProductorDuplicado      = "PRODUCTOR_ALREADY_EXISTS"
+	CodigoNombreDuplicado         = "PRODUCTO_NOMBRE_DUPLICATED"
+	CodigoIdempotenciaConflicto   = "IDEMPOTENCY_KEY_REUSED"
+	CodigoProductorNoAutorizado   = "PRODUCTOR_NOT_AUTHORIZED"
+	CodigoLimiteProductos         = "PRODUCTOR_PRODUCT_LIMIT_REACHED"
+	CodigoContenidoNoPermitido    = "CONTENT_NOT_ALLOWED"
+	CodigoProductoNoDisponible    = "PRODUCTO_NOT_AVAILABLE"
+	CodigoProductoAgotado         = "PRODUCTO_SOLD_OUT"
+	CodigoFueraDeTemporada        = "PRODUCTO_OUT_OF_SEASON"
+	CodigoStockInsuficiente       = "STOCK_INSUFFICIENT"
+	CodigoSinCambios              = "STATE_UNCHANGED"
+	CodigoTransicionInvalida      = "INVALID_STATE_TRANSITION"
+	CodigoProductorNoActivo       = "PRODUCTOR_NOT_ACTIVE"
+	CodigoProductorYaVerificado   = "PRODUCTOR_ALREADY_VERIFIED"
+	CodigoVerificacionEnCurso     = "VERIFICATION_IN_PROGRESS"
+	CodigoNoEnVerificacion        = "VERIFICATION_NOT_STARTED"
+	CodigoHorizonteExcedido       = "FORECAST_HORIZON_EXCEEDED"
+	CodigoDemasiadosEventos       = "TOO_MANY_PENDING_EVENTS"
+	CodigoCapacidadAlcanzada      = "CAPACITY_REACHED"
+	CodigoProductorNoIdentificado = "PRODUCTOR_ID_MISSING"
+	CodigoNoAutenticado           = "UNAUTHENTICATED"
+	CodigoServicioSaturado        = "SERVICE_OVERLOADED"
+	CodigoInterno                 = "INTERNAL_ERROR"
+)
+
+// respuestaError es el cuerpo de toda respuesta de error de la API.
+// Field solo aparece cuando el error corresponde a un dato concreto de la petición.
+type respuestaError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Field   string `json:"field,omitempty"`
+}
+
+// errorDominio asocia un error del dominio con su código HTTP y su código de API
+type errorDominio struct {
+	err    error
+	status int
+	code   string
+	field  string
+}
+
+// erroresDominio traduce los errores tipados del dominio. Se recorre en orden con errors.Is,
+// de modo que un error envuelto con %w también se reconoce.
+var erroresDominio = []errorDominio{
+	{service.ErrProductoNoEncontrado, http.StatusNotFound, CodigoProductoNoEncontrado, ""},
+	{service.ErrProductorNoEncontrado, http.StatusNotFound, CodigoProductorNoEncontrado, ""},
+
+	{producto.ErrProductoDuplicado, http.StatusConflict, CodigoProductoDuplicado, ""},
+	{productor.ErrProductorDuplicado, http.StatusConflict, CodigoProductorDuplicado, ""},
+	{service.ErrNombreDuplicado, http.StatusConflict, CodigoNombreDuplicado, "nombre"},
+	{service.ErrIdempotenciaConflicto, http.StatusConflict, CodigoIdempotenciaConflicto, HeaderIdempotencyKey},
+	{producto.ErrNoDisponible, http.StatusConflict, CodigoProductoNoDisponible, ""},
+	{producto.ErrProductoAgotado, http.StatusConflict, CodigoProductoAgotado, ""},
+	{producto.ErrStockInsuficiente, http.StatusConflict, CodigoStockInsuficiente, "cantidad"},
+	{producto.ErrSinCambios, http.StatusConflict, CodigoSinCambios, ""},
+	{productor.ErrSinCambios, http.StatusConflict, CodigoSinCambios, ""},
+	{productor.ErrTransicionInvalida, http.StatusConflict, CodigoTransicionInvalida, ""},
+	{productor.ErrProductorNoActivo, http.StatusConflict, CodigoProductorNoActivo, ""},
+	{productor.ErrProductorYaVerificado, http.StatusConflict, CodigoProductorYaVerificado, ""},
+	{productor.ErrVerificacionEnCurso, http.StatusConflict, CodigoVerificacionEnCurso, ""},
+	{productor.ErrNoEnVerificacion, http.StatusConflict, CodigoNoEnVerificacion, ""},
+	{producto.ErrDemasiadosEventosPendientes, http.StatusConflict, CodigoDemasiadosEventos, ""},
+	{productor.ErrDemasiadosEventosPendientes, http.StatusConflict, CodigoDemasiadosEventos, ""},
+
+	{service.ErrProductorNoAutorizado, http.StatusUnprocessableEntity, CodigoProductorNoAutorizado, "productor_id"},
+	{service.ErrLimiteProductosAlcanzado, http.StatusUnprocessableEntity, CodigoLimiteProductos, "productor_id"},
+	{producto.ErrFueraDeTemporada, http.StatusUnprocessableEntity, CodigoFueraDeTemporada, "fecha"},
+	{service.ErrHorizonteExcedido, http.StatusUnprocessableEntity, CodigoHorizonteExcedido, "mes"},
+
+	{service.ErrAgrupacionInvalida, http.StatusBadRequest, CodigoValidacion, "por"},
+	{service.ErrCursorInvalido, http.StatusBadRequest, CodigoValidacion, "cursor"},
+	{service.ErrCursorExpirado, http.StatusBadRequest, CodigoValidacion, "cursor"},
+	{service.ErrContenidoNoPermitido, http.StatusBadRequest, CodigoContenidoNoPermitido, ""},
+
+	{producto.ErrCapacidadAlcanzada, http.StatusInsufficientStorage, CodigoCapacidadAlcanzada, ""},
+	{productor.ErrCapacidadAlcanzada, http.StatusInsufficientStorage, CodigoCapacidadAlcanzada, ""},
+}
+
+// mensajeErrorInterno es el mensaje de toda respuesta 500
+const mensajeErrorInterno = "error interno del servidor"
+
+// escribirError responde con el cuerpo de error de la API
+func escribirError(c *gin.Context, status int, code, field, message string) {
+	c.JSON(status, respuestaError{Code: code, Message: message, Field: field})
+}
+
+// responderError traduce un error del dominio o del servicio a su respuesta HTTP.
+// Los errores de validación de value objects responden 400 con el campo inválido y
+// cualquier error no reconocido responde 500 con un mensaje genérico.
+func responderError(c *gin.Context, err error) {
+	var validacion *shared.ErrorValidacion
+	if errors.As(err, &validacion) {
+		escribirError(c, http.StatusBadRequest, CodigoValidacion, validacion.Campo, validacion.Mensaje)
+		return
+	}
+
+	var vistaNoEncontrada service.ErrVistaNoEncontrada
+	if errors.As(err, &vistaNoEncontrada) {
+		escribirError(c, http.StatusNotFound, CodigoVistaNoEncontrada, "", err.Error())
+		return
+	}
+
+	for _, e := range erroresDominio {
+		if errors.Is(err, e.err) {
+			escribirError(c, e.status, e.code, e.field, err.Error())
+			return
+		}
+	}
+
+	// El detalle puede exponer la infraestructura (consultas, direcciones, etc.): queda solo en
+	// el contexto para que AccessLog lo registre y el cliente recibe un mensaje genérico
+	c.Error(err)
+	escribirError(c, http.StatusInternalServerError, CodigoInterno, "", mensajeErrorInterno)
+}
+
+// responderValidacion responde 400 por un dato de la petición que el handler valida por sí mismo
+func responderValidacion(c *gin.Context, campo, mensaje string) {
+	escribirError(c, http.StatusBadRequest, CodigoValidacion, campo, mensaje)
+}
+
+// responderJSONInvalido responde 400 cuando el cuerpo no se puede leer como JSON
+func responderJSONInvalido(c *gin.Context, err error) {
+	escribirError(c, http.StatusBadRequest, CodigoJSONInvalido, "", "JSON inválido: "+err.Error())
+}
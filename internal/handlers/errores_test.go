@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+	"Product_Catalog_Microservice/internal/domain/service"
+	"Product_Catalog_Microservice/internal/repository"
+)
+
+// productoRepoSinConexion falla toda lectura por ID con un error que expone la infraestructura
+type productoRepoSinConexion struct {
+	*repository.ProductoRepository
+}
+
+func (productoRepoSinConexion) GetByID(producto.ProductoID) (*producto.ProductoAgroecologico, error) {
+	return nil, errors.New("dial tcp 10.0.3.7:5432: connect: connection refused")
+}
+
+func TestResponderError_500ConMensajeGenerico(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var salida bytes.Buffer
+	catalogo := service.NewCatalogoService(repository.NewProductorRepository(0),
+		productoRepoSinConexion{repository.NewProductoRepository(0)}, &publicadorRegistro{})
+	r := gin.New()
+	r.Use(AccessLog(slog.New(slog.NewJSONHandler(&salida, nil)), MuestreoAccessLog{Porcentaje: 100}))
+	r.GET("catalogo/productos/:id", (&ProductoHandler{Catalogo: catalogo}).GetProductoByID)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/catalogo/productos/p-1", nil))
+
+	// Una falla del repositorio no es un 404, y su detalle no llega al cliente
+	respuesta := exigirError(t, w, http.StatusInternalServerError, CodigoInterno)
+	if respuesta.Message != mensajeErrorInterno {
+		t.Errorf("message = %q, se esperaba el mensaje genérico", respuesta.Message)
+	}
+	if strings.Contains(w.Body.String(), "10.0.3.7") {
+		t.Errorf("la respuesta expone el detalle interno: %s", w.Body.String())
+	}
+
+	var registro map[string]any
+	if err := json.Unmarshal(salida.Bytes(), &registro); err != nil {
+		t.Fatalf("registro inválido: %v\n%s", err, salida.String())
+	}
+	if detalle, _ := registro["error"].(string); !strings.Contains(detalle, "10.0.3.7:5432") {
+		t.Errorf("error en el access log = %q, se esperaba el detalle de la falla", detalle)
+	}
+}
+
+func TestResponderError_NoEncontradoResponde404(t *testing.T) {
+	s := nuevoServidorPrueba(t)
+	exigirError(t, s.hacer(http.MethodGet, "/catalogo/productos/no-existe", ""), http.StatusNotFound, CodigoProductoNoEncontrado)
+	exigirError(t, s.hacer(http.MethodGet, "/catalogo/productores/no-existe", ""), http.StatusNotFound, CodigoProductorNoEncontrado)
+}
+
+func TestPublicarProducto_CuerpoDeError(t *testing.T) {
+	s := nuevoServidorPrueba(t, service.WithMaxProductosPorProductor(2),
+		service.WithContentModeration(service.ContentModerationConfig{PalabrasProhibidas: []string{"milagroso"}}))
+	s.publicar(t, s.semilla1, "Fresa")
+
+	publicar := func(cambios map[string]any) *httptest.ResponseRecorder {
+		solicitud := solicitudPublicacion(s.semilla1, "Mora")
+		for k, v := range cambios {
+			solicitud[k] = v
+		}
+		return s.hacer(http.MethodPost, "/catalogo/producto", aJSON(t, solicitud))
+	}
+
+	casos := []struct {
+		nombre  string
+		cambios map[string]any
+		status  int
+		code    string
+		field   string
+	}{
+		{"categoría inválida", map[string]any{"categoria": "Juguete"}, http.StatusBadRequest, CodigoValidacion, "categoria"},
+		{"contenido no permitido", map[string]any{"descripcion": "Un abono milagroso"}, http.StatusBadRequest, CodigoContenidoNoPermitido, ""},
+		{"productor inexistente", map[string]any{"productor_id": "no-existe"}, http.StatusNotFound, CodigoProductorNoEncontrado, ""},
+		{"nombre duplicado", map[string]any{"nombre": "Fresa"}, http.StatusConflict, CodigoNombreDuplicado, "nombre"},
+		{"reputación insuficiente", map[string]any{"min_reputacion": 5}, http.StatusUnprocessableEntity, CodigoProductorNoAutorizado, "productor_id"},
+	}
+	for _, tc := range casos {
+		t.Run(tc.nombre, func(t *testing.T) {
+			if r := exigirError(t, publicar(tc.cambios), tc.status, tc.code); r.Field != tc.field {
+				t.Errorf("field = %q, se esperaba %q", r.Field, tc.field)
+			}
+		})
+	}
+
+	// Con el límite alcanzado la publicación válida también se rechaza
+	exigirStatus(t, publicar(nil), http.StatusCreated)
+	if r := exigirError(t, publicar(map[string]any{"nombre": "Lulo"}), http.StatusUnprocessableEntity, CodigoLimiteProductos); r.Field != "productor_id" {
+		t.Errorf("field = %q, se esperaba productor_id", r.Field)
+	}
+}
@@ -0,0 +1,24 @@
+package handlers
+
+import (
+	"time"
+)
+
+// formatoSoloFecha es el formato de fecha sin hora aceptado en las peticiones
+const formatoSoloFecha = "2006-01-02"
+
+// formatosFechaAceptados describe en los mensajes de error los formatos que acepta parsearFecha
+const formatosFechaAceptados = "se espera AAAA-MM-DD o RFC3339 (p. ej. 2025-03-01T00:00:00Z)"
+
+// parsearFecha lee una fecha de una petición en formato RFC3339 o solo fecha (2006-01-02).
+// Solo la fecha se interpreta como la medianoche de ese día en loc. Una fecha RFC3339
+// conserva el offset enviado por el cliente y no se convierte a loc: las temporadas toman
+// el día calendario de la fecha tal como se escribió, así que 2025-12-31T00:00:00Z sigue
+// siendo el 31 de diciembre y no el 30 en Bogotá. NewTemporadaLocal lleva después
+// temporada_fin al final de ese día, de modo que el producto no se agota un día antes.
+func parsearFecha(valor string, loc *time.Location) (time.Time, error) {
+	if fecha, err := time.Parse(time.RFC3339, valor); err == nil {
+		return fecha, nil
+	}
+	return time.ParseInLocation(formatoSoloFecha, valor, loc)
+}
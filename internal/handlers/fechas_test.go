@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+	"Product_Catalog_Microservice/internal/handlers/dto"
+)
+
+func TestParsearFecha(t *testing.T) {
+	bogota := time.FixedZone("COT", -5*3600)
+	casos := []struct {
+		valor  string
+		espera time.Time
+	}{
+		{"2025-03-01", time.Date(2025, time.March, 1, 0, 0, 0, 0, bogota)},
+		{"2025-03-01T00:00:00Z", time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)},
+		{"2025-03-01T10:30:00+02:00", time.Date(2025, time.March, 1, 8, 30, 0, 0, time.UTC)},
+	}
+	for _, tc := range casos {
+		t.Run(tc.valor, func(t *testing.T) {
+			got, err := parsearFecha(tc.valor, bogota)
+			if err != nil {
+				t.Fatalf("parsearFecha: %v", err)
+			}
+			if !got.Equal(tc.espera) {
+				t.Errorf("fecha = %s, se esperaba %s", got, tc.espera)
+			}
+		})
+	}
+
+	for _, valor := range []string{"", "01-03-2025", "2025-03-01 00:00:00", "2025-03-01T00:00:00"} {
+		if _, err := parsearFecha(valor, bogota); err == nil {
+			t.Errorf("parsearFecha(%q) no falló", valor)
+		}
+	}
+}
+
+func TestPublicarProducto_FechasRFC3339(t *testing.T) {
+	s := nuevoServidorPrueba(t)
+	anio := time.Now().Year() + 1
+	solicitud := solicitudPublicacion(s.semilla1, "Fresa")
+	solicitud["temporada_inicio"] = time.Date(anio, time.December, 1, 0, 0, 0, 0, time.UTC).Format(time.RFC3339)
+	// La medianoche UTC del 31 es todavía el 30 en Bogotá; la temporada debe cubrir el 31 completo
+	solicitud["temporada_fin"] = time.Date(anio, time.December, 31, 0, 0, 0, 0, time.UTC).Format(time.RFC3339)
+
+	w := s.hacer(http.MethodPost, "/catalogo/producto", aJSON(t, solicitud))
+	exigirStatus(t, w, http.StatusCreated)
+	creado := decodificar[dto.ProductoResponse](t, w)
+	if creado.TemporadaFin != time.Date(anio, time.December, 31, 0, 0, 0, 0, time.UTC).Format(dto.FormatoFecha) {
+		t.Errorf("temporada_fin = %s, se esperaba el 31 de diciembre", creado.TemporadaFin)
+	}
+
+	prod, err := s.productoRepo.GetByID(producto.ProductoID(creado.ID))
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	ultimaMediaHora := time.Date(anio, time.December, 31, 23, 30, 0, 0, producto.ZonaHoraria())
+	if !prod.Temporada.IsInSeason(ultimaMediaHora) {
+		t.Errorf("temporada = %s a %s, debía incluir %s", prod.Temporada.Inicio, prod.Temporada.Fin, ultimaMediaHora)
+	}
+
+	// El mensaje de error nombra los dos formatos aceptados
+	solicitud["temporada_fin"] = "31/12/" + time.Now().Format("2006")
+	r := exigirError(t, s.hacer(http.MethodPost, "/catalogo/producto", aJSON(t, solicitud)), http.StatusBadRequest, CodigoValidacion)
+	if r.Field != "temporada_fin" || !strings.Contains(r.Message, "AAAA-MM-DD") || !strings.Contains(r.Message, "RFC3339") {
+		t.Errorf("error = %+v, se esperaban los dos formatos en temporada_fin", r)
+	}
+}
+
+func TestMarcarExcedente_FechaRFC3339(t *testing.T) {
+	s := nuevoServidorPrueba(t)
+	s.publicar(t, s.semilla1, "Fresa")
+
+	fecha := time.Now().In(producto.ZonaHoraria()).Format(time.RFC3339)
+	w := s.hacer(http.MethodPost, "/catalogo/productos/excedente",
+		aJSON(t, map[string]string{"producto_id": "producto-000001", "fecha": fecha}))
+	exigirStatus(t, w, http.StatusNoContent)
+}
@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+	"Product_Catalog_Microservice/internal/domain/productor"
+	"Product_Catalog_Microservice/internal/domain/service"
+	"Product_Catalog_Microservice/internal/ids"
+	"Product_Catalog_Microservice/internal/repository"
+)
+
+// publicadorRegistro guarda los eventos publicados para inspeccionarlos en las pruebas
+type publicadorRegistro struct {
+	mu      sync.Mutex
+	eventos []any
+}
+
+func (p *publicadorRegistro) Publish(event any) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.eventos = append(p.eventos, event)
+	return nil
+}
+
+// servidorPrueba es el router de la API sobre repositorios en memoria con los productores
+// semilla.
+type servidorPrueba struct {
+	router        *gin.Engine
+	catalogo      *service.CatalogoService
+	productoRepo  *repository.ProductoRepository
+	productorRepo *repository.ProductorRepository
+	eventos       *publicadorRegistro
+
+	semilla1, semilla2 productor.ProductorID
+}
+
+func nuevoServidorPrueba(t testing.TB, opts ...service.CatalogoServiceOption) *servidorPrueba {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	s := &servidorPrueba{
+		productoRepo:  repository.NewProductoRepository(0),
+		productorRepo: repository.NewProductorRepository(0),
+		eventos:       &publicadorRegistro{},
+	}
+	s.catalogo = service.NewCatalogoService(s.productorRepo, s.productoRepo, s.eventos, opts...)
+	s.semilla1 = productorSemilla1
+	s.semilla2 = productorSemilla2
+
+	productoHandler := &ProductoHandler{Catalogo: s.catalogo, IDs: ids.NewSecuencialGenerator()}
+	productorHandler := &ProductorHandler{Catalogo: s.catalogo, IDs: ids.NewSecuencialGenerator(), MinReputacionAptos: 3}
+
+	// La misma tabla de rutas que cmd/app, sin limitadores ni métricas
+	r := gin.New()
+	RegistrarRutas(r, Rutas{
+		Producto:     productoHandler,
+		Productor:    productorHandler,
+		Admin:        &AdminHandler{Catalogo: s.catalogo},
+		Salud:        &SaludHandler{},
+		GuardiaAdmin: GuardiaAdmin(tokenAdminPrueba),
+	})
+	s.router = r
+
+	return s
+}
+
+// tokenAdminPrueba es el token de administración del servidor de prueba; autorizacionAdmin
+// son los headers que lo envían
+const tokenAdminPrueba = "token-admin-prueba"
+
+var autorizacionAdmin = []string{"Authorization", "Bearer " + tokenAdminPrueba}
+
+// IDs de los productores semilla que carga NewProductorRepository, ambos verificados y activos
+const (
+	productorSemilla1 productor.ProductorID = "quemado-1"
+	productorSemilla2 productor.ProductorID = "quemado-2"
+)
+
+// hacer ejecuta una petición contra el router; cuerpo vacío significa sin cuerpo.
+// headers alterna nombre y valor.
+func (s *servidorPrueba) hacer(metodo, ruta, cuerpo string, headers ...string) *httptest.ResponseRecorder {
+	var body io.Reader
+	if cuerpo != "" {
+		body = strings.NewReader(cuerpo)
+	}
+	req := httptest.NewRequest(metodo, ruta, body)
+	if cuerpo != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+// decodificar lee el cuerpo JSON de la respuesta en un valor de tipo T
+func decodificar[T any](t testing.TB, w *httptest.ResponseRecorder) T {
+	t.Helper()
+	var v T
+	if err := json.Unmarshal(w.Body.Bytes(), &v); err != nil {
+		t.Fatalf("cuerpo no es JSON válido: %v\n%s", err, w.Body.String())
+	}
+	return v
+}
+
+// exigirError comprueba el status y el código de una respuesta de error
+func exigirError(t testing.TB, w *httptest.ResponseRecorder, status int, code string) respuestaError {
+	t.Helper()
+	if w.Code != status {
+		t.Fatalf("status = %d, se esperaba %d; cuerpo: %s", w.Code, status, w.Body.String())
+	}
+	r := decodificar[respuestaError](t, w)
+	if r.Code != code {
+		t.Fatalf("code = %q, se esperaba %q; cuerpo: %s", r.Code, code, w.Body.String())
+	}
+	if r.Message == "" {
+		t.Errorf("la respuesta de error no trae mensaje: %s", w.Body.String())
+	}
+	return r
+}
+
+// exigirStatus comprueba el status de una respuesta
+func exigirStatus(t testing.TB, w *httptest.ResponseRecorder, status int) {
+	t.Helper()
+	if w.Code != status {
+		t.Fatalf("status = %d, se esperaba %d; cuerpo: %s", w.Code, status, w.Body.String())
+	}
+}
+
+// solicitudPublicacion retorna el cuerpo de una publicación válida del productor indicado, en
+// temporada desde hoy y durante 30 días
+func solicitudPublicacion(productorID productor.ProductorID, nombre string) map[string]any {
+	hoy := time.Now().In(producto.ZonaHoraria())
+	return map[string]any{
+		"productor_id":     productorID,
+		"nombre":           nombre,
+		"descripcion":      "Cosecha fresca sin agroquímicos",
+		"categoria":        "Fruta",
+		"tipo_produccion":  string(producto.ProduccionAgroecologica),
+		"temporada_inicio": hoy.Format("2006-01-02"),
+		"temporada_fin":    hoy.AddDate(0, 0, 30).Format("2006-01-02"),
+		"zona_veredal":     "Vereda El Paraíso",
+		"finca":            "Finca La Esperanza",
+		"imagen_url":       "https://img.example.com/fresa.jpg",
+		"imagen_desc":      "Fresas",
+		"precio_valor":     4500,
+		"precio_moneda":    "COP",
+		"cantidad_valor":   40,
+		"cantidad_unidad":  producto.UnidadKilogramo,
+	}
+}
+
+// aJSON serializa v o falla la prueba
+func aJSON(t testing.TB, v any) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("json.Marshal: %v", err)
+	}
+	return string(data)
+}
@@ -0,0 +1,212 @@
+package handlers
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+	"Product_Catalog_Microservice/internal/domain/productor"
+	"Product_Catalog_Microservice/internal/domain/service"
+)
+
+// ExponerMetricas atiende GET /metrics con las métricas de gatherer en el formato de Prometheus
+func ExponerMetricas(gatherer prometheus.Gatherer) gin.HandlerFunc {
+	return gin.WrapH(promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{}))
+}
+
+// rutaDesconocida es la etiqueta route de las peticiones que no coinciden con ninguna ruta,
+// para que las URLs arbitrarias no creen series nuevas
+const rutaDesconocida = "desconocida"
+
+// MetricasHTTP registra en reg, por ruta y método, la cantidad de peticiones atendidas con su
+// status, su duración y las que están en curso. La ruta es el patrón registrado, p. ej.
+// /catalogo/productos/:id, y no la URL. Las métricas se registran una sola vez por registro.
+func MetricasHTTP(reg prometheus.Registerer) gin.HandlerFunc {
+	fabrica := promauto.With(reg)
+	peticiones := fabrica.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "Peticiones HTTP atendidas.",
+	}, []string{"route", "method", "status"})
+	duracion := fabrica.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "Duración de las peticiones HTTP.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"route", "method"})
+	enCurso := fabrica.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "http_requests_in_flight",
+		Help: "Peticiones HTTP en curso.",
+	}, []string{"route", "method"})
+
+	return func(c *gin.Context) {
+		ruta := c.FullPath()
+		if ruta == "" {
+			ruta = rutaDesconocida
+		}
+		metodo := c.Request.Method
+
+		activas := enCurso.WithLabelValues(ruta, metodo)
+		activas.Inc()
+		defer activas.Dec()
+
+		inicio := time.Now()
+		c.Next()
+
+		duracion.WithLabelValues(ruta, metodo).Observe(time.Since(inicio).Seconds())
+		peticiones.WithLabelValues(ruta, metodo, strconv.Itoa(c.Writer.Status())).Inc()
+	}
+}
+
+// NuevoContadorDegradaciones registra en reg el contador, por motivo, de las respuestas que el
+// catálogo entrega degradadas. Se alimenta con service.WithAlDegradar.
+func NuevoContadorDegradaciones(reg prometheus.Registerer) *prometheus.CounterVec {
+	return promauto.With(reg).NewCounterVec(prometheus.CounterOpts{
+		Name: "catalogo_respuestas_degradadas_total",
+		Help: "Respuestas del catálogo entregadas sin parte de su información.",
+	}, []string{"motivo"})
+}
+
+// NuevoHistogramaEtapasPublicacion registra en reg el histograma, por etapa, de la duración de
+// las publicaciones de productos. Se asigna a ProductoHandler.EtapasPublicacion.
+func NuevoHistogramaEtapasPublicacion(reg prometheus.Registerer) *prometheus.HistogramVec {
+	return promauto.With(reg).NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "catalogo_publicacion_etapa_segundos",
+		Help:    "Duración de cada etapa de la publicación de un producto.",
+		Buckets: []float64{.001, .005, .01, .025, .05, .1, .25, .5, 1},
+	}, []string{"etapa"})
+}
+
+// NuevoGaugeCapacidad registra en reg la utilización, entre 0 y 1, del repositorio en memoria
+// de recurso según capacidad. Un repositorio sin máximo reporta 0.
+func NuevoGaugeCapacidad(reg prometheus.Registerer, recurso string, capacidad func() (usados, maximo int)) prometheus.GaugeFunc {
+	return promauto.With(reg).NewGaugeFunc(prometheus.GaugeOpts{
+		Name:        "catalogo_capacidad_utilizacion",
+		Help:        "Fracción usada de la capacidad máxima del repositorio en memoria.",
+		ConstLabels: prometheus.Labels{"recurso": recurso},
+	}, func() float64 {
+		usados, maximo := capacidad()
+		if maximo <= 0 {
+			return 0
+		}
+		return float64(usados) / float64(maximo)
+	})
+}
+
+// MetricasNegocio es un EventPublisher que reenvía cada evento a siguiente y, a partir de los
+// eventos, mantiene las métricas de negocio con las que operaciones alerta sobre anomalías
+// del catálogo. El estado vive en memoria y se reconstruye con los eventos tras reiniciar.
+type MetricasNegocio struct {
+	siguiente service.EventPublisher
+	ahora     func() time.Time
+
+	mu             sync.Mutex
+	publicaciones  []time.Time // instantes de publicación, en orden de llegada
+	verificaciones map[productor.ProductorID]time.Time
+
+	PublicacionesUltimas24h            prometheus.GaugeFunc
+	VerificacionesPendientesMaxEdadHrs prometheus.GaugeFunc
+}
+
+// NuevasMetricasNegocio registra en reg las métricas de negocio alimentadas por los eventos
+// que se publiquen a través del valor retornado
+func NuevasMetricasNegocio(reg prometheus.Registerer, siguiente service.EventPublisher) *MetricasNegocio {
+	m := &MetricasNegocio{
+		siguiente:      siguiente,
+		ahora:          time.Now,
+		verificaciones: make(map[productor.ProductorID]time.Time),
+	}
+	fabrica := promauto.With(reg)
+	m.PublicacionesUltimas24h = fabrica.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "catalogo_publicaciones_ultimas_24h",
+		Help: "Productos publicados en las últimas 24 horas.",
+	}, m.publicacionesUltimas24h)
+	m.VerificacionesPendientesMaxEdadHrs = fabrica.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "catalogo_verificaciones_pendientes_max_edad_horas",
+		Help: "Horas desde que el productor más antiguo en verificación inició el proceso.",
+	}, m.verificacionesMaxEdadHoras)
+	return m
+}
+
+// Publish actualiza las métricas con el evento y lo reenvía a siguiente
+func (m *MetricasNegocio) Publish(event any) error {
+	m.mu.Lock()
+	switch e := event.(type) {
+	case producto.ProductoPublicado:
+		m.publicaciones = append(m.publicaciones, e.At)
+	case productor.ProductorEnVerificacion:
+		m.verificaciones[e.ProductorID] = e.At
+	case productor.ProductorVerificado:
+		delete(m.verificaciones, e.ProductorID)
+	}
+	m.mu.Unlock()
+
+	return m.siguiente.Publish(event)
+}
+
+func (m *MetricasNegocio) publicacionesUltimas24h() float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// Se descartan las publicaciones que ya salieron de la ventana
+	desde := m.ahora().Add(-24 * time.Hour)
+	vigentes := m.publicaciones[:0]
+	for _, at := range m.publicaciones {
+		if at.After(desde) {
+			vigentes = append(vigentes, at)
+		}
+	}
+	m.publicaciones = vigentes
+	return float64(len(vigentes))
+}
+
+func (m *MetricasNegocio) verificacionesMaxEdadHoras() float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var maxEdad time.Duration
+	for _, inicio := range m.verificaciones {
+		if edad := m.ahora().Sub(inicio); edad > maxEdad {
+			maxEdad = edad
+		}
+	}
+	return maxEdad.Hours()
+}
+
+// MetricasCatalogo implementa service.Metricas con contadores de Prometheus. Se asigna al
+// servicio con service.WithMetricas.
+type MetricasCatalogo struct {
+	Publicados prometheus.Counter
+	Excedentes prometheus.Counter
+	Agotados   prometheus.Counter
+}
+
+var _ service.Metricas = (*MetricasCatalogo)(nil)
+
+// NuevasMetricasCatalogo registra en reg los contadores de productos publicados, marcados como
+// excedente y agotados
+func NuevasMetricasCatalogo(reg prometheus.Registerer) *MetricasCatalogo {
+	fabrica := promauto.With(reg)
+	return &MetricasCatalogo{
+		Publicados: fabrica.NewCounter(prometheus.CounterOpts{
+			Name: "catalogo_productos_publicados_total",
+			Help: "Productos publicados en el catálogo.",
+		}),
+		Excedentes: fabrica.NewCounter(prometheus.CounterOpts{
+			Name: "catalogo_productos_excedente_total",
+			Help: "Productos marcados como excedente.",
+		}),
+		Agotados: fabrica.NewCounter(prometheus.CounterOpts{
+			Name: "catalogo_productos_agotados_total",
+			Help: "Productos agotados, incluidos los de productores suspendidos.",
+		}),
+	}
+}
+
+func (m *MetricasCatalogo) ProductoPublicado()            { m.Publicados.Inc() }
+func (m *MetricasCatalogo) ProductoMarcadoComoExcedente() { m.Excedentes.Inc() }
+func (m *MetricasCatalogo) ProductoAgotado()              { m.Agotados.Inc() }
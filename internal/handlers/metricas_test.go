@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+	"Product_Catalog_Microservice/internal/domain/productor"
+	"Product_Catalog_Microservice/internal/domain/service"
+	"Product_Catalog_Microservice/internal/ids"
+	"Product_Catalog_Microservice/internal/repository"
+)
+
+func TestMetricasNegocio_PublicarProductoMueveLasPublicaciones(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	eventos := &publicadorRegistro{}
+	metricas := NuevasMetricasNegocio(prometheus.NewRegistry(), eventos)
+
+	productorRepo := repository.NewProductorRepository(0)
+	catalogo := service.NewCatalogoService(productorRepo, repository.NewProductoRepository(0), metricas)
+	handler := &ProductoHandler{Catalogo: catalogo, IDs: ids.NewSecuencialGenerator()}
+	s := &servidorPrueba{router: gin.New()}
+	s.router.POST("catalogo/producto", handler.PublicarProducto)
+
+	semilla := productorSemilla1
+	for i, nombre := range []string{"Fresa", "Mora"} {
+		s.publicar(t, semilla, nombre)
+		if got := testutil.ToFloat64(metricas.PublicacionesUltimas24h); got != float64(i+1) {
+			t.Errorf("catalogo_publicaciones_ultimas_24h = %v tras publicar %s, se esperaba %d", got, nombre, i+1)
+		}
+	}
+	if len(eventos.eventos) == 0 {
+		t.Error("los eventos debían llegar al publicador siguiente")
+	}
+}
+
+func TestMetricasNegocio_VentanaDePublicaciones(t *testing.T) {
+	ahora := time.Now()
+	metricas := NuevasMetricasNegocio(prometheus.NewRegistry(), &publicadorRegistro{})
+	metricas.ahora = func() time.Time { return ahora }
+
+	metricas.Publish(producto.ProductoPublicado{ProductoID: "p-1", At: ahora.Add(-25 * time.Hour)})
+	metricas.Publish(producto.ProductoPublicado{ProductoID: "p-2", At: ahora.Add(-time.Hour)})
+	if got := testutil.ToFloat64(metricas.PublicacionesUltimas24h); got != 1 {
+		t.Errorf("catalogo_publicaciones_ultimas_24h = %v, se esperaba 1", got)
+	}
+
+	ahora = ahora.Add(24 * time.Hour)
+	if got := testutil.ToFloat64(metricas.PublicacionesUltimas24h); got != 0 {
+		t.Errorf("catalogo_publicaciones_ultimas_24h = %v un día después, se esperaba 0", got)
+	}
+}
+
+func TestMetricasNegocio_EdadDeVerificacionesPendientes(t *testing.T) {
+	ahora := time.Now()
+	metricas := NuevasMetricasNegocio(prometheus.NewRegistry(), &publicadorRegistro{})
+	metricas.ahora = func() time.Time { return ahora }
+
+	edad := func() float64 { return testutil.ToFloat64(metricas.VerificacionesPendientesMaxEdadHrs) }
+	if got := edad(); got != 0 {
+		t.Errorf("sin verificaciones pendientes la edad = %v, se esperaba 0", got)
+	}
+
+	metricas.Publish(productor.ProductorEnVerificacion{ProductorID: "antiguo", At: ahora.AddDate(0, 0, -8)})
+	metricas.Publish(productor.ProductorEnVerificacion{ProductorID: "reciente", At: ahora.Add(-2 * time.Hour)})
+	if got := edad(); got != 8*24 {
+		t.Errorf("edad = %v, se esperaba %d horas", got, 8*24)
+	}
+
+	// Al verificar al más antiguo la edad pasa a la del siguiente en la cola
+	metricas.Publish(productor.ProductorVerificado{ProductorID: "antiguo", At: ahora})
+	if got := edad(); got != 2 {
+		t.Errorf("edad = %v tras verificar al más antiguo, se esperaba 2 horas", got)
+	}
+}
+
+func TestMetricasNegocio_ExpuestasEnMetrics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	reg := prometheus.NewRegistry()
+	NuevasMetricasNegocio(reg, &publicadorRegistro{})
+
+	s := &servidorPrueba{router: gin.New()}
+	s.router.GET("metrics", ExponerMetricas(reg))
+	w := s.hacer(http.MethodGet, "/metrics", "")
+	exigirStatus(t, w, http.StatusOK)
+	for _, nombre := range []string{"catalogo_publicaciones_ultimas_24h", "catalogo_verificaciones_pendientes_max_edad_horas"} {
+		if !strings.Contains(w.Body.String(), nombre) {
+			t.Errorf("/metrics no expone %s", nombre)
+		}
+	}
+}
+
+func TestMetricasHTTP_EtiquetaPorPatronDeRuta(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	reg := prometheus.NewRegistry()
+	s := &servidorPrueba{router: gin.New()}
+	s.router.Use(MetricasHTTP(reg))
+	s.router.GET("catalogo/productos/:id", func(c *gin.Context) { c.Status(http.StatusNotFound) })
+	s.router.GET("metrics", ExponerMetricas(reg))
+
+	s.hacer(http.MethodGet, "/catalogo/productos/p-1", "")
+	s.hacer(http.MethodGet, "/catalogo/productos/p-2", "")
+	s.hacer(http.MethodGet, "/no/existe/123", "")
+
+	w := s.hacer(http.MethodGet, "/metrics", "")
+	exigirStatus(t, w, http.StatusOK)
+	for _, serie := range []string{
+		`http_requests_total{method="GET",route="/catalogo/productos/:id",status="404"} 2`,
+		`http_requests_total{method="GET",route="desconocida",status="404"} 1`,
+		`http_request_duration_seconds_count{method="GET",route="/catalogo/productos/:id"} 2`,
+		`http_requests_in_flight{method="GET",route="/catalogo/productos/:id"} 0`,
+		// La petición que lee /metrics sigue en curso mientras se genera la respuesta
+		`http_requests_in_flight{method="GET",route="/metrics"} 1`,
+	} {
+		if !strings.Contains(w.Body.String(), serie) {
+			t.Errorf("/metrics no contiene %s", serie)
+		}
+	}
+	if strings.Contains(w.Body.String(), "p-1") {
+		t.Error("la etiqueta route lleva el ID en lugar del patrón de la ruta")
+	}
+}
+
+func TestMetricasCatalogo_CuentanPublicaciones(t *testing.T) {
+	metricas := NuevasMetricasCatalogo(prometheus.NewRegistry())
+	s := nuevoServidorPrueba(t, service.WithMetricas(metricas))
+	s.publicar(t, s.semilla1, "Fresa")
+	exigirStatus(t, s.hacer(http.MethodPost, "/catalogo/productos/producto-000001/agotar", ""), http.StatusNoContent)
+
+	if got := testutil.ToFloat64(metricas.Publicados); got != 1 {
+		t.Errorf("catalogo_productos_publicados_total = %v, se esperaba 1", got)
+	}
+	if got := testutil.ToFloat64(metricas.Agotados); got != 1 {
+		t.Errorf("catalogo_productos_agotados_total = %v, se esperaba 1", got)
+	}
+	if got := testutil.ToFloat64(metricas.Excedentes); got != 0 {
+		t.Errorf("catalogo_productos_excedente_total = %v, se esperaba 0", got)
+	}
+}
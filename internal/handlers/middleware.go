@@ -0,0 +1,187 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"hash/fnv"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// HeaderRequestID es el header con el que se propaga el identificador de la petición
+const HeaderRequestID = "X-Request-ID"
+
+// GuardiaAdmin protege las rutas de administración: exige el header Authorization con
+// "Bearer {token}" y responde 401 sin él o con otro token. token no puede ser vacío.
+func GuardiaAdmin(token string) gin.HandlerFunc {
+	esperado := []byte("Bearer " + token)
+	return func(c *gin.Context) {
+		recibido := []byte(c.GetHeader("Authorization"))
+		if token == "" || subtle.ConstantTimeCompare(recibido, esperado) != 1 {
+			c.Header("WWW-Authenticate", `Bearer realm="catalogo-admin"`)
+			escribirError(c, http.StatusUnauthorized, CodigoNoAutenticado, "", "se requiere el token de administración")
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// NuevoContadorDescartes registra en reg el contador, por ruta, de las peticiones que
+// LimitarConcurrencia rechaza con 503. Se crea una sola vez por registro y se comparte
+// entre todos los limitadores.
+func NuevoContadorDescartes(reg prometheus.Registerer) *prometheus.CounterVec {
+	return promauto.With(reg).NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_shed_total",
+		Help: "Peticiones rechazadas con 503 por el límite de concurrencia de rutas costosas.",
+	}, []string{"route"})
+}
+
+// LimitarConcurrencia limita la cantidad de peticiones simultáneas que atiende una ruta costosa.
+// Cuando se alcanza el límite, la petición espera hasta `espera` por un cupo; si no lo obtiene
+// responde 503 con Retry-After para que el cliente reintente más tarde y se cuenta en
+// descartadas, que puede ser nil.
+// Cada llamada crea su propio semáforo, por lo que debe usarse una instancia por grupo de rutas.
+func LimitarConcurrencia(limite int, espera time.Duration, descartadas *prometheus.CounterVec) gin.HandlerFunc {
+	cupos := make(chan struct{}, limite)
+	reintentarEn := strconv.Itoa(max(1, int(espera.Round(time.Second)/time.Second)))
+
+	return func(c *gin.Context) {
+		timer := time.NewTimer(espera)
+		defer timer.Stop()
+
+		select {
+		case cupos <- struct{}{}:
+		case <-timer.C:
+			if descartadas != nil {
+				descartadas.WithLabelValues(c.FullPath()).Inc()
+			}
+			c.Header("Retry-After", reintentarEn)
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, respuestaError{
+				Code:    CodigoServicioSaturado,
+				Message: "servicio saturado, intente más tarde",
+			})
+			return
+		case <-c.Request.Context().Done():
+			c.Abort()
+			return
+		}
+		defer func() { <-cupos }()
+
+		c.Next()
+	}
+}
+
+// MuestreoAccessLog define qué peticiones exitosas se registran en el access log.
+// Los errores (4xx/5xx), las escrituras y las peticiones más lentas que UmbralLento
+// siempre se registran; del resto se registra solo el Porcentaje indicado.
+type MuestreoAccessLog struct {
+	Porcentaje  int
+	UmbralLento time.Duration
+}
+
+// Claves del contexto de gin con los agregados involucrados en la petición; AccessLog las
+// incluye en su línea
+const (
+	claveLogProductorID = "productor_id"
+	claveLogProductoID  = "producto_id"
+)
+
+// anotarAgregadosEnLog registra los IDs de productor y producto que el handler conoce por el
+// cuerpo o por el resultado de la petición. Los valores vacíos se ignoran.
+func anotarAgregadosEnLog(c *gin.Context, productorID, productoID string) {
+	if productorID != "" {
+		c.Set(claveLogProductorID, productorID)
+	}
+	if productoID != "" {
+		c.Set(claveLogProductoID, productoID)
+	}
+}
+
+// anotarAgregadosDeRuta toma el :id de las rutas de productores y de productos
+func anotarAgregadosDeRuta(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		return
+	}
+	switch ruta := c.FullPath(); {
+	case strings.HasPrefix(ruta, "/catalogo/productores/:id"):
+		anotarAgregadosEnLog(c, id, "")
+	case strings.HasPrefix(ruta, "/catalogo/productos/:id"), strings.HasPrefix(ruta, "/catalogo/producto/:id"):
+		anotarAgregadosEnLog(c, "", id)
+	}
+}
+
+// AccessLog registra cada petición como una línea estructurada en el logger dado, con el
+// productor_id y el producto_id de la ruta o del cuerpo cuando los hay.
+// Asigna un request ID (o reutiliza el recibido en X-Request-ID) y lo devuelve en la respuesta.
+// La decisión de muestreo depende solo del request ID, de modo que una petición muestreada
+// conserva todos sus logs.
+func AccessLog(logger *slog.Logger, muestreo MuestreoAccessLog) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		inicio := time.Now()
+
+		requestID := c.GetHeader(HeaderRequestID)
+		if requestID == "" {
+			requestID = uuid.NewString()
+		}
+		c.Set("request_id", requestID)
+		c.Header(HeaderRequestID, requestID)
+		anotarAgregadosDeRuta(c)
+
+		c.Next()
+
+		latencia := time.Since(inicio)
+		status := c.Writer.Status()
+		siempre := status >= http.StatusBadRequest ||
+			c.Request.Method != http.MethodGet ||
+			latencia >= muestreo.UmbralLento
+		if !siempre && !muestreado(requestID, muestreo.Porcentaje) {
+			return
+		}
+
+		nivel := slog.LevelInfo
+		if status >= http.StatusInternalServerError {
+			nivel = slog.LevelError
+		} else if status >= http.StatusBadRequest {
+			nivel = slog.LevelWarn
+		}
+
+		atributos := []slog.Attr{
+			slog.String("request_id", requestID),
+			slog.String("metodo", c.Request.Method),
+			slog.String("ruta", c.FullPath()),
+			slog.String("path", c.Request.URL.Path),
+			slog.Int("status", status),
+			slog.Duration("latencia", latencia),
+			slog.String("ip", c.ClientIP()),
+		}
+		for _, clave := range []string{claveLogProductorID, claveLogProductoID} {
+			if id := c.GetString(clave); id != "" {
+				atributos = append(atributos, slog.String(clave, id))
+			}
+		}
+		// Detalle de los errores internos, que la respuesta no expone
+		if err := c.Errors.Last(); err != nil {
+			atributos = append(atributos, slog.String("error", err.Error()))
+		}
+		logger.LogAttrs(c.Request.Context(), nivel, "peticion", atributos...)
+	}
+}
+
+// muestreado decide de forma determinista si un request ID cae dentro del porcentaje
+func muestreado(requestID string, porcentaje int) bool {
+	if porcentaje >= 100 {
+		return true
+	}
+	h := fnv.New32a()
+	h.Write([]byte(requestID))
+	return int(h.Sum32()%100) < porcentaje
+}
@@ -0,0 +1,219 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"Product_Catalog_Microservice/internal/ids"
+)
+
+func TestLimitarConcurrencia_DescartaCuandoNoHayCupo(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	descartadas := NuevoContadorDescartes(prometheus.NewRegistry())
+
+	dentro := make(chan struct{})
+	liberar := make(chan struct{})
+	r := gin.New()
+	r.GET("/costosa", LimitarConcurrencia(1, 20*time.Millisecond, descartadas), func(c *gin.Context) {
+		select {
+		case dentro <- struct{}{}:
+			<-liberar
+		default:
+		}
+		c.Status(http.StatusOK)
+	})
+	hacer := func() *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/costosa", nil))
+		return w
+	}
+
+	// La primera petición ocupa el único cupo y se queda bloqueada en el handler
+	primera := make(chan *httptest.ResponseRecorder)
+	go func() { primera <- hacer() }()
+	<-dentro
+
+	w := hacer()
+	exigirStatus(t, w, http.StatusServiceUnavailable)
+	if got := w.Header().Get("Retry-After"); got != "1" {
+		t.Errorf("Retry-After = %q, se esperaba 1", got)
+	}
+	if n := testutil.ToFloat64(descartadas.WithLabelValues("/costosa")); n != 1 {
+		t.Errorf("http_requests_shed_total = %v, se esperaba 1", n)
+	}
+
+	// Al liberar el cupo la ruta vuelve a atender
+	close(liberar)
+	exigirStatus(t, <-primera, http.StatusOK)
+	exigirStatus(t, hacer(), http.StatusOK)
+	if n := testutil.ToFloat64(descartadas.WithLabelValues("/costosa")); n != 1 {
+		t.Errorf("http_requests_shed_total = %v después de liberar, se esperaba 1", n)
+	}
+}
+
+func TestLimitarConcurrencia_SinContador(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	liberar := make(chan struct{})
+	dentro := make(chan struct{})
+	r := gin.New()
+	r.GET("/costosa", LimitarConcurrencia(1, time.Millisecond, nil), func(c *gin.Context) {
+		close(dentro)
+		<-liberar
+	})
+	go r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/costosa", nil))
+	<-dentro
+	defer close(liberar)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/costosa", nil))
+	exigirStatus(t, w, http.StatusServiceUnavailable)
+}
+
+// registroAccessLog es un router con el access log sobre un buffer y rutas que responden
+// con el status indicado en la ruta
+func registroAccessLog(muestreo MuestreoAccessLog) (*gin.Engine, *bytes.Buffer) {
+	gin.SetMode(gin.TestMode)
+	var buf bytes.Buffer
+	r := gin.New()
+	r.Use(AccessLog(slog.New(slog.NewJSONHandler(&buf, nil)), muestreo))
+	r.Any("/status/:codigo", func(c *gin.Context) {
+		var codigo int
+		fmt.Sscan(c.Param("codigo"), &codigo)
+		c.Status(codigo)
+	})
+	return r, &buf
+}
+
+func TestAccessLog_SiempreRegistraErroresYEscrituras(t *testing.T) {
+	r, buf := registroAccessLog(MuestreoAccessLog{Porcentaje: 0, UmbralLento: time.Hour})
+
+	peticiones := []struct{ metodo, ruta string }{
+		{http.MethodGet, "/status/200"},
+		{http.MethodGet, "/status/404"},
+		{http.MethodGet, "/status/500"},
+		{http.MethodPost, "/status/201"},
+	}
+	for _, p := range peticiones {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(p.metodo, p.ruta, nil))
+	}
+
+	// Con muestreo 0 solo la lectura exitosa queda fuera
+	if n := strings.Count(buf.String(), "\n"); n != 3 {
+		t.Errorf("líneas de log = %d, se esperaban 3:\n%s", n, buf.String())
+	}
+	if strings.Contains(buf.String(), `"status":200`) {
+		t.Errorf("la lectura exitosa no debía registrarse:\n%s", buf.String())
+	}
+}
+
+func TestAccessLog_MuestreoDeterministaPorRequestID(t *testing.T) {
+	r, buf := registroAccessLog(MuestreoAccessLog{Porcentaje: 50, UmbralLento: time.Hour})
+
+	for i := 0; i < 20; i++ {
+		id := fmt.Sprintf("req-%d", i)
+		for intento := 0; intento < 2; intento++ {
+			buf.Reset()
+			req := httptest.NewRequest(http.MethodGet, "/status/200", nil)
+			req.Header.Set(HeaderRequestID, id)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if got := w.Header().Get(HeaderRequestID); got != id {
+				t.Fatalf("X-Request-ID = %q, se esperaba %q", got, id)
+			}
+			if registrado := buf.Len() > 0; registrado != muestreado(id, 50) {
+				t.Errorf("%s intento %d: registrado = %v, se esperaba %v", id, intento, registrado, !registrado)
+			}
+		}
+	}
+}
+
+func TestAccessLog_IncluyeLosAgregados(t *testing.T) {
+	s := nuevoServidorPrueba(t)
+	var buf bytes.Buffer
+	productoHandler := &ProductoHandler{Catalogo: s.catalogo, IDs: ids.NewSecuencialGenerator()}
+	productorHandler := &ProductorHandler{Catalogo: s.catalogo, IDs: ids.NewSecuencialGenerator()}
+	r := gin.New()
+	r.Use(AccessLog(slog.New(slog.NewJSONHandler(&buf, nil)), MuestreoAccessLog{Porcentaje: 100}))
+	r.POST("catalogo/producto", productoHandler.PublicarProducto)
+	r.POST("catalogo/productos/excedente", productoHandler.MarcarProductoComoExcedente)
+	r.GET("catalogo/productos/:id", productoHandler.GetProductoByID)
+	r.GET("catalogo/productores/:id/productos", productorHandler.GetProductosDeProductor)
+	s.router = r
+
+	lineaDe := func(metodo, ruta, cuerpo string) map[string]any {
+		t.Helper()
+		buf.Reset()
+		s.hacer(metodo, ruta, cuerpo)
+		var linea map[string]any
+		if err := json.Unmarshal(buf.Bytes(), &linea); err != nil {
+			t.Fatalf("%s %s: línea de log inválida %q: %v", metodo, ruta, buf.String(), err)
+		}
+		return linea
+	}
+
+	casos := []struct {
+		nombre, metodo, ruta, cuerpo string
+		productorID, productoID      string
+	}{
+		// El ID generado solo se conoce con el resultado
+		{"publicar", http.MethodPost, "/catalogo/producto", aJSON(t, solicitudPublicacion(s.semilla1, "Fresa")), string(s.semilla1), "producto-000001"},
+		{"ruta de producto", http.MethodGet, "/catalogo/productos/producto-000001", "", "", "producto-000001"},
+		{"ruta de productor", http.MethodGet, "/catalogo/productores/" + string(s.semilla2) + "/productos", "", string(s.semilla2), ""},
+		// Aunque la petición falle, el cuerpo ya identificó al producto
+		{"excedente", http.MethodPost, "/catalogo/productos/excedente", `{"producto_id":"no-existe","fecha":"2099-01-01"}`, "", "no-existe"},
+	}
+	for _, tc := range casos {
+		t.Run(tc.nombre, func(t *testing.T) {
+			linea := lineaDe(tc.metodo, tc.ruta, tc.cuerpo)
+			for clave, esperado := range map[string]string{"productor_id": tc.productorID, "producto_id": tc.productoID} {
+				got, presente := linea[clave]
+				if esperado == "" && presente {
+					t.Errorf("%s = %v, no debía aparecer", clave, got)
+				}
+				if esperado != "" && got != esperado {
+					t.Errorf("%s = %v, se esperaba %q", clave, got, esperado)
+				}
+			}
+		})
+	}
+}
+
+func TestGuardiaAdmin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	pedir := func(token string, autorizacion ...string) *httptest.ResponseRecorder {
+		t.Helper()
+		r := gin.New()
+		r.GET("/admin", GuardiaAdmin(token), func(c *gin.Context) { c.Status(http.StatusNoContent) })
+		req := httptest.NewRequest(http.MethodGet, "/admin", nil)
+		for _, valor := range autorizacion {
+			req.Header.Set("Authorization", valor)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	exigirStatus(t, pedir("secreto", "Bearer secreto"), http.StatusNoContent)
+	for _, autorizacion := range [][]string{nil, {"Bearer otro"}, {"secreto"}, {"Basic secreto"}, {"Bearer "}} {
+		w := pedir("secreto", autorizacion...)
+		exigirError(t, w, http.StatusUnauthorized, CodigoNoAutenticado)
+		if w.Header().Get("WWW-Authenticate") == "" {
+			t.Errorf("%v: falta WWW-Authenticate", autorizacion)
+		}
+	}
+	// Un token vacío no abre las rutas
+	exigirStatus(t, pedir("", "Bearer "), http.StatusUnauthorized)
+}
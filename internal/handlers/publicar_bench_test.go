@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"Product_Catalog_Microservice/internal/domain/service"
+	"Product_Catalog_Microservice/internal/ids"
+	"Product_Catalog_Microservice/internal/repository"
+)
+
+// publicadorLog registra cada evento como lo haría un publicador de logging
+type publicadorLog struct {
+	logger *log.Logger
+}
+
+func (p publicadorLog) Publish(event any) error {
+	p.logger.Printf("evento de dominio %T %+v", event, event)
+	return nil
+}
+
+// BenchmarkPublicarProducto mide POST /catalogo/producto de punta a punta: handler, servicio,
+// repositorio en memoria y un publicador que registra cada evento. Cada iteración publica un
+// nombre distinto para no chocar con la unicidad.
+//
+//	go test ./internal/handlers -run '^$' -bench PublicarProducto -benchmem
+func BenchmarkPublicarProducto(b *testing.B) {
+	gin.SetMode(gin.TestMode)
+	logger := log.New(io.Discard, "", log.LstdFlags)
+
+	var (
+		r       *gin.Engine
+		cuerpos []string
+	)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		// El catálogo se reinicia cada productosPorCatalogo publicaciones para que el costo por
+		// op no crezca con b.N: la verificación del nombre duplicado recorre el repositorio
+		if i%productosPorCatalogo == 0 {
+			b.StopTimer()
+			r, cuerpos = nuevoRouterPublicacion(b, logger)
+			b.StartTimer()
+		}
+
+		req := httptest.NewRequest(http.MethodPost, "/catalogo/producto", strings.NewReader(cuerpos[i%productosPorCatalogo]))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if w.Code != http.StatusCreated {
+			b.Fatalf("status = %d: %s", w.Code, w.Body.String())
+		}
+	}
+}
+
+// productosPorCatalogo es la cantidad de productos que publica BenchmarkPublicarProducto
+// sobre un mismo catálogo
+const productosPorCatalogo = 1000
+
+// nuevoRouterPublicacion arma POST /catalogo/producto sobre un catálogo vacío y retorna los
+// cuerpos de productosPorCatalogo publicaciones con nombres distintos
+func nuevoRouterPublicacion(b *testing.B, logger *log.Logger) (*gin.Engine, []string) {
+	productorRepo := repository.NewProductorRepository(0)
+	catalogo := service.NewCatalogoService(productorRepo, repository.NewProductoRepository(0), publicadorLog{logger})
+	handler := &ProductoHandler{Catalogo: catalogo, IDs: ids.NewSecuencialGenerator()}
+
+	productorID := productorSemilla1
+	cuerpos := make([]string, productosPorCatalogo)
+	for i := range cuerpos {
+		cuerpos[i] = aJSON(b, solicitudPublicacion(productorID, fmt.Sprintf("Producto %d", i)))
+	}
+
+	r := gin.New()
+	r.POST("catalogo/producto", handler.PublicarProducto)
+	return r, cuerpos
+}
@@ -0,0 +1,103 @@
+package handlers
+
+import "github.com/gin-gonic/gin"
+
+// Grupos de rutas costosas; cada grupo tiene su propio límite de concurrencia
+const (
+	GrupoCostosoProductos = "productos" // catalogo/productos
+	GrupoCostosoCompleto  = "completo"  // catalogo/completo
+	GrupoCostosoBuscar    = "buscar"    // catalogo/buscar
+	GrupoCostosoAgrupado  = "agrupado"  // catalogo/agrupado
+)
+
+// GruposCostosos lista los grupos de rutas costosas que monta RegistrarRutas
+var GruposCostosos = []string{GrupoCostosoProductos, GrupoCostosoCompleto, GrupoCostosoBuscar, GrupoCostosoAgrupado}
+
+// Rutas reúne los handlers de la API que monta RegistrarRutas
+type Rutas struct {
+	Producto  *ProductoHandler
+	Productor *ProductorHandler
+	Admin     *AdminHandler
+	Salud     *SaludHandler
+
+	// GuardiaAdmin autentica las rutas catalogo/admin; nil no las registra
+	GuardiaAdmin gin.HandlerFunc
+
+	// Metricas atiende GET /metrics; nil no registra la ruta
+	Metricas gin.HandlerFunc
+
+	// LimitarRutaCostosa crea el limitador de concurrencia de la ruta costosa del grupo indicado,
+	// uno por ruta; nil no limita
+	LimitarRutaCostosa func(grupo string) gin.HandlerFunc
+}
+
+// RegistrarRutas monta en r todas las rutas de la API. cmd/app y las pruebas comparten esta
+// tabla para que no diverjan.
+func RegistrarRutas(r gin.IRouter, rutas Rutas) {
+	// costosa antepone al handler el limitador de las rutas costosas, si lo hay
+	costosa := func(grupo string, h gin.HandlerFunc) []gin.HandlerFunc {
+		if rutas.LimitarRutaCostosa == nil {
+			return []gin.HandlerFunc{h}
+		}
+		return []gin.HandlerFunc{rutas.LimitarRutaCostosa(grupo), h}
+	}
+
+	if rutas.Metricas != nil {
+		r.GET("metrics", rutas.Metricas)
+	}
+
+	producto := rutas.Producto
+	r.POST("catalogo/producto", producto.PublicarProducto)
+	r.POST("catalogo/productos/excedente", producto.MarcarProductoComoExcedente)
+	r.POST("catalogo/productos/:id/agotar", producto.AgotarProducto)
+	r.PUT("catalogo/productos/disponibilidad", producto.ActualizarDisponibilidadPorTemporada)
+	r.GET("catalogo/productos", costosa(GrupoCostosoProductos, producto.GetProductos)...)
+	r.GET("catalogo/productos/zona", producto.GetProductosEnZona)
+	r.GET("catalogo/productos/categoria/:categoria", producto.GetProductosPorCategoria)
+	r.GET("catalogo/productos/tipo/:tipo", producto.GetProductosPorTipoProduccion)
+	r.GET("catalogo/productos/temporada", producto.GetProductosEnTemporada)
+	r.GET("catalogo/productos/excedentes", producto.GetProductosExcedentes)
+	r.GET("catalogo/productos/:id", producto.GetProductoByID)
+	r.PUT("catalogo/productos/:id", producto.ActualizarInformacionProducto)
+	r.PUT("catalogo/productos/:id/precio", producto.ActualizarPrecio)
+	r.PUT("catalogo/productos/:id/stock", producto.DecrementarStock)
+	r.GET("catalogo/producto/:id", producto.GetProductoByID) // alias en singular, como POST catalogo/producto
+	r.GET("catalogo/completo", costosa(GrupoCostosoCompleto, producto.GetCatalogoCompleto)...)
+	r.GET("catalogo/buscar", costosa(GrupoCostosoBuscar, producto.BuscarProductos)...)
+	r.GET("catalogo/agrupado", costosa(GrupoCostosoAgrupado, producto.GetCatalogoAgrupado)...)
+	r.GET("catalogo/pronostico", producto.GetPronostico)
+	r.GET("catalogo/sugerencias/temporada", producto.GetSugerenciaTemporada)
+	r.GET("catalogo/vistas", producto.GetVistas)
+	r.GET("catalogo/vistas/:nombre", producto.GetVista)
+	r.GET("catalogo/estadisticas/zonas", producto.GetResumenCatalogoPorZona)
+
+	productor := rutas.Productor
+	r.POST("catalogo/productor", productor.RegistrarProductor)
+	r.POST("catalogo/productores", productor.RegistrarProductor)
+	r.GET("catalogo/productores/practica", productor.GetProductoresPorPractica)
+	r.GET("catalogo/productores/aptos", productor.GetProductoresAptos)
+	r.GET("catalogo/productores/:id", productor.GetProductor)
+	r.GET("catalogo/productores/:id/productos", productor.GetProductosDeProductor)
+	r.PUT("catalogo/productores/:id/preferencias", productor.ActualizarPreferenciasNotificacion)
+	r.PUT("catalogo/productores/:id/reputacion", productor.ActualizarReputacion)
+	r.PUT("catalogo/productores/:id/suspender", productor.SuspenderProductor)
+	r.PUT("catalogo/productores/:id/reactivar", productor.ReactivarProductor)
+	r.POST("catalogo/productores/:id/verificacion/iniciar", productor.IniciarVerificacion)
+	r.POST("catalogo/productores/:id/verificacion/completar", productor.CompletarVerificacion)
+
+	if rutas.GuardiaAdmin != nil {
+		admin := r.Group("catalogo/admin", rutas.GuardiaAdmin)
+		admin.GET("configuracion", rutas.Admin.GetConfiguracion)
+		admin.POST("auditar-invariantes", rutas.Admin.AuditarInvariantes)
+		admin.GET("rechazos", productor.GetRechazos)
+		admin.GET("productores/cohorte", productor.GetCohorteProductores)
+		admin.GET("productores/inactivos", productor.GetProductoresInactivos)
+
+		// X-Productor-ID no autentica al productor: solo se acepta de quien tiene el token,
+		// como el portal de productores que ya lo autenticó
+		r.GET("catalogo/mis-rechazos", rutas.GuardiaAdmin, productor.GetMisRechazos)
+	}
+
+	r.GET("healthz", rutas.Salud.Healthz)
+	r.GET("readyz", rutas.Salud.Readyz)
+}
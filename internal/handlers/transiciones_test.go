@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+	"Product_Catalog_Microservice/internal/domain/service"
+)
+
+// solicitudExcedente publica un producto del primer productor semilla y retorna el cuerpo que
+// lo marca como excedente dentro de su temporada
+func solicitudExcedente(t *testing.T, s *servidorPrueba) string {
+	t.Helper()
+	s.publicar(t, s.semilla1, "Fresa")
+	productos, err := s.productoRepo.GetAll()
+	if err != nil || len(productos) != 1 {
+		t.Fatalf("GetAll = (%d productos, %v), se esperaba 1", len(productos), err)
+	}
+	hoy := time.Now().In(producto.ZonaHoraria()).Format("2006-01-02")
+	return aJSON(t, map[string]string{"producto_id": string(productos[0].ID), "fecha": hoy})
+}
+
+func TestMarcarExcedente_RepetirEsIdempotente(t *testing.T) {
+	s := nuevoServidorPrueba(t)
+	cuerpo := solicitudExcedente(t, s)
+
+	exigirStatus(t, s.hacer(http.MethodPost, "/catalogo/productos/excedente", cuerpo), http.StatusNoContent)
+
+	w := s.hacer(http.MethodPost, "/catalogo/productos/excedente", cuerpo)
+	exigirStatus(t, w, http.StatusOK)
+	if r := decodificar[map[string]bool](t, w); !r["sin_cambios"] {
+		t.Errorf("cuerpo = %s, se esperaba {\"sin_cambios\": true}", w.Body.String())
+	}
+}
+
+func TestMarcarExcedente_Estricta(t *testing.T) {
+	s := nuevoServidorPrueba(t, service.WithTransicionesEstrictas(service.TransicionExcedente))
+	cuerpo := solicitudExcedente(t, s)
+
+	exigirStatus(t, s.hacer(http.MethodPost, "/catalogo/productos/excedente", cuerpo), http.StatusNoContent)
+	exigirError(t, s.hacer(http.MethodPost, "/catalogo/productos/excedente", cuerpo), http.StatusConflict, CodigoSinCambios)
+}
+
+// solicitudPublicacion publica en temporada desde hoy y durante 30 días, límites incluidos
+func TestMarcarExcedente_Temporada(t *testing.T) {
+	hoy := time.Now().In(producto.ZonaHoraria())
+	casos := []struct {
+		nombre string
+		fecha  time.Time
+		status int
+	}{
+		{"inicio de temporada", hoy, http.StatusNoContent},
+		{"fin de temporada", hoy.AddDate(0, 0, 30), http.StatusNoContent},
+		{"antes de la temporada", hoy.AddDate(0, 0, -1), http.StatusUnprocessableEntity},
+		{"después de la temporada", hoy.AddDate(0, 0, 31), http.StatusUnprocessableEntity},
+	}
+	for _, tc := range casos {
+		t.Run(tc.nombre, func(t *testing.T) {
+			s := nuevoServidorPrueba(t)
+			s.publicar(t, s.semilla1, "Fresa")
+
+			w := s.hacer(http.MethodPost, "/catalogo/productos/excedente",
+				aJSON(t, map[string]string{"producto_id": "producto-000001", "fecha": tc.fecha.Format("2006-01-02")}))
+			exigirStatus(t, w, tc.status)
+
+			prod, err := s.productoRepo.GetByID("producto-000001")
+			if err != nil {
+				t.Fatalf("GetByID: %v", err)
+			}
+			espera := producto.Excedente
+			if tc.status != http.StatusNoContent {
+				espera = producto.Disponible
+			}
+			if prod.Estado.Value != espera {
+				t.Errorf("Estado = %q, se esperaba %q", prod.Estado.Value, espera)
+			}
+		})
+	}
+}
+
+func TestAgotarProducto(t *testing.T) {
+	s := nuevoServidorPrueba(t)
+	cuerpo := solicitudExcedente(t, s)
+	s.publicar(t, s.semilla1, "Mora")
+	exigirStatus(t, s.hacer(http.MethodPost, "/catalogo/productos/excedente", cuerpo), http.StatusNoContent)
+
+	pasos := []struct {
+		nombre string
+		id     string
+		status int
+	}{
+		{"disponible", "producto-000002", http.StatusNoContent},
+		{"ya agotado", "producto-000002", http.StatusOK},
+		{"excedente", "producto-000001", http.StatusConflict},
+		{"producto inexistente", "no-existe", http.StatusNotFound},
+	}
+	for _, paso := range pasos {
+		w := s.hacer(http.MethodPost, "/catalogo/productos/"+paso.id+"/agotar", "")
+		if w.Code != paso.status {
+			t.Errorf("%s: status = %d, se esperaba %d: %s", paso.nombre, w.Code, paso.status, w.Body.String())
+		}
+	}
+
+	agotados := 0
+	for _, evento := range s.eventos.eventos {
+		if _, ok := evento.(producto.ProductoAgotado); ok {
+			agotados++
+		}
+	}
+	if agotados != 1 {
+		t.Errorf("eventos ProductoAgotado = %d, se esperaba 1", agotados)
+	}
+}
+
+func TestAgotarProducto_Estricta(t *testing.T) {
+	s := nuevoServidorPrueba(t, service.WithTransicionesEstrictas(service.TransicionAgotar))
+	s.publicar(t, s.semilla1, "Fresa")
+
+	exigirStatus(t, s.hacer(http.MethodPost, "/catalogo/productos/producto-000001/agotar", ""), http.StatusNoContent)
+	exigirStatus(t, s.hacer(http.MethodPost, "/catalogo/productos/producto-000001/agotar", ""), http.StatusConflict)
+}
@@ -0,0 +1,110 @@
+// Package ids centraliza la generación de identificadores de los agregados, de modo que
+// el formato (UUID, ULID o secuencial para pruebas) se elija por configuración sin tocar
+// los puntos donde se crean productos y productores.
+package ids
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+	"Product_Catalog_Microservice/internal/domain/productor"
+)
+
+// Formatos de identificador soportados por NewDesdeFormato
+const (
+	FormatoUUID = "uuid"
+	FormatoULID = "ulid"
+)
+
+// IDGenerator genera identificadores nuevos para los agregados del catálogo
+type IDGenerator interface {
+	NewProductoID() producto.ProductoID
+	NewProductorID() productor.ProductorID
+}
+
+// NewDesdeFormato retorna el generador correspondiente al formato configurado.
+// Un formato vacío equivale a uuid.
+func NewDesdeFormato(formato string) (IDGenerator, error) {
+	switch formato {
+	case "", FormatoUUID:
+		return UUIDGenerator{}, nil
+	case FormatoULID:
+		return ULIDGenerator{}, nil
+	default:
+		return nil, fmt.Errorf("formato de ID inválido %q, valores permitidos: uuid, ulid", formato)
+	}
+}
+
+// UUIDGenerator genera UUID v4; es el generador por defecto
+type UUIDGenerator struct{}
+
+func (UUIDGenerator) NewProductoID() producto.ProductoID {
+	return producto.ProductoID(uuid.New().String())
+}
+
+func (UUIDGenerator) NewProductorID() productor.ProductorID {
+	return productor.ProductorID(uuid.New().String())
+}
+
+// ULIDGenerator genera ULID: 48 bits de tiempo en milisegundos y 80 bits aleatorios,
+// codificados en base32 de Crockford. Los IDs se ordenan lexicográficamente por creación
+// (con resolución de milisegundo).
+type ULIDGenerator struct{}
+
+func (ULIDGenerator) NewProductoID() producto.ProductoID {
+	return producto.ProductoID(nuevoULID(time.Now()))
+}
+
+func (ULIDGenerator) NewProductorID() productor.ProductorID {
+	return productor.ProductorID(nuevoULID(time.Now()))
+}
+
+// alfabetoCrockford es el alfabeto base32 de Crockford usado por ULID
+const alfabetoCrockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+func nuevoULID(t time.Time) string {
+	var aleatorio [10]byte
+	if _, err := rand.Read(aleatorio[:]); err != nil {
+		panic(fmt.Sprintf("no se pudo leer entropía para el ULID: %v", err))
+	}
+
+	// hi: 48 bits de tiempo + 16 bits aleatorios; lo: 64 bits aleatorios
+	ms := uint64(t.UnixMilli())
+	hi := ms<<16 | uint64(binary.BigEndian.Uint16(aleatorio[:2]))
+	lo := binary.BigEndian.Uint64(aleatorio[2:])
+
+	// 128 bits en 26 caracteres de 5 bits, del menos al más significativo
+	var salida [26]byte
+	for i := len(salida) - 1; i >= 0; i-- {
+		salida[i] = alfabetoCrockford[lo&31]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(salida[:])
+}
+
+// SecuencialGenerator genera IDs deterministas ("producto-000001", "productor-000001")
+// para pruebas de integración y archivos golden. Es seguro para uso concurrente.
+type SecuencialGenerator struct {
+	productos   atomic.Int64
+	productores atomic.Int64
+}
+
+// NewSecuencialGenerator crea un generador secuencial que empieza en 1
+func NewSecuencialGenerator() *SecuencialGenerator {
+	return &SecuencialGenerator{}
+}
+
+func (g *SecuencialGenerator) NewProductoID() producto.ProductoID {
+	return producto.ProductoID(fmt.Sprintf("producto-%06d", g.productos.Add(1)))
+}
+
+func (g *SecuencialGenerator) NewProductorID() productor.ProductorID {
+	return productor.ProductorID(fmt.Sprintf("productor-%06d", g.productores.Add(1)))
+}
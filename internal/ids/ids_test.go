@@ -0,0 +1,91 @@
+package ids
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNewDesdeFormato(t *testing.T) {
+	casos := []struct {
+		formato string
+		tipo    IDGenerator
+	}{
+		{"", UUIDGenerator{}},
+		{FormatoUUID, UUIDGenerator{}},
+		{FormatoULID, ULIDGenerator{}},
+	}
+	for _, tc := range casos {
+		generador, err := NewDesdeFormato(tc.formato)
+		if err != nil || generador != tc.tipo {
+			t.Errorf("NewDesdeFormato(%q) = (%T, %v), se esperaba %T", tc.formato, generador, err, tc.tipo)
+		}
+	}
+	if _, err := NewDesdeFormato("snowflake"); err == nil {
+		t.Error("NewDesdeFormato(snowflake): se esperaba error")
+	}
+}
+
+func TestULID_FormatoYOrden(t *testing.T) {
+	inicio := time.Date(2026, time.March, 14, 9, 30, 0, 0, time.UTC)
+	generados := make([]string, 0, 50)
+	for i := 0; i < 50; i++ {
+		id := nuevoULID(inicio.Add(time.Duration(i) * time.Millisecond))
+		if len(id) != 26 {
+			t.Fatalf("len(%q) = %d, se esperaba 26", id, len(id))
+		}
+		for _, c := range id {
+			if !strings.ContainsRune(alfabetoCrockford, c) {
+				t.Fatalf("%q tiene el carácter %q fuera del alfabeto de Crockford", id, c)
+			}
+		}
+		generados = append(generados, id)
+	}
+	if !sort.StringsAreSorted(generados) {
+		t.Errorf("los ULID de milisegundos crecientes no quedaron ordenados: %v", generados)
+	}
+	// 1 ms tras la época: todo ceros salvo el último carácter de tiempo
+	if id := nuevoULID(time.UnixMilli(1)); !strings.HasPrefix(id, "0000000001") {
+		t.Errorf("nuevoULID(1 ms) = %q, se esperaba el prefijo de tiempo 0000000001", id)
+	}
+}
+
+func TestSecuencialGenerator(t *testing.T) {
+	g := NewSecuencialGenerator()
+	if id := g.NewProductoID(); id != "producto-000001" {
+		t.Errorf("primer producto = %q", id)
+	}
+	if id := g.NewProductorID(); id != "productor-000001" {
+		t.Errorf("primer productor = %q, los contadores deben ser independientes", id)
+	}
+	if id := g.NewProductoID(); id != "producto-000002" {
+		t.Errorf("segundo producto = %q", id)
+	}
+}
+
+func TestSecuencialGenerator_Concurrente(t *testing.T) {
+	g := NewSecuencialGenerator()
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		vistos = make(map[string]bool)
+	)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				id := string(g.NewProductoID())
+				mu.Lock()
+				vistos[id] = true
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if len(vistos) != 800 {
+		t.Errorf("se generaron %d IDs distintos, se esperaban 800", len(vistos))
+	}
+}
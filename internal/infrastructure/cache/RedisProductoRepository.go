@@ -0,0 +1,139 @@
+// Package cache contiene decoradores de repositorios que guardan lecturas frecuentes en Redis.
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+)
+
+var _ producto.ProductoRepositoryInterface = (*RedisProductoRepository)(nil)
+
+// ClienteRedis es la parte del cliente de Redis que usa el decorador; *redis.Client la cumple
+type ClienteRedis interface {
+	Get(ctx context.Context, key string) *redis.StringCmd
+	Set(ctx context.Context, key string, value any, expiration time.Duration) *redis.StatusCmd
+	Del(ctx context.Context, keys ...string) *redis.IntCmd
+}
+
+// RedisProductoRepository decora un ProductoRepositoryInterface guardando en Redis, bajo la
+// clave producto:{id}, los productos leídos con GetByID. Save, Update y
+// UpdateEstadoDisponibilidad invalidan la clave del producto; los demás métodos pasan
+// directamente al repositorio decorado.
+//
+// Redis es solo una optimización: si no responde, las lecturas van al repositorio decorado y
+// el error se registra en el logger.
+type RedisProductoRepository struct {
+	producto.ProductoRepositoryInterface
+
+	cliente ClienteRedis
+	ttl     time.Duration
+	logger  *slog.Logger
+}
+
+// NewRedisProductoRepository decora repo. Cada producto permanece en Redis a lo sumo ttl.
+func NewRedisProductoRepository(
+	repo producto.ProductoRepositoryInterface,
+	cliente ClienteRedis,
+	ttl time.Duration,
+	logger *slog.Logger,
+) *RedisProductoRepository {
+	return &RedisProductoRepository{
+		ProductoRepositoryInterface: repo,
+		cliente:                     cliente,
+		ttl:                         ttl,
+		logger:                      logger,
+	}
+}
+
+func claveProducto(id producto.ProductoID) string {
+	return "producto:" + string(id)
+}
+
+func (r *RedisProductoRepository) GetByID(id producto.ProductoID) (*producto.ProductoAgroecologico, error) {
+	ctx := context.Background()
+	clave := claveProducto(id)
+
+	data, err := r.cliente.Get(ctx, clave).Bytes()
+	switch {
+	case err == nil:
+		var guardado productoEnCache
+		if err := json.Unmarshal(data, &guardado); err == nil {
+			return guardado.restaurar(), nil
+		}
+		r.logger.Warn("producto en caché ilegible; se lee del repositorio", "clave", clave, "error", err)
+	case !errors.Is(err, redis.Nil):
+		r.logger.Warn("no se pudo leer el producto de la caché", "clave", clave, "error", err)
+	}
+
+	prod, err := r.ProductoRepositoryInterface.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err = json.Marshal(nuevoProductoEnCache(prod))
+	if err == nil {
+		err = r.cliente.Set(ctx, clave, data, r.ttl).Err()
+	}
+	if err != nil {
+		r.logger.Warn("no se pudo guardar el producto en la caché", "clave", clave, "error", err)
+	}
+	return prod, nil
+}
+
+func (r *RedisProductoRepository) Save(prod *producto.ProductoAgroecologico) error {
+	if err := r.ProductoRepositoryInterface.Save(prod); err != nil {
+		return err
+	}
+	r.invalidar(prod.ID)
+	return nil
+}
+
+func (r *RedisProductoRepository) Update(prod *producto.ProductoAgroecologico) error {
+	if err := r.ProductoRepositoryInterface.Update(prod); err != nil {
+		return err
+	}
+	r.invalidar(prod.ID)
+	return nil
+}
+
+func (r *RedisProductoRepository) UpdateEstadoDisponibilidad(id producto.ProductoID, estado producto.EstadoDisponibilidad) error {
+	if err := r.ProductoRepositoryInterface.UpdateEstadoDisponibilidad(id, estado); err != nil {
+		return err
+	}
+	r.invalidar(id)
+	return nil
+}
+
+// invalidar borra el producto de la caché. La escritura ya se aplicó, así que un fallo solo se
+// registra: la copia vieja dura a lo sumo ttl.
+func (r *RedisProductoRepository) invalidar(id producto.ProductoID) {
+	if err := r.cliente.Del(context.Background(), claveProducto(id)).Err(); err != nil {
+		r.logger.Warn("no se pudo invalidar el producto en la caché", "clave", claveProducto(id), "error", err)
+	}
+}
+
+// productoEnCache es la forma en que se guarda un producto en Redis. Incluye la fecha de
+// publicación, que el agregado no exporta.
+type productoEnCache struct {
+	Producto    producto.ProductoAgroecologico `json:"producto"`
+	PublicadoEn time.Time                      `json:"publicado_en"`
+}
+
+func nuevoProductoEnCache(p *producto.ProductoAgroecologico) productoEnCache {
+	return productoEnCache{Producto: *p, PublicadoEn: p.PublicadoEn()}
+}
+
+// restaurar reconstruye el agregado con las fechas de temporada en la zona horaria del despliegue
+func (c productoEnCache) restaurar() *producto.ProductoAgroecologico {
+	p := c.Producto
+	p.Temporada.Inicio = p.Temporada.Inicio.In(producto.ZonaHoraria())
+	p.Temporada.Fin = p.Temporada.Fin.In(producto.ZonaHoraria())
+	return producto.RestaurarProducto(p, c.PublicadoEn)
+}
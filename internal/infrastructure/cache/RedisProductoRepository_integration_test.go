@@ -0,0 +1,115 @@
+//go:build integration
+
+package cache
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/testcontainers/testcontainers-go"
+	tcredis "github.com/testcontainers/testcontainers-go/modules/redis"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+	"Product_Catalog_Microservice/internal/repository"
+)
+
+// nuevoRedis levanta un Redis y retorna un cliente conectado. Requiere Docker:
+//
+//	go test -tags integration ./internal/infrastructure/cache
+func nuevoRedis(t *testing.T) *redis.Client {
+	t.Helper()
+	testcontainers.SkipIfProviderIsNotHealthy(t)
+	ctx := t.Context()
+
+	contenedor, err := tcredis.Run(ctx, "redis:7-alpine")
+	testcontainers.CleanupContainer(t, contenedor)
+	if err != nil {
+		t.Fatalf("no se pudo iniciar Redis: %v", err)
+	}
+	url, err := contenedor.ConnectionString(ctx)
+	if err != nil {
+		t.Fatalf("ConnectionString: %v", err)
+	}
+	opciones, err := redis.ParseURL(url)
+	if err != nil {
+		t.Fatalf("redis.ParseURL: %v", err)
+	}
+	cliente := redis.NewClient(opciones)
+	t.Cleanup(func() { cliente.Close() })
+	return cliente
+}
+
+func TestRedisProductoRepository_CicloCompleto(t *testing.T) {
+	cliente := nuevoRedis(t)
+	ctx := t.Context()
+	interno := &repoContador{ProductoRepositoryInterface: repository.NewProductoRepository(0)}
+	repo := NewRedisProductoRepository(interno, cliente, ttlPrueba, slog.New(slog.DiscardHandler))
+
+	prod := nuevoProductoPrueba(t, "p-1", "Fresa")
+	if err := repo.Save(prod); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	// Fallo: lee del repositorio y deja el producto en Redis con el ttl configurado
+	obtenido, err := repo.GetByID("p-1")
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	exigirMismoProducto(t, obtenido, prod)
+	ttl, err := cliente.TTL(ctx, "producto:p-1").Result()
+	if err != nil {
+		t.Fatalf("TTL: %v", err)
+	}
+	if ttl <= 0 || ttl > ttlPrueba {
+		t.Errorf("ttl de producto:p-1 = %v, se esperaba en (0, %v]", ttl, ttlPrueba)
+	}
+
+	// Acierto: no pasa por el repositorio
+	obtenido, err = repo.GetByID("p-1")
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	exigirMismoProducto(t, obtenido, prod)
+	if interno.lecturas != 1 {
+		t.Errorf("lecturas del repositorio = %d, se esperaba 1", interno.lecturas)
+	}
+
+	// La escritura invalida la clave y la siguiente lectura ve el estado nuevo
+	if err := repo.UpdateEstadoDisponibilidad("p-1", producto.EstadoDisponibilidad{Value: producto.Agotado}); err != nil {
+		t.Fatalf("UpdateEstadoDisponibilidad: %v", err)
+	}
+	if n, err := cliente.Exists(ctx, "producto:p-1").Result(); err != nil || n != 0 {
+		t.Fatalf("Exists(producto:p-1) = %d, %v; se esperaba 0", n, err)
+	}
+	obtenido, err = repo.GetByID("p-1")
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if obtenido.Estado.Value != producto.Agotado {
+		t.Errorf("Estado = %q, se esperaba %q", obtenido.Estado.Value, producto.Agotado)
+	}
+	if interno.lecturas != 2 {
+		t.Errorf("lecturas del repositorio = %d, se esperaba 2", interno.lecturas)
+	}
+}
+
+func TestRedisProductoRepository_RedisCerrado(t *testing.T) {
+	cliente := nuevoRedis(t)
+	interno := &repoContador{ProductoRepositoryInterface: repository.NewProductoRepository(0)}
+	repo := NewRedisProductoRepository(interno, cliente, ttlPrueba, slog.New(slog.DiscardHandler))
+	prod := nuevoProductoPrueba(t, "p-1", "Fresa")
+	if err := interno.Save(prod); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	cliente.Close()
+
+	obtenido, err := repo.GetByID("p-1")
+	if err != nil {
+		t.Fatalf("GetByID con Redis cerrado: %v", err)
+	}
+	exigirMismoProducto(t, obtenido, prod)
+	if err := repo.UpdateEstadoDisponibilidad("p-1", producto.EstadoDisponibilidad{Value: producto.Agotado}); err != nil {
+		t.Fatalf("UpdateEstadoDisponibilidad con Redis cerrado: %v", err)
+	}
+}
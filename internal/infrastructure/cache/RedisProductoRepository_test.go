@@ -0,0 +1,325 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+	"Product_Catalog_Microservice/internal/repository"
+)
+
+const ttlPrueba = time.Minute
+
+// clienteFalso guarda las claves en memoria y registra las llamadas. Si err no es nil, todas
+// las operaciones fallan con ese error.
+type clienteFalso struct {
+	mu       sync.Mutex
+	datos    map[string][]byte
+	ttls     map[string]time.Duration
+	borradas []string
+	err      error
+}
+
+func nuevoClienteFalso() *clienteFalso {
+	return &clienteFalso{datos: map[string][]byte{}, ttls: map[string]time.Duration{}}
+}
+
+func (c *clienteFalso) Get(ctx context.Context, key string) *redis.StringCmd {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return redis.NewStringResult("", c.err)
+	}
+	data, ok := c.datos[key]
+	if !ok {
+		return redis.NewStringResult("", redis.Nil)
+	}
+	return redis.NewStringResult(string(data), nil)
+}
+
+func (c *clienteFalso) Set(ctx context.Context, key string, value any, expiration time.Duration) *redis.StatusCmd {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return redis.NewStatusResult("", c.err)
+	}
+	c.datos[key] = value.([]byte)
+	c.ttls[key] = expiration
+	return redis.NewStatusResult("OK", nil)
+}
+
+func (c *clienteFalso) Del(ctx context.Context, keys ...string) *redis.IntCmd {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return redis.NewIntResult(0, c.err)
+	}
+	var n int64
+	for _, k := range keys {
+		c.borradas = append(c.borradas, k)
+		if _, ok := c.datos[k]; ok {
+			delete(c.datos, k)
+			n++
+		}
+	}
+	return redis.NewIntResult(n, nil)
+}
+
+// guardar deja p en la caché como lo haría GetByID
+func (c *clienteFalso) guardar(t *testing.T, p *producto.ProductoAgroecologico) {
+	t.Helper()
+	data, err := json.Marshal(nuevoProductoEnCache(p))
+	if err != nil {
+		t.Fatalf("json.Marshal: %v", err)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.datos[claveProducto(p.ID)] = data
+}
+
+func (c *clienteFalso) contiene(clave string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.datos[clave]
+	return ok
+}
+
+// repoContador cuenta las lecturas que llegan al repositorio decorado
+type repoContador struct {
+	producto.ProductoRepositoryInterface
+	lecturas int
+}
+
+func (r *repoContador) GetByID(id producto.ProductoID) (*producto.ProductoAgroecologico, error) {
+	r.lecturas++
+	return r.ProductoRepositoryInterface.GetByID(id)
+}
+
+func nuevoRepoCache(cliente ClienteRedis) (*RedisProductoRepository, *repoContador) {
+	interno := &repoContador{ProductoRepositoryInterface: repository.NewProductoRepository(0)}
+	return NewRedisProductoRepository(interno, cliente, ttlPrueba, slog.New(slog.DiscardHandler)), interno
+}
+
+// nuevoProductoPrueba crea un producto Disponible de quemado-1, en temporada desde hoy y durante 30 días
+func nuevoProductoPrueba(t *testing.T, id producto.ProductoID, nombre string) *producto.ProductoAgroecologico {
+	t.Helper()
+	n, err := producto.NewNombreProducto(nombre)
+	if err != nil {
+		t.Fatalf("nombre: %v", err)
+	}
+	desc, _ := producto.NewDescripcionProducto("Cosecha fresca sin agroquímicos")
+	ahora := time.Now()
+	temporada, err := producto.NewTemporadaLocal(ahora, ahora.AddDate(0, 0, 30))
+	if err != nil {
+		t.Fatalf("temporada: %v", err)
+	}
+	ubicacion, _ := producto.NewUbicacion("Vereda El Paraíso", "Finca La Esperanza")
+	imagen, _ := producto.NewImagen("https://img.example.com/fresa.jpg", "Fresas")
+	precio, _ := producto.NewPrecio(4500, "COP")
+	cantidad, _ := producto.NewCantidadDisponible(20, "kg")
+
+	p, err := producto.NewProductoAgroecologico(id, n, desc, "Fruta", producto.ProduccionAgroecologica,
+		temporada, ubicacion, imagen, precio, cantidad, "quemado-1")
+	if err != nil {
+		t.Fatalf("NewProductoAgroecologico: %v", err)
+	}
+	p.TomarEventos()
+	return p
+}
+
+// exigirMismoProducto compara los campos que la caché debe conservar
+func exigirMismoProducto(t *testing.T, obtenido, esperado *producto.ProductoAgroecologico) {
+	t.Helper()
+	if obtenido.ID != esperado.ID || obtenido.Nombre != esperado.Nombre || obtenido.Estado != esperado.Estado ||
+		obtenido.ProductorID != esperado.ProductorID {
+		t.Errorf("producto = %+v, se esperaba %+v", obtenido, esperado)
+	}
+	if !obtenido.PublicadoEn().Equal(esperado.PublicadoEn()) {
+		t.Errorf("PublicadoEn = %v, se esperaba %v", obtenido.PublicadoEn(), esperado.PublicadoEn())
+	}
+	if !obtenido.Temporada.Inicio.Equal(esperado.Temporada.Inicio) || !obtenido.Temporada.Fin.Equal(esperado.Temporada.Fin) {
+		t.Errorf("Temporada = %+v, se esperaba %+v", obtenido.Temporada, esperado.Temporada)
+	}
+}
+
+func TestGetByID_AciertoNoConsultaElRepositorio(t *testing.T) {
+	cliente := nuevoClienteFalso()
+	repo, interno := nuevoRepoCache(cliente)
+	// El producto solo existe en la caché: si GetByID lo encuentra, no pasó por el repositorio
+	prod := nuevoProductoPrueba(t, "p-1", "Fresa")
+	cliente.guardar(t, prod)
+
+	obtenido, err := repo.GetByID("p-1")
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	exigirMismoProducto(t, obtenido, prod)
+	if interno.lecturas != 0 {
+		t.Errorf("lecturas del repositorio = %d, se esperaba 0", interno.lecturas)
+	}
+}
+
+func TestGetByID_FalloLeeDelRepositorioYGuardaEnCache(t *testing.T) {
+	cliente := nuevoClienteFalso()
+	repo, interno := nuevoRepoCache(cliente)
+	prod := nuevoProductoPrueba(t, "p-1", "Fresa")
+	if err := interno.Save(prod); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	obtenido, err := repo.GetByID("p-1")
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	exigirMismoProducto(t, obtenido, prod)
+	if !cliente.contiene("producto:p-1") {
+		t.Fatal("GetByID no guardó el producto bajo producto:p-1")
+	}
+	if ttl := cliente.ttls["producto:p-1"]; ttl != ttlPrueba {
+		t.Errorf("ttl = %v, se esperaba %v", ttl, ttlPrueba)
+	}
+
+	// La segunda lectura se sirve desde la caché
+	if _, err := repo.GetByID("p-1"); err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if interno.lecturas != 1 {
+		t.Errorf("lecturas del repositorio = %d, se esperaba 1", interno.lecturas)
+	}
+}
+
+func TestGetByID_NoEncontradoNoSeGuarda(t *testing.T) {
+	cliente := nuevoClienteFalso()
+	repo, _ := nuevoRepoCache(cliente)
+
+	if _, err := repo.GetByID("no-existe"); !errors.Is(err, producto.ErrNoEncontrado) {
+		t.Fatalf("err = %v, se esperaba ErrNoEncontrado", err)
+	}
+	if cliente.contiene("producto:no-existe") {
+		t.Error("un producto inexistente no debía guardarse en la caché")
+	}
+}
+
+func TestGetByID_EntradaIlegibleLeeDelRepositorio(t *testing.T) {
+	cliente := nuevoClienteFalso()
+	repo, interno := nuevoRepoCache(cliente)
+	prod := nuevoProductoPrueba(t, "p-1", "Fresa")
+	if err := interno.Save(prod); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	cliente.datos["producto:p-1"] = []byte("{no es json")
+
+	obtenido, err := repo.GetByID("p-1")
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	exigirMismoProducto(t, obtenido, prod)
+	if interno.lecturas != 1 {
+		t.Errorf("lecturas del repositorio = %d, se esperaba 1", interno.lecturas)
+	}
+	if err := json.Unmarshal(cliente.datos["producto:p-1"], &productoEnCache{}); err != nil {
+		t.Errorf("la entrada ilegible no se reemplazó: %v", err)
+	}
+}
+
+func TestEscrituras_InvalidanLaClave(t *testing.T) {
+	casos := []struct {
+		nombre   string
+		escribir func(r *RedisProductoRepository, p *producto.ProductoAgroecologico) error
+	}{
+		{"Save", func(r *RedisProductoRepository, p *producto.ProductoAgroecologico) error {
+			return r.Save(p)
+		}},
+		{"Update", func(r *RedisProductoRepository, p *producto.ProductoAgroecologico) error {
+			p.Precio, _ = producto.NewPrecio(5200, "COP")
+			return r.Update(p)
+		}},
+		{"UpdateEstadoDisponibilidad", func(r *RedisProductoRepository, p *producto.ProductoAgroecologico) error {
+			return r.UpdateEstadoDisponibilidad(p.ID, producto.EstadoDisponibilidad{Value: producto.Agotado})
+		}},
+	}
+	for _, tc := range casos {
+		t.Run(tc.nombre, func(t *testing.T) {
+			cliente := nuevoClienteFalso()
+			repo, interno := nuevoRepoCache(cliente)
+			prod := nuevoProductoPrueba(t, "p-1", "Fresa")
+			if tc.nombre != "Save" {
+				if err := interno.Save(prod); err != nil {
+					t.Fatalf("Save: %v", err)
+				}
+			}
+			// Copia vieja del producto en la caché
+			cliente.guardar(t, prod)
+
+			if err := tc.escribir(repo, prod); err != nil {
+				t.Fatalf("%s: %v", tc.nombre, err)
+			}
+			if !slices.Equal(cliente.borradas, []string{"producto:p-1"}) {
+				t.Errorf("claves borradas = %v, se esperaba [producto:p-1]", cliente.borradas)
+			}
+			if cliente.contiene("producto:p-1") {
+				t.Error("la clave producto:p-1 sigue en la caché")
+			}
+
+			// La siguiente lectura vuelve al repositorio
+			if _, err := repo.GetByID("p-1"); err != nil {
+				t.Fatalf("GetByID: %v", err)
+			}
+			if interno.lecturas != 1 {
+				t.Errorf("lecturas del repositorio = %d, se esperaba 1", interno.lecturas)
+			}
+		})
+	}
+}
+
+func TestEscrituras_FallidasNoInvalidan(t *testing.T) {
+	cliente := nuevoClienteFalso()
+	repo, _ := nuevoRepoCache(cliente)
+	prod := nuevoProductoPrueba(t, "p-1", "Fresa")
+
+	if err := repo.Update(prod); !errors.Is(err, producto.ErrNoEncontrado) {
+		t.Fatalf("Update: err = %v, se esperaba ErrNoEncontrado", err)
+	}
+	if err := repo.UpdateEstadoDisponibilidad("p-1", producto.EstadoDisponibilidad{Value: producto.Agotado}); !errors.Is(err, producto.ErrNoEncontrado) {
+		t.Fatalf("UpdateEstadoDisponibilidad: err = %v, se esperaba ErrNoEncontrado", err)
+	}
+	if len(cliente.borradas) != 0 {
+		t.Errorf("claves borradas = %v, se esperaba ninguna", cliente.borradas)
+	}
+}
+
+func TestRedisCaido_UsaElRepositorio(t *testing.T) {
+	cliente := nuevoClienteFalso()
+	cliente.err = errors.New("dial tcp: connection refused")
+	repo, interno := nuevoRepoCache(cliente)
+	prod := nuevoProductoPrueba(t, "p-1", "Fresa")
+
+	// Las escrituras se aplican aunque no se pueda invalidar la caché
+	if err := repo.Save(prod); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if err := repo.UpdateEstadoDisponibilidad("p-1", producto.EstadoDisponibilidad{Value: producto.Agotado}); err != nil {
+		t.Fatalf("UpdateEstadoDisponibilidad: %v", err)
+	}
+
+	for i := range 2 {
+		obtenido, err := repo.GetByID("p-1")
+		if err != nil {
+			t.Fatalf("GetByID #%d: %v", i+1, err)
+		}
+		if obtenido.Estado.Value != producto.Agotado {
+			t.Errorf("Estado = %q, se esperaba %q", obtenido.Estado.Value, producto.Agotado)
+		}
+	}
+	if interno.lecturas != 2 {
+		t.Errorf("lecturas del repositorio = %d, se esperaba 2", interno.lecturas)
+	}
+}
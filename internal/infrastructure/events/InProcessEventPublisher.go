@@ -0,0 +1,58 @@
+// Package events contiene las implementaciones de service.EventPublisher.
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// HandlerFunc procesa un evento de dominio publicado
+type HandlerFunc func(event any) error
+
+// InProcessEventPublisher entrega cada evento, dentro del mismo proceso, a los handlers
+// suscritos a su tipo. Los eventos sin suscriptores se descartan sin error.
+type InProcessEventPublisher struct {
+	mu       sync.RWMutex
+	handlers map[reflect.Type][]HandlerFunc
+}
+
+func NewInProcessEventPublisher() *InProcessEventPublisher {
+	return &InProcessEventPublisher{
+		handlers: make(map[reflect.Type][]HandlerFunc),
+	}
+}
+
+// Subscribe registra handler para los eventos del mismo tipo que eventType, que solo se usa
+// para obtener el tipo (p. ej. producto.ProductoPublicado{}). Los handlers de un tipo se
+// invocan en el orden en que se suscribieron.
+func (p *InProcessEventPublisher) Subscribe(eventType any, handler HandlerFunc) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	tipo := reflect.TypeOf(eventType)
+	p.handlers[tipo] = append(p.handlers[tipo], handler)
+}
+
+// Check siempre retorna nil: el publicador en proceso no depende de nada externo
+func (p *InProcessEventPublisher) Check(ctx context.Context) error {
+	return nil
+}
+
+// Publish invoca de forma síncrona a todos los handlers suscritos al tipo del evento. Un handler
+// que falla no impide que se invoque a los siguientes; los errores se retornan combinados.
+func (p *InProcessEventPublisher) Publish(event any) error {
+	p.mu.RLock()
+	handlers := p.handlers[reflect.TypeOf(event)]
+	p.mu.RUnlock()
+
+	var errs []error
+	for i, handler := range handlers {
+		if err := handler(event); err != nil {
+			errs = append(errs, fmt.Errorf("handler %d de %T: %w", i, event, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,79 @@
+package events
+
+import (
+	"errors"
+	"testing"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+)
+
+func TestInProcessEventPublisher_HandlersEnOrdenDeSuscripcion(t *testing.T) {
+	p := NewInProcessEventPublisher()
+	var recibidos []string
+	registrar := func(nombre string) HandlerFunc {
+		return func(event any) error {
+			e, ok := event.(producto.ProductoPublicado)
+			if !ok {
+				t.Fatalf("%s recibió %T, se esperaba ProductoPublicado", nombre, event)
+			}
+			recibidos = append(recibidos, nombre+":"+string(e.ProductoID))
+			return nil
+		}
+	}
+	p.Subscribe(producto.ProductoPublicado{}, registrar("primero"))
+	p.Subscribe(producto.ProductoPublicado{}, registrar("segundo"))
+
+	if err := p.Publish(producto.ProductoPublicado{ProductoID: "p-1"}); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	if err := p.Publish(producto.ProductoPublicado{ProductoID: "p-2"}); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+
+	want := []string{"primero:p-1", "segundo:p-1", "primero:p-2", "segundo:p-2"}
+	if len(recibidos) != len(want) {
+		t.Fatalf("recibidos = %v, se esperaba %v", recibidos, want)
+	}
+	for i := range want {
+		if recibidos[i] != want[i] {
+			t.Errorf("recibidos = %v, se esperaba %v", recibidos, want)
+			break
+		}
+	}
+}
+
+func TestInProcessEventPublisher_CombinaErroresSinDetenerse(t *testing.T) {
+	p := NewInProcessEventPublisher()
+	errUno, errDos := errors.New("falla uno"), errors.New("falla dos")
+	invocados := 0
+	p.Subscribe(producto.ProductoPublicado{}, func(any) error { invocados++; return errUno })
+	p.Subscribe(producto.ProductoPublicado{}, func(any) error { invocados++; return nil })
+	p.Subscribe(producto.ProductoPublicado{}, func(any) error { invocados++; return errDos })
+
+	err := p.Publish(producto.ProductoPublicado{ProductoID: "p-1"})
+	if invocados != 3 {
+		t.Errorf("handlers invocados = %d, se esperaban 3", invocados)
+	}
+	if !errors.Is(err, errUno) || !errors.Is(err, errDos) {
+		t.Errorf("err = %v, se esperaban ambos errores combinados", err)
+	}
+}
+
+func TestInProcessEventPublisher_SoloElTipoSuscrito(t *testing.T) {
+	p := NewInProcessEventPublisher()
+	invocados := 0
+	p.Subscribe(producto.ProductoAgotado{}, func(any) error { invocados++; return nil })
+
+	// Sin suscriptores el evento se descarta sin error; un puntero es otro tipo
+	for _, evento := range []any{producto.ProductoPublicado{}, &producto.ProductoAgotado{}} {
+		if err := p.Publish(evento); err != nil {
+			t.Errorf("Publish(%T): %v", evento, err)
+		}
+	}
+	if invocados != 0 {
+		t.Errorf("handlers invocados = %d, se esperaban 0", invocados)
+	}
+	if err := p.Publish(producto.ProductoAgotado{}); err != nil || invocados != 1 {
+		t.Errorf("Publish(ProductoAgotado) = %v con %d invocaciones, se esperaba 1", err, invocados)
+	}
+}
@@ -0,0 +1,196 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// KafkaConfig configura KafkaEventPublisher.
+//
+// ProducerConfig admite las claves:
+//   - acks: all (por defecto), one o none
+//   - compression: gzip, snappy, lz4 o zstd (sin compresión por defecto)
+//   - batch_timeout_ms: espera máxima para completar un lote (por defecto 10)
+//   - write_timeout_ms: tiempo máximo de una escritura (por defecto 10000)
+//   - max_attempts: intentos por mensaje (por defecto 3)
+//   - allow_auto_topic_creation: true o false (por defecto false)
+//   - publish_timeout_ms: tiempo máximo de un Publish, reintentos incluidos (por defecto 5000)
+type KafkaConfig struct {
+	Brokers        []string
+	TopicPrefix    string
+	ProducerConfig map[string]string
+}
+
+// KafkaEventPublisher publica cada evento de dominio como JSON en el tópico
+// {TopicPrefix}.{tipo del evento}, p. ej. catalogo.ProductoPublicado. La clave del mensaje
+// es el ID del agregado, de modo que los eventos de un mismo agregado conservan su orden.
+type KafkaEventPublisher struct {
+	writer         *kafka.Writer
+	brokers        []string
+	topicPrefix    string
+	publishTimeout time.Duration
+}
+
+// publishTimeoutPorDefecto acota cada Publish. El servicio publica con los bloqueos de los
+// agregados tomados, así que un broker que no responde no debe retenerlos indefinidamente.
+const publishTimeoutPorDefecto = 5 * time.Second
+
+// NewKafkaEventPublisher crea el publicador. No se conecta a los brokers hasta el primer
+// Publish; retorna error si la configuración es inválida.
+func NewKafkaEventPublisher(cfg KafkaConfig) (*KafkaEventPublisher, error) {
+	if len(cfg.Brokers) == 0 {
+		return nil, errors.New("kafka: se requiere al menos un broker")
+	}
+	if cfg.TopicPrefix == "" {
+		return nil, errors.New("kafka: se requiere un prefijo de tópico")
+	}
+
+	writer := &kafka.Writer{
+		Addr:         kafka.TCP(cfg.Brokers...),
+		Balancer:     &kafka.Hash{},
+		RequiredAcks: kafka.RequireAll,
+		BatchTimeout: 10 * time.Millisecond,
+		WriteTimeout: 10 * time.Second,
+		MaxAttempts:  3,
+	}
+	p := &KafkaEventPublisher{writer: writer, brokers: cfg.Brokers, topicPrefix: cfg.TopicPrefix, publishTimeout: publishTimeoutPorDefecto}
+	if err := p.aplicarProducerConfig(cfg.ProducerConfig); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// aplicarProducerConfig traslada ProducerConfig al writer; una clave desconocida es un error
+// para que un error de escritura en la configuración no pase inadvertido
+func (p *KafkaEventPublisher) aplicarProducerConfig(config map[string]string) error {
+	writer := p.writer
+	for clave, valor := range config {
+		switch clave {
+		case "acks":
+			switch valor {
+			case "all":
+				writer.RequiredAcks = kafka.RequireAll
+			case "one":
+				writer.RequiredAcks = kafka.RequireOne
+			case "none":
+				writer.RequiredAcks = kafka.RequireNone
+			default:
+				return fmt.Errorf("kafka: acks inválido %q", valor)
+			}
+		case "compression":
+			switch valor {
+			case "gzip":
+				writer.Compression = kafka.Gzip
+			case "snappy":
+				writer.Compression = kafka.Snappy
+			case "lz4":
+				writer.Compression = kafka.Lz4
+			case "zstd":
+				writer.Compression = kafka.Zstd
+			default:
+				return fmt.Errorf("kafka: compression inválida %q", valor)
+			}
+		case "batch_timeout_ms", "write_timeout_ms", "max_attempts", "publish_timeout_ms":
+			numero, err := strconv.Atoi(valor)
+			if err != nil || numero <= 0 {
+				return fmt.Errorf("kafka: %s debe ser un entero positivo", clave)
+			}
+			switch clave {
+			case "batch_timeout_ms":
+				writer.BatchTimeout = time.Duration(numero) * time.Millisecond
+			case "write_timeout_ms":
+				writer.WriteTimeout = time.Duration(numero) * time.Millisecond
+			case "max_attempts":
+				writer.MaxAttempts = numero
+			case "publish_timeout_ms":
+				p.publishTimeout = time.Duration(numero) * time.Millisecond
+			}
+		case "allow_auto_topic_creation":
+			permitir, err := strconv.ParseBool(valor)
+			if err != nil {
+				return fmt.Errorf("kafka: allow_auto_topic_creation debe ser true o false")
+			}
+			writer.AllowAutoTopicCreation = permitir
+		default:
+			return fmt.Errorf("kafka: opción de productor desconocida %q", clave)
+		}
+	}
+	return nil
+}
+
+// Publish serializa el evento como JSON y lo escribe de forma síncrona en su tópico. Si el
+// broker no confirma dentro de publish_timeout_ms retorna error en lugar de seguir esperando.
+func (p *KafkaEventPublisher) Publish(event any) error {
+	valor, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("kafka: no se pudo serializar %T: %w", event, err)
+	}
+
+	mensaje := kafka.Message{
+		Topic: p.Topic(event),
+		Key:   []byte(claveAgregado(event)),
+		Value: valor,
+	}
+	ctx, cancelar := context.WithTimeout(context.Background(), p.publishTimeout)
+	defer cancelar()
+	if err := p.writer.WriteMessages(ctx, mensaje); err != nil {
+		return fmt.Errorf("kafka: no se pudo publicar %T: %w", event, err)
+	}
+	return nil
+}
+
+// Topic retorna el tópico en el que se publica el evento
+func (p *KafkaEventPublisher) Topic(event any) string {
+	return p.topicPrefix + "." + tipoEvento(event)
+}
+
+// Check comprueba que al menos uno de los brokers acepta conexiones
+func (p *KafkaEventPublisher) Check(ctx context.Context) error {
+	var errs []error
+	for _, broker := range p.brokers {
+		conexion, err := kafka.DialContext(ctx, "tcp", broker)
+		if err == nil {
+			return conexion.Close()
+		}
+		errs = append(errs, err)
+	}
+	return fmt.Errorf("kafka: ningún broker disponible: %w", errors.Join(errs...))
+}
+
+// Close entrega los mensajes pendientes y cierra las conexiones con los brokers
+func (p *KafkaEventPublisher) Close() error {
+	return p.writer.Close()
+}
+
+// tipoEvento es el nombre del tipo del evento sin paquete, p. ej. ProductoPublicado
+func tipoEvento(event any) string {
+	tipo := reflect.TypeOf(event)
+	for tipo != nil && tipo.Kind() == reflect.Pointer {
+		tipo = tipo.Elem()
+	}
+	if tipo == nil {
+		return "nil"
+	}
+	return tipo.Name()
+}
+
+// claveAgregado retorna el ProductoID o ProductorID del evento, o "" si no tiene ninguno
+func claveAgregado(event any) string {
+	valor := reflect.Indirect(reflect.ValueOf(event))
+	if valor.Kind() != reflect.Struct {
+		return ""
+	}
+	for _, campo := range []string{"ProductoID", "ProductorID"} {
+		if id := valor.FieldByName(campo); id.IsValid() && id.Kind() == reflect.String {
+			return id.String()
+		}
+	}
+	return ""
+}
@@ -0,0 +1,75 @@
+//go:build integration
+
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/testcontainers/testcontainers-go"
+	tckafka "github.com/testcontainers/testcontainers-go/modules/kafka"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+)
+
+// Requiere Docker:
+//
+//	go test -tags integration ./internal/infrastructure/events
+func TestKafkaEventPublisher_PublicaYUnConsumidorLoRecibe(t *testing.T) {
+	testcontainers.SkipIfProviderIsNotHealthy(t)
+	ctx := t.Context()
+	contenedor, err := tckafka.Run(ctx, "confluentinc/confluent-local:7.5.0", tckafka.WithClusterID("catalogo-test"))
+	testcontainers.CleanupContainer(t, contenedor)
+	if err != nil {
+		t.Fatalf("no se pudo iniciar Kafka: %v", err)
+	}
+	brokers, err := contenedor.Brokers(ctx)
+	if err != nil {
+		t.Fatalf("Brokers: %v", err)
+	}
+
+	p, err := NewKafkaEventPublisher(KafkaConfig{
+		Brokers:        brokers,
+		TopicPrefix:    "catalogo",
+		ProducerConfig: map[string]string{"allow_auto_topic_creation": "true", "publish_timeout_ms": "30000"},
+	})
+	if err != nil {
+		t.Fatalf("NewKafkaEventPublisher: %v", err)
+	}
+	defer p.Close()
+
+	evento := producto.ProductoPublicado{
+		ProductoID: "4f9c2d1e-8b7a-4c3d-9e2f-1a0b9c8d7e6f",
+		At:         time.Date(2026, time.March, 14, 9, 30, 0, 0, producto.ZonaHoraria()),
+	}
+	if err := p.Publish(evento); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+
+	lector := kafka.NewReader(kafka.ReaderConfig{Brokers: brokers, Topic: "catalogo.ProductoPublicado", Partition: 0})
+	defer lector.Close()
+	lectura, cancelar := context.WithTimeout(ctx, 30*time.Second)
+	defer cancelar()
+	mensaje, err := lector.ReadMessage(lectura)
+	if err != nil {
+		t.Fatalf("ReadMessage: %v", err)
+	}
+
+	if string(mensaje.Key) != string(evento.ProductoID) {
+		t.Errorf("clave = %q, se esperaba el ID del producto", mensaje.Key)
+	}
+	var recibido map[string]any
+	if err := json.Unmarshal(mensaje.Value, &recibido); err != nil {
+		t.Fatalf("el valor no es JSON: %v\n%s", err, mensaje.Value)
+	}
+	want := map[string]any{
+		"ProductoID": "4f9c2d1e-8b7a-4c3d-9e2f-1a0b9c8d7e6f",
+		"At":         "2026-03-14T09:30:00-05:00",
+	}
+	if len(recibido) != len(want) || recibido["ProductoID"] != want["ProductoID"] || recibido["At"] != want["At"] {
+		t.Errorf("payload = %s, se esperaba %v", mensaje.Value, want)
+	}
+}
@@ -0,0 +1,141 @@
+package events
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+	"Product_Catalog_Microservice/internal/domain/productor"
+)
+
+// brokerMudo acepta conexiones TCP y nunca responde, como un broker colgado
+func brokerMudo(t *testing.T) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	var conexiones []net.Conn
+	hecho := make(chan struct{})
+	go func() {
+		defer close(hecho)
+		for {
+			c, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			conexiones = append(conexiones, c)
+		}
+	}()
+	t.Cleanup(func() {
+		ln.Close()
+		<-hecho
+		for _, c := range conexiones {
+			c.Close()
+		}
+	})
+	return ln.Addr().String()
+}
+
+func TestKafkaEventPublisher_PublishAcotadoPorPublishTimeout(t *testing.T) {
+	p, err := NewKafkaEventPublisher(KafkaConfig{
+		Brokers:        []string{brokerMudo(t)},
+		TopicPrefix:    "catalogo",
+		ProducerConfig: map[string]string{"publish_timeout_ms": "200"},
+	})
+	if err != nil {
+		t.Fatalf("NewKafkaEventPublisher: %v", err)
+	}
+	defer p.Close()
+
+	inicio := time.Now()
+	err = p.Publish(producto.ProductoPublicado{ProductoID: "p-1"})
+	if err == nil {
+		t.Fatal("se esperaba error con un broker que no responde")
+	}
+	// Sin el límite, Publish esperaría el write_timeout (10 s) en cada intento
+	if d := time.Since(inicio); d > 2*time.Second {
+		t.Errorf("Publish tardó %v, se esperaba que terminara cerca de los 200 ms", d)
+	}
+}
+
+func TestNewKafkaEventPublisher_ProducerConfig(t *testing.T) {
+	casos := []struct {
+		nombre string
+		config map[string]string
+		valida bool
+	}{
+		{"sin opciones", nil, true},
+		{"publish_timeout_ms", map[string]string{"publish_timeout_ms": "1500"}, true},
+		{"publish_timeout_ms cero", map[string]string{"publish_timeout_ms": "0"}, false},
+		{"publish_timeout_ms no numérico", map[string]string{"publish_timeout_ms": "5s"}, false},
+		{"acks desconocido", map[string]string{"acks": "todos"}, false},
+		{"clave desconocida", map[string]string{"linger_ms": "5"}, false},
+	}
+	for _, tc := range casos {
+		t.Run(tc.nombre, func(t *testing.T) {
+			p, err := NewKafkaEventPublisher(KafkaConfig{Brokers: []string{"localhost:9092"}, TopicPrefix: "catalogo", ProducerConfig: tc.config})
+			if tc.valida != (err == nil) {
+				t.Fatalf("err = %v, se esperaba válida = %v", err, tc.valida)
+			}
+			if err != nil {
+				return
+			}
+			defer p.Close()
+			want := publishTimeoutPorDefecto
+			if tc.config["publish_timeout_ms"] != "" {
+				want = 1500 * time.Millisecond
+			}
+			if p.publishTimeout != want {
+				t.Errorf("publishTimeout = %v, se esperaba %v", p.publishTimeout, want)
+			}
+		})
+	}
+}
+
+func TestKafkaEventPublisher_TopicYClave(t *testing.T) {
+	p, err := NewKafkaEventPublisher(KafkaConfig{Brokers: []string{"localhost:9092"}, TopicPrefix: "catalogo"})
+	if err != nil {
+		t.Fatalf("NewKafkaEventPublisher: %v", err)
+	}
+	defer p.Close()
+
+	casos := []struct {
+		evento any
+		topico string
+		clave  string
+	}{
+		{producto.ProductoPublicado{ProductoID: "p-1"}, "catalogo.ProductoPublicado", "p-1"},
+		{&producto.ProductoAgotado{ProductoID: "p-2"}, "catalogo.ProductoAgotado", "p-2"},
+		{productor.ProductorSuspendido{ProductorID: "quemado-1"}, "catalogo.ProductorSuspendido", "quemado-1"},
+		{struct{ Nombre string }{"sin agregado"}, "catalogo.", ""},
+	}
+	for _, tc := range casos {
+		if got := p.Topic(tc.evento); got != tc.topico {
+			t.Errorf("Topic(%T) = %q, se esperaba %q", tc.evento, got, tc.topico)
+		}
+		if got := claveAgregado(tc.evento); got != tc.clave {
+			t.Errorf("claveAgregado(%T) = %q, se esperaba %q", tc.evento, got, tc.clave)
+		}
+	}
+}
+
+func TestKafkaEventPublisher_CheckSinBrokers(t *testing.T) {
+	// Un puerto recién liberado no acepta conexiones
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	direccion := ln.Addr().String()
+	ln.Close()
+
+	p, err := NewKafkaEventPublisher(KafkaConfig{Brokers: []string{direccion}, TopicPrefix: "catalogo"})
+	if err != nil {
+		t.Fatalf("NewKafkaEventPublisher: %v", err)
+	}
+	defer p.Close()
+	if err := p.Check(t.Context()); err == nil {
+		t.Error("Check no falló sin brokers disponibles")
+	}
+}
@@ -0,0 +1,253 @@
+// Package postgres contiene los repositorios respaldados por PostgreSQL. El esquema que
+// esperan está en migrations/ en la raíz del repositorio.
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+	"Product_Catalog_Microservice/internal/domain/shared"
+)
+
+var _ producto.ProductoRepositoryInterface = (*ProductoRepositoryPostgres)(nil)
+
+// columnasProducto en el orden en que las lee escanearProducto
+const columnasProducto = `id, productor_id, nombre, descripcion, categoria, tipo_produccion, estado,
+	temporada_inicio, temporada_fin, zona_veredal, finca, imagen_url, imagen_desc,
+	precio_valor, precio_moneda, cantidad_valor, cantidad_unidad, publicado_en`
+
+// ProductoRepositoryPostgres guarda los productos en la tabla productos. A diferencia del
+// repositorio en memoria, cada lectura retorna un agregado nuevo y no tiene límite de capacidad.
+type ProductoRepositoryPostgres struct {
+	db *sql.DB
+}
+
+func NewProductoRepositoryPostgres(db *sql.DB) *ProductoRepositoryPostgres {
+	return &ProductoRepositoryPostgres{db: db}
+}
+
+// Check comprueba que la base de datos responde
+func (pr *ProductoRepositoryPostgres) Check(ctx context.Context) error {
+	return pr.db.PingContext(ctx)
+}
+
+func (pr *ProductoRepositoryPostgres) Save(prod *producto.ProductoAgroecologico) error {
+	resultado, err := pr.db.Exec(`INSERT INTO productos (`+columnasProducto+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
+		ON CONFLICT (id) DO NOTHING`,
+		prod.ID, prod.ProductorID, prod.Nombre.Value, prod.Descripcion.Value, prod.Categoria,
+		prod.TipoProduccion, prod.Estado.Value, prod.Temporada.Inicio, prod.Temporada.Fin,
+		prod.Ubicacion.ZonaVeredal, prod.Ubicacion.Finca, prod.Imagen.URL, prod.Imagen.DescripcionCorta,
+		prod.Precio.Valor, prod.Precio.Moneda, prod.Cantidad.Valor, prod.Cantidad.Unidad, prod.PublicadoEn(),
+	)
+	if err != nil {
+		return fmt.Errorf("guardar producto %s: %w", prod.ID, err)
+	}
+	if filas, err := resultado.RowsAffected(); err == nil && filas == 0 {
+		return fmt.Errorf("%w: %s", producto.ErrProductoDuplicado, prod.ID)
+	}
+	return nil
+}
+
+func (pr *ProductoRepositoryPostgres) GetByID(id producto.ProductoID) (*producto.ProductoAgroecologico, error) {
+	fila := pr.db.QueryRow(`SELECT `+columnasProducto+` FROM productos WHERE id = $1`, id)
+	prod, err := escanearProducto(fila)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", producto.ErrNoEncontrado, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("leer producto %s: %w", id, err)
+	}
+	return prod, nil
+}
+
+// Update reemplaza todos los campos del producto salvo su fecha de publicación
+func (pr *ProductoRepositoryPostgres) Update(prod *producto.ProductoAgroecologico) error {
+	resultado, err := pr.db.Exec(`UPDATE productos SET
+		productor_id = $2, nombre = $3, descripcion = $4, categoria = $5, tipo_produccion = $6,
+		estado = $7, temporada_inicio = $8, temporada_fin = $9, zona_veredal = $10, finca = $11,
+		imagen_url = $12, imagen_desc = $13, precio_valor = $14, precio_moneda = $15,
+		cantidad_valor = $16, cantidad_unidad = $17
+		WHERE id = $1`,
+		prod.ID, prod.ProductorID, prod.Nombre.Value, prod.Descripcion.Value, prod.Categoria,
+		prod.TipoProduccion, prod.Estado.Value, prod.Temporada.Inicio, prod.Temporada.Fin,
+		prod.Ubicacion.ZonaVeredal, prod.Ubicacion.Finca, prod.Imagen.URL, prod.Imagen.DescripcionCorta,
+		prod.Precio.Valor, prod.Precio.Moneda, prod.Cantidad.Valor, prod.Cantidad.Unidad,
+	)
+	return verificarActualizado(resultado, err, fmt.Errorf("%w: %s", producto.ErrNoEncontrado, prod.ID))
+}
+
+func (pr *ProductoRepositoryPostgres) GetByProductorID(productorID string) ([]*producto.ProductoAgroecologico, error) {
+	return pr.consultar(`WHERE productor_id = $1`, productorID)
+}
+
+func (pr *ProductoRepositoryPostgres) GetByProductorIDAndCategoria(productorID string, categoria producto.Categoria) ([]*producto.ProductoAgroecologico, error) {
+	return pr.consultar(`WHERE productor_id = $1 AND categoria = $2`, productorID, categoria)
+}
+
+// ExisteNombreParaProductor indica si el productor ya tiene un producto con el mismo nombre,
+// sin distinguir mayúsculas
+func (pr *ProductoRepositoryPostgres) ExisteNombreParaProductor(nombre producto.NombreProducto, productorID string) (bool, error) {
+	var existe bool
+	err := pr.db.QueryRow(
+		`SELECT EXISTS (SELECT 1 FROM productos WHERE productor_id = $1 AND lower(nombre) = lower($2))`,
+		productorID, nombre.Value,
+	).Scan(&existe)
+	if err != nil {
+		return false, fmt.Errorf("buscar nombre de producto: %w", err)
+	}
+	return existe, nil
+}
+
+func (pr *ProductoRepositoryPostgres) GetByCategoria(categoria producto.Categoria) ([]*producto.ProductoAgroecologico, error) {
+	return pr.consultar(`WHERE categoria = $1`, categoria)
+}
+
+func (pr *ProductoRepositoryPostgres) GetByTipoProduccion(tipo producto.TipoProduccion) ([]*producto.ProductoAgroecologico, error) {
+	return pr.consultar(`WHERE tipo_produccion = $1`, tipo)
+}
+
+func (pr *ProductoRepositoryPostgres) GetByEstado(estado producto.EstadoDisponibilidad) ([]*producto.ProductoAgroecologico, error) {
+	return pr.consultar(`WHERE estado = $1`, estado.Value)
+}
+
+func (pr *ProductoRepositoryPostgres) GetByUbicacion(ubicacion producto.Ubicacion) ([]*producto.ProductoAgroecologico, error) {
+	return pr.consultar(`WHERE zona_veredal = $1 AND finca = $2`, ubicacion.ZonaVeredal, ubicacion.Finca)
+}
+
+func (pr *ProductoRepositoryPostgres) GetAll() ([]*producto.ProductoAgroecologico, error) {
+	return pr.consultar(``)
+}
+
+// GetAllPaginated retorna una página de todos los productos ordenados por ID
+func (pr *ProductoRepositoryPostgres) GetAllPaginated(params shared.PaginationParams) (shared.PagedResult[*producto.ProductoAgroecologico], error) {
+	return pr.consultarPagina(``, params)
+}
+
+func (pr *ProductoRepositoryPostgres) GetAvailableProducts() ([]*producto.ProductoAgroecologico, error) {
+	return pr.GetByEstado(producto.EstadoDisponibilidad{Value: producto.Disponible})
+}
+
+// GetAvailableProductsPaginated retorna una página de los productos disponibles ordenados por ID
+func (pr *ProductoRepositoryPostgres) GetAvailableProductsPaginated(params shared.PaginationParams) (shared.PagedResult[*producto.ProductoAgroecologico], error) {
+	return pr.consultarPagina(`WHERE estado = $1`, params, producto.Disponible)
+}
+
+// GetPageAfter retorna hasta limit productos posteriores al cursor, ordenados por publicación
+// e ID; con cursor nil empieza desde el primero
+func (pr *ProductoRepositoryPostgres) GetPageAfter(cursor *producto.CursorProducto, limit int) ([]*producto.ProductoAgroecologico, error) {
+	if cursor == nil {
+		return pr.consultar(`ORDER BY publicado_en, id LIMIT $1`, limit)
+	}
+	return pr.consultar(`WHERE (publicado_en, id) > ($1, $2) ORDER BY publicado_en, id LIMIT $3`,
+		cursor.PublicadoEn, cursor.ID, limit)
+}
+
+// GetProductsInSeason incluye el primer y el último instante de la temporada, como IsInSeason
+func (pr *ProductoRepositoryPostgres) GetProductsInSeason(now time.Time) ([]*producto.ProductoAgroecologico, error) {
+	return pr.consultar(`WHERE temporada_inicio <= $1 AND temporada_fin >= $1`, now)
+}
+
+func (pr *ProductoRepositoryPostgres) UpdateEstadoDisponibilidad(id producto.ProductoID, estado producto.EstadoDisponibilidad) error {
+	resultado, err := pr.db.Exec(`UPDATE productos SET estado = $2 WHERE id = $1`, id, estado.Value)
+	return verificarActualizado(resultado, err, fmt.Errorf("%w: %s", producto.ErrNoEncontrado, id))
+}
+
+// consultar retorna los productos que cumplen condicion, que puede incluir ORDER BY y LIMIT
+func (pr *ProductoRepositoryPostgres) consultar(condicion string, args ...any) ([]*producto.ProductoAgroecologico, error) {
+	filas, err := pr.db.Query(`SELECT `+columnasProducto+` FROM productos `+condicion, args...)
+	if err != nil {
+		return nil, fmt.Errorf("consultar productos: %w", err)
+	}
+	defer filas.Close()
+
+	var result []*producto.ProductoAgroecologico
+	for filas.Next() {
+		prod, err := escanearProducto(filas)
+		if err != nil {
+			return nil, fmt.Errorf("leer producto: %w", err)
+		}
+		result = append(result, prod)
+	}
+	if err := filas.Err(); err != nil {
+		return nil, fmt.Errorf("consultar productos: %w", err)
+	}
+	return result, nil
+}
+
+// consultarPagina retorna la página indicada de los productos que cumplen condicion,
+// ordenados por ID, junto con el total. condicion usa los placeholders de args; los de
+// LIMIT y OFFSET se numeran a continuación.
+func (pr *ProductoRepositoryPostgres) consultarPagina(condicion string, params shared.PaginationParams, args ...any) (shared.PagedResult[*producto.ProductoAgroecologico], error) {
+	params = shared.NewPaginationParams(params.Page, params.PageSize)
+	resultado := shared.PagedResult[*producto.ProductoAgroecologico]{
+		Items:    make([]*producto.ProductoAgroecologico, 0),
+		Page:     params.Page,
+		PageSize: params.PageSize,
+	}
+	if err := pr.db.QueryRow(`SELECT count(*) FROM productos `+condicion, args...).Scan(&resultado.TotalCount); err != nil {
+		return resultado, fmt.Errorf("contar productos: %w", err)
+	}
+	if params.Page > paginas(resultado.TotalCount, params.PageSize) {
+		return resultado, nil
+	}
+
+	orden := fmt.Sprintf(` ORDER BY id LIMIT $%d OFFSET $%d`, len(args)+1, len(args)+2)
+	items, err := pr.consultar(condicion+orden, append(args, params.PageSize, params.Offset())...)
+	if err != nil {
+		return resultado, err
+	}
+	resultado.Items = append(resultado.Items, items...)
+	return resultado, nil
+}
+
+// escaneable es la parte común de *sql.Row y *sql.Rows
+type escaneable interface {
+	Scan(dest ...any) error
+}
+
+// escanearProducto lee una fila con columnasProducto. Las fechas de temporada se expresan en
+// la zona horaria del despliegue, como las crea NewTemporadaLocal.
+func escanearProducto(fila escaneable) (*producto.ProductoAgroecologico, error) {
+	var (
+		p           producto.ProductoAgroecologico
+		publicadoEn time.Time
+	)
+	err := fila.Scan(
+		&p.ID, &p.ProductorID, &p.Nombre.Value, &p.Descripcion.Value, &p.Categoria, &p.TipoProduccion,
+		&p.Estado.Value, &p.Temporada.Inicio, &p.Temporada.Fin, &p.Ubicacion.ZonaVeredal, &p.Ubicacion.Finca,
+		&p.Imagen.URL, &p.Imagen.DescripcionCorta, &p.Precio.Valor, &p.Precio.Moneda,
+		&p.Cantidad.Valor, &p.Cantidad.Unidad, &publicadoEn,
+	)
+	if err != nil {
+		return nil, err
+	}
+	p.Temporada.Inicio = p.Temporada.Inicio.In(producto.ZonaHoraria())
+	p.Temporada.Fin = p.Temporada.Fin.In(producto.ZonaHoraria())
+	return producto.RestaurarProducto(p, publicadoEn), nil
+}
+
+// verificarActualizado convierte el resultado de un UPDATE en el error del repositorio:
+// noEncontrado, que envuelve el ErrNoEncontrado del dominio, si no afectó ninguna fila
+func verificarActualizado(resultado sql.Result, err error, noEncontrado error) error {
+	if err != nil {
+		return fmt.Errorf("actualizar: %w", err)
+	}
+	filas, err := resultado.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("actualizar: %w", err)
+	}
+	if filas == 0 {
+		return noEncontrado
+	}
+	return nil
+}
+
+// paginas es la cantidad de páginas de tamaño pageSize que ocupan total elementos
+func paginas(total, pageSize int) int {
+	return (total + pageSize - 1) / pageSize
+}
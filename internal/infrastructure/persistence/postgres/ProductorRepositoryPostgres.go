@@ -0,0 +1,227 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"Product_Catalog_Microservice/internal/domain/productor"
+	"Product_Catalog_Microservice/internal/domain/shared"
+)
+
+var _ productor.ProductorRepositoryInterface = (*ProductorRepositoryPostgres)(nil)
+
+// columnasProductor en el orden en que las lee escanearProductor
+const columnasProductor = `id, nombre, zona_veredal, finca, estado_verificacion, estado_actividad,
+	reputacion, practicas_cultivo, fecha_registro, ultima_actividad, preferencias_notificacion`
+
+// ProductorRepositoryPostgres guarda los productores en la tabla productores. No carga
+// productores por defecto: para una base nueva se usa el subcomando seed.
+type ProductorRepositoryPostgres struct {
+	db *sql.DB
+}
+
+func NewProductorRepositoryPostgres(db *sql.DB) *ProductorRepositoryPostgres {
+	return &ProductorRepositoryPostgres{db: db}
+}
+
+// Check comprueba que la base de datos responde
+func (pr *ProductorRepositoryPostgres) Check(ctx context.Context) error {
+	return pr.db.PingContext(ctx)
+}
+
+func (pr *ProductorRepositoryPostgres) Save(pro *productor.Productor) error {
+	if pro.ID == "" {
+		return fmt.Errorf("El productor no tiene id")
+	}
+	preferencias, err := json.Marshal(pro.PreferenciasNotificacion)
+	if err != nil {
+		return fmt.Errorf("serializar preferencias del productor %s: %w", pro.ID, err)
+	}
+
+	resultado, err := pr.db.Exec(`INSERT INTO productores (`+columnasProductor+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (id) DO NOTHING`,
+		pro.ID, pro.Nombre.Value, pro.Ubicacion.ZonaVeredal, pro.Ubicacion.Finca,
+		pro.EstadoVerificacion.Value, pro.EstadoActividad.Value, pro.Reputacion,
+		pro.PracticasCultivo.Descripcion, pro.FechaRegistro, pro.UltimaActividad, string(preferencias),
+	)
+	if err != nil {
+		return fmt.Errorf("guardar productor %s: %w", pro.ID, err)
+	}
+	if filas, err := resultado.RowsAffected(); err == nil && filas == 0 {
+		return fmt.Errorf("%w: %s", productor.ErrProductorDuplicado, pro.ID)
+	}
+	return nil
+}
+
+func (pr *ProductorRepositoryPostgres) GetByID(id productor.ProductorID) (*productor.Productor, error) {
+	fila := pr.db.QueryRow(`SELECT `+columnasProductor+` FROM productores WHERE id = $1`, id)
+	pro, err := escanearProductor(fila)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", productor.ErrNoEncontrado, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("leer productor %s: %w", id, err)
+	}
+	return pro, nil
+}
+
+// Delete marca al productor como inactivo; no borra la fila
+func (pr *ProductorRepositoryPostgres) Delete(id productor.ProductorID) error {
+	resultado, err := pr.db.Exec(`UPDATE productores SET estado_actividad = $2 WHERE id = $1`, id, productor.Inactivo)
+	return verificarActualizado(resultado, err, fmt.Errorf("%w: %s", productor.ErrNoEncontrado, id))
+}
+
+func (pr *ProductorRepositoryPostgres) GetByUbicacion(ubicacion productor.Ubicacion) ([]*productor.Productor, error) {
+	return pr.consultar(`WHERE zona_veredal = $1 AND finca = $2`, ubicacion.ZonaVeredal, ubicacion.Finca)
+}
+
+func (pr *ProductorRepositoryPostgres) GetByZonaVeredal(zona string) ([]*productor.Productor, error) {
+	return pr.consultar(`WHERE zona_veredal = $1`, zona)
+}
+
+func (pr *ProductorRepositoryPostgres) GetByEstadoVerificacion(estado productor.EstadoVerificacion) ([]*productor.Productor, error) {
+	return pr.consultar(`WHERE estado_verificacion = $1`, estado.Value)
+}
+
+func (pr *ProductorRepositoryPostgres) GetByReputacionMinima(minReputacion productor.Reputacion) ([]*productor.Productor, error) {
+	return pr.consultar(`WHERE reputacion >= $1`, minReputacion)
+}
+
+func (pr *ProductorRepositoryPostgres) GetVerificados() ([]*productor.Productor, error) {
+	return pr.consultar(`WHERE estado_verificacion = $1`, productor.Verificado)
+}
+
+// GetVerificadosPaginated retorna una página de los productores verificados ordenados por ID
+func (pr *ProductorRepositoryPostgres) GetVerificadosPaginated(params shared.PaginationParams) (shared.PagedResult[*productor.Productor], error) {
+	return pr.consultarPagina(`WHERE estado_verificacion = $1`, params, productor.Verificado)
+}
+
+func (pr *ProductorRepositoryPostgres) GetPendientesVerificacion() ([]*productor.Productor, error) {
+	return pr.consultar(`WHERE estado_verificacion = $1`, productor.EnProceso)
+}
+
+// GetByPracticaKeyword busca la palabra sin distinguir mayúsculas; strpos evita que % o _ en
+// la palabra actúen como comodines
+func (pr *ProductorRepositoryPostgres) GetByPracticaKeyword(keyword string) ([]*productor.Productor, error) {
+	return pr.consultar(`WHERE strpos(lower(practicas_cultivo), lower($1)) > 0`, keyword)
+}
+
+func (pr *ProductorRepositoryPostgres) GetRegistradosEnRango(desde, hasta time.Time) ([]*productor.Productor, error) {
+	return pr.consultar(`WHERE fecha_registro >= $1 AND fecha_registro < $2`, desde, hasta)
+}
+
+func (pr *ProductorRepositoryPostgres) GetInactivosPorMasDe(d time.Duration) ([]*productor.Productor, error) {
+	return pr.consultar(`WHERE ultima_actividad < $1`, time.Now().Add(-d))
+}
+
+func (pr *ProductorRepositoryPostgres) GetAll() ([]*productor.Productor, error) {
+	return pr.consultar(``)
+}
+
+// GetAllPaginated retorna una página de todos los productores ordenados por ID
+func (pr *ProductorRepositoryPostgres) GetAllPaginated(params shared.PaginationParams) (shared.PagedResult[*productor.Productor], error) {
+	return pr.consultarPagina(``, params)
+}
+
+func (pr *ProductorRepositoryPostgres) UpdateReputacion(id productor.ProductorID, nuevaReputacion productor.Reputacion) error {
+	return pr.actualizar(id, `reputacion`, nuevaReputacion)
+}
+
+func (pr *ProductorRepositoryPostgres) UpdateEstadoVerificacion(id productor.ProductorID, nuevoEstado productor.EstadoVerificacion) error {
+	return pr.actualizar(id, `estado_verificacion`, nuevoEstado.Value)
+}
+
+func (pr *ProductorRepositoryPostgres) UpdateEstadoActividad(id productor.ProductorID, nuevoEstado productor.EstadoActividad) error {
+	return pr.actualizar(id, `estado_actividad`, nuevoEstado.Value)
+}
+
+func (pr *ProductorRepositoryPostgres) UpdateUltimaActividad(id productor.ProductorID, ultimaActividad time.Time) error {
+	return pr.actualizar(id, `ultima_actividad`, ultimaActividad)
+}
+
+func (pr *ProductorRepositoryPostgres) UpdatePreferenciasNotificacion(id productor.ProductorID, preferencias productor.PreferenciasNotificacion) error {
+	data, err := json.Marshal(preferencias)
+	if err != nil {
+		return fmt.Errorf("serializar preferencias del productor %s: %w", id, err)
+	}
+	return pr.actualizar(id, `preferencias_notificacion`, string(data))
+}
+
+// actualizar asigna valor a una sola columna del productor. columna nunca viene del cliente.
+func (pr *ProductorRepositoryPostgres) actualizar(id productor.ProductorID, columna string, valor any) error {
+	resultado, err := pr.db.Exec(`UPDATE productores SET `+columna+` = $2 WHERE id = $1`, id, valor)
+	return verificarActualizado(resultado, err, fmt.Errorf("%w: %s", productor.ErrNoEncontrado, id))
+}
+
+// consultar retorna los productores que cumplen condicion, que puede incluir ORDER BY y LIMIT
+func (pr *ProductorRepositoryPostgres) consultar(condicion string, args ...any) ([]*productor.Productor, error) {
+	filas, err := pr.db.Query(`SELECT `+columnasProductor+` FROM productores `+condicion, args...)
+	if err != nil {
+		return nil, fmt.Errorf("consultar productores: %w", err)
+	}
+	defer filas.Close()
+
+	var result []*productor.Productor
+	for filas.Next() {
+		pro, err := escanearProductor(filas)
+		if err != nil {
+			return nil, fmt.Errorf("leer productor: %w", err)
+		}
+		result = append(result, pro)
+	}
+	if err := filas.Err(); err != nil {
+		return nil, fmt.Errorf("consultar productores: %w", err)
+	}
+	return result, nil
+}
+
+// consultarPagina retorna la página indicada de los productores que cumplen condicion,
+// ordenados por ID, junto con el total. condicion usa los placeholders de args; los de
+// LIMIT y OFFSET se numeran a continuación.
+func (pr *ProductorRepositoryPostgres) consultarPagina(condicion string, params shared.PaginationParams, args ...any) (shared.PagedResult[*productor.Productor], error) {
+	params = shared.NewPaginationParams(params.Page, params.PageSize)
+	resultado := shared.PagedResult[*productor.Productor]{
+		Items:    make([]*productor.Productor, 0),
+		Page:     params.Page,
+		PageSize: params.PageSize,
+	}
+	if err := pr.db.QueryRow(`SELECT count(*) FROM productores `+condicion, args...).Scan(&resultado.TotalCount); err != nil {
+		return resultado, fmt.Errorf("contar productores: %w", err)
+	}
+	if params.Page > paginas(resultado.TotalCount, params.PageSize) {
+		return resultado, nil
+	}
+
+	orden := fmt.Sprintf(` ORDER BY id LIMIT $%d OFFSET $%d`, len(args)+1, len(args)+2)
+	items, err := pr.consultar(condicion+orden, append(args, params.PageSize, params.Offset())...)
+	if err != nil {
+		return resultado, err
+	}
+	resultado.Items = append(resultado.Items, items...)
+	return resultado, nil
+}
+
+// escanearProductor lee una fila con columnasProductor
+func escanearProductor(fila escaneable) (*productor.Productor, error) {
+	var (
+		p            productor.Productor
+		preferencias []byte
+	)
+	err := fila.Scan(
+		&p.ID, &p.Nombre.Value, &p.Ubicacion.ZonaVeredal, &p.Ubicacion.Finca, &p.EstadoVerificacion.Value,
+		&p.EstadoActividad.Value, &p.Reputacion, &p.PracticasCultivo.Descripcion, &p.FechaRegistro,
+		&p.UltimaActividad, &preferencias,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(preferencias, &p.PreferenciasNotificacion); err != nil {
+		return nil, fmt.Errorf("preferencias del productor %s: %w", p.ID, err)
+	}
+	return &p, nil
+}
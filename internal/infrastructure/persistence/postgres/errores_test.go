@@ -0,0 +1,49 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+)
+
+// resultadoFijo es un sql.Result con la cantidad de filas afectadas indicada
+type resultadoFijo struct {
+	filas int64
+	err   error
+}
+
+func (r resultadoFijo) LastInsertId() (int64, error) { return 0, nil }
+func (r resultadoFijo) RowsAffected() (int64, error) { return r.filas, r.err }
+
+func TestVerificarActualizado(t *testing.T) {
+	noEncontrado := fmt.Errorf("%w: p-1", producto.ErrNoEncontrado)
+	errConexion := errors.New("conexión rechazada")
+
+	casos := []struct {
+		nombre       string
+		resultado    resultadoFijo
+		err          error
+		esperado     error
+		noEncontrado bool
+	}{
+		{"una fila", resultadoFijo{filas: 1}, nil, nil, false},
+		{"ninguna fila", resultadoFijo{filas: 0}, nil, producto.ErrNoEncontrado, true},
+		{"falla el UPDATE", resultadoFijo{}, errConexion, errConexion, false},
+		{"falla RowsAffected", resultadoFijo{err: errConexion}, nil, errConexion, false},
+	}
+	for _, tc := range casos {
+		t.Run(tc.nombre, func(t *testing.T) {
+			err := verificarActualizado(tc.resultado, tc.err, noEncontrado)
+			if !errors.Is(err, tc.esperado) || (tc.esperado == nil && err != nil) {
+				t.Errorf("err = %v, se esperaba %v", err, tc.esperado)
+			}
+			// Solo la ausencia de filas se reporta como no encontrado, para que el servicio
+			// no convierta una falla de la base de datos en un 404
+			if errors.Is(err, producto.ErrNoEncontrado) != tc.noEncontrado {
+				t.Errorf("errors.Is(err, ErrNoEncontrado) = %v, se esperaba %v", !tc.noEncontrado, tc.noEncontrado)
+			}
+		})
+	}
+}
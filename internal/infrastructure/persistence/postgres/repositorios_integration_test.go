@@ -0,0 +1,215 @@
+//go:build integration
+
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/testcontainers/testcontainers-go"
+	tcpostgres "github.com/testcontainers/testcontainers-go/modules/postgres"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+	"Product_Catalog_Microservice/internal/domain/productor"
+	"Product_Catalog_Microservice/internal/domain/shared"
+)
+
+// nuevaBaseDeDatos levanta un PostgreSQL con el esquema de migrations/. Requiere Docker:
+//
+//	go test -tags integration ./internal/infrastructure/persistence/postgres
+func nuevaBaseDeDatos(t *testing.T) *sql.DB {
+	t.Helper()
+	testcontainers.SkipIfProviderIsNotHealthy(t)
+	ctx := t.Context()
+
+	contenedor, err := tcpostgres.Run(ctx, "postgres:16-alpine",
+		tcpostgres.WithDatabase("catalogo"),
+		tcpostgres.WithUsername("catalogo"),
+		tcpostgres.WithPassword("catalogo"),
+		tcpostgres.WithInitScripts(
+			filepath.Join("..", "..", "..", "..", "migrations", "0001_crear_productores_y_productos.sql"),
+			filepath.Join("..", "..", "..", "..", "migrations", "0002_indice_publicacion_productos.sql"),
+		),
+		tcpostgres.BasicWaitStrategies(),
+	)
+	testcontainers.CleanupContainer(t, contenedor)
+	if err != nil {
+		t.Fatalf("no se pudo iniciar PostgreSQL: %v", err)
+	}
+	dsn, err := contenedor.ConnectionString(ctx, "sslmode=disable")
+	if err != nil {
+		t.Fatalf("ConnectionString: %v", err)
+	}
+	db, err := sql.Open("pgx", dsn)
+	if err != nil {
+		t.Fatalf("sql.Open: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func nuevoProducto(t *testing.T, id producto.ProductoID, nombre string, categoria producto.Categoria) *producto.ProductoAgroecologico {
+	t.Helper()
+	n, err := producto.NewNombreProducto(nombre)
+	if err != nil {
+		t.Fatalf("nombre: %v", err)
+	}
+	desc, _ := producto.NewDescripcionProducto("Cosecha fresca sin agroquímicos")
+	zona := producto.ZonaHoraria()
+	temporada, err := producto.NewTemporadaLocal(time.Date(2099, time.January, 1, 0, 0, 0, 0, zona), time.Date(2099, time.March, 31, 0, 0, 0, 0, zona))
+	if err != nil {
+		t.Fatalf("temporada: %v", err)
+	}
+	ubicacion, _ := producto.NewUbicacion("Vereda El Paraíso", "Finca La Esperanza")
+	imagen, _ := producto.NewImagen("https://img.example.com/fresa.jpg", "Fresas")
+	precio, _ := producto.NewPrecio(4500, "COP")
+	cantidad, _ := producto.NewCantidadDisponible(20, "kg")
+
+	p, err := producto.NewProductoAgroecologico(id, n, desc, categoria, producto.ProduccionAgroecologica,
+		temporada, ubicacion, imagen, precio, cantidad, "quemado-1")
+	if err != nil {
+		t.Fatalf("NewProductoAgroecologico: %v", err)
+	}
+	p.TomarEventos()
+	return p
+}
+
+func nuevoProductor(t *testing.T, id productor.ProductorID) *productor.Productor {
+	t.Helper()
+	nombre, _ := productor.NewNombreProducto("Productor " + string(id))
+	ubicacion, _ := productor.NewUbicacion("Vereda El Paraíso", "Finca La Esperanza")
+	practicas, _ := productor.NuevaPracticasDeCultivo("Abonos orgánicos")
+	p, err := productor.NewProductor(id, nombre, ubicacion, productor.EstadoVerificacion{Value: productor.Verificado},
+		productor.EstadoActividad{Value: productor.Activo}, 4, practicas)
+	if err != nil {
+		t.Fatalf("NewProductor: %v", err)
+	}
+	p.TomarEventos()
+	return p
+}
+
+func TestProductoRepositoryPostgres_CicloCompleto(t *testing.T) {
+	repo := NewProductoRepositoryPostgres(nuevaBaseDeDatos(t))
+
+	fresa := nuevoProducto(t, "p-1", "Fresa", producto.CategoriaFruta)
+	if err := repo.Save(fresa); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if err := repo.Save(nuevoProducto(t, "p-2", "Lechuga", producto.CategoriaHortaliza)); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if err := repo.Save(fresa); !errors.Is(err, producto.ErrProductoDuplicado) {
+		t.Errorf("Save repetido err = %v, se esperaba ErrProductoDuplicado", err)
+	}
+
+	// Lectura: los value objects vuelven con los mismos valores y la temporada en la zona del despliegue
+	leido, err := repo.GetByID("p-1")
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if leido.Nombre != fresa.Nombre || leido.Precio != fresa.Precio || leido.Cantidad != fresa.Cantidad ||
+		leido.Ubicacion != fresa.Ubicacion || leido.Imagen != fresa.Imagen || leido.Estado != fresa.Estado {
+		t.Errorf("GetByID = %+v, se esperaba %+v", leido, fresa)
+	}
+	if !leido.Temporada.Inicio.Equal(fresa.Temporada.Inicio) || leido.Temporada.Inicio.Location() != producto.ZonaHoraria() {
+		t.Errorf("temporada_inicio = %v, se esperaba %v", leido.Temporada.Inicio, fresa.Temporada.Inicio)
+	}
+	if !leido.Temporada.IsInSeason(fresa.Temporada.Fin) {
+		t.Error("el último instante de la temporada se perdió al guardarla")
+	}
+
+	// Actualización
+	nuevoPrecio, _ := producto.NewPrecio(5200, "COP")
+	leido.Precio = nuevoPrecio
+	if err := repo.Update(leido); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if err := repo.UpdateEstadoDisponibilidad("p-1", producto.EstadoDisponibilidad{Value: producto.Agotado}); err != nil {
+		t.Fatalf("UpdateEstadoDisponibilidad: %v", err)
+	}
+	if actualizado, _ := repo.GetByID("p-1"); actualizado.Precio != nuevoPrecio || actualizado.Estado.Value != producto.Agotado {
+		t.Errorf("tras actualizar: precio = %+v, estado = %s", actualizado.Precio, actualizado.Estado.Value)
+	}
+
+	// Consultas
+	if frutas, err := repo.GetByCategoria(producto.CategoriaFruta); err != nil || len(frutas) != 1 {
+		t.Errorf("GetByCategoria = %d productos, %v; se esperaba 1", len(frutas), err)
+	}
+	if disponibles, err := repo.GetAvailableProducts(); err != nil || len(disponibles) != 1 || disponibles[0].ID != "p-2" {
+		t.Errorf("GetAvailableProducts = %v, %v; se esperaba solo p-2", disponibles, err)
+	}
+	if existe, err := repo.ExisteNombreParaProductor(fresa.Nombre, "quemado-1"); err != nil || !existe {
+		t.Errorf("ExisteNombreParaProductor = %v, %v; se esperaba true", existe, err)
+	}
+	pagina, err := repo.GetAllPaginated(shared.PaginationParams{Page: 2, PageSize: 1})
+	if err != nil || pagina.TotalCount != 2 || len(pagina.Items) != 1 || pagina.Items[0].ID != "p-2" {
+		t.Errorf("GetAllPaginated = %+v, %v; se esperaba p-2 en la página 2 de 2", pagina, err)
+	}
+	primeros, err := repo.GetPageAfter(nil, 1)
+	if err != nil || len(primeros) != 1 {
+		t.Fatalf("GetPageAfter(nil, 1) = %v, %v; se esperaba un producto", primeros, err)
+	}
+	cursor := producto.CursorDe(primeros[0])
+	if siguientes, err := repo.GetPageAfter(&cursor, 5); err != nil || len(siguientes) != 1 || siguientes[0].ID == primeros[0].ID {
+		t.Errorf("GetPageAfter tras %s = %v, %v; se esperaba solo el otro producto", primeros[0].ID, siguientes, err)
+	}
+
+	// Un producto inexistente es ErrNoEncontrado, no una falla de la base de datos
+	if _, err := repo.GetByID("no-existe"); !errors.Is(err, producto.ErrNoEncontrado) {
+		t.Errorf("GetByID err = %v, se esperaba ErrNoEncontrado", err)
+	}
+	if err := repo.Update(nuevoProducto(t, "no-existe", "Mora", producto.CategoriaFruta)); !errors.Is(err, producto.ErrNoEncontrado) {
+		t.Errorf("Update err = %v, se esperaba ErrNoEncontrado", err)
+	}
+	if err := repo.UpdateEstadoDisponibilidad("no-existe", producto.EstadoDisponibilidad{Value: producto.Agotado}); !errors.Is(err, producto.ErrNoEncontrado) {
+		t.Errorf("UpdateEstadoDisponibilidad err = %v, se esperaba ErrNoEncontrado", err)
+	}
+}
+
+func TestProductorRepositoryPostgres_CicloCompleto(t *testing.T) {
+	repo := NewProductorRepositoryPostgres(nuevaBaseDeDatos(t))
+
+	pro := nuevoProductor(t, "x-1")
+	if err := repo.Save(pro); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if err := repo.Save(pro); !errors.Is(err, productor.ErrProductorDuplicado) {
+		t.Errorf("Save repetido err = %v, se esperaba ErrProductorDuplicado", err)
+	}
+
+	leido, err := repo.GetByID("x-1")
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if leido.Nombre != pro.Nombre || leido.Ubicacion != pro.Ubicacion || leido.Reputacion != pro.Reputacion ||
+		leido.EstadoVerificacion != pro.EstadoVerificacion || leido.PracticasCultivo != pro.PracticasCultivo {
+		t.Errorf("GetByID = %+v, se esperaba %+v", leido, pro)
+	}
+
+	if err := repo.UpdateReputacion("x-1", 4.5); err != nil {
+		t.Fatalf("UpdateReputacion: %v", err)
+	}
+	if err := repo.Delete("x-1"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if actualizado, _ := repo.GetByID("x-1"); actualizado.Reputacion != 4.5 || actualizado.EstadoActividad.Value != productor.Inactivo {
+		t.Errorf("tras actualizar: reputacion = %v, actividad = %s", actualizado.Reputacion, actualizado.EstadoActividad.Value)
+	}
+	if verificados, err := repo.GetVerificados(); err != nil || len(verificados) != 1 {
+		t.Errorf("GetVerificados = %d, %v; se esperaba 1", len(verificados), err)
+	}
+
+	if _, err := repo.GetByID("no-existe"); !errors.Is(err, productor.ErrNoEncontrado) {
+		t.Errorf("GetByID err = %v, se esperaba ErrNoEncontrado", err)
+	}
+	if err := repo.Delete("no-existe"); !errors.Is(err, productor.ErrNoEncontrado) {
+		t.Errorf("Delete err = %v, se esperaba ErrNoEncontrado", err)
+	}
+	if err := repo.UpdateReputacion("no-existe", 3); !errors.Is(err, productor.ErrNoEncontrado) {
+		t.Errorf("UpdateReputacion err = %v, se esperaba ErrNoEncontrado", err)
+	}
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"Product_Catalog_Microservice/internal/domain/service"
+	"sync"
+	"time"
+)
+
+var _ service.IdempotenciaRepositoryInterface = (*IdempotenciaRepository)(nil)
+
+// IdempotenciaRepository guarda en memoria las claves de idempotencia durante ttl.
+// Los registros vencidos se descartan al guardar uno nuevo.
+type IdempotenciaRepository struct {
+	mu        sync.RWMutex
+	ttl       time.Duration
+	registros map[string]service.RegistroIdempotencia
+}
+
+func NewIdempotenciaRepository(ttl time.Duration) *IdempotenciaRepository {
+	return &IdempotenciaRepository{
+		ttl:       ttl,
+		registros: make(map[string]service.RegistroIdempotencia),
+	}
+}
+
+func (ir *IdempotenciaRepository) Save(registro service.RegistroIdempotencia) error {
+	ir.mu.Lock()
+	defer ir.mu.Unlock()
+
+	ahora := time.Now()
+	for clave, r := range ir.registros {
+		if ir.vencido(r, ahora) {
+			delete(ir.registros, clave)
+		}
+	}
+	ir.registros[registro.Clave] = registro
+	return nil
+}
+
+func (ir *IdempotenciaRepository) Get(clave string) (service.RegistroIdempotencia, bool, error) {
+	ir.mu.RLock()
+	defer ir.mu.RUnlock()
+
+	registro, ok := ir.registros[clave]
+	if !ok || ir.vencido(registro, time.Now()) {
+		return service.RegistroIdempotencia{}, false, nil
+	}
+	return registro, true, nil
+}
+
+func (ir *IdempotenciaRepository) vencido(registro service.RegistroIdempotencia, ahora time.Time) bool {
+	return ahora.Sub(registro.CreadoEn) > ir.ttl
+}
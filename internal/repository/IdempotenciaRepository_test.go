@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"Product_Catalog_Microservice/internal/domain/service"
+)
+
+func TestIdempotenciaRepository_VencePasadoElTTL(t *testing.T) {
+	repo := NewIdempotenciaRepository(time.Hour)
+	ahora := time.Now()
+	repo.Save(service.RegistroIdempotencia{Clave: "vigente", Huella: "h", ProductoID: "p-1", CreadoEn: ahora})
+	repo.Save(service.RegistroIdempotencia{Clave: "vencida", Huella: "h", ProductoID: "p-2", CreadoEn: ahora.Add(-2 * time.Hour)})
+
+	if registro, ok, _ := repo.Get("vigente"); !ok || registro.ProductoID != "p-1" {
+		t.Errorf("vigente = %+v, %v; se esperaba p-1", registro, ok)
+	}
+	if _, ok, _ := repo.Get("vencida"); ok {
+		t.Error("la clave vencida se encontró")
+	}
+
+	// Guardar otro registro descarta los vencidos
+	repo.Save(service.RegistroIdempotencia{Clave: "otra", Huella: "h", ProductoID: "p-3", CreadoEn: ahora})
+	if _, ok := repo.registros["vencida"]; ok {
+		t.Error("la clave vencida sigue guardada")
+	}
+}
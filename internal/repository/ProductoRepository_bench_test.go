@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"fmt"
+	"testing"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+)
+
+// BenchmarkGetByTipoProduccion mide el recorrido completo del mapa en memoria, con los productos
+// repartidos por igual entre los tres tipos, como línea base antes de indexar por tipo.
+//
+//	go test ./internal/repository -run '^$' -bench GetByTipoProduccion
+func BenchmarkGetByTipoProduccion(b *testing.B) {
+	tipos := []producto.TipoProduccion{producto.ProduccionAgroecologica, producto.ProduccionOrganica, producto.ProduccionTradicional}
+
+	for _, n := range []int{100, 1_000, 10_000} {
+		b.Run(fmt.Sprintf("productos=%d", n), func(b *testing.B) {
+			repo := NewProductoRepository(0)
+			for i := range n {
+				p := nuevoProductoPrueba(b, producto.ProductoID(fmt.Sprintf("p-%d", i)), fmt.Sprintf("Producto %d", i),
+					"quemado-1", producto.CategoriaFruta)
+				p.TipoProduccion = tipos[i%len(tipos)]
+				if err := repo.Save(p); err != nil {
+					b.Fatalf("Save: %v", err)
+				}
+			}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				productos, err := repo.GetByTipoProduccion(producto.ProduccionOrganica)
+				if err != nil {
+					b.Fatal(err)
+				}
+				if len(productos) != n/len(tipos) {
+					b.Fatalf("productos = %d, se esperaba %d", len(productos), n/len(tipos))
+				}
+			}
+		})
+	}
+}
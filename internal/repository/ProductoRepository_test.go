@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+	"Product_Catalog_Microservice/internal/domain/shared"
+)
+
+// idsDe retorna los IDs de los productos ordenados
+func idsDe(productos []*producto.ProductoAgroecologico) []producto.ProductoID {
+	ids := make([]producto.ProductoID, 0, len(productos))
+	for _, p := range productos {
+		ids = append(ids, p.ID)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+func TestProductoRepository_GetByProductorIDAndCategoria(t *testing.T) {
+	repo := NewProductoRepository(0)
+	for _, p := range []*producto.ProductoAgroecologico{
+		nuevoProductoPrueba(t, "fresa", "Fresa", "prod-a", "Fruta"),
+		nuevoProductoPrueba(t, "mora", "Mora", "prod-a", "Fruta"),
+		nuevoProductoPrueba(t, "papa", "Papa", "prod-a", "Tubérculo"),
+		nuevoProductoPrueba(t, "lulo", "Lulo", "prod-b", "Fruta"),
+	} {
+		if err := repo.Save(p); err != nil {
+			t.Fatalf("Save(%s): %v", p.ID, err)
+		}
+	}
+
+	casos := []struct {
+		nombre      string
+		productorID string
+		categoria   producto.Categoria
+		ids         []producto.ProductoID
+	}{
+		{"varias coincidencias", "prod-a", "Fruta", []producto.ProductoID{"fresa", "mora"}},
+		{"otra categoría", "prod-a", "Tubérculo", []producto.ProductoID{"papa"}},
+		{"otro productor", "prod-b", "Fruta", []producto.ProductoID{"lulo"}},
+		{"categoría sin productos", "prod-b", "Tubérculo", []producto.ProductoID{}},
+		{"productor sin productos", "prod-c", "Fruta", []producto.ProductoID{}},
+	}
+	for _, tc := range casos {
+		t.Run(tc.nombre, func(t *testing.T) {
+			productos, err := repo.GetByProductorIDAndCategoria(tc.productorID, tc.categoria)
+			if err != nil {
+				t.Fatalf("GetByProductorIDAndCategoria: %v", err)
+			}
+			if ids := idsDe(productos); !slices.Equal(ids, tc.ids) {
+				t.Errorf("ids = %v, se esperaba %v", ids, tc.ids)
+			}
+		})
+	}
+}
+
+func TestProductoRepository_IndicePorProductor(t *testing.T) {
+	repo := NewProductoRepository(0)
+	if err := repo.Save(nuevoProductoPrueba(t, "fresa", "Fresa", "prod-a", "Fruta")); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if err := repo.Save(nuevoProductoPrueba(t, "lulo", "Lulo", "prod-b", "Fruta")); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	productos, err := repo.GetByProductorID("prod-a")
+	if err != nil {
+		t.Fatalf("GetByProductorID: %v", err)
+	}
+	if ids := idsDe(productos); !slices.Equal(ids, []producto.ProductoID{"fresa"}) {
+		t.Errorf("ids de prod-a = %v, se esperaba [fresa]", ids)
+	}
+
+	// El índice sigue al producto cuando Update cambia su productor
+	if err := repo.Update(nuevoProductoPrueba(t, "fresa", "Fresa", "prod-b", "Fruta")); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if productos, _ := repo.GetByProductorID("prod-a"); len(productos) != 0 {
+		t.Errorf("prod-a conserva %v después del Update", idsDe(productos))
+	}
+	productos, _ = repo.GetByProductorID("prod-b")
+	if ids := idsDe(productos); !slices.Equal(ids, []producto.ProductoID{"fresa", "lulo"}) {
+		t.Errorf("ids de prod-b = %v, se esperaba [fresa lulo]", ids)
+	}
+}
+
+// Los llamadores modifican su copia sin tocar el agregado guardado. Con -race, compartir el
+// puntero guardado además se reporta como carrera de datos.
+func TestProductoRepository_GetByIDRetornaUnaCopia(t *testing.T) {
+	repo := NewProductoRepository(0)
+	if err := repo.Save(nuevoProductoPrueba(t, "p-1", "Fresa", "prod-a", "Fruta")); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			prod, err := repo.GetByID("p-1")
+			if err != nil {
+				t.Errorf("GetByID: %v", err)
+				return
+			}
+			prod.Nombre = producto.NombreProducto{Value: fmt.Sprintf("Modificado %d", i)}
+			prod.Estado = producto.EstadoDisponibilidad{Value: producto.Agotado}
+			prod.ProductorID = "prod-b"
+		}()
+	}
+	wg.Wait()
+
+	guardado, err := repo.GetByID("p-1")
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if guardado.Nombre.Value != "Fresa" || guardado.Estado.Value != producto.Disponible || guardado.ProductorID != "prod-a" {
+		t.Errorf("el producto guardado cambió: %+v", guardado)
+	}
+}
+
+func TestProductoRepository_GetAllPaginated(t *testing.T) {
+	repo := NewProductoRepository(0)
+	// Se guardan en desorden: las páginas siguen el orden por ID
+	for _, id := range []producto.ProductoID{"p-3", "p-1", "p-5", "p-2", "p-4"} {
+		if err := repo.Save(nuevoProductoPrueba(t, id, "Fresa "+string(id), "quemado-1", producto.CategoriaFruta)); err != nil {
+			t.Fatalf("Save(%s): %v", id, err)
+		}
+	}
+
+	var recorridos []producto.ProductoID
+	for page := 1; page <= 3; page++ {
+		pagina, err := repo.GetAllPaginated(shared.PaginationParams{Page: page, PageSize: 2})
+		if err != nil {
+			t.Fatalf("GetAllPaginated(%d): %v", page, err)
+		}
+		if pagina.TotalCount != 5 || pagina.Page != page || pagina.PageSize != 2 {
+			t.Errorf("página %d = {TotalCount: %d, Page: %d, PageSize: %d}", page, pagina.TotalCount, pagina.Page, pagina.PageSize)
+		}
+		for _, p := range pagina.Items {
+			recorridos = append(recorridos, p.ID)
+		}
+	}
+	if esperado := []producto.ProductoID{"p-1", "p-2", "p-3", "p-4", "p-5"}; !slices.Equal(recorridos, esperado) {
+		t.Errorf("productos recorridos = %v, se esperaba %v", recorridos, esperado)
+	}
+}
+
+func TestProductoRepository_GetPageAfter(t *testing.T) {
+	repo := NewProductoRepository(0)
+	// p-3 y p-1 se publican en el mismo instante: el ID desempata
+	base := time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)
+	publicaciones := map[producto.ProductoID]time.Time{
+		"p-3": base,
+		"p-1": base,
+		"p-2": base.Add(time.Minute),
+		"p-0": base.Add(2 * time.Minute),
+	}
+	for id, publicadoEn := range publicaciones {
+		p := nuevoProductoPrueba(t, id, "Fresa "+string(id), "quemado-1", producto.CategoriaFruta)
+		if err := repo.Save(producto.RestaurarProducto(*p, publicadoEn)); err != nil {
+			t.Fatalf("Save(%s): %v", id, err)
+		}
+	}
+
+	var (
+		recorridos []producto.ProductoID
+		cursor     *producto.CursorProducto
+	)
+	for range 3 {
+		pagina, err := repo.GetPageAfter(cursor, 3)
+		if err != nil {
+			t.Fatalf("GetPageAfter: %v", err)
+		}
+		for _, p := range pagina {
+			recorridos = append(recorridos, p.ID)
+		}
+		if len(pagina) == 0 {
+			break
+		}
+		siguiente := producto.CursorDe(pagina[len(pagina)-1])
+		cursor = &siguiente
+	}
+	if esperado := []producto.ProductoID{"p-1", "p-3", "p-2", "p-0"}; !slices.Equal(recorridos, esperado) {
+		t.Errorf("productos recorridos = %v, se esperaba %v", recorridos, esperado)
+	}
+}
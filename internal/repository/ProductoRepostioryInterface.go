@@ -2,31 +2,77 @@ package repository
 
 import (
 	"Product_Catalog_Microservice/internal/domain/producto"
+	"Product_Catalog_Microservice/internal/domain/shared"
+	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 )
 
 type ProductoRepository struct {
-	mu        sync.RWMutex                                            //To sync the concurrent request
-	productos map[producto.ProductoID]*producto.ProductoAgroecologico //map to save the Productos Agroecologicos by ID
+	mu                   sync.RWMutex                                            //To sync the concurrent request
+	productos            map[producto.ProductoID]*producto.ProductoAgroecologico //map to save the Productos Agroecologicos by ID
+	productosByProductor map[string]map[producto.ProductoID]struct{}             //secondary index of product IDs by ProductorID
+	limite               limiteCapacidad
 }
 
-func NewProductoRepository() *ProductoRepository {
+// NewProductoRepository crea el repositorio con un máximo de maxProductos; cero es sin límite.
+func NewProductoRepository(maxProductos int) *ProductoRepository {
 	return &ProductoRepository{
-		productos: make(map[producto.ProductoID]*producto.ProductoAgroecologico),
+		productos:            make(map[producto.ProductoID]*producto.ProductoAgroecologico),
+		productosByProductor: make(map[string]map[producto.ProductoID]struct{}),
+		limite:               limiteCapacidad{recurso: "productos", maximo: maxProductos},
 	}
 }
 
-func (pr *ProductoRepository) Save(producto *producto.ProductoAgroecologico) error {
+// Capacidad reporta la utilización del repositorio frente a su máximo
+func (pr *ProductoRepository) Capacidad() Capacidad {
+	pr.mu.RLock()
+	defer pr.mu.RUnlock()
+	return pr.limite.capacidad(len(pr.productos))
+}
+
+// indexar registra el producto en el índice por productor. Se debe llamar con el lock tomado.
+func (pr *ProductoRepository) indexar(prod *producto.ProductoAgroecologico) {
+	ids, ok := pr.productosByProductor[prod.ProductorID]
+	if !ok {
+		ids = make(map[producto.ProductoID]struct{})
+		pr.productosByProductor[prod.ProductorID] = ids
+	}
+	ids[prod.ID] = struct{}{}
+}
+
+// desindexar elimina el producto del índice por productor. Se debe llamar con el lock tomado.
+func (pr *ProductoRepository) desindexar(prod *producto.ProductoAgroecologico) {
+	if ids, ok := pr.productosByProductor[prod.ProductorID]; ok {
+		delete(ids, prod.ID)
+		if len(ids) == 0 {
+			delete(pr.productosByProductor, prod.ProductorID)
+		}
+	}
+}
+
+// Check siempre retorna nil: el repositorio en memoria está disponible mientras el proceso viva
+func (pr *ProductoRepository) Check(ctx context.Context) error {
+	return nil
+}
+
+func (pr *ProductoRepository) Save(prod *producto.ProductoAgroecologico) error {
 	pr.mu.Lock()
 	defer pr.mu.Unlock()
 
-	if _, exist := pr.productos[producto.ID]; exist {
-		return fmt.Errorf("El producto con id %s ya existe", producto.ID)
+	if _, exist := pr.productos[prod.ID]; exist {
+		return fmt.Errorf("%w: %s", producto.ErrProductoDuplicado, prod.ID)
+	}
+	if !pr.limite.admite(len(pr.productos)) {
+		return fmt.Errorf("%w (%d)", producto.ErrCapacidadAlcanzada, pr.limite.maximo)
 	}
 
-	pr.productos[producto.ID] = producto
+	pr.productos[prod.ID] = prod
+	pr.indexar(prod)
+	pr.limite.registrar(len(pr.productos))
 	return nil
 }
 
@@ -34,34 +80,50 @@ func (pr *ProductoRepository) GetByID(id producto.ProductoID) (*producto.Product
 	pr.mu.RLock()
 	defer pr.mu.RUnlock()
 
+	// Se retorna una copia para que el llamador no modifique el agregado guardado sin pasar por Update
 	if prod, ok := pr.productos[id]; ok {
-		response := &prod
-		return *response, nil
+		response := *prod
+		return &response, nil
 	}
 
-	return nil, fmt.Errorf("No se ha encontrado del producto con id %s", id)
+	return nil, fmt.Errorf("%w: %s", producto.ErrNoEncontrado, id)
 }
 
-func (pr *ProductoRepository) Update(producto *producto.ProductoAgroecologico) error {
+func (pr *ProductoRepository) Update(prod *producto.ProductoAgroecologico) error {
 	pr.mu.Lock()
 	defer pr.mu.Unlock()
 
-	if _, ok := pr.productos[producto.ID]; ok {
-		pr.productos[producto.ID] = producto
+	if anterior, ok := pr.productos[prod.ID]; ok {
+		pr.desindexar(anterior)
+		pr.productos[prod.ID] = prod
+		pr.indexar(prod)
 		return nil
 	}
 
-	return fmt.Errorf("Producto con id %s no encontrado", producto.ID)
+	return fmt.Errorf("%w: %s", producto.ErrNoEncontrado, prod.ID)
 }
 
 func (pr *ProductoRepository) GetByProductorID(productorID string) ([]*producto.ProductoAgroecologico, error) {
 	pr.mu.RLock()
 	defer pr.mu.RUnlock()
 
+	ids := pr.productosByProductor[productorID]
+	result := make([]*producto.ProductoAgroecologico, 0, len(ids))
+	for id := range ids {
+		result = append(result, pr.productos[id])
+	}
+
+	return result, nil
+}
+
+func (pr *ProductoRepository) GetByProductorIDAndCategoria(productorID string, categoria producto.Categoria) ([]*producto.ProductoAgroecologico, error) {
+	pr.mu.RLock()
+	defer pr.mu.RUnlock()
+
 	var result []*producto.ProductoAgroecologico
 
-	for _, prod := range pr.productos {
-		if prod.ProductorID == productorID {
+	for id := range pr.productosByProductor[productorID] {
+		if prod := pr.productos[id]; prod.Categoria == categoria {
 			result = append(result, prod)
 		}
 	}
@@ -69,6 +131,21 @@ func (pr *ProductoRepository) GetByProductorID(productorID string) ([]*producto.
 	return result, nil
 }
 
+// ExisteNombreParaProductor indica si el productor ya tiene un producto con el mismo nombre.
+// La comparación no distingue mayúsculas y es por productor, no global.
+func (pr *ProductoRepository) ExisteNombreParaProductor(nombre producto.NombreProducto, productorID string) (bool, error) {
+	pr.mu.RLock()
+	defer pr.mu.RUnlock()
+
+	for id := range pr.productosByProductor[productorID] {
+		if strings.EqualFold(pr.productos[id].Nombre.Value, nombre.Value) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
 func (pr *ProductoRepository) GetByCategoria(categoria producto.Categoria) ([]*producto.ProductoAgroecologico, error) {
 	pr.mu.RLock()
 	defer pr.mu.RUnlock()
@@ -84,6 +161,21 @@ func (pr *ProductoRepository) GetByCategoria(categoria producto.Categoria) ([]*p
 	return result, nil
 }
 
+func (pr *ProductoRepository) GetByTipoProduccion(tipo producto.TipoProduccion) ([]*producto.ProductoAgroecologico, error) {
+	pr.mu.RLock()
+	defer pr.mu.RUnlock()
+
+	var result []*producto.ProductoAgroecologico
+
+	for _, prod := range pr.productos {
+		if prod.TipoProduccion == tipo {
+			result = append(result, prod)
+		}
+	}
+
+	return result, nil
+}
+
 func (pr *ProductoRepository) GetByEstado(estado producto.EstadoDisponibilidad) ([]*producto.ProductoAgroecologico, error) {
 	pr.mu.RLock()
 	defer pr.mu.RUnlock()
@@ -126,10 +218,44 @@ func (pr *ProductoRepository) GetAll() ([]*producto.ProductoAgroecologico, error
 
 }
 
+// GetAllPaginated retorna una página de todos los productos ordenados por ID, para que las
+// páginas sean estables entre peticiones
+func (pr *ProductoRepository) GetAllPaginated(params shared.PaginationParams) (shared.PagedResult[*producto.ProductoAgroecologico], error) {
+	productos, _ := pr.GetAll()
+	sort.Slice(productos, func(i, j int) bool { return productos[i].ID < productos[j].ID })
+	return shared.Paginar(productos, params), nil
+}
+
 func (pr *ProductoRepository) GetAvailableProducts() ([]*producto.ProductoAgroecologico, error) {
 	return pr.GetByEstado(producto.EstadoDisponibilidad{Value: producto.Disponible})
 }
 
+// GetAvailableProductsPaginated retorna una página de los productos disponibles ordenados por ID
+func (pr *ProductoRepository) GetAvailableProductsPaginated(params shared.PaginationParams) (shared.PagedResult[*producto.ProductoAgroecologico], error) {
+	productos, _ := pr.GetAvailableProducts()
+	sort.Slice(productos, func(i, j int) bool { return productos[i].ID < productos[j].ID })
+	return shared.Paginar(productos, params), nil
+}
+
+// GetPageAfter retorna hasta limit productos posteriores al cursor, ordenados por publicación
+// e ID; con cursor nil empieza desde el primero
+func (pr *ProductoRepository) GetPageAfter(cursor *producto.CursorProducto, limit int) ([]*producto.ProductoAgroecologico, error) {
+	productos, _ := pr.GetAll()
+	result := make([]*producto.ProductoAgroecologico, 0, len(productos))
+	for _, prod := range productos {
+		if cursor == nil || cursor.Antes(prod) {
+			result = append(result, prod)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return producto.CursorDe(result[i]).Antes(result[j])
+	})
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
 func (pr *ProductoRepository) GetProductsInSeason(now time.Time) ([]*producto.ProductoAgroecologico, error) {
 	pr.mu.RLock()
 	defer pr.mu.RUnlock()
@@ -154,5 +280,5 @@ func (pr *ProductoRepository) UpdateEstadoDisponibilidad(id producto.ProductoID,
 		return nil
 	}
 
-	return fmt.Errorf("No se encontro el producto con id %s", id)
+	return fmt.Errorf("%w: %s", producto.ErrNoEncontrado, id)
 }
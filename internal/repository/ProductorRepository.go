@@ -2,21 +2,26 @@ package repository
 
 import (
 	"Product_Catalog_Microservice/internal/domain/productor"
+	"Product_Catalog_Microservice/internal/domain/shared"
+	"context"
 	"fmt"
+	"sort"
 	"sync"
-
-	"github.com/google/uuid"
+	"time"
 )
 
 type ProductorRepository struct {
 	mu          sync.RWMutex // To sync the concurrent request
 	productores map[productor.ProductorID]*productor.Productor
+	limite      limiteCapacidad
 }
 
-
-func NewProductorRepository() *ProductorRepository {
+// NewProductorRepository crea el repositorio con un máximo de maxProductores; cero es sin límite.
+// Los productores cargados por defecto cuentan para el máximo.
+func NewProductorRepository(maxProductores int) *ProductorRepository {
     repo := &ProductorRepository{
         productores: make(map[productor.ProductorID]*productor.Productor),
+        limite:      limiteCapacidad{recurso: "productores", maximo: maxProductores},
     }
     loadProductores(repo)
     return repo
@@ -26,16 +31,34 @@ func (pr *ProductorRepository) Save(pro *productor.Productor) error {
 	pr.mu.Lock()
 	defer pr.mu.Unlock()
 
-	pro.ID = productor.ProductorID(uuid.New().String())
-
+	// El ID lo asigna quien crea el agregado; el repositorio solo rechaza duplicados
+	if pro.ID == "" {
+		return fmt.Errorf("El productor no tiene id")
+	}
 	if _, exist := pr.productores[pro.ID]; exist {
-		return fmt.Errorf("El producotr con id %s ya existe", pro.ID)
+		return fmt.Errorf("%w: %s", productor.ErrProductorDuplicado, pro.ID)
+	}
+	if !pr.limite.admite(len(pr.productores)) {
+		return fmt.Errorf("%w (%d)", productor.ErrCapacidadAlcanzada, pr.limite.maximo)
 	}
 
 	pr.productores[pro.ID] = pro
+	pr.limite.registrar(len(pr.productores))
 	return nil
 }
 
+// Check siempre retorna nil: el repositorio en memoria está disponible mientras el proceso viva
+func (pr *ProductorRepository) Check(ctx context.Context) error {
+	return nil
+}
+
+// Capacidad reporta la utilización del repositorio frente a su máximo
+func (pr *ProductorRepository) Capacidad() Capacidad {
+	pr.mu.RLock()
+	defer pr.mu.RUnlock()
+	return pr.limite.capacidad(len(pr.productores))
+}
+
 func (pr *ProductorRepository) GetByID(id productor.ProductorID) (*productor.Productor, error) {
 	pr.mu.RLock()
 	defer pr.mu.RUnlock()
@@ -44,7 +67,7 @@ func (pr *ProductorRepository) GetByID(id productor.ProductorID) (*productor.Pro
 		response := *prod
 		return &response, nil
 	}
-	return nil, fmt.Errorf("No se ha encontrado el productor con id %s", id)
+	return nil, fmt.Errorf("%w: %s", productor.ErrNoEncontrado, id)
 }
 
 func (pr *ProductorRepository) Delete(id productor.ProductorID) error {
@@ -58,7 +81,7 @@ func (pr *ProductorRepository) Delete(id productor.ProductorID) error {
 		return nil
 	}
 
-	return fmt.Errorf("No se ha encontrado el productor con id %s", id)
+	return fmt.Errorf("%w: %s", productor.ErrNoEncontrado, id)
 }
 func (pr *ProductorRepository) GetByUbicacion(ubicacion productor.Ubicacion) ([]*productor.Productor, error) {
 	pr.mu.RLock()
@@ -72,6 +95,18 @@ func (pr *ProductorRepository) GetByUbicacion(ubicacion productor.Ubicacion) ([]
 	return result, nil
 }
 
+func (pr *ProductorRepository) GetByZonaVeredal(zona string) ([]*productor.Productor, error) {
+	pr.mu.RLock()
+	defer pr.mu.RUnlock()
+	var result []*productor.Productor
+	for _, prod := range pr.productores {
+		if prod.Ubicacion.ZonaVeredal == zona {
+			result = append(result, prod)
+		}
+	}
+	return result, nil
+}
+
 func (pr *ProductorRepository) GetByEstadoVerificacion(estado productor.EstadoVerificacion) ([]*productor.Productor, error) {
 	pr.mu.RLock()
 	defer pr.mu.RUnlock()
@@ -108,6 +143,13 @@ func (pr *ProductorRepository) GetVerificados() ([]*productor.Productor, error)
 	return result, nil
 }
 
+// GetVerificadosPaginated retorna una página de los productores verificados ordenados por ID
+func (pr *ProductorRepository) GetVerificadosPaginated(params shared.PaginationParams) (shared.PagedResult[*productor.Productor], error) {
+	productores, _ := pr.GetVerificados()
+	sort.Slice(productores, func(i, j int) bool { return productores[i].ID < productores[j].ID })
+	return shared.Paginar(productores, params), nil
+}
+
 func (pr *ProductorRepository) GetPendientesVerificacion() ([]*productor.Productor, error) {
 	pr.mu.RLock()
 	defer pr.mu.RUnlock()
@@ -120,6 +162,42 @@ func (pr *ProductorRepository) GetPendientesVerificacion() ([]*productor.Product
 	return result, nil
 }
 
+func (pr *ProductorRepository) GetByPracticaKeyword(keyword string) ([]*productor.Productor, error) {
+	pr.mu.RLock()
+	defer pr.mu.RUnlock()
+	var result []*productor.Productor
+	for _, prod := range pr.productores {
+		if prod.PracticasCultivo.HasKeyword(keyword) {
+			result = append(result, prod)
+		}
+	}
+	return result, nil
+}
+
+func (pr *ProductorRepository) GetRegistradosEnRango(desde, hasta time.Time) ([]*productor.Productor, error) {
+	pr.mu.RLock()
+	defer pr.mu.RUnlock()
+	var result []*productor.Productor
+	for _, prod := range pr.productores {
+		if !prod.FechaRegistro.Before(desde) && prod.FechaRegistro.Before(hasta) {
+			result = append(result, prod)
+		}
+	}
+	return result, nil
+}
+
+func (pr *ProductorRepository) GetInactivosPorMasDe(d time.Duration) ([]*productor.Productor, error) {
+	pr.mu.RLock()
+	defer pr.mu.RUnlock()
+	var result []*productor.Productor
+	for _, prod := range pr.productores {
+		if prod.InactivoPorMasDe(d) {
+			result = append(result, prod)
+		}
+	}
+	return result, nil
+}
+
 func (pr *ProductorRepository) GetAll() ([]*productor.Productor, error) {
 	pr.mu.RLock()
 	defer pr.mu.RUnlock()
@@ -130,6 +208,14 @@ func (pr *ProductorRepository) GetAll() ([]*productor.Productor, error) {
 	return result, nil
 }
 
+// GetAllPaginated retorna una página de todos los productores ordenados por ID, para que las
+// páginas sean estables entre peticiones
+func (pr *ProductorRepository) GetAllPaginated(params shared.PaginationParams) (shared.PagedResult[*productor.Productor], error) {
+	productores, _ := pr.GetAll()
+	sort.Slice(productores, func(i, j int) bool { return productores[i].ID < productores[j].ID })
+	return shared.Paginar(productores, params), nil
+}
+
 func (pr *ProductorRepository) UpdateReputacion(id productor.ProductorID, nuevaReputacion productor.Reputacion) error {
 	pr.mu.Lock()
 	defer pr.mu.Unlock()
@@ -137,7 +223,7 @@ func (pr *ProductorRepository) UpdateReputacion(id productor.ProductorID, nuevaR
 		prod.Reputacion = nuevaReputacion
 		return nil
 	}
-	return fmt.Errorf("No se encontró el productor con id %s", id)
+	return fmt.Errorf("%w: %s", productor.ErrNoEncontrado, id)
 }
 
 func (pr *ProductorRepository) UpdateEstadoVerificacion(id productor.ProductorID, nuevoEstado productor.EstadoVerificacion) error {
@@ -147,7 +233,37 @@ func (pr *ProductorRepository) UpdateEstadoVerificacion(id productor.ProductorID
 		prod.EstadoVerificacion = nuevoEstado
 		return nil
 	}
-	return fmt.Errorf("No se encontró el productor con id %s", id)
+	return fmt.Errorf("%w: %s", productor.ErrNoEncontrado, id)
+}
+
+func (pr *ProductorRepository) UpdateEstadoActividad(id productor.ProductorID, nuevoEstado productor.EstadoActividad) error {
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+	if prod, ok := pr.productores[id]; ok {
+		prod.EstadoActividad = nuevoEstado
+		return nil
+	}
+	return fmt.Errorf("%w: %s", productor.ErrNoEncontrado, id)
+}
+
+func (pr *ProductorRepository) UpdateUltimaActividad(id productor.ProductorID, ultimaActividad time.Time) error {
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+	if prod, ok := pr.productores[id]; ok {
+		prod.UltimaActividad = ultimaActividad
+		return nil
+	}
+	return fmt.Errorf("%w: %s", productor.ErrNoEncontrado, id)
+}
+
+func (pr *ProductorRepository) UpdatePreferenciasNotificacion(id productor.ProductorID, preferencias productor.PreferenciasNotificacion) error {
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+	if prod, ok := pr.productores[id]; ok {
+		prod.PreferenciasNotificacion = preferencias
+		return nil
+	}
+	return fmt.Errorf("%w: %s", productor.ErrNoEncontrado, id)
 }
 
 func loadProductores(repo *ProductorRepository) {
@@ -160,6 +276,8 @@ func loadProductores(repo *ProductorRepository) {
     prod1, _ := productor.NewProductor(
         "quemado-1", nombre1, ubicacion1, estadoVerif1, estadoAct1, reputacion1, practicas1,
     )
+    // Los productores semilla ya existían: su ProductorCreado no se publica
+    prod1.ClearEvents()
     repo.Save(prod1)
 
     nombre2, _ := productor.NewNombreProducto("Maria Gómez")
@@ -171,5 +289,6 @@ func loadProductores(repo *ProductorRepository) {
     prod2, _ := productor.NewProductor(
         "quemado-2", nombre2, ubicacion2, estadoVerif2, estadoAct2, reputacion2, practicas2,
     )
+    prod2.ClearEvents()
     repo.Save(prod2)
 }
\ No newline at end of file
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"Product_Catalog_Microservice/internal/domain/productor"
+)
+
+func TestProductorRepository_SaveConservaElIDDelAgregado(t *testing.T) {
+	repo := NewProductorRepository(0)
+	prod := nuevoProductorPrueba(t, "prod-1", "Ana Gómez")
+
+	if err := repo.Save(prod); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if prod.ID != "prod-1" {
+		t.Errorf("Save cambió el ID del agregado a %q", prod.ID)
+	}
+	if _, err := repo.GetByID("prod-1"); err != nil {
+		t.Errorf("GetByID(prod-1): %v", err)
+	}
+}
+
+func TestProductorRepository_SaveIDDuplicado(t *testing.T) {
+	repo := NewProductorRepository(0)
+	if err := repo.Save(nuevoProductorPrueba(t, "prod-1", "Ana Gómez")); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	antes, _ := repo.GetAll()
+
+	otro := nuevoProductorPrueba(t, "prod-1", "Luis Rojas")
+	if err := repo.Save(otro); !errors.Is(err, productor.ErrProductorDuplicado) {
+		t.Fatalf("segundo Save err = %v, se esperaba ErrProductorDuplicado", err)
+	}
+
+	if otro.ID != "prod-1" {
+		t.Errorf("el Save rechazado cambió el ID a %q", otro.ID)
+	}
+	guardado, err := repo.GetByID("prod-1")
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if guardado.Nombre.Value != "Ana Gómez" {
+		t.Errorf("Nombre = %q, el Save rechazado reemplazó al productor guardado", guardado.Nombre.Value)
+	}
+	if despues, _ := repo.GetAll(); len(despues) != len(antes) {
+		t.Errorf("productores = %d, se esperaba %d", len(despues), len(antes))
+	}
+}
+
+func TestProductorRepository_SaveSinID(t *testing.T) {
+	repo := NewProductorRepository(0)
+	antes, _ := repo.GetAll()
+
+	prod := nuevoProductorPrueba(t, "prod-1", "Ana Gómez")
+	prod.ID = ""
+	if err := repo.Save(prod); err == nil {
+		t.Fatal("Save sin ID debía fallar")
+	}
+	if despues, _ := repo.GetAll(); len(despues) != len(antes) {
+		t.Errorf("productores = %d, se esperaba %d", len(despues), len(antes))
+	}
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"Product_Catalog_Microservice/internal/domain/productor"
+	"sort"
+	"sync"
+)
+
+// RechazoRepository guarda en memoria los últimos rechazos de cada productor.
+// Al superar maxPorProductor se descarta el rechazo más antiguo.
+type RechazoRepository struct {
+	mu              sync.RWMutex
+	maxPorProductor int
+	rechazos        map[productor.ProductorID][]productor.RechazoPublicacion
+}
+
+func NewRechazoRepository(maxPorProductor int) *RechazoRepository {
+	return &RechazoRepository{
+		maxPorProductor: maxPorProductor,
+		rechazos:        make(map[productor.ProductorID][]productor.RechazoPublicacion),
+	}
+}
+
+func (rr *RechazoRepository) Save(rechazo productor.RechazoPublicacion) error {
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+
+	historial := append(rr.rechazos[rechazo.ProductorID], rechazo)
+	if exceso := len(historial) - rr.maxPorProductor; exceso > 0 {
+		historial = append([]productor.RechazoPublicacion(nil), historial[exceso:]...)
+	}
+	rr.rechazos[rechazo.ProductorID] = historial
+	return nil
+}
+
+// GetByProductor retorna los rechazos del productor, del más reciente al más antiguo
+func (rr *RechazoRepository) GetByProductor(id productor.ProductorID) ([]productor.RechazoPublicacion, error) {
+	rr.mu.RLock()
+	defer rr.mu.RUnlock()
+
+	historial := rr.rechazos[id]
+	response := make([]productor.RechazoPublicacion, 0, len(historial))
+	for i := len(historial) - 1; i >= 0; i-- {
+		response = append(response, historial[i])
+	}
+	return response, nil
+}
+
+// GetByCodigo retorna los rechazos con el código indicado de todos los productores,
+// del más reciente al más antiguo
+func (rr *RechazoRepository) GetByCodigo(codigo string) ([]productor.RechazoPublicacion, error) {
+	rr.mu.RLock()
+	defer rr.mu.RUnlock()
+
+	response := make([]productor.RechazoPublicacion, 0)
+	for _, historial := range rr.rechazos {
+		for _, rechazo := range historial {
+			if codigo == "" || rechazo.Codigo == codigo {
+				response = append(response, rechazo)
+			}
+		}
+	}
+	sort.Slice(response, func(i, j int) bool { return response[i].Fecha.After(response[j].Fecha) })
+	return response, nil
+}
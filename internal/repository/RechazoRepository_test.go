@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"Product_Catalog_Microservice/internal/domain/productor"
+)
+
+func TestRechazoRepository_DescartaLosMasAntiguos(t *testing.T) {
+	repo := NewRechazoRepository(2)
+	inicio := time.Now()
+	for i, nombre := range []string{"Fresa", "Mora", "Lulo"} {
+		repo.Save(productor.RechazoPublicacion{
+			ProductorID:    "prod-a",
+			NombreProducto: nombre,
+			Codigo:         productor.RechazoNombreDuplicado,
+			Fecha:          inicio.Add(time.Duration(i) * time.Minute),
+		})
+	}
+	repo.Save(productor.RechazoPublicacion{ProductorID: "prod-b", NombreProducto: "Papa", Codigo: productor.RechazoNoAutorizado, Fecha: inicio})
+
+	historial, _ := repo.GetByProductor("prod-a")
+	if len(historial) != 2 || historial[0].NombreProducto != "Lulo" || historial[1].NombreProducto != "Mora" {
+		t.Errorf("historial de prod-a = %+v, se esperaban Lulo y Mora", historial)
+	}
+	// El límite es por productor: los rechazos de prod-a no desplazan los de prod-b
+	if historial, _ := repo.GetByProductor("prod-b"); len(historial) != 1 {
+		t.Errorf("historial de prod-b = %+v, se esperaba 1 rechazo", historial)
+	}
+
+	duplicados, _ := repo.GetByCodigo(productor.RechazoNombreDuplicado)
+	if len(duplicados) != 2 {
+		t.Errorf("rechazos por nombre duplicado = %d, se esperaban 2", len(duplicados))
+	}
+	if todos, _ := repo.GetByCodigo(""); len(todos) != 3 {
+		t.Errorf("todos los rechazos = %d, se esperaban 3", len(todos))
+	}
+}
@@ -0,0 +1,49 @@
+package repository
+
+import "log/slog"
+
+// porcentajeAlertaCapacidad es la utilización a partir de la cual se registra una advertencia
+const porcentajeAlertaCapacidad = 80
+
+// Capacidad reporta cuántos registros guarda un repositorio en memoria frente a su máximo.
+// Maximo cero significa sin límite.
+type Capacidad struct {
+	Recurso string `json:"recurso"`
+	Usados  int    `json:"usados"`
+	Maximo  int    `json:"maximo"`
+}
+
+// limiteCapacidad acota la cantidad de registros de un repositorio en memoria para que una
+// importación grande no agote la memoria de despliegues pequeños. Sus métodos se llaman con
+// el lock del repositorio tomado.
+type limiteCapacidad struct {
+	recurso  string
+	maximo   int
+	alertado bool
+}
+
+// admite indica si cabe un registro más cuando el repositorio ya guarda usados
+func (l *limiteCapacidad) admite(usados int) bool {
+	return l.maximo <= 0 || usados < l.maximo
+}
+
+// registrar actualiza la utilización después de guardar o eliminar. La advertencia se registra
+// una sola vez al cruzar el umbral y se rearma cuando la utilización vuelve a bajar.
+func (l *limiteCapacidad) registrar(usados int) {
+	if l.maximo <= 0 {
+		return
+	}
+	sobreUmbral := usados*100 >= l.maximo*porcentajeAlertaCapacidad
+	if sobreUmbral && !l.alertado {
+		slog.Warn("capacidad del repositorio en memoria casi agotada",
+			slog.String("recurso", l.recurso),
+			slog.Int("usados", usados),
+			slog.Int("maximo", l.maximo),
+		)
+	}
+	l.alertado = sobreUmbral
+}
+
+func (l *limiteCapacidad) capacidad(usados int) Capacidad {
+	return Capacidad{Recurso: l.recurso, Usados: usados, Maximo: l.maximo}
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+	"Product_Catalog_Microservice/internal/domain/productor"
+)
+
+func TestProductoRepository_Capacidad(t *testing.T) {
+	repo := NewProductoRepository(2)
+	for _, id := range []producto.ProductoID{"p-1", "p-2"} {
+		if err := repo.Save(nuevoProductoPrueba(t, id, "Fresa", "prod-1", producto.CategoriaFruta)); err != nil {
+			t.Fatalf("Save(%s): %v", id, err)
+		}
+	}
+
+	err := repo.Save(nuevoProductoPrueba(t, "p-3", "Mora", "prod-1", producto.CategoriaFruta))
+	if !errors.Is(err, producto.ErrCapacidadAlcanzada) {
+		t.Fatalf("Save sobre el máximo err = %v, se esperaba ErrCapacidadAlcanzada", err)
+	}
+	// Un duplicado sigue siendo un duplicado aunque el repositorio esté lleno
+	err = repo.Save(nuevoProductoPrueba(t, "p-1", "Fresa", "prod-1", producto.CategoriaFruta))
+	if err == nil || errors.Is(err, producto.ErrCapacidadAlcanzada) {
+		t.Errorf("Save duplicado err = %v, se esperaba el error de ID duplicado", err)
+	}
+
+	if got := repo.Capacidad(); got != (Capacidad{Recurso: "productos", Usados: 2, Maximo: 2}) {
+		t.Errorf("Capacidad = %+v", got)
+	}
+}
+
+func TestProductorRepository_CapacidadIncluyeLosProductoresPorDefecto(t *testing.T) {
+	repo := NewProductorRepository(3)
+	if got := repo.Capacidad().Usados; got != 2 {
+		t.Fatalf("Usados = %d, se esperaba que contara los 2 productores por defecto", got)
+	}
+
+	if err := repo.Save(nuevoProductorPrueba(t, "prod-1", "Ana Gómez")); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	err := repo.Save(nuevoProductorPrueba(t, "prod-2", "Luis Rojas"))
+	if !errors.Is(err, productor.ErrCapacidadAlcanzada) || errors.Is(err, productor.ErrProductorDuplicado) {
+		t.Fatalf("Save sobre el máximo err = %v, se esperaba solo ErrCapacidadAlcanzada", err)
+	}
+	err = repo.Save(nuevoProductorPrueba(t, "prod-1", "Ana Gómez"))
+	if !errors.Is(err, productor.ErrProductorDuplicado) {
+		t.Errorf("Save duplicado err = %v, se esperaba ErrProductorDuplicado", err)
+	}
+}
+
+func TestProductoRepository_SinLimite(t *testing.T) {
+	repo := NewProductoRepository(0)
+	for _, id := range []producto.ProductoID{"p-1", "p-2", "p-3"} {
+		if err := repo.Save(nuevoProductoPrueba(t, id, "Fresa", "prod-1", producto.CategoriaFruta)); err != nil {
+			t.Fatalf("Save(%s): %v", id, err)
+		}
+	}
+	if got := repo.Capacidad(); got.Maximo != 0 || got.Usados != 3 {
+		t.Errorf("Capacidad = %+v, se esperaba 3 usados sin máximo", got)
+	}
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"Product_Catalog_Microservice/internal/domain/producto"
+	"Product_Catalog_Microservice/internal/domain/productor"
+)
+
+// nuevoProductoPrueba crea un producto Disponible del productor y la categoría indicados, en
+// temporada desde hoy y durante 30 días
+func nuevoProductoPrueba(t testing.TB, id producto.ProductoID, nombre, productorID string, categoria producto.Categoria) *producto.ProductoAgroecologico {
+	t.Helper()
+	n, err := producto.NewNombreProducto(nombre)
+	if err != nil {
+		t.Fatalf("nombre: %v", err)
+	}
+	desc, _ := producto.NewDescripcionProducto("Cosecha fresca sin agroquímicos")
+	ahora := time.Now()
+	temporada, err := producto.NewTemporadaLocal(ahora, ahora.AddDate(0, 0, 30))
+	if err != nil {
+		t.Fatalf("temporada: %v", err)
+	}
+	ubicacion, _ := producto.NewUbicacion("Vereda El Paraíso", "Finca La Esperanza")
+	imagen, _ := producto.NewImagen("https://img.example.com/fresa.jpg", "Fresas")
+	precio, _ := producto.NewPrecio(4500, "COP")
+	cantidad, _ := producto.NewCantidadDisponible(40, producto.UnidadKilogramo)
+
+	p, err := producto.NewProductoAgroecologico(id, n, desc, categoria, producto.ProduccionAgroecologica,
+		temporada, ubicacion, imagen, precio, cantidad, productorID)
+	if err != nil {
+		t.Fatalf("NewProductoAgroecologico: %v", err)
+	}
+	p.ClearEvents()
+	return p
+}
+
+// nuevoProductorPrueba crea un productor verificado y activo con el nombre indicado
+func nuevoProductorPrueba(t testing.TB, id productor.ProductorID, nombre string) *productor.Productor {
+	t.Helper()
+	n, err := productor.NewNombreProducto(nombre)
+	if err != nil {
+		t.Fatalf("nombre: %v", err)
+	}
+	ubicacion, _ := productor.NewUbicacion("Vereda El Paraíso", "Finca La Esperanza")
+	practicas, _ := productor.NuevaPracticasDeCultivo("Abonos orgánicos")
+
+	p, err := productor.NewProductor(id, n, ubicacion, productor.EstadoVerificacion{Value: productor.Verificado},
+		productor.EstadoActividad{Value: productor.Activo}, 4, practicas)
+	if err != nil {
+		t.Fatalf("NewProductor: %v", err)
+	}
+	p.ClearEvents()
+	return p
+}
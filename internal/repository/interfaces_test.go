@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"Product_Catalog_Microservice/internal/domain/producto"
+	"Product_Catalog_Microservice/internal/domain/productor"
+)
+
+// Los repositorios en memoria deben cumplir las interfaces del dominio; si alguna firma se
+// desalinea, las pruebas del paquete dejan de compilar.
+var (
+	_ producto.ProductoRepositoryInterface   = (*ProductoRepository)(nil)
+	_ productor.ProductorRepositoryInterface = (*ProductorRepository)(nil)
+	_ productor.RechazoRepositoryInterface   = (*RechazoRepository)(nil)
+)
@@ -0,0 +1,47 @@
+-- Esquema de los repositorios de internal/infrastructure/persistence/postgres.
+-- Los valores de los value objects se guardan en columnas planas; las validaciones viven en
+-- el dominio, por lo que aquí solo se restringe lo que protege la integridad de las consultas.
+
+CREATE TABLE IF NOT EXISTS productores (
+    id                        TEXT PRIMARY KEY,
+    nombre                    TEXT NOT NULL,
+    zona_veredal              TEXT NOT NULL,
+    finca                     TEXT NOT NULL,
+    estado_verificacion       TEXT NOT NULL,
+    estado_actividad          TEXT NOT NULL,
+    reputacion                REAL NOT NULL,
+    practicas_cultivo         TEXT NOT NULL,
+    fecha_registro            TIMESTAMPTZ NOT NULL,
+    ultima_actividad          TIMESTAMPTZ NOT NULL,
+    preferencias_notificacion JSONB NOT NULL DEFAULT '{}'
+);
+
+CREATE INDEX IF NOT EXISTS productores_zona_veredal_idx ON productores (zona_veredal);
+CREATE INDEX IF NOT EXISTS productores_estado_verificacion_idx ON productores (estado_verificacion);
+
+-- productor_id referencia al productor solo por identidad, como en el dominio: sin clave foránea
+CREATE TABLE IF NOT EXISTS productos (
+    id               TEXT PRIMARY KEY,
+    productor_id     TEXT NOT NULL,
+    nombre           TEXT NOT NULL,
+    descripcion      TEXT NOT NULL,
+    categoria        TEXT NOT NULL,
+    tipo_produccion  TEXT NOT NULL,
+    estado           TEXT NOT NULL,
+    temporada_inicio TIMESTAMPTZ NOT NULL,
+    temporada_fin    TIMESTAMPTZ NOT NULL,
+    zona_veredal     TEXT NOT NULL,
+    finca            TEXT NOT NULL,
+    imagen_url       TEXT NOT NULL,
+    imagen_desc      TEXT NOT NULL,
+    precio_valor     DOUBLE PRECISION NOT NULL,
+    precio_moneda    TEXT NOT NULL,
+    cantidad_valor   INTEGER NOT NULL,
+    cantidad_unidad  TEXT NOT NULL,
+    publicado_en     TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS productos_productor_nombre_idx ON productos (productor_id, lower(nombre));
+CREATE INDEX IF NOT EXISTS productos_categoria_idx ON productos (categoria);
+CREATE INDEX IF NOT EXISTS productos_estado_idx ON productos (estado);
+CREATE INDEX IF NOT EXISTS productos_temporada_idx ON productos (temporada_inicio, temporada_fin);
@@ -0,0 +1,2 @@
+-- Índice para recorrer los productos por publicación con cursor (GetPageAfter)
+CREATE INDEX IF NOT EXISTS productos_publicado_en_id_idx ON productos (publicado_en, id);